@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/cartRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/couponRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/userService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
+)
+
+// createAdmin creates an admin account, or resets the password of an existing
+// account and promotes it. The password is taken from ADMIN_PASSWORD or, when
+// unset, from the first line of stdin.
+func createAdmin(db *sql.DB, args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	name := fs.String("name", "Admin User", "display name of the admin")
+	email := fs.String("email", os.Getenv("ADMIN_EMAIL"), "email of the admin (defaults to ADMIN_EMAIL)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	adminEmail := strings.ToLower(strings.TrimSpace(*email))
+	adminName := strings.TrimSpace(*name)
+	if err := validators.ValidateEmail(adminEmail); err != nil {
+		return err
+	}
+	if err := validators.ValidateName(adminName); err != nil {
+		return err
+	}
+
+	password := os.Getenv("ADMIN_PASSWORD")
+	if password == "" {
+		fmt.Fprintln(os.Stderr, "Enter admin password:")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("can not read password: %v", err)
+		}
+		password = strings.TrimSpace(line)
+	}
+	if err := validators.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	userRepo := userRepository.NewUserRepository(db)
+	user, err := userRepo.GetUserByEmail(adminEmail)
+	if errors.Is(err, sql.ErrNoRows) {
+		userServ := userService.NewUserService(userRepo, productRepository.NewProductRepository(db), couponRepository.NewCouponRepository(db), cartRepository.NewCartRepository(db), nil, nil, nil, nil, nil, nil)
+		err = userServ.RegisterUser(adminName, adminEmail, password, models.Admin)
+		if err != nil {
+			return err
+		}
+		user, err = userRepo.GetUserByEmail(adminEmail)
+		if err != nil {
+			return err
+		}
+		err = userRepo.MarkEmailVerified(user.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Admin %s created\n", adminEmail)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	hashedPass, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("unable to hash the password")
+	}
+	err = userRepo.UpdatePassword(user.ID, hashedPass)
+	if err != nil {
+		return err
+	}
+	if user.Role != models.Admin {
+		err = userRepo.UpdateUserRole(user.ID, models.Admin)
+		if err != nil {
+			return err
+		}
+	}
+	if !user.IsEmailVerified() {
+		err = userRepo.MarkEmailVerified(user.ID, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Admin %s updated\n", adminEmail)
+	return nil
+}
+
+// checkAdminPasswords refuses to start the server while an admin account still
+// has a plaintext password, such as the one older versions seeded.
+func checkAdminPasswords(db *sql.DB) error {
+	admins, err := userRepository.NewUserRepository(db).GetUsersByRole(models.Admin)
+	if err != nil {
+		return err
+	}
+	for _, admin := range admins {
+		if !utils.IsHashedPassword(admin.Password) {
+			return fmt.Errorf("admin %s has an unhashed password, reset it with: create-admin -email %s", admin.Email, admin.Email)
+		}
+	}
+	return nil
+}
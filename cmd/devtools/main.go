@@ -0,0 +1,32 @@
+//go:build devtools
+
+// Command devtools runs local stand-ins for the external services the shop
+// talks to. It is only built with the devtools tag:
+//
+//	go run -tags devtools ./cmd/devtools mock-oidc -client-id shop
+package main
+
+import (
+	"log"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("Usage: devtools <command> [flags], available commands: mock-oidc, mock-s3")
+	}
+	switch os.Args[1] {
+	case "mock-oidc":
+		err := mockOIDC(os.Args[2:])
+		if err != nil {
+			log.Fatal("Error running mock OIDC provider:", err)
+		}
+	case "mock-s3":
+		err := mockS3(os.Args[2:])
+		if err != nil {
+			log.Fatal("Error running mock S3 service:", err)
+		}
+	default:
+		log.Fatalf("Unknown command %q, available commands: mock-oidc, mock-s3", os.Args[1])
+	}
+}
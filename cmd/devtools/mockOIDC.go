@@ -0,0 +1,51 @@
+//go:build devtools
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/oidc/oidctest"
+)
+
+// mockOIDC runs a mock identity provider for trying single sign-on locally.
+// Every authorization is approved at once for the user given by the flags.
+func mockOIDC(args []string) error {
+	fs := flag.NewFlagSet("mock-oidc", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:9000", "address to listen on, the issuer is http://<addr>")
+	clientID := fs.String("client-id", config.OIDCClientID, "client id (defaults to OIDC_CLIENT_ID)")
+	clientSecret := fs.String("client-secret", config.OIDCClientSecret, "client secret (defaults to OIDC_CLIENT_SECRET)")
+	subject := fs.String("sub", "mock-user", "subject of the logged in user")
+	email := fs.String("email", "staff@example.com", "email of the logged in user")
+	name := fs.String("name", "Mock Staff", "name of the logged in user")
+	groups := fs.String("groups", "", "comma separated groups of the logged in user")
+	verified := fs.Bool("email-verified", true, "whether the provider vouches for the email")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *clientID == "" {
+		return fmt.Errorf("a client id is required")
+	}
+
+	var groupList []string
+	for _, group := range strings.Split(*groups, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groupList = append(groupList, group)
+		}
+	}
+
+	provider := oidctest.NewProvider("http://"+*addr, *clientID, *clientSecret)
+	provider.SetUser(oidctest.User{
+		Subject:       *subject,
+		Email:         *email,
+		EmailVerified: *verified,
+		Name:          *name,
+		Groups:        groupList,
+	})
+	fmt.Printf("Mock OIDC provider with issuer %s, logging everyone in as %s\n", provider.Issuer, *email)
+	return http.ListenAndServe(*addr, provider)
+}
@@ -0,0 +1,33 @@
+//go:build devtools
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/blobstore/s3test"
+)
+
+// mockS3 runs an in-memory S3 compatible service for trying the s3 blob store
+// locally. Objects are readable without signing and gone once it stops.
+func mockS3(args []string) error {
+	fs := flag.NewFlagSet("mock-s3", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:9100", "address to listen on, the endpoint is http://<addr>")
+	bucket := fs.String("bucket", os.Getenv("S3_BUCKET"), "bucket name (defaults to S3_BUCKET)")
+	accessKeyID := fs.String("access-key-id", os.Getenv("S3_ACCESS_KEY_ID"), "access key (defaults to S3_ACCESS_KEY_ID)")
+	secret := fs.String("secret-access-key", os.Getenv("S3_SECRET_ACCESS_KEY"), "secret key (defaults to S3_SECRET_ACCESS_KEY)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *bucket == "" || *accessKeyID == "" || *secret == "" {
+		return fmt.Errorf("a bucket, access key and secret key are required")
+	}
+
+	server := s3test.NewServer(*bucket, *accessKeyID, *secret)
+	server.PublicRead = true
+	fmt.Printf("Mock S3 service at http://%s serving bucket %s\n", *addr, *bucket)
+	return http.ListenAndServe(*addr, server)
+}
@@ -2,16 +2,69 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"os/signal"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/db"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/app"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/blobstore"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mailer"
 )
 
 func main() {
+	loaded, err := config.LoadJWTKeys()
+	if err != nil {
+		log.Fatal("Error loading JWT keys:", err)
+	}
+
 	db := db.InitDB()
 
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "create-admin":
+			err := createAdmin(db, os.Args[2:])
+			db.Close()
+			if err != nil {
+				log.Fatal("Error creating admin:", err)
+			}
+			return
+		case "rebuild-search-index":
+			err := rebuildSearchIndex(db)
+			db.Close()
+			if err != nil {
+				log.Fatal("Error rebuilding search index:", err)
+			}
+			return
+		default:
+			db.Close()
+			log.Fatalf("Unknown command %q, available commands: create-admin, rebuild-search-index", os.Args[1])
+		}
+	}
+
+	if !loaded {
+		log.Println("JWT_KEYS and JWT_SECRET are not set, using a random secret; tokens will not survive a restart")
+	}
+
+	mailer, err := mailer.NewFromEnv()
+	if err != nil {
+		db.Close()
+		log.Fatal("Error configuring mailer:", err)
+	}
+
+	store, err := blobstore.NewFromEnv(config.AppBaseURL + "/media")
+	if err != nil {
+		db.Close()
+		log.Fatal("Error configuring blob store:", err)
+	}
+
+	err = checkAdminPasswords(db)
+	if err != nil {
+		db.Close()
+		log.Fatal("Refusing to start: ", err)
+	}
+
 	ch := make(chan os.Signal, 1)
 
 	signal.Notify(ch, os.Interrupt)
@@ -23,7 +76,7 @@ func main() {
 		os.Exit(1)
 	}()
 
-	app := app.NewApp(db)
+	app := app.NewApp(db, mailer, store)
 
 	app.Run()
 }
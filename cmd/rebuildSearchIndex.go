@@ -0,0 +1,19 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+)
+
+// rebuildSearchIndex refills the full-text search index from the products
+// table, for when it has drifted or after a bulk change made outside the app.
+func rebuildSearchIndex(db *sql.DB) error {
+	err := productRepository.NewProductRepository(db).RebuildSearchIndex()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Search index rebuilt")
+	return nil
+}
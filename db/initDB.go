@@ -5,21 +5,21 @@ import (
 	"log"
 
 	_"github.com/mattn/go-sqlite3"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 )
 
 
 func InitDB() *sql.DB {
-	db, err := sql.Open("sqlite3", "./shopping_cart.db")
+	// foreign keys are enabled per connection, so set them in the DSN for the
+	// whole pool; the cascades and restrictions below rely on it
+	db, err := sql.Open("sqlite3", "./shopping_cart.db?_foreign_keys=on")
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	_, err = db.Exec("PRAGMA foreign_keys = ON")
-	if err != nil {
-		log.Fatal("Error enabling foreign keys:", err)
-	}
-
 	createTables(db)
+	migrate(db)
+	createSearchIndex(db)
 	seed(db)
 
 	return db
@@ -33,16 +33,135 @@ func createTables(db *sql.DB) {
 	    name TEXT NOT NULL,
 		email TEXT NOT NULL UNIQUE,
 	    password TEXT NOT NULL,
-	    role INTEGER NOT NULL
+	    role INTEGER NOT NULL,
+	    token_version INTEGER NOT NULL DEFAULT 0,
+	    email_verified_at DATETIME,
+	    verification_sent_at DATETIME,
+	    status TEXT NOT NULL DEFAULT 'active'
 	);
 
 	CREATE TABLE IF NOT EXISTS products (
 	    id TEXT PRIMARY KEY,
 	    name TEXT NOT NULL,
 	    price REAL NOT NULL CHECK (price >= 0),
-	    stock INTEGER NOT NULL CHECK (stock >= 0)
+	    stock INTEGER NOT NULL CHECK (stock >= 0),
+	    created_at DATETIME,
+	    description TEXT NOT NULL DEFAULT '',
+	    tags TEXT NOT NULL DEFAULT '',
+	    brand TEXT NOT NULL DEFAULT '',
+	    weight_grams INTEGER NOT NULL DEFAULT 0 CHECK (weight_grams >= 0),
+	    length_mm INTEGER,
+	    width_mm INTEGER,
+	    height_mm INTEGER,
+	    specs TEXT NOT NULL DEFAULT '[]',
+	    sku TEXT,
+	    status TEXT NOT NULL DEFAULT 'published',
+	    archived_at DATETIME,
+	    compare_at_price REAL CHECK (compare_at_price IS NULL OR compare_at_price >= 0)
+	);
+
+	CREATE TABLE IF NOT EXISTS categories (
+	    id TEXT PRIMARY KEY,
+	    parent_id TEXT,
+	    name TEXT NOT NULL,
+	    slug TEXT NOT NULL UNIQUE,
+	    sort_order INTEGER NOT NULL DEFAULT 0,
+	    FOREIGN KEY (parent_id) REFERENCES categories(id)
+	);
+
+	CREATE TABLE IF NOT EXISTS product_categories (
+	    product_id TEXT NOT NULL,
+	    category_id TEXT NOT NULL,
+	    PRIMARY KEY (product_id, category_id),
+	    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+	    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS product_options (
+	    product_id TEXT NOT NULL,
+	    name TEXT NOT NULL,
+	    position INTEGER NOT NULL,
+	    option_values TEXT NOT NULL,
+	    PRIMARY KEY (product_id, name),
+	    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS product_variants (
+	    id TEXT PRIMARY KEY,
+	    product_id TEXT NOT NULL,
+	    sku TEXT NOT NULL UNIQUE,
+	    options TEXT NOT NULL,
+	    price REAL CHECK (price IS NULL OR price >= 0),
+	    stock INTEGER NOT NULL CHECK (stock >= 0),
+	    UNIQUE (product_id, options),
+	    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS product_images (
+	    id TEXT PRIMARY KEY,
+	    product_id TEXT NOT NULL,
+	    position INTEGER NOT NULL,
+	    is_primary INTEGER NOT NULL DEFAULT 0,
+	    content_type TEXT NOT NULL,
+	    width INTEGER NOT NULL,
+	    height INTEGER NOT NULL,
+	    size_bytes INTEGER NOT NULL,
+	    created_at DATETIME NOT NULL,
+	    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS price_history (
+	    id TEXT PRIMARY KEY,
+	    product_id TEXT NOT NULL,
+	    price REAL NOT NULL,
+	    compare_at_price REAL,
+	    reason TEXT NOT NULL,
+	    schedule_id TEXT,
+	    changed_at DATETIME NOT NULL,
+	    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS price_history_product ON price_history (product_id, changed_at);
+
+	CREATE TABLE IF NOT EXISTS price_schedules (
+	    id TEXT PRIMARY KEY,
+	    product_id TEXT NOT NULL,
+	    price REAL NOT NULL CHECK (price > 0),
+	    starts_at DATETIME NOT NULL,
+	    ends_at DATETIME,
+	    status TEXT NOT NULL,
+	    created_by TEXT NOT NULL,
+	    created_at DATETIME NOT NULL,
+	    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
 	);
 
+	CREATE INDEX IF NOT EXISTS price_schedules_status ON price_schedules (status);
+
+	CREATE TABLE IF NOT EXISTS stock_movements (
+	    id TEXT PRIMARY KEY,
+	    product_id TEXT NOT NULL,
+	    variant_id TEXT,
+	    delta INTEGER NOT NULL,
+	    balance INTEGER NOT NULL CHECK (balance >= 0),
+	    reason TEXT NOT NULL,
+	    actor_id TEXT,
+	    reference TEXT,
+	    note TEXT NOT NULL DEFAULT '',
+	    created_at DATETIME NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS stock_movements_product ON stock_movements (product_id, created_at);
+
+	CREATE TRIGGER IF NOT EXISTS stock_movements_no_update BEFORE UPDATE ON stock_movements
+	BEGIN
+	    SELECT RAISE(ABORT, 'stock movements can not be changed');
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS stock_movements_no_delete BEFORE DELETE ON stock_movements
+	BEGIN
+	    SELECT RAISE(ABORT, 'stock movements can not be removed');
+	END;
+
 	CREATE TABLE IF NOT EXISTS cart (
 	    id TEXT PRIMARY KEY,
 	    user_id TEXT NOT NULL UNIQUE,
@@ -54,6 +173,7 @@ func createTables(db *sql.DB) {
 	    cart_id TEXT NOT NULL,
 	    product_id TEXT NOT NULL,
 	    quantity INTEGER NOT NULL CHECK (quantity > 0),
+	    variant_id TEXT REFERENCES product_variants(id) ON DELETE CASCADE,
 	    FOREIGN KEY (cart_id) REFERENCES cart(id) ON DELETE CASCADE,
 	    FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
 	);
@@ -62,6 +182,157 @@ func createTables(db *sql.DB) {
 	    code TEXT NOT NULL UNIQUE,
 	    discount REAL NOT NULL CHECK (discount > 0)
 	);
+
+	CREATE TABLE IF NOT EXISTS revoked_tokens (
+	    jti TEXT PRIMARY KEY,
+	    user_id TEXT NOT NULL,
+	    expires_at DATETIME NOT NULL,
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS sessions (
+	    id TEXT PRIMARY KEY,
+	    user_id TEXT NOT NULL,
+	    user_agent TEXT NOT NULL DEFAULT '',
+	    ip TEXT NOT NULL DEFAULT '',
+	    token_version INTEGER NOT NULL,
+	    created_at DATETIME NOT NULL,
+	    last_seen_at DATETIME NOT NULL,
+	    expires_at DATETIME NOT NULL,
+	    revoked_at DATETIME,
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS password_reset_tokens (
+	    id TEXT PRIMARY KEY,
+	    user_id TEXT NOT NULL,
+	    token_hash TEXT NOT NULL UNIQUE,
+	    expires_at DATETIME NOT NULL,
+	    used_at DATETIME,
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS user_mfa (
+	    user_id TEXT PRIMARY KEY,
+	    secret TEXT NOT NULL,
+	    enabled_at DATETIME,
+	    last_used_step INTEGER NOT NULL DEFAULT 0,
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+	    id TEXT PRIMARY KEY,
+	    user_id TEXT NOT NULL,
+	    code_hash TEXT NOT NULL,
+	    used_at DATETIME,
+	    UNIQUE (user_id, code_hash),
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS orders (
+	    id TEXT PRIMARY KEY,
+	    user_id TEXT NOT NULL,
+	    total REAL NOT NULL,
+	    coupon_code TEXT,
+	    created_at DATETIME NOT NULL,
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE RESTRICT
+	);
+
+	CREATE TABLE IF NOT EXISTS order_items (
+	    order_id TEXT NOT NULL,
+	    product_id TEXT NOT NULL,
+	    product_name TEXT NOT NULL,
+	    price REAL NOT NULL,
+	    quantity INTEGER NOT NULL,
+	    variant_id TEXT,
+	    sku TEXT,
+	    options TEXT,
+	    FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS login_attempts (
+	    key TEXT PRIMARY KEY,
+	    failures INTEGER NOT NULL DEFAULT 0,
+	    last_failure_at DATETIME NOT NULL,
+	    locked_until DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS roles (
+	    name TEXT PRIMARY KEY,
+	    description TEXT NOT NULL DEFAULT '',
+	    built_in INTEGER NOT NULL DEFAULT 0
+	);
+
+	CREATE TABLE IF NOT EXISTS role_permissions (
+	    role TEXT NOT NULL,
+	    permission TEXT NOT NULL,
+	    PRIMARY KEY (role, permission),
+	    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS user_roles (
+	    user_id TEXT NOT NULL,
+	    role TEXT NOT NULL,
+	    PRIMARY KEY (user_id, role),
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS api_keys (
+	    id TEXT PRIMARY KEY,
+	    user_id TEXT NOT NULL,
+	    name TEXT NOT NULL,
+	    prefix TEXT NOT NULL,
+	    key_hash TEXT NOT NULL UNIQUE,
+	    scopes TEXT NOT NULL,
+	    created_at DATETIME NOT NULL,
+	    last_used_at DATETIME,
+	    revoked_at DATETIME,
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS oidc_login_states (
+	    state_hash TEXT PRIMARY KEY,
+	    nonce TEXT NOT NULL,
+	    code_verifier TEXT NOT NULL,
+	    expires_at DATETIME NOT NULL
+	);
+
+	CREATE TABLE IF NOT EXISTS user_identities (
+	    issuer TEXT NOT NULL,
+	    subject TEXT NOT NULL,
+	    user_id TEXT NOT NULL,
+	    email TEXT NOT NULL DEFAULT '',
+	    created_at DATETIME NOT NULL,
+	    PRIMARY KEY (issuer, subject),
+	    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE IF NOT EXISTS import_jobs (
+	    id TEXT PRIMARY KEY,
+	    created_by TEXT NOT NULL,
+	    format TEXT NOT NULL,
+	    dry_run INTEGER NOT NULL DEFAULT 0,
+	    status TEXT NOT NULL,
+	    total_rows INTEGER NOT NULL DEFAULT 0,
+	    processed_rows INTEGER NOT NULL DEFAULT 0,
+	    created INTEGER NOT NULL DEFAULT 0,
+	    updated INTEGER NOT NULL DEFAULT 0,
+	    failed INTEGER NOT NULL DEFAULT 0,
+	    errors TEXT NOT NULL DEFAULT '[]',
+	    created_at DATETIME NOT NULL,
+	    finished_at DATETIME
+	);
+
+	CREATE TABLE IF NOT EXISTS audit_log (
+	    id TEXT PRIMARY KEY,
+	    event TEXT NOT NULL,
+	    user_id TEXT,
+	    actor_id TEXT,
+	    ip TEXT,
+	    detail TEXT,
+	    created_at DATETIME NOT NULL
+	);
 	`
 
 	_, err := db.Exec(createTables)
@@ -70,35 +341,196 @@ func createTables(db *sql.DB) {
 	}	
 }
 
-func seed(db *sql.DB) {
-	_, err := db.Exec(`
-		INSERT OR IGNORE INTO users (id, name, email, password, role)
-		VALUES ('u_Admin', 'Admin User', 'admin@shyam.com', 'admin@123', 1)
+// migrate brings databases created by older versions up to the current schema.
+func migrate(db *sql.DB) {
+	addColumn(db, "users", "token_version", "INTEGER NOT NULL DEFAULT 0")
+	if addColumn(db, "users", "email_verified_at", "DATETIME") {
+		// accounts created before verification existed are trusted as-is
+		_, err := db.Exec("UPDATE users SET email_verified_at = CURRENT_TIMESTAMP")
+		if err != nil {
+			log.Fatal("Error backfilling email verification:", err)
+		}
+	}
+	addColumn(db, "users", "verification_sent_at", "DATETIME")
+	addColumn(db, "users", "status", "TEXT NOT NULL DEFAULT 'active'")
+	if addColumn(db, "products", "created_at", "DATETIME") {
+		_, err := db.Exec("UPDATE products SET created_at = CURRENT_TIMESTAMP")
+		if err != nil {
+			log.Fatal("Error backfilling product creation dates:", err)
+		}
+	}
+	addColumn(db, "products", "description", "TEXT NOT NULL DEFAULT ''")
+	addColumn(db, "products", "tags", "TEXT NOT NULL DEFAULT ''")
+	addColumn(db, "products", "brand", "TEXT NOT NULL DEFAULT ''")
+	addColumn(db, "products", "weight_grams", "INTEGER NOT NULL DEFAULT 0 CHECK (weight_grams >= 0)")
+	addColumn(db, "products", "length_mm", "INTEGER")
+	addColumn(db, "products", "width_mm", "INTEGER")
+	addColumn(db, "products", "height_mm", "INTEGER")
+	addColumn(db, "products", "specs", "TEXT NOT NULL DEFAULT '[]'")
+	addColumn(db, "products", "sku", "TEXT")
+	addColumn(db, "products", "status", "TEXT NOT NULL DEFAULT 'published'")
+	addColumn(db, "products", "archived_at", "DATETIME")
+	addColumn(db, "products", "compare_at_price", "REAL CHECK (compare_at_price IS NULL OR compare_at_price >= 0)")
+	// ALTER TABLE can not add a UNIQUE column, so SKUs are kept unique by an
+	// index; products without a SKU store NULL, which it allows repeatedly
+	_, err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS products_sku ON products (sku)")
+	if err != nil {
+		log.Fatal("Error creating product SKU index:", err)
+	}
+	addColumn(db, "cart_items", "variant_id", "TEXT REFERENCES product_variants(id) ON DELETE CASCADE")
+	addColumn(db, "order_items", "variant_id", "TEXT")
+	addColumn(db, "order_items", "sku", "TEXT")
+	addColumn(db, "order_items", "options", "TEXT")
+}
+
+var searchTriggers = []string{"products_fts_insert", "products_fts_update", "products_fts_delete"}
+
+// createSearchIndex sets up the FTS5 product index and the triggers that keep
+// it in sync. FTS5 is only compiled into the sqlite driver with the
+// sqlite_fts5 build tag; without it the shop runs with search disabled.
+func createSearchIndex(db *sql.DB) {
+	var fts5 bool
+	err := db.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5)
+	if err != nil {
+		log.Fatal("Error reading sqlite options:", err)
+	}
+	if !fts5 {
+		// triggers left by an FTS5 build would fail every product write
+		// with "no such module"; the index is rebuilt once they come back
+		for _, trigger := range searchTriggers {
+			_, err = db.Exec("DROP TRIGGER IF EXISTS " + trigger)
+			if err != nil {
+				log.Fatal("Error dropping search trigger:", err)
+			}
+		}
+		log.Println("Full-text search is disabled, build with -tags sqlite_fts5 to enable it")
+		return
+	}
+
+	var exists, triggers int
+	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'products_fts'").Scan(&exists)
+	if err != nil {
+		log.Fatal("Error reading search index:", err)
+	}
+	err = db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name LIKE 'products_fts_%'").Scan(&triggers)
+	if err != nil {
+		log.Fatal("Error reading search index:", err)
+	}
+	_, err = db.Exec(`
+	CREATE VIRTUAL TABLE IF NOT EXISTS products_fts USING fts5(
+	    product_id UNINDEXED,
+	    name,
+	    description,
+	    tags,
+	    tokenize = 'unicode61 remove_diacritics 2',
+	    prefix = '2 3'
+	);
+
+	CREATE TRIGGER IF NOT EXISTS products_fts_insert AFTER INSERT ON products BEGIN
+	    INSERT INTO products_fts (product_id, name, description, tags) VALUES (new.id, new.name, new.description, new.tags);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS products_fts_update AFTER UPDATE OF name, description, tags ON products BEGIN
+	    DELETE FROM products_fts WHERE product_id = old.id;
+	    INSERT INTO products_fts (product_id, name, description, tags) VALUES (new.id, new.name, new.description, new.tags);
+	END;
+
+	CREATE TRIGGER IF NOT EXISTS products_fts_delete AFTER DELETE ON products BEGIN
+	    DELETE FROM products_fts WHERE product_id = old.id;
+	END;
 	`)
 	if err != nil {
-		log.Fatal("Error seeding admin:", err)
+		log.Fatal("Error creating search index:", err)
 	}
+	// a new index is empty, and one whose triggers were dropped has missed
+	// every product change since
+	if exists == 0 || triggers < len(searchTriggers) {
+		_, err = db.Exec("DELETE FROM products_fts")
+		if err != nil {
+			log.Fatal("Error building search index:", err)
+		}
+		_, err = db.Exec("INSERT INTO products_fts (product_id, name, description, tags) SELECT id, name, description, tags FROM products")
+		if err != nil {
+			log.Fatal("Error building search index:", err)
+		}
+	}
+}
 
+// addColumn adds the column when it is missing and reports whether it did.
+func addColumn(db *sql.DB, table, column, definition string) bool {
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&count)
+	if err != nil {
+		log.Fatal("Error reading table info:", err)
+	}
+	if count > 0 {
+		return false
+	}
+	_, err = db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition)
+	if err != nil {
+		log.Fatalf("Error adding column %s.%s: %v", table, column, err)
+	}
+	return true
+}
+
+func seed(db *sql.DB) {
 	products := []struct {
-		id    string
-		name  string
-		price float64
-		stock int
+		id          string
+		sku         string
+		name        string
+		price       float64
+		stock       int
+		description string
+		tags        string
+		brand       string
+		weightGrams int
+		specs       string
 	}{
-		{"p1", "Laptop", 75000.00, 10},
-		{"p2", "Smartphone", 35000.00, 25},
-		{"p3", "Headphones", 2500.00, 50},
-		{"p4", "Keyboard", 1200.00, 30},
-		{"p5", "Monitor", 15000.00, 15},
+		{"p1", "LAP-001", "Laptop", 75000.00, 10, "14 inch notebook with 16 GB of memory and a 512 GB SSD", "computer,notebook", "Lenovo", 1400,
+			`[{"key":"screen_size","type":"number","value":14,"unit":"in"},{"key":"ram","type":"number","value":16,"unit":"GB"},{"key":"touchscreen","type":"bool","value":false}]`},
+		{"p2", "PHN-001", "Smartphone", 35000.00, 25, "Android phone with a 6.5 inch display and dual cameras", "phone,mobile", "Samsung", 190,
+			`[{"key":"screen_size","type":"number","value":6.5,"unit":"in"},{"key":"os","type":"text","value":"Android"}]`},
+		{"p3", "AUD-001", "Headphones", 2500.00, 50, "Over-ear wireless headphones with noise cancellation", "audio,wireless", "Sony", 250,
+			`[{"key":"wireless","type":"bool","value":true},{"key":"noise_cancelling","type":"bool","value":true}]`},
+		{"p4", "KBD-001", "Keyboard", 1200.00, 30, "Mechanical keyboard with backlit keys", "computer,accessory", "Logitech", 900,
+			`[{"key":"layout","type":"text","value":"US"},{"key":"wireless","type":"bool","value":false}]`},
+		{"p5", "MON-001", "Monitor", 15000.00, 15, "27 inch IPS display for the office", "computer,display", "Dell", 5200,
+			`[{"key":"screen_size","type":"number","value":27,"unit":"in"},{"key":"panel","type":"text","value":"IPS"}]`},
 	}
 
 	for _, p := range products {
 		_, err := db.Exec(`
-			INSERT OR IGNORE INTO products (id, name, price, stock)
-			VALUES (?, ?, ?, ?)
-		`, p.id, p.name, p.price, p.stock)
+			INSERT OR IGNORE INTO products (id, sku, name, price, stock, created_at, description, tags, brand, weight_grams, specs)
+			VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?, ?, ?, ?, ?)
+		`, p.id, p.sku, p.name, p.price, p.stock, p.description, p.tags, p.brand, p.weightGrams, p.specs)
 		if err != nil {
 			log.Fatal("Error seeding products:", err)
 		}
 	}
+
+	seedRoles(db)
+}
+
+// seedRoles writes the built-in roles, resetting their permissions to the
+// current definitions.
+func seedRoles(db *sql.DB) {
+	for _, role := range models.BuiltInRoles {
+		_, err := db.Exec(`
+			INSERT INTO roles (name, description, built_in) VALUES (?, ?, 1)
+			ON CONFLICT(name) DO UPDATE SET description = excluded.description, built_in = 1
+		`, role.Name, role.Description)
+		if err != nil {
+			log.Fatal("Error seeding roles:", err)
+		}
+		_, err = db.Exec("DELETE FROM role_permissions WHERE role = ?", role.Name)
+		if err != nil {
+			log.Fatal("Error seeding roles:", err)
+		}
+		for _, perm := range role.Permissions {
+			_, err = db.Exec("INSERT INTO role_permissions (role, permission) VALUES (?, ?)", role.Name, perm)
+			if err != nil {
+				log.Fatal("Error seeding roles:", err)
+			}
+		}
+	}
 }
\ No newline at end of file
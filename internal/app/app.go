@@ -6,53 +6,197 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/blobstore"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
 	adminhandler "github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/adminHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/apiKeyHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/authHandler"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/cartHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/categoryHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/imageHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/inventoryHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/lockoutHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/mfaHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/oidcHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/passwordHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/priceHandler"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/productHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/roleHandler"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/userHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/variantHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/handlers/verificationHandler"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mailer"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/oidc"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/apiKeyRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/auditRepository"
 	cartRepository "github.com/meshyampratap01/OnlineShoppingCart/internal/repository/cartRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/categoryRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/couponRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/imageRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/importJobRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/inventoryRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/loginAttemptRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/mfaRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/oidcRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/orderRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/priceRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/resetTokenRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/roleRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/sessionRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/tokenRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/variantRepository"
 	adminservice "github.com/meshyampratap01/OnlineShoppingCart/internal/services/adminService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/apiKeyService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authzService"
 	cartservice "github.com/meshyampratap01/OnlineShoppingCart/internal/services/cartService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/categoryService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/imageService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/inventoryService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/mfaService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/oidcService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/passwordService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/priceService"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/productService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/suggestService"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/userService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/variantService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/verificationService"
 )
 
 type App struct {
 	db     *sql.DB
 	apimux *http.ServeMux
+	// media serves the blobs of a local store; nil when they are served
+	// elsewhere.
+	media http.Handler
 
-	UserHandler    userHandler.UserHandler
-	ProductHandler productHandler.ProductHandler
-	AdminHandler   adminhandler.AdminHandler
-	CartHandler    cartHandler.CartHandler
+	authService  authService.AuthServiceManager
+	authzService authzService.AuthzServiceManager
+
+	UserHandler         userHandler.UserHandler
+	ProductHandler      productHandler.ProductHandler
+	AdminHandler        adminhandler.AdminHandler
+	CartHandler         cartHandler.CartHandler
+	AuthHandler         authHandler.AuthHandler
+	PasswordHandler     passwordHandler.PasswordHandler
+	VerificationHandler verificationHandler.VerificationHandler
+	MFAHandler          mfaHandler.MFAHandler
+	LockoutHandler      lockoutHandler.LockoutHandler
+	RoleHandler         roleHandler.RoleHandler
+	APIKeyHandler       apiKeyHandler.APIKeyHandler
+	OIDCHandler         oidcHandler.OIDCHandler
+	CategoryHandler     categoryHandler.CategoryHandler
+	VariantHandler      variantHandler.VariantHandler
+	ImageHandler        imageHandler.ImageHandler
+	PriceHandler        priceHandler.PriceHandler
+	InventoryHandler    inventoryHandler.InventoryHandler
 }
 
-func NewApp(db *sql.DB) *App {
+func NewApp(db *sql.DB, mailer mailer.Mailer, store blobstore.BlobStore) *App {
 	userRepo := userRepository.NewUserRepository(db)
 	prodRepo := productRepository.NewProductRepository(db)
 	couponRepo := couponRepository.NewCouponRepository(db)
 	cartRepo := cartRepository.NewCartRepository(db)
+	tokenRepo := tokenRepository.NewTokenRepository(db)
+	resetTokenRepo := resetTokenRepository.NewResetTokenRepository(db)
+	mfaRepo := mfaRepository.NewMFARepository(db)
+	loginAttemptRepo := loginAttemptRepository.NewLoginAttemptRepository(db)
+	auditRepo := auditRepository.NewAuditRepository(db)
+	orderRepo := orderRepository.NewOrderRepository(db)
+	roleRepo := roleRepository.NewRoleRepository(db)
+	apiKeyRepo := apiKeyRepository.NewAPIKeyRepository(db)
+	oidcRepo := oidcRepository.NewOIDCRepository(db)
+	sessionRepo := sessionRepository.NewSessionRepository(db)
+	categoryRepo := categoryRepository.NewCategoryRepository(db)
+	variantRepo := variantRepository.NewVariantRepository(db)
+	imageRepo := imageRepository.NewImageRepository(db)
+	importJobRepo := importJobRepository.NewImportJobRepository(db)
+	priceRepo := priceRepository.NewPriceRepository(db)
+	inventoryRepo := inventoryRepository.NewInventoryRepository(db)
 
-	userServ := userService.NewUserService(userRepo, prodRepo, couponRepo, cartRepo)
-	prodServ := productService.NewProductService(prodRepo)
-	adminServ := adminservice.NewAdminService(prodRepo, couponRepo)
-	cartServ := cartservice.NewCartService(cartRepo, prodRepo, couponRepo)
+	authzServ := authzService.NewAuthzService(roleRepo, userRepo)
+	verificationServ := verificationService.NewVerificationService(userRepo, mailer)
+	mfaServ := mfaService.NewMFAService(mfaRepo, userRepo, authzServ)
+	lockoutServ := lockoutService.NewLockoutService(loginAttemptRepo, auditRepo, userRepo)
+	userServ := userService.NewUserService(userRepo, prodRepo, couponRepo, cartRepo, orderRepo, sessionRepo, auditRepo, verificationServ, mfaServ, lockoutServ)
+	imageServ := imageService.NewImageService(imageRepo, prodRepo, store)
+	prodServ := productService.NewProductService(prodRepo, categoryRepo, variantRepo, imageServ)
+	categoryServ := categoryService.NewCategoryService(categoryRepo, prodRepo)
+	variantServ := variantService.NewVariantService(variantRepo, prodRepo)
+	suggestServ := suggestService.NewSuggestService(prodRepo)
+	err := suggestServ.Refresh()
+	if err != nil {
+		log.Printf("can not build search suggestions: %v", err)
+	}
+	adminServ := adminservice.NewAdminService(prodRepo, couponRepo, userRepo, cartRepo, orderRepo, suggestServ, imageServ, importJobRepo, priceRepo, inventoryRepo, authzServ)
+	adminServ.FailInterruptedImports()
+	priceServ := priceService.NewPriceService(priceRepo, prodRepo)
+	go priceServ.RunScheduler(config.PriceScheduleInterval)
+	inventoryServ := inventoryService.NewInventoryService(inventoryRepo, prodRepo, variantRepo)
+	err = inventoryServ.ReconcileStock()
+	if err != nil {
+		log.Printf("can not reconcile stock with the ledger: %v", err)
+	}
+	cartServ := cartservice.NewCartService(cartRepo, prodRepo, couponRepo, userRepo, orderRepo, variantRepo, inventoryRepo)
+	authServ := authService.NewAuthService(tokenRepo, userRepo, apiKeyRepo, sessionRepo)
+	apiKeyServ := apiKeyService.NewAPIKeyService(apiKeyRepo, authzServ)
+	passwordServ := passwordService.NewPasswordService(userRepo, resetTokenRepo, tokenRepo, mailer, authzServ, lockoutServ)
+	oidcServ := oidcService.NewOIDCService(oidc.Config{
+		Issuer:       config.OIDCIssuer,
+		ClientID:     config.OIDCClientID,
+		ClientSecret: config.OIDCClientSecret,
+		RedirectURL:  config.OIDCRedirectURL,
+		Scopes:       config.OIDCScopes,
+		GroupsClaim:  config.OIDCGroupsClaim,
+	}, config.OIDCAdminGroups, oidcRepo, userRepo, cartRepo, tokenRepo, userServ)
 
 	userHandler := userHandler.NewUserHandler(userServ)
-	prodHandler := productHandler.NewProductHandler(prodServ)
+	prodHandler := productHandler.NewProductHandler(prodServ, suggestServ)
 	adminHandler := adminhandler.NewAdminHandler(adminServ)
 	cartHandler := cartHandler.NewCartHandler(cartServ)
+	authHandler := authHandler.NewAuthHandler(authServ)
+	passwordHandler := passwordHandler.NewPasswordHandler(passwordServ)
+	verificationHandler := verificationHandler.NewVerificationHandler(verificationServ)
+	mfaHandler := mfaHandler.NewMFAHandler(mfaServ)
+	lockoutHandler := lockoutHandler.NewLockoutHandler(lockoutServ)
+	roleHandler := roleHandler.NewRoleHandler(authzServ)
+	apiKeyHandler := apiKeyHandler.NewAPIKeyHandler(apiKeyServ)
+	oidcHandler := oidcHandler.NewOIDCHandler(oidcServ)
+	categoryHandler := categoryHandler.NewCategoryHandler(categoryServ)
+	variantHandler := variantHandler.NewVariantHandler(variantServ)
+	imageHandler := imageHandler.NewImageHandler(imageServ)
+	priceHandler := priceHandler.NewPriceHandler(priceServ)
+	inventoryHandler := inventoryHandler.NewInventoryHandler(inventoryServ)
 
 	app := &App{
-		db:             db,
-		apimux:         http.NewServeMux(),
-		UserHandler:    *userHandler,
-		ProductHandler: *prodHandler,
-		AdminHandler:   *adminHandler,
-		CartHandler:    *cartHandler,
+		db:                  db,
+		apimux:              http.NewServeMux(),
+		authService:         authServ,
+		authzService:        authzServ,
+		UserHandler:         *userHandler,
+		ProductHandler:      *prodHandler,
+		AdminHandler:        *adminHandler,
+		CartHandler:         *cartHandler,
+		AuthHandler:         *authHandler,
+		PasswordHandler:     *passwordHandler,
+		VerificationHandler: *verificationHandler,
+		MFAHandler:          *mfaHandler,
+		LockoutHandler:      *lockoutHandler,
+		RoleHandler:         *roleHandler,
+		APIKeyHandler:       *apiKeyHandler,
+		OIDCHandler:         *oidcHandler,
+		CategoryHandler:     *categoryHandler,
+		VariantHandler:      *variantHandler,
+		ImageHandler:        *imageHandler,
+		PriceHandler:        *priceHandler,
+		InventoryHandler:    *inventoryHandler,
+	}
+	if local, ok := store.(*blobstore.LocalStore); ok {
+		app.media = local
 	}
 
 	app.RegisterRoutes()
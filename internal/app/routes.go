@@ -4,36 +4,139 @@ import (
 	"net/http"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/middleware"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 )
 
 var baseURL = "/api/v1"
 
-func withAuth(next http.HandlerFunc) http.HandlerFunc {
+// withAuth authenticates the caller with a session token; API keys are
+// refused.
+func (app *App) withAuth(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		middleware.AuthMiddleware(next).ServeHTTP(w, r)
+		middleware.AuthMiddleware(app.authService, middleware.RequireSession(next)).ServeHTTP(w, r)
+	}
+}
+
+// withPermission authenticates the caller, with a session token or an API
+// key, and then requires perm.
+func (app *App) withPermission(perm models.Permission, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		middleware.AuthMiddleware(app.authService, middleware.RequirePermission(app.authzService, perm, next)).ServeHTTP(w, r)
 	}
 }
 
 
 func (app *App) RegisterRoutes() {
+	app.apimux.HandleFunc("GET /.well-known/jwks.json", app.AuthHandler.JWKSHandler)
+	if app.media != nil {
+		app.apimux.Handle("GET /media/", http.StripPrefix("/media", app.media))
+	}
+
 	app.apimux.HandleFunc("POST "+baseURL+"/register", app.UserHandler.RegisterUser)
 	app.apimux.HandleFunc("POST "+baseURL+"/login", app.UserHandler.LoginHandler)
+	app.apimux.HandleFunc("POST "+baseURL+"/login/mfa", app.UserHandler.MFALoginHandler)
+	app.apimux.HandleFunc("POST "+baseURL+"/login/mfa/setup", app.UserHandler.MFASetupHandler)
+	app.apimux.HandleFunc("POST "+baseURL+"/login/mfa/setup/confirm", app.UserHandler.MFASetupConfirmHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/login/oidc", app.OIDCHandler.LoginHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/login/oidc/callback", app.OIDCHandler.CallbackHandler)
+	app.apimux.HandleFunc("POST "+baseURL+"/logout", app.withAuth(app.AuthHandler.LogoutHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/logout-all", app.withAuth(app.AuthHandler.LogoutAllHandler))
+
+	app.apimux.HandleFunc("GET "+baseURL+"/verify-email", app.VerificationHandler.VerifyEmailHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/verify-email/change", app.VerificationHandler.ConfirmEmailChangeHandler)
+	app.apimux.HandleFunc("POST "+baseURL+"/verify-email/resend", app.withAuth(app.VerificationHandler.ResendVerificationHandler))
+
+	app.apimux.HandleFunc("POST "+baseURL+"/password/forgot", app.PasswordHandler.ForgotPasswordHandler)
+	app.apimux.HandleFunc("POST "+baseURL+"/password/reset", app.PasswordHandler.ResetPasswordHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/me", app.withAuth(app.UserHandler.GetProfileHandler))
+	app.apimux.HandleFunc("PATCH "+baseURL+"/me", app.withAuth(app.UserHandler.UpdateProfileHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/me", app.withAuth(app.UserHandler.DeleteAccountHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/me/export", app.withAuth(app.UserHandler.ExportDataHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/me/password", app.withAuth(app.PasswordHandler.ChangePasswordHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/me/sessions", app.withAuth(app.AuthHandler.ListSessionsHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/me/sessions/{sessionID}", app.withAuth(app.AuthHandler.TerminateSessionHandler))
 
-	app.apimux.HandleFunc("GET "+baseURL+"/products", app.ProductHandler.GetAllProducts)//can search by name with "name" query param
+	app.apimux.HandleFunc("POST "+baseURL+"/me/mfa/enroll", app.withAuth(app.MFAHandler.EnrollHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/me/mfa/confirm", app.withAuth(app.MFAHandler.ConfirmHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/me/mfa/recovery-codes", app.withAuth(app.MFAHandler.RegenerateRecoveryCodesHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/me/mfa", app.withAuth(app.MFAHandler.DisableHandler))
+
+	app.apimux.HandleFunc("GET "+baseURL+"/products", app.ProductHandler.GetAllProducts)//filters, sort and pages as query params
 	app.apimux.HandleFunc("GET "+baseURL+"/products/{prodID}", app.ProductHandler.GetProductByID)
+	app.apimux.HandleFunc("GET "+baseURL+"/search", app.ProductHandler.SearchProducts)
+	app.apimux.HandleFunc("GET "+baseURL+"/search/suggest", app.ProductHandler.SuggestHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/products/{prodID}/categories", app.CategoryHandler.GetProductCategoriesHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/products/{prodID}/variants", app.VariantHandler.ListVariantsHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/products/{prodID}/images", app.ImageHandler.ListImagesHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/categories", app.CategoryHandler.GetCategoryTreeHandler)
+	app.apimux.HandleFunc("GET "+baseURL+"/categories/{slug}/products", app.ProductHandler.GetCategoryProducts)
+
+	app.apimux.HandleFunc("POST "+baseURL+"/cart/{prodID}", app.withPermission(models.PermCartUse, app.CartHandler.AddToCartHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/cart", app.withPermission(models.PermCartUse, app.CartHandler.GetCartHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/cart/{prodID}", app.withPermission(models.PermCartUse, app.CartHandler.RemoveFromCartHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/checkout", app.withPermission(models.PermCartUse, app.CartHandler.CheckOutHandler))// can use a code for discount "code" query param
+
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/products", app.withPermission(models.PermProductsWrite, app.ProductHandler.GetAdminProducts))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/products", app.withPermission(models.PermProductsWrite, app.AdminHandler.AddProductHandler))
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/products/{prodID}", app.withPermission(models.PermProductsWrite, app.AdminHandler.UpdateProductHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/products/{prodID}", app.withPermission(models.PermProductsWrite, app.ProductHandler.GetAdminProductByID))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/products/{prodID}", app.withPermission(models.PermProductsWrite, app.AdminHandler.ArchiveProductHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/products/{prodID}/restore", app.withPermission(models.PermProductsWrite, app.AdminHandler.RestoreProductHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/products/{prodID}/purge", app.withPermission(models.PermProductsWrite, app.AdminHandler.PurgeProductHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/products/import", app.withPermission(models.PermProductsWrite, app.AdminHandler.ImportProductsHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/products/export", app.withPermission(models.PermProductsWrite, app.AdminHandler.ExportProductsHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/imports/{jobID}", app.withPermission(models.PermProductsWrite, app.AdminHandler.GetImportJobHandler))
+
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/products/{prodID}/categories", app.withPermission(models.PermProductsWrite, app.CategoryHandler.SetProductCategoriesHandler))
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/products/{prodID}/options", app.withPermission(models.PermProductsWrite, app.VariantHandler.SetOptionsHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/products/{prodID}/variants", app.withPermission(models.PermProductsWrite, app.VariantHandler.CreateVariantHandler))
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/products/{prodID}/variants/{variantID}", app.withPermission(models.PermProductsWrite, app.VariantHandler.UpdateVariantHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/products/{prodID}/variants/{variantID}", app.withPermission(models.PermProductsWrite, app.VariantHandler.DeleteVariantHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/products/{prodID}/images", app.withPermission(models.PermProductsWrite, app.ImageHandler.UploadImageHandler))
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/products/{prodID}/images/order", app.withPermission(models.PermProductsWrite, app.ImageHandler.ReorderImagesHandler))
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/products/{prodID}/images/{imageID}/primary", app.withPermission(models.PermProductsWrite, app.ImageHandler.SetPrimaryImageHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/products/{prodID}/images/{imageID}", app.withPermission(models.PermProductsWrite, app.ImageHandler.DeleteImageHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/products/{prodID}/prices/timeline", app.withPermission(models.PermProductsWrite, app.PriceHandler.GetPriceTimelineHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/products/{prodID}/prices/schedules", app.withPermission(models.PermProductsWrite, app.PriceHandler.SchedulePriceHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/products/{prodID}/prices/schedules/{scheduleID}", app.withPermission(models.PermProductsWrite, app.PriceHandler.CancelScheduleHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/products/{prodID}/stock-movements", app.withPermission(models.PermProductsWrite, app.InventoryHandler.ListStockMovementsHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/products/{prodID}/stock-adjustments", app.withPermission(models.PermProductsWrite, app.InventoryHandler.AdjustStockHandler))
+
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/categories", app.withPermission(models.PermProductsWrite, app.CategoryHandler.ListCategoriesHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/categories", app.withPermission(models.PermProductsWrite, app.CategoryHandler.CreateCategoryHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/categories/{categoryID}", app.withPermission(models.PermProductsWrite, app.CategoryHandler.GetCategoryHandler))
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/categories/{categoryID}", app.withPermission(models.PermProductsWrite, app.CategoryHandler.UpdateCategoryHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/categories/{categoryID}", app.withPermission(models.PermProductsWrite, app.CategoryHandler.DeleteCategoryHandler))
+
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/coupons", app.withPermission(models.PermCouponsWrite, app.AdminHandler.AddCouponHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/coupons/{code}", app.withPermission(models.PermCouponsWrite, app.AdminHandler.RemoveCouponHandler))
+
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/orders", app.withPermission(models.PermOrdersRead, app.AdminHandler.ListOrdersHandler))
 
-	app.apimux.HandleFunc("POST "+baseURL+"/cart/{prodID}", withAuth(app.CartHandler.AddToCartHandler))
-	app.apimux.HandleFunc("GET "+baseURL+"/cart", withAuth(app.CartHandler.GetCartHandler))
-	app.apimux.HandleFunc("DELETE "+baseURL+"/cart/{prodID}", withAuth(app.CartHandler.RemoveFromCartHandler))
-	app.apimux.HandleFunc("POST "+baseURL+"/checkout", withAuth(app.CartHandler.CheckOutHandler))// can use a code for discount "code" query param
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/users", app.withPermission(models.PermUsersRead, app.AdminHandler.ListUsersHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/users/{userID}", app.withPermission(models.PermUsersRead, app.AdminHandler.GetUserHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/users/{userID}/cart", app.withPermission(models.PermOrdersRead, app.AdminHandler.GetUserCartHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/users/{userID}/orders", app.withPermission(models.PermOrdersRead, app.AdminHandler.GetUserOrdersHandler))
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/users/{userID}/role", app.withPermission(models.PermRolesManage, app.AdminHandler.UpdateUserRoleHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/users/{userID}/suspend", app.withPermission(models.PermUsersManage, app.AdminHandler.SuspendUserHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/users/{userID}/reactivate", app.withPermission(models.PermUsersManage, app.AdminHandler.ReactivateUserHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/users/{userID}/password-reset", app.withPermission(models.PermUsersManage, app.PasswordHandler.ForceResetHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/users/{userID}/logout-all", app.withPermission(models.PermUsersManage, app.AuthHandler.RevokeUserSessionsHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/users/{userID}/unlock", app.withPermission(models.PermUsersManage, app.LockoutHandler.UnlockUserHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/users/{userID}/roles", app.withPermission(models.PermRolesManage, app.RoleHandler.GetUserRolesHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/users/{userID}/roles", app.withPermission(models.PermRolesManage, app.RoleHandler.AssignRoleHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/users/{userID}/roles/{name}", app.withPermission(models.PermRolesManage, app.RoleHandler.UnassignRoleHandler))
 
-	app.apimux.HandleFunc("GET "+baseURL+"/admin/products", withAuth(app.ProductHandler.GetAllProducts))
-	app.apimux.HandleFunc("POST "+baseURL+"/admin/products", withAuth(app.AdminHandler.AddProductHandler))
-	app.apimux.HandleFunc("PUT "+baseURL+"/admin/products/{prodID}", withAuth(app.AdminHandler.UpdateProductHandler))
-	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/products/{prodID}", withAuth(app.AdminHandler.RemoveProductHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/permissions", app.withPermission(models.PermRolesManage, app.RoleHandler.ListPermissionsHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/roles", app.withPermission(models.PermRolesManage, app.RoleHandler.ListRolesHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/roles", app.withPermission(models.PermRolesManage, app.RoleHandler.CreateRoleHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/roles/{name}", app.withPermission(models.PermRolesManage, app.RoleHandler.GetRoleHandler))
+	app.apimux.HandleFunc("PUT "+baseURL+"/admin/roles/{name}", app.withPermission(models.PermRolesManage, app.RoleHandler.UpdateRoleHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/roles/{name}", app.withPermission(models.PermRolesManage, app.RoleHandler.DeleteRoleHandler))
 
-	app.apimux.HandleFunc("POST "+baseURL+"/admin/coupons", withAuth(app.AdminHandler.AddCouponHandler))
-	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/coupons/{code}", withAuth(app.AdminHandler.RemoveCouponHandler))
+	app.apimux.HandleFunc("GET "+baseURL+"/admin/api-keys", app.withPermission(models.PermAPIKeysManage, app.APIKeyHandler.ListAPIKeysHandler))
+	app.apimux.HandleFunc("POST "+baseURL+"/admin/api-keys", app.withPermission(models.PermAPIKeysManage, app.APIKeyHandler.CreateAPIKeyHandler))
+	app.apimux.HandleFunc("DELETE "+baseURL+"/admin/api-keys/{keyID}", app.withPermission(models.PermAPIKeysManage, app.APIKeyHandler.RevokeAPIKeyHandler))
 }
 
 
@@ -0,0 +1,77 @@
+package blobstore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore keeps binary objects such as product images under slash
+// separated keys. Deleting a missing key is not an error.
+type BlobStore interface {
+	Put(key, contentType string, data []byte) error
+	Get(key string) (io.ReadCloser, string, error)
+	Delete(key string) error
+	// URL is where clients can download the blob.
+	URL(key string) string
+}
+
+// checkKey accepts relative keys made of non-empty segments other than "."
+// and "..", so a key can not escape the store's root.
+func checkKey(key string) error {
+	if key == "" || strings.ContainsAny(key, "\\\x00") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// NewFromEnv picks the blob store from the environment:
+//
+//	BLOB_STORE            "local" (default) or "s3"
+//	BLOB_DIR              directory of the local store, "./media" when unset
+//	S3_ENDPOINT           base URL of the S3 compatible service
+//	S3_BUCKET             bucket name, addressed path style
+//	S3_REGION             signing region, "us-east-1" when unset
+//	S3_ACCESS_KEY_ID      access key
+//	S3_SECRET_ACCESS_KEY  secret key
+//	S3_PUBLIC_URL         optional base URL blobs are downloaded from,
+//	                      S3_ENDPOINT/S3_BUCKET when unset
+//
+// Local blobs are served by the app under localURL.
+func NewFromEnv(localURL string) (BlobStore, error) {
+	switch os.Getenv("BLOB_STORE") {
+	case "", "local":
+		dir := os.Getenv("BLOB_DIR")
+		if dir == "" {
+			dir = "./media"
+		}
+		return NewLocalStore(dir, localURL)
+	case "s3":
+		cfg := S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			Region:          os.Getenv("S3_REGION"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+		}
+		if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+			return nil, fmt.Errorf("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required for the s3 blob store")
+		}
+		return NewS3Store(cfg, http.DefaultClient), nil
+	}
+	return nil, fmt.Errorf("unknown blob store %q", os.Getenv("BLOB_STORE"))
+}
@@ -0,0 +1,100 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckKey(t *testing.T) {
+	for _, key := range []string{"a", "products/1/2/original.jpg", "a.b/c~d"} {
+		if err := checkKey(key); err != nil {
+			t.Errorf("checkKey(%q) = %v, want nil", key, err)
+		}
+	}
+	for _, key := range []string{"", "/a", "a/", "a//b", "../a", "a/../b", "./a", "a\\b", "a\x00b"} {
+		if err := checkKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("checkKey(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(root, "http://shop.test/media/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Put("products/1/7/original.png", "image/png", []byte("png bytes"))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	blob, contentType, err := store.Get("products/1/7/original.png")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	if string(data) != "png bytes" || contentType != "image/png" {
+		t.Errorf("Get = %q, %q", data, contentType)
+	}
+	if got := store.URL("products/1/7/original.png"); got != "http://shop.test/media/products/1/7/original.png" {
+		t.Errorf("URL = %q", got)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/products/1/7/original.png", nil)
+	rec := httptest.NewRecorder()
+	store.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.String() != "png bytes" || rec.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("ServeHTTP = %d %q %q", rec.Code, rec.Body.String(), rec.Header().Get("Content-Type"))
+	}
+	rec = httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products/1", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("ServeHTTP of a directory = %d, want 404", rec.Code)
+	}
+
+	if err := store.Delete("products/1/7/original.png"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, _, err := store.Get("products/1/7/original.png"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "products")); !os.IsNotExist(err) {
+		t.Errorf("empty directories were left behind: %v", err)
+	}
+	if err := store.Delete("products/1/7/original.png"); err != nil {
+		t.Errorf("Delete of a missing blob = %v, want nil", err)
+	}
+	if err := store.Put("../escape.png", "image/png", nil); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put outside the root = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("BLOB_STORE", "")
+	t.Setenv("BLOB_DIR", t.TempDir())
+	store, err := NewFromEnv("http://shop.test/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.(*LocalStore); !ok {
+		t.Errorf("NewFromEnv = %T, want *LocalStore", store)
+	}
+
+	t.Setenv("BLOB_STORE", "s3")
+	t.Setenv("S3_ENDPOINT", "http://localhost:9100")
+	if _, err := NewFromEnv(""); err == nil {
+		t.Error("NewFromEnv without a bucket and keys succeeded")
+	}
+
+	t.Setenv("BLOB_STORE", "ftp")
+	if _, err := NewFromEnv(""); err == nil {
+		t.Error("NewFromEnv with an unknown store succeeded")
+	}
+}
@@ -0,0 +1,124 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps blobs as files under a directory. The content type is
+// taken from the key's extension, so keys should carry one.
+type LocalStore struct {
+	root    string
+	baseURL string
+}
+
+func NewLocalStore(root, baseURL string) (*LocalStore, error) {
+	err := os.MkdirAll(root, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalStore{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+func (ls *LocalStore) path(key string) string {
+	return filepath.Join(ls.root, filepath.FromSlash(key))
+}
+
+// Put writes to a temporary file first, so readers never see half a blob.
+func (ls *LocalStore) Put(key, contentType string, data []byte) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	target := ls.path(key)
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (ls *LocalStore) Get(key string) (io.ReadCloser, string, error) {
+	if err := checkKey(key); err != nil {
+		return nil, "", err
+	}
+	file, err := os.Open(ls.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, "", ErrNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return file, mime.TypeByExtension(path.Ext(key)), nil
+}
+
+// Delete removes the blob and then any directories it leaves empty.
+func (ls *LocalStore) Delete(key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(ls.path(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for dir := path.Dir(key); dir != "."; dir = path.Dir(dir) {
+		if os.Remove(ls.path(dir)) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (ls *LocalStore) URL(key string) string {
+	return ls.baseURL + "/" + key
+}
+
+// ServeHTTP serves the blob named by the request path. Keys never change
+// content, so responses may be cached for good.
+func (ls *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	blob, contentType, err := ls.Get(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer blob.Close()
+	file, ok := blob.(*os.File)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", info.ModTime(), file)
+}
@@ -0,0 +1,105 @@
+package blobstore_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/blobstore"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/blobstore/s3test"
+)
+
+// TestSignature checks the signing key derivation against the GET object
+// example of the Signature Version 4 documentation.
+func TestSignature(t *testing.T) {
+	canonical := "GET\n/test.txt\n\n" +
+		"host:examplebucket.s3.amazonaws.com\n" +
+		"range:bytes=0-9\n" +
+		"x-amz-content-sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\n" +
+		"x-amz-date:20130524T000000Z\n\n" +
+		"host;range;x-amz-content-sha256;x-amz-date\n" +
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	got := blobstore.Signature("wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY", "20130524/us-east-1/s3/aws4_request", "20130524T000000Z", canonical)
+	if want := "f0e8bdb87c964420e857bd35b5d6ed310bd44f0170aba48dd91039c6036bdb41"; got != want {
+		t.Errorf("Signature = %s, want %s", got, want)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	server, srv := s3test.Start("media", "AKIDTEST", "secret")
+	defer srv.Close()
+	store := blobstore.NewS3Store(blobstore.S3Config{
+		Endpoint:        srv.URL,
+		Bucket:          "media",
+		AccessKeyID:     "AKIDTEST",
+		SecretAccessKey: "secret",
+	}, srv.Client())
+
+	key := "products/1/7/small+thumb.jpg"
+	if err := store.Put(key, "image/jpeg", []byte("jpeg bytes")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	blob, contentType, err := store.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	if string(data) != "jpeg bytes" || contentType != "image/jpeg" {
+		t.Errorf("Get = %q, %q", data, contentType)
+	}
+	if got, want := store.URL(key), srv.URL+"/media/products/1/7/small%2Bthumb.jpg"; got != want {
+		t.Errorf("URL = %q, want %q", got, want)
+	}
+
+	// downloads through URL are refused until the bucket is public
+	resp, err := http.Get(store.URL(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("anonymous GET = %d, want 403", resp.StatusCode)
+	}
+	server.PublicRead = true
+	resp, err = http.Get(store.URL(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("anonymous GET of a public bucket = %d, want 200", resp.StatusCode)
+	}
+
+	if err := store.Delete(key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if len(server.Keys()) != 0 {
+		t.Errorf("keys left after Delete: %v", server.Keys())
+	}
+	if _, _, err := store.Get(key); !errors.Is(err, blobstore.ErrNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(key); err != nil {
+		t.Errorf("Delete of a missing blob = %v, want nil", err)
+	}
+}
+
+func TestS3StoreRejectsBadCredentials(t *testing.T) {
+	server, srv := s3test.Start("media", "AKIDTEST", "secret")
+	defer srv.Close()
+	store := blobstore.NewS3Store(blobstore.S3Config{
+		Endpoint:        srv.URL,
+		Bucket:          "media",
+		AccessKeyID:     "AKIDTEST",
+		SecretAccessKey: "wrong",
+	}, srv.Client())
+
+	if err := store.Put("a.png", "image/png", []byte("x")); err == nil {
+		t.Fatal("Put with a wrong secret succeeded")
+	}
+	if len(server.Keys()) != 0 {
+		t.Errorf("keys stored: %v", server.Keys())
+	}
+}
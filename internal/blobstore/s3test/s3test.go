@@ -0,0 +1,171 @@
+// Package s3test is an in-memory stand-in for an S3 compatible service, for
+// tests and local development. It serves path style object requests and
+// checks their Signature Version 4 signatures.
+package s3test
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/blobstore"
+)
+
+// maxSkew is how far a request's date may be from the server's clock.
+const maxSkew = 15 * time.Minute
+
+type object struct {
+	data        []byte
+	contentType string
+}
+
+type Server struct {
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicRead lets unsigned requests download objects, as a public-read
+	// bucket policy would.
+	PublicRead bool
+
+	mu      sync.Mutex
+	objects map[string]object
+}
+
+func NewServer(bucket, accessKeyID, secretAccessKey string) *Server {
+	return &Server{
+		Bucket:          bucket,
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		objects:         make(map[string]object),
+	}
+}
+
+// Start serves s on a local port; the caller closes the server.
+func Start(bucket, accessKeyID, secretAccessKey string) (*Server, *httptest.Server) {
+	s := NewServer(bucket, accessKeyID, secretAccessKey)
+	return s, httptest.NewServer(s)
+}
+
+// Keys lists the stored object keys.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != s.Bucket {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+	if key == "" {
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+	anonymousRead := s.PublicRead && r.Header.Get("Authorization") == "" &&
+		(r.Method == http.MethodGet || r.Method == http.MethodHead)
+	if !anonymousRead {
+		if code := s.verify(r, body); code != "" {
+			writeError(w, http.StatusForbidden, code)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		s.mu.Lock()
+		s.objects[key] = object{data: body, contentType: r.Header.Get("Content-Type")}
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		s.mu.Lock()
+		obj, ok := s.objects[key]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		if obj.contentType != "" {
+			w.Header().Set("Content-Type", obj.contentType)
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(obj.data)))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(obj.data)
+		}
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.objects, key)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// verify recomputes the request's signature and returns the S3 error code
+// of the first problem found, or "" for a good signature.
+func (s *Server) verify(r *http.Request, body []byte) string {
+	auth, ok := strings.CutPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ")
+	if !ok {
+		return "AccessDenied"
+	}
+	fields := make(map[string]string)
+	for _, field := range strings.Split(auth, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		fields[name] = value
+	}
+	accessKeyID, scope, _ := strings.Cut(fields["Credential"], "/")
+	if accessKeyID != s.AccessKeyID {
+		return "InvalidAccessKeyId"
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	signedAt, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil || !strings.HasPrefix(scope, amzDate[:8]+"/") || !strings.HasSuffix(scope, "/s3/aws4_request") {
+		return "AuthorizationHeaderMalformed"
+	}
+	if skew := time.Since(signedAt); skew > maxSkew || skew < -maxSkew {
+		return "RequestTimeTooSkewed"
+	}
+
+	sum := sha256.Sum256(body)
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	if payloadHash != hex.EncodeToString(sum[:]) {
+		return "XAmzContentSHA256Mismatch"
+	}
+
+	signedHeaders, canonical := blobstore.CanonicalRequest(r, payloadHash)
+	if signedHeaders != fields["SignedHeaders"] {
+		return "SignatureDoesNotMatch"
+	}
+	want := blobstore.Signature(s.SecretAccessKey, scope, amzDate, canonical)
+	if subtle.ConstantTimeCompare([]byte(want), []byte(fields["Signature"])) != 1 {
+		return "SignatureDoesNotMatch"
+	}
+	return ""
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Error><Code>%s</Code></Error>", code)
+}
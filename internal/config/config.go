@@ -1,11 +1,123 @@
 package config
 
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/jwtKeys"
+)
+
 type ContextKey string
 
 const (
 	User ContextKey = "user"
+
+	// APIKeyHeader carries an API key in place of a bearer token.
+	APIKeyHeader = "X-API-Key"
 )
 
 var (
-	JWT_Secret = []byte("my_jwt_secret_key")
-)
\ No newline at end of file
+	// JWTKeys defaults to a random per-process secret; LoadJWTKeys replaces it
+	// with the configured keys at startup.
+	JWTKeys = jwtKeys.NewRandomKeySet()
+	JWT_TTL = 24 * time.Hour
+
+	AppBaseURL       = envOr("APP_BASE_URL", "http://localhost:8080")
+	PasswordResetTTL = 30 * time.Minute
+	// PasswordResetCooldown is how long a reset link stands before another
+	// one is mailed to the same account.
+	PasswordResetCooldown = 5 * time.Minute
+	// ReauthWindow is how recent a login has to be to close the account
+	// without the password, which single sign-on accounts do not have.
+	ReauthWindow = 5 * time.Minute
+
+	EmailVerificationTTL       = 48 * time.Hour
+	VerificationResendInterval = 2 * time.Minute
+	// RequireVerifiedEmailForCheckout blocks checkout for unverified accounts.
+	RequireVerifiedEmailForCheckout = os.Getenv("REQUIRE_VERIFIED_EMAIL") != "false"
+
+	MFAIssuer       = envOr("MFA_ISSUER", "OnlineShoppingCart")
+	MFAChallengeTTL = 5 * time.Minute
+	// RequireAdminMFA makes admins and other staff enrol in TOTP before they get
+	// a session.
+	RequireAdminMFA = os.Getenv("REQUIRE_ADMIN_MFA") != "false"
+
+	// Failed logins beyond the free attempts back off exponentially from
+	// LoginBackoffBase; reaching the threshold locks for LoginLockoutDuration.
+	AccountFreeAttempts     = 3
+	AccountLockoutThreshold = 10
+	IPFreeAttempts          = 10
+	IPLockoutThreshold      = 50
+	LoginBackoffBase        = time.Second
+	LoginLockoutDuration    = 15 * time.Minute
+	// LoginAttemptWindow is how long a failure is remembered.
+	LoginAttemptWindow = time.Hour
+	// TrustProxyHeaders takes the client address from X-Forwarded-For; only
+	// enable it behind a proxy that sets the header.
+	TrustProxyHeaders = os.Getenv("TRUST_PROXY_HEADERS") == "true"
+
+	// APIKeyLastUsedInterval limits how often a key's last-used time is written.
+	APIKeyLastUsedInterval = time.Minute
+	// SessionLastSeenInterval limits how often a session's last-seen time is
+	// written.
+	SessionLastSeenInterval = time.Minute
+
+	// Single sign-on is offered when OIDC_ISSUER and OIDC_CLIENT_ID are set.
+	OIDCIssuer       = os.Getenv("OIDC_ISSUER")
+	OIDCClientID     = os.Getenv("OIDC_CLIENT_ID")
+	OIDCClientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+	OIDCRedirectURL  = envOr("OIDC_REDIRECT_URL", AppBaseURL+"/api/v1/login/oidc/callback")
+	OIDCScopes       = splitList(envOr("OIDC_SCOPES", "openid,email,profile"))
+	OIDCGroupsClaim  = envOr("OIDC_GROUPS_CLAIM", "groups")
+	// OIDCAdminGroups are the provider groups whose members are admins. When
+	// set, the role of single sign-on users follows membership on every login.
+	OIDCAdminGroups = splitList(os.Getenv("OIDC_ADMIN_GROUPS"))
+	OIDCLoginTTL    = 10 * time.Minute
+
+	MaxImageUploadBytes int64 = 5 << 20
+	MaxImagesPerProduct       = 10
+
+	// Catalogue imports of more than ImportSyncRows rows run in the
+	// background.
+	MaxImportBytes int64 = 20 << 20
+	MaxImportRows        = 20000
+	ImportSyncRows       = 200
+
+	// PriceScheduleInterval is how often scheduled prices are checked, so a
+	// sale starts and ends at most this late.
+	PriceScheduleInterval = time.Minute
+)
+
+func OIDCEnabled() bool {
+	return OIDCIssuer != "" && OIDCClientID != ""
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func LoadJWTKeys() (bool, error) {
+	keys, err := jwtKeys.LoadFromEnv()
+	if err != nil {
+		return false, err
+	}
+	if keys == nil {
+		return false, nil
+	}
+	JWTKeys = keys
+	return true, nil
+}
\ No newline at end of file
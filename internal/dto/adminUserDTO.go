@@ -0,0 +1,18 @@
+package dto
+
+// AdminUserDTO is the admin view of an account.
+type AdminUserDTO struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Role          string `json:"role"`
+	Status        string `json:"status"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+type UserListDTO struct {
+	Users []AdminUserDTO `json:"users"`
+	Total int            `json:"total"`
+	Page  int            `json:"page"`
+	Limit int            `json:"limit"`
+}
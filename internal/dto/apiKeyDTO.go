@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type CreateAPIKeyDTO struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// APIKeyCreatedDTO is the only response that contains the key itself.
+type APIKeyCreatedDTO struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Key       string    `json:"key"`
+	Prefix    string    `json:"prefix"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,8 +1,24 @@
 package dto
 
 type CartItemsDTO struct {
-	ProductID   string  `json:"product_id"`
-	ProductName string  `json:"product_name"`
-	Price      float32 `json:"price"`
-	Quantity   int     `json:"quantity"`
-}
\ No newline at end of file
+	ProductID   string            `json:"product_id"`
+	ProductName string            `json:"product_name"`
+	VariantID   string            `json:"variant_id,omitempty"`
+	SKU         string            `json:"sku,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+	Price       float32           `json:"price"`
+	Quantity    int               `json:"quantity"`
+	WeightGrams int               `json:"weight_grams"`
+	// Available is false once the product is archived or unpublished; such
+	// items stay in the cart but can not be checked out.
+	Available bool `json:"available"`
+}
+
+// CartWeightGrams is the total weight of the items for shipping.
+func CartWeightGrams(items []CartItemsDTO) int {
+	total := 0
+	for _, item := range items {
+		total += item.WeightGrams * item.Quantity
+	}
+	return total
+}
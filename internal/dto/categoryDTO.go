@@ -0,0 +1,15 @@
+package dto
+
+// CategoryDTO creates or replaces a category. An empty slug is derived from
+// the name.
+type CategoryDTO struct {
+	ParentID  *string `json:"parent_id"`
+	Name      string  `json:"name"`
+	Slug      string  `json:"slug"`
+	SortOrder int     `json:"sort_order"`
+}
+
+// ProductCategoriesDTO lists category slugs to assign to a product.
+type ProductCategoriesDTO struct {
+	Categories []string `json:"categories"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+// ImageOrderDTO lists every image of a product in the new gallery order.
+type ImageOrderDTO struct {
+	ImageIDs []string `json:"image_ids"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+// StockAdjustmentDTO adds Delta to the stock of a product, or of one of its
+// variants. A negative delta takes stock away.
+type StockAdjustmentDTO struct {
+	VariantID string `json:"variant_id,omitempty"`
+	Delta     int    `json:"delta"`
+	Reason    string `json:"reason"`
+	Note      string `json:"note"`
+}
+
+type StockMovementListDTO struct {
+	Movements []models.StockMovement `json:"movements"`
+	Total     int                    `json:"total"`
+	Page      int                    `json:"page"`
+	Limit     int                    `json:"limit"`
+}
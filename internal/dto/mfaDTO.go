@@ -0,0 +1,24 @@
+package dto
+
+type LoginResultDTO struct {
+	Token            string `json:"token,omitempty"`
+	MFARequired      bool   `json:"mfa_required,omitempty"`
+	MFASetupRequired bool   `json:"mfa_setup_required,omitempty"`
+	MFAToken         string `json:"mfa_token,omitempty"`
+}
+
+type MFAEnrollmentDTO struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+	QRPayload  string `json:"qr_payload"`
+}
+
+type MFACodeDTO struct {
+	MFAToken string `json:"mfa_token,omitempty"`
+	Code     string `json:"code"`
+}
+
+type MFASetupResultDTO struct {
+	Token         string   `json:"token,omitempty"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type OrderListDTO struct {
+	Orders []models.Order `json:"orders"`
+	Total  int            `json:"total"`
+	Page   int            `json:"page"`
+	Limit  int            `json:"limit"`
+}
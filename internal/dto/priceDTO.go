@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+// PriceScheduleDTO schedules a price. With an end time it is a sale, without
+// one the price stays.
+type PriceScheduleDTO struct {
+	Price    float32    `json:"price"`
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+// PriceTimelineDTO is a product's current prices, how they changed and the
+// prices scheduled for it.
+type PriceTimelineDTO struct {
+	ProductID      string                 `json:"product_id"`
+	Price          float32                `json:"price"`
+	CompareAtPrice *float32               `json:"compare_at_price,omitempty"`
+	History        []models.PriceChange   `json:"history"`
+	Schedules      []models.PriceSchedule `json:"schedules"`
+}
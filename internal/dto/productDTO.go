@@ -1,7 +1,57 @@
 package dto
 
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+// ProductDTO adds or updates a product. On update, omitted fields are left
+// unchanged.
 type ProductDTO struct {
-	Name  string  `json:"name,omitempty"`
-	Price float32 `json:"price,omitempty"`
-	Stock int     `json:"stock,omitempty"`
+	SKU         *string              `json:"sku,omitempty"`
+	Name        string               `json:"name,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Brand       *string              `json:"brand,omitempty"`
+	WeightGrams *int                 `json:"weight_grams,omitempty"`
+	Dimensions  *models.Dimensions   `json:"dimensions,omitempty"`
+	Specs       []models.ProductSpec `json:"specs,omitempty"`
+	Price       float32              `json:"price,omitempty"`
+	Stock       *int                 `json:"stock,omitempty"`
+	Status      models.ProductStatus `json:"status,omitempty"`
+}
+
+type ProductListDTO struct {
+	Products []models.Product `json:"products"`
+	Total    int              `json:"total"`
+	Page     int              `json:"page"`
+	Limit    int              `json:"limit"`
+}
+
+type ProductSearchDTO struct {
+	Query   string                       `json:"query"`
+	Results []models.ProductSearchResult `json:"results"`
+	Total   int                          `json:"total"`
+	Page    int                          `json:"page"`
+	Limit   int                          `json:"limit"`
+}
+
+type SuggestionDTO struct {
+	Query       string   `json:"query"`
+	Completions []string `json:"completions"`
+	DidYouMean  string   `json:"did_you_mean,omitempty"`
+}
+
+// ProductImportDTO is one row of a catalogue import or export. Rows are
+// matched to products by SKU; fields left out are not changed, and a new
+// product needs at least a name and a price.
+type ProductImportDTO struct {
+	SKU         string               `json:"sku"`
+	Name        *string              `json:"name,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	Brand       *string              `json:"brand,omitempty"`
+	Price       *float32             `json:"price,omitempty"`
+	Stock       *int                 `json:"stock,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	WeightGrams *int                 `json:"weight_grams,omitempty"`
+	Dimensions  *models.Dimensions   `json:"dimensions,omitempty"`
+	Specs       []models.ProductSpec `json:"specs,omitempty"`
+	Status      *string              `json:"status,omitempty"`
 }
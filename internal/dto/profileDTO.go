@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+// ProfileDTO is what a user sees about themselves; it deliberately has no
+// password field.
+type ProfileDTO struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	Email           string     `json:"email"`
+	Role            string     `json:"role"`
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// PendingEmail is set after an email change until it is confirmed.
+	PendingEmail string `json:"pending_email,omitempty"`
+}
+
+// UpdateProfileDTO fields are optional; only the ones sent are changed.
+// CurrentPassword is required to change the email.
+type UpdateProfileDTO struct {
+	Name            *string `json:"name"`
+	Email           *string `json:"email"`
+	CurrentPassword string  `json:"current_password"`
+}
+
+type DeleteAccountDTO struct {
+	Password string `json:"password"`
+}
+
+// UserExportDTO is the archive returned by a data export.
+type UserExportDTO struct {
+	ExportedAt time.Time        `json:"exported_at"`
+	Profile    ProfileDTO       `json:"profile"`
+	MFAEnabled bool             `json:"mfa_enabled"`
+	Cart       []CartItemsDTO   `json:"cart"`
+	Orders     []models.Order   `json:"orders"`
+	Sessions   []models.Session `json:"sessions"`
+}
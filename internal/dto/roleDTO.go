@@ -0,0 +1,11 @@
+package dto
+
+type RoleDTO struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type AssignRoleDTO struct {
+	Role string `json:"role"`
+}
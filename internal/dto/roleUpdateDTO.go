@@ -0,0 +1,5 @@
+package dto
+
+type RoleUpdateDTO struct {
+	Role string `json:"role"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+// ProductOptionsDTO replaces a product's option types.
+type ProductOptionsDTO struct {
+	Options []models.ProductOption `json:"options"`
+}
+
+// VariantDTO creates or replaces a variant. Options must hold one value for
+// each of the product's option types; a nil Price uses the product's price.
+// Stock is only taken on create; on update it must match the current stock.
+type VariantDTO struct {
+	SKU     string            `json:"sku"`
+	Options map[string]string `json:"options"`
+	Price   *float32          `json:"price"`
+	Stock   *int              `json:"stock"`
+}
+
+// ProductVariantsDTO lists a product's option types and variants.
+type ProductVariantsDTO struct {
+	Options  []models.ProductOption `json:"options"`
+	Variants []models.Variant       `json:"variants"`
+}
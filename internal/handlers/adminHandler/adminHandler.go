@@ -2,8 +2,13 @@ package adminhandler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
@@ -25,14 +30,6 @@ func NewAdminHandler(adminService adminservice.AdminServiceManager) *AdminHandle
 
 // api/v1/admin/product [POST]
 func (ah *AdminHandler) AddProductHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
-	if !ok || userClaims.Role != models.Admin {
-		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
-		w.WriteHeader(resp.Code)
-		json.NewEncoder(w).Encode(resp)
-		return
-	}
 	var req dto.ProductDTO
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
@@ -54,15 +51,29 @@ func (ah *AdminHandler) AddProductHandler(w http.ResponseWriter, r *http.Request
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	if req.Stock < 0 {
+	if req.Stock != nil && *req.Stock < 0 {
 		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "stock can't be negative")
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	err = ah.AdminService.AddProduct(req.Name, req.Price, req.Stock)
+	err = validateProductDetails(req)
 	if err != nil {
-		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err = ah.AdminService.AddProduct(userClaims.UserID, req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(productErrorCode(err), err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
@@ -74,26 +85,25 @@ func (ah *AdminHandler) AddProductHandler(w http.ResponseWriter, r *http.Request
 
 // api/v1/admin/product/{prodID} [PUT]
 func (ah *AdminHandler) UpdateProductHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	userClaims, ok := ctx.Value(config.User).(models.UserJWT)	
-	if !ok || userClaims.Role != models.Admin {
-		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+	var req dto.ProductDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	var req dto.ProductDTO
-	err := json.NewDecoder(r.Body).Decode(&req)
+	err = validateProductDetails(req)
 	if err != nil {
-		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
 	prodID := r.PathValue("prodID")
-	err = ah.AdminService.UpdateProduct(prodID, req.Name, req.Price, req.Stock)
+	err = ah.AdminService.UpdateProduct(prodID, req)
 	if err != nil {
-		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		resp := webResponse.NewErrorResponse(productErrorCode(err), err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
@@ -103,41 +113,148 @@ func (ah *AdminHandler) UpdateProductHandler(w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(resp)
 }
 
-// api/v1/admin/product/{prodID} [DELETE]
-func (ah *AdminHandler) RemoveProductHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
-	if !ok || userClaims.Role != models.Admin {
-		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+// productErrorCode is the status for a failed product change.
+func productErrorCode(err error) int {
+	switch {
+	case errors.Is(err, adminservice.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, adminservice.ErrSKUExists), errors.Is(err, adminservice.ErrProductNotArchived),
+		errors.Is(err, adminservice.ErrProductOrdered):
+		return http.StatusConflict
+	case errors.Is(err, adminservice.ErrStockNotEditable):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func validateProductDetails(req dto.ProductDTO) error {
+	if req.Status != "" {
+		_, err := models.ParseProductStatus(string(req.Status))
+		if err != nil {
+			return err
+		}
+	}
+	// an empty SKU clears it
+	if req.SKU != nil && strings.TrimSpace(*req.SKU) != "" {
+		err := validators.ValidateSKU(strings.TrimSpace(*req.SKU))
+		if err != nil {
+			return err
+		}
+	}
+	if req.Description != nil {
+		err := validators.ValidateDescription(*req.Description)
+		if err != nil {
+			return err
+		}
+	}
+	if req.Brand != nil {
+		err := validators.ValidateBrand(*req.Brand)
+		if err != nil {
+			return err
+		}
+	}
+	if req.WeightGrams != nil {
+		err := validators.ValidateWeight(*req.WeightGrams)
+		if err != nil {
+			return err
+		}
+	}
+	if req.Dimensions != nil {
+		err := validators.ValidateDimensions(*req.Dimensions)
+		if err != nil {
+			return err
+		}
+	}
+	err := validators.ValidateSpecs(req.Specs)
+	if err != nil {
+		return err
+	}
+	return validators.ValidateTags(req.Tags)
+}
+
+// api/v1/admin/products/{prodID} [DELETE] archives the product
+func (ah *AdminHandler) ArchiveProductHandler(w http.ResponseWriter, r *http.Request) {
+	err := ah.AdminService.ArchiveProduct(r.PathValue("prodID"))
+	writeProductChange(w, err, "product archived successfully")
+}
+
+// api/v1/admin/products/{prodID}/restore [POST]
+func (ah *AdminHandler) RestoreProductHandler(w http.ResponseWriter, r *http.Request) {
+	err := ah.AdminService.RestoreProduct(r.PathValue("prodID"))
+	writeProductChange(w, err, "product restored successfully")
+}
+
+// api/v1/admin/products/{prodID}/purge [POST]
+func (ah *AdminHandler) PurgeProductHandler(w http.ResponseWriter, r *http.Request) {
+	err := ah.AdminService.PurgeProduct(r.PathValue("prodID"))
+	writeProductChange(w, err, "product purged successfully")
+}
+
+func writeProductChange(w http.ResponseWriter, err error, message string) {
+	resp := webResponse.NewSuccessResponse(http.StatusOK, message, nil)
+	if err != nil {
+		resp = webResponse.NewErrorResponse(productErrorCode(err), err.Error())
+	}
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+
+// api/v1/admin/coupon [POST]
+func (ah *AdminHandler) AddCouponHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CouponDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	prodID := r.PathValue("prodID")
-	err := ah.AdminService.RemoveProduct(prodID)
+	err = validators.ValidateCoupon(req.Code, req.Discount)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err = ah.AdminService.AddCoupon(req.Code, req.Discount)
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	resp := webResponse.NewSuccessResponse(http.StatusOK, "product removed successfully", nil)
+	resp := webResponse.NewSuccessResponse(http.StatusCreated, "coupon added successfully", nil)
 	w.WriteHeader(resp.Code)
 	json.NewEncoder(w).Encode(resp)
 }
 
+// api/v1/admin/coupon/{code} [DELETE]
+func (ah *AdminHandler) RemoveCouponHandler(w http.ResponseWriter, r *http.Request) {
+	couponCode := r.PathValue("code")
+	err := ah.AdminService.RemoveCoupon(couponCode)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "coupon removed successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
 
-// api/v1/admin/coupon [POST]
-func (ah *AdminHandler) AddCouponHandler(w http.ResponseWriter, r *http.Request) {
+// api/v1/admin/users/{userID}/role [PUT]
+func (ah *AdminHandler) UpdateUserRoleHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
-	if !ok || userClaims.Role != models.Admin {
+	if !ok {
 		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	var req dto.CouponDTO
+	var req dto.RoleUpdateDTO
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
@@ -145,44 +262,212 @@ func (ah *AdminHandler) AddCouponHandler(w http.ResponseWriter, r *http.Request)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	err = validators.ValidateCoupon(req.Code, req.Discount)
+	role, err := models.ParseUserRole(req.Role)
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	err = ah.AdminService.AddCoupon(req.Code, req.Discount)
+	userID := r.PathValue("userID")
+	err = ah.AdminService.ChangeUserRole(userClaims.UserID, userID, role)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, adminservice.ErrOutranked) || errors.Is(err, adminservice.ErrNotGrantable) {
+			code = http.StatusForbidden
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "user role updated successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+const (
+	defaultUserPageSize  = 20
+	maxUserPageSize      = 100
+	defaultOrderPageSize = 20
+	maxOrderPageSize     = 100
+)
+
+// api/v1/admin/users?q=&role=&status=&page=&limit= [GET]
+func (ah *AdminHandler) ListUsersHandler(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseUserFilter(r.URL.Query())
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	users, err := ah.AdminService.ListUsers(filter)
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	resp := webResponse.NewSuccessResponse(http.StatusCreated, "coupon added successfully", nil)
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "users fetched successfully", users)
 	w.WriteHeader(resp.Code)
 	json.NewEncoder(w).Encode(resp)
 }
 
-// api/v1/admin/coupon/{code} [DELETE]
-func (ah *AdminHandler) RemoveCouponHandler(w http.ResponseWriter, r *http.Request) {
+func parseUserFilter(query url.Values) (models.UserFilter, error) {
+	filter := models.UserFilter{
+		Query: strings.TrimSpace(query.Get("q")),
+	}
+	if role := query.Get("role"); role != "" {
+		parsed, err := models.ParseUserRole(role)
+		if err != nil {
+			return filter, err
+		}
+		filter.Role = &parsed
+	}
+	if status := query.Get("status"); status != "" {
+		parsed, err := models.ParseUserStatus(status)
+		if err != nil {
+			return filter, err
+		}
+		filter.Status = parsed
+	}
+	var err error
+	filter.Limit, filter.Offset, err = parsePage(query, defaultUserPageSize, maxUserPageSize)
+	return filter, err
+}
+
+func parsePage(query url.Values, defaultLimit, maxLimit int) (int, int, error) {
+	limit := defaultLimit
+	if l := query.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 {
+			return 0, 0, fmt.Errorf("invalid limit")
+		}
+		limit = min(n, maxLimit)
+	}
+	page := 1
+	if p := query.Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			return 0, 0, fmt.Errorf("invalid page")
+		}
+		page = n
+	}
+	return limit, (page - 1) * limit, nil
+}
+
+// api/v1/admin/users/{userID} [GET]
+func (ah *AdminHandler) GetUserHandler(w http.ResponseWriter, r *http.Request) {
+	user, err := ah.AdminService.GetUser(r.PathValue("userID"))
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusNotFound, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "user fetched successfully", user)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/users/{userID}/suspend [POST]
+func (ah *AdminHandler) SuspendUserHandler(w http.ResponseWriter, r *http.Request) {
+	ah.setUserStatus(w, r, models.UserSuspended, "user suspended successfully")
+}
+
+// api/v1/admin/users/{userID}/reactivate [POST]
+func (ah *AdminHandler) ReactivateUserHandler(w http.ResponseWriter, r *http.Request) {
+	ah.setUserStatus(w, r, models.UserActive, "user reactivated successfully")
+}
+
+func (ah *AdminHandler) setUserStatus(w http.ResponseWriter, r *http.Request, status models.UserStatus, message string) {
 	ctx := r.Context()
 	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
-	if !ok || userClaims.Role != models.Admin {
+	if !ok {
 		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	couponCode := r.PathValue("code")
-	err := ah.AdminService.RemoveCoupon(couponCode)
+	err := ah.AdminService.SetUserStatus(userClaims.UserID, r.PathValue("userID"), status)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, adminservice.ErrOutranked) {
+			code = http.StatusForbidden
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, message, nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/users/{userID}/cart [GET]
+func (ah *AdminHandler) GetUserCartHandler(w http.ResponseWriter, r *http.Request) {
+	items, err := ah.AdminService.GetUserCart(r.PathValue("userID"))
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusNotFound, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "cart fetched successfully", items)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/users/{userID}/orders [GET]
+func (ah *AdminHandler) GetUserOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	orders, err := ah.AdminService.GetUserOrders(r.PathValue("userID"))
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusNotFound, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "orders fetched successfully", orders)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/orders?user_id=&since=&page=&limit= [GET]
+func (ah *AdminHandler) ListOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseOrderFilter(r.URL.Query())
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	orders, err := ah.AdminService.ListOrders(filter)
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	resp := webResponse.NewSuccessResponse(http.StatusOK, "coupon removed successfully", nil)
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "orders fetched successfully", orders)
 	w.WriteHeader(resp.Code)
 	json.NewEncoder(w).Encode(resp)
 }
+
+func parseOrderFilter(query url.Values) (models.OrderFilter, error) {
+	filter := models.OrderFilter{
+		UserID: strings.TrimSpace(query.Get("user_id")),
+	}
+	if since := query.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return filter, fmt.Errorf("invalid since, expected an RFC 3339 timestamp")
+		}
+		parsed = parsed.UTC()
+		filter.Since = &parsed
+	}
+	var err error
+	filter.Limit, filter.Offset, err = parsePage(query, defaultOrderPageSize, maxOrderPageSize)
+	return filter, err
+}
@@ -8,11 +8,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	adminservice "github.com/meshyampratap01/OnlineShoppingCart/internal/services/adminService"
 	"go.uber.org/mock/gomock"
 )
 
@@ -20,10 +22,6 @@ func getAdminContext() context.Context {
 	return context.WithValue(context.Background(), config.User, models.UserJWT{Role: models.Admin})
 }
 
-func getUserContext() context.Context {
-	return context.WithValue(context.Background(), config.User, models.UserJWT{Role: models.Customer})
-}
-
 func TestAddProductHandler_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -31,14 +29,15 @@ func TestAddProductHandler_Success(t *testing.T) {
 	mockService := mocks.NewMockAdminServiceManager(ctrl)
 	handler := NewAdminHandler(mockService)
 
-	reqBody := dto.ProductDTO{Name: "Laptop", Price: 1000, Stock: 10}
+	stock := 10
+	reqBody := dto.ProductDTO{Name: "Laptop", Price: 1000, Stock: &stock}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewReader(body))
 	req = req.WithContext(getAdminContext())
 	w := httptest.NewRecorder()
 
-	mockService.EXPECT().AddProduct("Laptop", float32(1000), 10).Return(nil)
+	mockService.EXPECT().AddProduct("", reqBody).Return(nil)
 
 	handler.AddProductHandler(w, req)
 
@@ -47,20 +46,6 @@ func TestAddProductHandler_Success(t *testing.T) {
 	}
 }
 
-func TestAddProductHandler_InvalidRole(t *testing.T) {
-	handler := NewAdminHandler(nil)
-
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", nil)
-	req = req.WithContext(getUserContext())
-	w := httptest.NewRecorder()
-
-	handler.AddProductHandler(w, req)
-
-	if w.Code != http.StatusUnauthorized {
-		t.Errorf("expected status 401, got %d", w.Code)
-	}
-}
-
 func TestUpdateProductHandler_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -68,7 +53,8 @@ func TestUpdateProductHandler_Success(t *testing.T) {
 	mockService := mocks.NewMockAdminServiceManager(ctrl)
 	handler := NewAdminHandler(mockService)
 
-	reqBody := dto.ProductDTO{Name: "Phone", Price: 500, Stock: 5}
+	stock := 5
+	reqBody := dto.ProductDTO{Name: "Phone", Price: 500, Stock: &stock}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/product/123", bytes.NewReader(body))
@@ -76,7 +62,7 @@ func TestUpdateProductHandler_Success(t *testing.T) {
 	req.SetPathValue("prodID", "123")
 	w := httptest.NewRecorder()
 
-	mockService.EXPECT().UpdateProduct("123", "Phone", float32(500), 5).Return(nil)
+	mockService.EXPECT().UpdateProduct("123", reqBody).Return(nil)
 
 	handler.UpdateProductHandler(w, req)
 
@@ -85,24 +71,61 @@ func TestUpdateProductHandler_Success(t *testing.T) {
 	}
 }
 
-func TestRemoveProductHandler_Error(t *testing.T) {
+func TestAddProductHandler_Status(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockService := mocks.NewMockAdminServiceManager(ctrl)
 	handler := NewAdminHandler(mockService)
 
-	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/product/999", nil)
-	req = req.WithContext(getAdminContext())
-	req.SetPathValue("prodID", "999")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products", bytes.NewBufferString(`{"name":"Lamp","price":20,"status":"hidden"}`))
 	w := httptest.NewRecorder()
+	handler.AddProductHandler(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown status, got %d", w.Code)
+	}
+
+	mockService.EXPECT().AddProduct("", dto.ProductDTO{Name: "Lamp", Price: 20, Status: models.ProductDraft}).Return(nil)
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/products", bytes.NewBufferString(`{"name":"Lamp","price":20,"status":"draft"}`))
+	req = req.WithContext(getAdminContext())
+	w = httptest.NewRecorder()
+	handler.AddProductHandler(w, req)
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", w.Code)
+	}
+}
 
-	mockService.EXPECT().RemoveProduct("999").Return(errors.New("not found"))
+func TestProductLifecycleHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	handler.RemoveProductHandler(w, req)
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
 
-	if w.Code != http.StatusInternalServerError {
-		t.Errorf("expected status 500, got %d", w.Code)
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		expect  func(id any) *gomock.Call
+		err     error
+		code    int
+	}{
+		{"archive", handler.ArchiveProductHandler, mockService.EXPECT().ArchiveProduct, nil, http.StatusOK},
+		{"archive missing", handler.ArchiveProductHandler, mockService.EXPECT().ArchiveProduct, adminservice.ErrProductNotFound, http.StatusNotFound},
+		{"restore", handler.RestoreProductHandler, mockService.EXPECT().RestoreProduct, nil, http.StatusOK},
+		{"restore failure", handler.RestoreProductHandler, mockService.EXPECT().RestoreProduct, errors.New("db error"), http.StatusInternalServerError},
+		{"purge", handler.PurgeProductHandler, mockService.EXPECT().PurgeProduct, nil, http.StatusOK},
+		{"purge live", handler.PurgeProductHandler, mockService.EXPECT().PurgeProduct, adminservice.ErrProductNotArchived, http.StatusConflict},
+		{"purge ordered", handler.PurgeProductHandler, mockService.EXPECT().PurgeProduct, adminservice.ErrProductOrdered, http.StatusConflict},
+	}
+	for _, test := range tests {
+		test.expect("p1").Return(test.err)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/p1", nil)
+		req.SetPathValue("prodID", "p1")
+		w := httptest.NewRecorder()
+		test.handler(w, req)
+		if w.Code != test.code {
+			t.Errorf("%s: expected %d, got %d", test.name, test.code, w.Code)
+		}
 	}
 }
 
@@ -173,7 +196,8 @@ func TestAddProductHandler_InvalidName(t *testing.T) {
 	mockService := mocks.NewMockAdminServiceManager(ctrl)
 	handler := NewAdminHandler(mockService)
 
-	reqBody := dto.ProductDTO{Name: "", Price: 100, Stock: 5}
+	stock := 5
+	reqBody := dto.ProductDTO{Name: "", Price: 100, Stock: &stock}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewReader(body))
@@ -190,7 +214,8 @@ func TestAddProductHandler_InvalidName(t *testing.T) {
 func TestAddProductHandler_NegativePrice(t *testing.T) {
 	handler := NewAdminHandler(nil)
 
-	reqBody := dto.ProductDTO{Name: "Item", Price: -10, Stock: 5}
+	stock := 5
+	reqBody := dto.ProductDTO{Name: "Item", Price: -10, Stock: &stock}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewReader(body))
@@ -207,7 +232,26 @@ func TestAddProductHandler_NegativePrice(t *testing.T) {
 func TestAddProductHandler_NegativeStock(t *testing.T) {
 	handler := NewAdminHandler(nil)
 
-	reqBody := dto.ProductDTO{Name: "Item", Price: 100, Stock: -5}
+	negative := -5
+	reqBody := dto.ProductDTO{Name: "Item", Price: 100, Stock: &negative}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	handler.AddProductHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAddProductHandler_InvalidTags(t *testing.T) {
+	handler := NewAdminHandler(nil)
+
+	stock := 5
+	reqBody := dto.ProductDTO{Name: "Item", Price: 100, Stock: &stock, Tags: []string{"red,blue"}}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewReader(body))
@@ -221,6 +265,30 @@ func TestAddProductHandler_NegativeStock(t *testing.T) {
 	}
 }
 
+func TestAddProductHandler_InvalidDetails(t *testing.T) {
+	handler := NewAdminHandler(nil)
+
+	weight := -5
+	stock := 5
+	for _, reqBody := range []dto.ProductDTO{
+		{Name: "Item", Price: 100, Stock: &stock, WeightGrams: &weight},
+		{Name: "Item", Price: 100, Stock: &stock, Dimensions: &models.Dimensions{LengthMM: 10}},
+		{Name: "Item", Price: 100, Stock: &stock, Specs: []models.ProductSpec{{Key: "ram", Type: models.SpecNumber, Value: "lots"}}},
+	} {
+		body, _ := json.Marshal(reqBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewReader(body))
+		req = req.WithContext(getAdminContext())
+		w := httptest.NewRecorder()
+
+		handler.AddProductHandler(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+}
+
 func TestAddProductHandler_ServiceError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -228,14 +296,15 @@ func TestAddProductHandler_ServiceError(t *testing.T) {
 	mockService := mocks.NewMockAdminServiceManager(ctrl)
 	handler := NewAdminHandler(mockService)
 
-	reqBody := dto.ProductDTO{Name: "Item", Price: 100, Stock: 5}
+	stock := 5
+	reqBody := dto.ProductDTO{Name: "Item", Price: 100, Stock: &stock}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewReader(body))
 	req = req.WithContext(getAdminContext())
 	w := httptest.NewRecorder()
 
-	mockService.EXPECT().AddProduct("Item", float32(100), 5).Return(errors.New("db error"))
+	mockService.EXPECT().AddProduct("", reqBody).Return(errors.New("db error"))
 
 	handler.AddProductHandler(w, req)
 
@@ -266,7 +335,8 @@ func TestUpdateProductHandler_ServiceError(t *testing.T) {
 	mockService := mocks.NewMockAdminServiceManager(ctrl)
 	handler := NewAdminHandler(mockService)
 
-	reqBody := dto.ProductDTO{Name: "Item", Price: 100, Stock: 5}
+	stock := 5
+	reqBody := dto.ProductDTO{Name: "Item", Price: 100, Stock: &stock}
 	body, _ := json.Marshal(reqBody)
 
 	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/product/123", bytes.NewReader(body))
@@ -274,7 +344,7 @@ func TestUpdateProductHandler_ServiceError(t *testing.T) {
 	req.SetPathValue("prodID", "123")
 	w := httptest.NewRecorder()
 
-	mockService.EXPECT().UpdateProduct("123", "Item", float32(100), 5).Return(errors.New("update failed"))
+	mockService.EXPECT().UpdateProduct("123", reqBody).Return(errors.New("update failed"))
 
 	handler.UpdateProductHandler(w, req)
 
@@ -336,3 +406,297 @@ func TestAddCouponHandler_ServiceError(t *testing.T) {
 		t.Errorf("expected 500, got %d", w.Code)
 	}
 }
+
+func TestUpdateUserRoleHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	body, _ := json.Marshal(dto.RoleUpdateDTO{Role: "admin"})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/users/u1/role", bytes.NewReader(body))
+	req.SetPathValue("userID", "u1")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ChangeUserRole("", "u1", models.Admin).Return(nil)
+
+	handler.UpdateUserRoleHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestUpdateUserRoleHandler_NotGrantable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	body, _ := json.Marshal(dto.RoleUpdateDTO{Role: "admin"})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/users/u1/role", bytes.NewReader(body))
+	req.SetPathValue("userID", "u1")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ChangeUserRole("", "u1", models.Admin).Return(adminservice.ErrNotGrantable)
+
+	handler.UpdateUserRoleHandler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestUpdateUserRoleHandler_InvalidRole(t *testing.T) {
+	handler := NewAdminHandler(nil)
+
+	body, _ := json.Marshal(dto.RoleUpdateDTO{Role: "superuser"})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/users/u1/role", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	handler.UpdateUserRoleHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestUpdateUserRoleHandler_Unauthorized(t *testing.T) {
+	handler := NewAdminHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/users/u1/role", nil)
+	w := httptest.NewRecorder()
+
+	handler.UpdateUserRoleHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestListUsersHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?q=bob&role=customer&status=suspended&page=3&limit=10", nil)
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	role := models.Customer
+	mockService.EXPECT().ListUsers(models.UserFilter{Query: "bob", Role: &role, Status: models.UserSuspended, Limit: 10, Offset: 20}).
+		Return(dto.UserListDTO{Total: 21, Page: 3, Limit: 10}, nil)
+
+	handler.ListUsersHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestListUsersHandler_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?limit=1000", nil)
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ListUsers(models.UserFilter{Limit: maxUserPageSize}).Return(dto.UserListDTO{}, nil)
+
+	handler.ListUsersHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestListUsersHandler_InvalidQuery(t *testing.T) {
+	handler := NewAdminHandler(nil)
+
+	for _, query := range []string{"role=owner", "status=deleted", "page=0", "limit=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users?"+query, nil)
+		req = req.WithContext(getAdminContext())
+		w := httptest.NewRecorder()
+
+		handler.ListUsersHandler(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestGetUserHandler_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/404", nil)
+	req.SetPathValue("userID", "404")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().GetUser("404").Return(dto.AdminUserDTO{}, errors.New("user not found"))
+
+	handler.GetUserHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestSuspendUserHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/suspend", nil)
+	req.SetPathValue("userID", "u1")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().SetUserStatus("", "u1", models.UserSuspended).Return(nil)
+
+	handler.SuspendUserHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestReactivateUserHandler_ServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/reactivate", nil)
+	req.SetPathValue("userID", "u1")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().SetUserStatus("", "u1", models.UserActive).Return(errors.New("user not found"))
+
+	handler.ReactivateUserHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestSuspendUserHandler_Outranked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/admin2/suspend", nil)
+	req.SetPathValue("userID", "admin2")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().SetUserStatus("", "admin2", models.UserSuspended).Return(adminservice.ErrOutranked)
+
+	handler.SuspendUserHandler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestGetUserCartHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/u1/cart", nil)
+	req.SetPathValue("userID", "u1")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().GetUserCart("u1").Return([]dto.CartItemsDTO{{ProductID: "p1", Quantity: 1}}, nil)
+
+	handler.GetUserCartHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestListOrdersHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/orders?user_id=u1&since=2026-01-02T03:00:00%2B02:00&page=2&limit=500", nil)
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	since := time.Date(2026, 1, 2, 1, 0, 0, 0, time.UTC)
+	mockService.EXPECT().ListOrders(models.OrderFilter{UserID: "u1", Since: &since, Limit: maxOrderPageSize, Offset: maxOrderPageSize}).
+		Return(dto.OrderListDTO{Orders: []models.Order{{ID: "o1"}}, Total: 101, Page: 2, Limit: maxOrderPageSize}, nil)
+
+	handler.ListOrdersHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestListOrdersHandler_InvalidQuery(t *testing.T) {
+	handler := NewAdminHandler(nil)
+
+	for _, query := range []string{"since=yesterday", "page=-1", "limit=0"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/orders?"+query, nil)
+		req = req.WithContext(getAdminContext())
+		w := httptest.NewRecorder()
+
+		handler.ListOrdersHandler(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestGetUserOrdersHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/u1/orders", nil)
+	req.SetPathValue("userID", "u1")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().GetUserOrders("u1").Return([]models.Order{{ID: "o1"}}, nil)
+
+	handler.GetUserOrdersHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,136 @@
+package adminhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	adminservice "github.com/meshyampratap01/OnlineShoppingCart/internal/services/adminService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+// importFormats maps the accepted content types of an import to its format.
+var importFormats = map[string]string{
+	"text/csv":             models.FormatCSV,
+	"application/x-ndjson": models.FormatJSONL,
+	"application/jsonl":    models.FormatJSONL,
+}
+
+var exportContentTypes = map[string]string{
+	models.FormatCSV:   "text/csv; charset=utf-8",
+	models.FormatJSONL: "application/x-ndjson",
+}
+
+// api/v1/admin/products/import?dry_run= [POST]
+func (ah *AdminHandler) ImportProductsHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	format, ok := importFormats[mediaType]
+	if !ok {
+		writeImportError(w, adminservice.ErrUnsupportedFormat)
+		return
+	}
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			resp := webResponse.NewErrorResponse(http.StatusBadRequest, "dry_run must be true or false")
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, config.MaxImportBytes)
+	job, err := ah.AdminService.ImportProducts(userClaims.UserID, format, r.Body, dryRun)
+	if err != nil {
+		writeImportError(w, err)
+		return
+	}
+	var resp *webResponse.WebResponse
+	switch {
+	case job.Status == models.ImportRunning:
+		resp = webResponse.NewSuccessResponse(http.StatusAccepted, "import started", job)
+	case job.Status == models.ImportFailed:
+		// nothing was written; the job lists the rows to fix
+		resp = webResponse.NewErrorResponse(http.StatusBadRequest, "the import has invalid rows")
+		resp.Data = job
+	case job.DryRun:
+		resp = webResponse.NewSuccessResponse(http.StatusOK, "dry run finished", job)
+	default:
+		resp = webResponse.NewSuccessResponse(http.StatusOK, "import finished", job)
+	}
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/imports/{jobID} [GET]
+func (ah *AdminHandler) GetImportJobHandler(w http.ResponseWriter, r *http.Request) {
+	job, err := ah.AdminService.GetImportJob(r.PathValue("jobID"))
+	if err != nil {
+		writeImportError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "import job fetched successfully", job)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/export?format=csv|jsonl [GET]
+func (ah *AdminHandler) ExportProductsHandler(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = models.FormatCSV
+	}
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "format must be csv or jsonl")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	filename := fmt.Sprintf("catalogue-%s.%s", time.Now().UTC().Format("20060102"), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	// the status is already sent, so a failure can only cut the file short
+	err := ah.AdminService.ExportProducts(w, format)
+	if err != nil {
+		log.Printf("catalogue export failed: %v", err)
+	}
+}
+
+func writeImportError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		code = http.StatusRequestEntityTooLarge
+		err = fmt.Errorf("import files can be at most %d MiB", config.MaxImportBytes>>20)
+	case errors.Is(err, adminservice.ErrTooManyRows):
+		code = http.StatusRequestEntityTooLarge
+	case errors.Is(err, adminservice.ErrImportJobNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, adminservice.ErrImportRunning):
+		code = http.StatusConflict
+	case errors.Is(err, adminservice.ErrUnsupportedFormat):
+		code = http.StatusUnsupportedMediaType
+	}
+	resp := webResponse.NewErrorResponse(code, err.Error())
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
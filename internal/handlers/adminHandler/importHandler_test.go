@@ -0,0 +1,234 @@
+package adminhandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	adminservice "github.com/meshyampratap01/OnlineShoppingCart/internal/services/adminService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+	"go.uber.org/mock/gomock"
+)
+
+func newImportRequest(contentType, query, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/import"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	return req.WithContext(getAdminContext())
+}
+
+func TestImportProductsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	tests := []struct {
+		contentType string
+		format      string
+		query       string
+		dryRun      bool
+		job         models.ImportJob
+		code        int
+	}{
+		{"text/csv; charset=utf-8", models.FormatCSV, "?dry_run=true", true, models.ImportJob{Status: models.ImportSucceeded, DryRun: true}, http.StatusOK},
+		{"application/x-ndjson", models.FormatJSONL, "", false, models.ImportJob{Status: models.ImportSucceeded}, http.StatusOK},
+		{"application/jsonl", models.FormatJSONL, "?dry_run=false", false, models.ImportJob{Status: models.ImportRunning}, http.StatusAccepted},
+		{"text/csv", models.FormatCSV, "", false, models.ImportJob{Status: models.ImportFailed, Failed: 1}, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		mockService.EXPECT().ImportProducts("", test.format, gomock.Any(), test.dryRun).
+			DoAndReturn(func(adminID, format string, body io.Reader, dryRun bool) (models.ImportJob, error) {
+				data, _ := io.ReadAll(body)
+				if string(data) != "sku\nA-1\n" {
+					t.Errorf("unexpected body %q", data)
+				}
+				return test.job, nil
+			})
+		w := httptest.NewRecorder()
+		handler.ImportProductsHandler(w, newImportRequest(test.contentType, test.query, "sku\nA-1\n"))
+
+		var resp struct {
+			webResponse.WebResponse
+			Data models.ImportJob `json:"data"`
+		}
+		json.NewDecoder(w.Body).Decode(&resp)
+		if w.Code != test.code || resp.Data.Status != test.job.Status {
+			t.Errorf("%s %s: expected %d with the job, got %d: %+v", test.contentType, test.query, test.code, w.Code, resp)
+		}
+	}
+}
+
+func TestImportProductsHandler_BadRequest(t *testing.T) {
+	handler := NewAdminHandler(nil)
+
+	tests := []struct {
+		contentType string
+		query       string
+		code        int
+	}{
+		{"application/json", "", http.StatusUnsupportedMediaType},
+		{"", "", http.StatusUnsupportedMediaType},
+		{"text/csv", "?dry_run=maybe", http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		handler.ImportProductsHandler(w, newImportRequest(test.contentType, test.query, "sku\nA-1\n"))
+		if w.Code != test.code {
+			t.Errorf("%q %s: expected %d, got %d", test.contentType, test.query, test.code, w.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/import", nil)
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+	handler.ImportProductsHandler(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a user, got %d", w.Code)
+	}
+}
+
+func TestImportProductsHandler_ServiceErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{adminservice.ErrImportRunning, http.StatusConflict},
+		{adminservice.ErrTooManyRows, http.StatusRequestEntityTooLarge},
+		{adminservice.ErrMalformedImport, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		mockService.EXPECT().ImportProducts("", models.FormatCSV, gomock.Any(), false).Return(models.ImportJob{}, test.err)
+		w := httptest.NewRecorder()
+		handler.ImportProductsHandler(w, newImportRequest("text/csv", "", "sku\n"))
+		if w.Code != test.code {
+			t.Errorf("%v: expected %d, got %d", test.err, test.code, w.Code)
+		}
+	}
+}
+
+func TestImportProductsHandler_TooLarge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	maxBytes := config.MaxImportBytes
+	config.MaxImportBytes = 8
+	defer func() { config.MaxImportBytes = maxBytes }()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	mockService.EXPECT().ImportProducts("", models.FormatCSV, gomock.Any(), false).
+		DoAndReturn(func(adminID, format string, body io.Reader, dryRun bool) (models.ImportJob, error) {
+			_, err := io.ReadAll(body)
+			return models.ImportJob{}, err
+		})
+	w := httptest.NewRecorder()
+	handler.ImportProductsHandler(w, newImportRequest("text/csv", "", "sku\nA-1\nA-2\n"))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d", w.Code)
+	}
+}
+
+func TestGetImportJobHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	mockService.EXPECT().GetImportJob("j1").Return(models.ImportJob{ID: "j1", Status: models.ImportRunning, ProcessedRows: 300}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/import/j1", nil)
+	req.SetPathValue("jobID", "j1")
+	w := httptest.NewRecorder()
+	handler.GetImportJobHandler(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"processed_rows":300`) {
+		t.Errorf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+
+	mockService.EXPECT().GetImportJob("missing").Return(models.ImportJob{}, adminservice.ErrImportJobNotFound)
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/import/missing", nil)
+	req.SetPathValue("jobID", "missing")
+	w = httptest.NewRecorder()
+	handler.GetImportJobHandler(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestExportProductsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	mockService.EXPECT().ExportProducts(gomock.Any(), models.FormatJSONL).DoAndReturn(func(w io.Writer, format string) error {
+		_, err := io.WriteString(w, `{"sku":"A-1"}`+"\n")
+		return err
+	})
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/export?format=jsonl", nil)
+	w := httptest.NewRecorder()
+	handler.ExportProductsHandler(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/x-ndjson" || w.Body.String() != `{"sku":"A-1"}`+"\n" {
+		t.Errorf("unexpected response %d %v: %s", w.Code, w.Header(), w.Body.String())
+	}
+	if disposition := w.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, `attachment; filename="catalogue-`) ||
+		!strings.HasSuffix(disposition, `.jsonl"`) {
+		t.Errorf("unexpected Content-Disposition %q", disposition)
+	}
+
+	// csv is the default
+	mockService.EXPECT().ExportProducts(gomock.Any(), models.FormatCSV).Return(errors.New("db error"))
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/export", nil)
+	w = httptest.NewRecorder()
+	handler.ExportProductsHandler(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Errorf("unexpected response %d %v", w.Code, w.Header())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/export?format=xlsx", nil)
+	w = httptest.NewRecorder()
+	handler.ExportProductsHandler(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAddProductHandler_SKU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAdminServiceManager(ctrl)
+	handler := NewAdminHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewBufferString(`{"sku":"bad sku","name":"Lamp","price":20}`))
+	w := httptest.NewRecorder()
+	handler.AddProductHandler(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid sku, got %d", w.Code)
+	}
+
+	mockService.EXPECT().AddProduct(gomock.Any(), gomock.Any()).Return(adminservice.ErrSKUExists)
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/product", bytes.NewBufferString(`{"sku":"LAMP-1","name":"Lamp","price":20}`))
+	req = req.WithContext(getAdminContext())
+	w = httptest.NewRecorder()
+	handler.AddProductHandler(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a taken sku, got %d", w.Code)
+	}
+}
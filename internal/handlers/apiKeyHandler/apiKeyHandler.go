@@ -0,0 +1,85 @@
+package apiKeyHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/apiKeyService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type APIKeyHandler struct {
+	apiKeyService apiKeyService.APIKeyServiceManager
+}
+
+func NewAPIKeyHandler(apiKeyService apiKeyService.APIKeyServiceManager) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// api/v1/admin/api-keys [POST]
+func (ah *APIKeyHandler) CreateAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.CreateAPIKeyDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	created, err := ah.apiKeyService.CreateAPIKey(userClaims.UserID, userClaims.Role, req.Name, req.Scopes)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusCreated, "api key created, store it now as it will not be shown again", created)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/api-keys [GET]
+func (ah *APIKeyHandler) ListAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	keys, err := ah.apiKeyService.ListAPIKeys()
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "api keys fetched successfully", keys)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/api-keys/{keyID} [DELETE]
+func (ah *APIKeyHandler) RevokeAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	err := ah.apiKeyService.RevokeAPIKey(r.PathValue("keyID"))
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, apiKeyService.ErrAPIKeyNotFound) {
+			code = http.StatusNotFound
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "api key revoked successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
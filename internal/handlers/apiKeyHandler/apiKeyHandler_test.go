@@ -0,0 +1,121 @@
+package apiKeyHandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/apiKeyService"
+	"go.uber.org/mock/gomock"
+)
+
+func getAdminContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "admin1", Role: models.Admin})
+}
+
+func TestCreateAPIKeyHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAPIKeyServiceManager(ctrl)
+	handler := NewAPIKeyHandler(mockService)
+
+	body, _ := json.Marshal(dto.CreateAPIKeyDTO{Name: "ERP", Scopes: []string{"products:write"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/api-keys", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().CreateAPIKey("admin1", models.Admin, "ERP", []string{"products:write"}).
+		Return(dto.APIKeyCreatedDTO{ID: "k1", Key: "osk_secret"}, nil)
+
+	handler.CreateAPIKeyHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", w.Code)
+	}
+}
+
+func TestCreateAPIKeyHandler_InvalidScope(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAPIKeyServiceManager(ctrl)
+	handler := NewAPIKeyHandler(mockService)
+
+	body, _ := json.Marshal(dto.CreateAPIKeyDTO{Name: "ERP", Scopes: []string{"everything"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/api-keys", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().CreateAPIKey("admin1", models.Admin, "ERP", []string{"everything"}).
+		Return(dto.APIKeyCreatedDTO{}, errors.New(`unknown permission "everything"`))
+
+	handler.CreateAPIKeyHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestCreateAPIKeyHandler_Unauthorized(t *testing.T) {
+	handler := NewAPIKeyHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/api-keys", nil)
+	w := httptest.NewRecorder()
+
+	handler.CreateAPIKeyHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestListAPIKeysHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAPIKeyServiceManager(ctrl)
+	handler := NewAPIKeyHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/api-keys", nil)
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ListAPIKeys().Return([]models.APIKey{{ID: "k1", Name: "ERP", KeyHash: "secret-hash"}}, nil)
+
+	handler.ListAPIKeysHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte("secret-hash")) {
+		t.Error("key hash must not be returned")
+	}
+}
+
+func TestRevokeAPIKeyHandler_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAPIKeyServiceManager(ctrl)
+	handler := NewAPIKeyHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/api-keys/k1", nil)
+	req.SetPathValue("keyID", "k1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().RevokeAPIKey("k1").Return(apiKeyService.ErrAPIKeyNotFound)
+
+	handler.RevokeAPIKeyHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,136 @@
+package authHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type AuthHandler struct {
+	authService authService.AuthServiceManager
+}
+
+func NewAuthHandler(authService authService.AuthServiceManager) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+	}
+}
+
+// api/v1/logout [POST]
+func (ah *AuthHandler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err := ah.authService.Logout(userClaims)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Logged out successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/logout-all [POST]
+func (ah *AuthHandler) LogoutAllHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err := ah.authService.LogoutAll(userClaims.UserID)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Logged out of all sessions successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me/sessions [GET]
+func (ah *AuthHandler) ListSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	sessions, err := ah.authService.ListSessions(userClaims.UserID, userClaims.ID)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "sessions fetched successfully", sessions)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me/sessions/{sessionID} [DELETE]
+func (ah *AuthHandler) TerminateSessionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err := ah.authService.TerminateSession(userClaims.UserID, r.PathValue("sessionID"))
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, authService.ErrSessionNotFound) {
+			code = http.StatusNotFound
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "session terminated successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/users/{userID}/logout-all [POST]
+func (ah *AuthHandler) RevokeUserSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("userID")
+	err := ah.authService.RevokeUserSessions(userID)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "user sessions revoked successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// .well-known/jwks.json [GET]
+func (ah *AuthHandler) JWKSHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(ah.authService.GetJWKS())
+}
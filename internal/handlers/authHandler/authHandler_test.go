@@ -0,0 +1,186 @@
+package authHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authService"
+	"go.uber.org/mock/gomock"
+)
+
+func getAdminContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "admin1", Role: models.Admin})
+}
+
+func getCustomerContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "user123", Role: models.Customer})
+}
+
+func TestLogoutHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+	handler := NewAuthHandler(mockAuthService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logout", nil)
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().Logout(gomock.Any()).Return(nil)
+
+	handler.LogoutHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestLogoutHandler_Unauthorized(t *testing.T) {
+	handler := NewAuthHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logout", nil)
+	w := httptest.NewRecorder()
+
+	handler.LogoutHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestLogoutAllHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+	handler := NewAuthHandler(mockAuthService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logout-all", nil)
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().LogoutAll("user123").Return(nil)
+
+	handler.LogoutAllHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestLogoutAllHandler_ServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+	handler := NewAuthHandler(mockAuthService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/logout-all", nil)
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().LogoutAll("user123").Return(errors.New("db error"))
+
+	handler.LogoutAllHandler(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
+
+func TestRevokeUserSessionsHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+	handler := NewAuthHandler(mockAuthService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/logout-all", nil)
+	req.SetPathValue("userID", "u1")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().RevokeUserSessions("u1").Return(nil)
+
+	handler.RevokeUserSessionsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestListSessionsHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+	handler := NewAuthHandler(mockAuthService)
+
+	claims := models.UserJWT{UserID: "user123", Role: models.Customer, RegisteredClaims: jwt.RegisteredClaims{ID: "s1"}}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me/sessions", nil)
+	req = req.WithContext(context.WithValue(context.Background(), config.User, claims))
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().ListSessions("user123", "s1").Return([]dto.SessionDTO{{ID: "s1", Current: true}}, nil)
+
+	handler.ListSessionsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestListSessionsHandler_Unauthorized(t *testing.T) {
+	handler := NewAuthHandler(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me/sessions", nil)
+	w := httptest.NewRecorder()
+
+	handler.ListSessionsHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestTerminateSessionHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", wantCode: http.StatusOK},
+		{name: "Not found", err: authService.ErrSessionNotFound, wantCode: http.StatusNotFound},
+		{name: "Service error", err: errors.New("db error"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+			handler := NewAuthHandler(mockAuthService)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/me/sessions/s2", nil)
+			req.SetPathValue("sessionID", "s2")
+			req = req.WithContext(getCustomerContext())
+			w := httptest.NewRecorder()
+
+			mockAuthService.EXPECT().TerminateSession("user123", "s2").Return(tt.err)
+
+			handler.TerminateSessionHandler(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
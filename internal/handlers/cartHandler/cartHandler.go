@@ -2,6 +2,7 @@ package cartHandler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -29,13 +30,6 @@ func (ch *CartHandler) GetCartHandler(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	userRole := userClaims.Role
-	if userRole != models.Customer {
-		resp := webResponse.NewErrorResponse(http.StatusForbidden, "forbidden")
-		w.WriteHeader(resp.Code)
-		json.NewEncoder(w).Encode(resp)
-		return
-	}
 	userId := userClaims.UserID
 	cartItems, err := ch.cartService.GetCartItems(userId)
 	if err != nil {
@@ -55,7 +49,7 @@ func (ch *CartHandler) GetCartHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-// api/v1/cart/{prodID} [POST]
+// api/v1/cart/{prodID}?variant={variantID} [POST]
 func (ch *CartHandler) AddToCartHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
@@ -65,16 +59,16 @@ func (ch *CartHandler) AddToCartHandler(w http.ResponseWriter, r *http.Request)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	userRole := userClaims.Role
-	if userRole != models.Customer {
-		resp := webResponse.NewErrorResponse(http.StatusForbidden, "forbidden")
+	userId := userClaims.UserID
+	prodID := r.PathValue("prodID")
+	err := ch.cartService.AddToCart(userId, prodID, r.URL.Query().Get("variant"))
+	if errors.Is(err, cartService.ErrVariantRequired) || errors.Is(err, cartService.ErrVariantNotFound) ||
+		errors.Is(err, cartService.ErrUnavailable) {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	userId := userClaims.UserID
-	prodID := r.PathValue("prodID")
-	err := ch.cartService.AddToCart(userId, prodID)
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, fmt.Sprintf("problem while adding product to cart: %v",err.Error()))
 		w.WriteHeader(resp.Code)
@@ -86,7 +80,7 @@ func (ch *CartHandler) AddToCartHandler(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(resp)
 }
 
-// api/v1/cart/{prodID} [DELETE]
+// api/v1/cart/{prodID}?variant={variantID} [DELETE]
 func (ch *CartHandler) RemoveFromCartHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
@@ -96,16 +90,9 @@ func (ch *CartHandler) RemoveFromCartHandler(w http.ResponseWriter, r *http.Requ
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	userRole := userClaims.Role
-	if userRole != models.Customer {
-		resp := webResponse.NewErrorResponse(http.StatusForbidden, "forbidden")
-		w.WriteHeader(resp.Code)
-		json.NewEncoder(w).Encode(resp)
-		return
-	}
 	userId := userClaims.UserID
 	prodID := r.PathValue("prodID")
-	err := ch.cartService.RemoveFromCart(userId, prodID)
+	err := ch.cartService.RemoveFromCart(userId, prodID, r.URL.Query().Get("variant"))
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
 		w.WriteHeader(resp.Code)
@@ -127,16 +114,27 @@ func (ch *CartHandler) CheckOutHandler(w http.ResponseWriter, r *http.Request) {
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	userRole := userClaims.Role
-	if userRole != models.Customer {
-		resp := webResponse.NewErrorResponse(http.StatusForbidden, "forbidden")
+	userId := userClaims.UserID
+	couponCode := r.URL.Query().Get("code")
+	finalAmount, err := ch.cartService.Checkout(userId, couponCode)
+	if errors.Is(err, cartService.ErrEmailNotVerified) {
+		resp := webResponse.NewErrorResponse(http.StatusForbidden, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if errors.Is(err, cartService.ErrUnavailable) {
+		resp := webResponse.NewErrorResponse(http.StatusConflict, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if errors.Is(err, cartService.ErrEmptyCart) {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	userId := userClaims.UserID
-	couponCode := r.URL.Query().Get("code")
-	finalAmount, err := ch.cartService.Checkout(userId, couponCode)
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
 		w.WriteHeader(resp.Code)
@@ -3,6 +3,7 @@ package cartHandler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	cartService "github.com/meshyampratap01/OnlineShoppingCart/internal/services/cartService"
 	"go.uber.org/mock/gomock"
 )
 
@@ -18,10 +20,6 @@ func getCustomerContext() context.Context {
 	return context.WithValue(context.Background(), config.User, models.UserJWT{Role: models.Customer, UserID: "user123"})
 }
 
-func getAdminContext() context.Context {
-	return context.WithValue(context.Background(), config.User, models.UserJWT{Role: models.Admin, UserID: "admin123"})
-}
-
 func TestGetCartHandler_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -75,20 +73,6 @@ func TestGetCartHandler_Unauthorized(t *testing.T) {
 	}
 }
 
-func TestGetCartHandler_Forbidden(t *testing.T) {
-	handler := NewCartHandler(nil)
-
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/cart", nil)
-	req = req.WithContext(getAdminContext())
-	w := httptest.NewRecorder()
-
-	handler.GetCartHandler(w, req)
-
-	if w.Code != http.StatusForbidden {
-		t.Errorf("expected 403, got %d", w.Code)
-	}
-}
-
 func TestGetCartHandler_ServiceError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -121,7 +105,7 @@ func TestAddToCartHandler_Success(t *testing.T) {
 	req.SetPathValue("prodID", "p1")
 	w := httptest.NewRecorder()
 
-	mockCartService.EXPECT().AddToCart("user123", "p1").Return(nil)
+	mockCartService.EXPECT().AddToCart("user123", "p1", "").Return(nil)
 
 	handler.AddToCartHandler(w, req)
 
@@ -142,7 +126,7 @@ func TestAddToCartHandler_Error(t *testing.T) {
 	req.SetPathValue("prodID", "p1")
 	w := httptest.NewRecorder()
 
-	mockCartService.EXPECT().AddToCart("user123", "p1").Return(errors.New("add error"))
+	mockCartService.EXPECT().AddToCart("user123", "p1", "").Return(errors.New("add error"))
 
 	handler.AddToCartHandler(w, req)
 
@@ -151,6 +135,40 @@ func TestAddToCartHandler_Error(t *testing.T) {
 	}
 }
 
+func TestAddToCartHandler_Variant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mocks.NewMockCartServiceManager(ctrl)
+	handler := NewCartHandler(mockCartService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/cart/p1?variant=v1", nil)
+	req = req.WithContext(getCustomerContext())
+	req.SetPathValue("prodID", "p1")
+	w := httptest.NewRecorder()
+
+	mockCartService.EXPECT().AddToCart("user123", "p1", "v1").Return(nil)
+
+	handler.AddToCartHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/cart/p1", nil)
+	req = req.WithContext(getCustomerContext())
+	req.SetPathValue("prodID", "p1")
+	w = httptest.NewRecorder()
+
+	mockCartService.EXPECT().AddToCart("user123", "p1", "").Return(cartService.ErrVariantRequired)
+
+	handler.AddToCartHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
 func TestRemoveFromCartHandler_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -163,7 +181,7 @@ func TestRemoveFromCartHandler_Success(t *testing.T) {
 	req.SetPathValue("prodID", "p1")
 	w := httptest.NewRecorder()
 
-	mockCartService.EXPECT().RemoveFromCart("user123", "p1").Return(nil)
+	mockCartService.EXPECT().RemoveFromCart("user123", "p1", "").Return(nil)
 
 	handler.RemoveFromCartHandler(w, req)
 
@@ -184,7 +202,7 @@ func TestRemoveFromCartHandler_Error(t *testing.T) {
 	req.SetPathValue("prodID", "p1")
 	w := httptest.NewRecorder()
 
-	mockCartService.EXPECT().RemoveFromCart("user123", "p1").Return(errors.New("remove error"))
+	mockCartService.EXPECT().RemoveFromCart("user123", "p1", "").Return(errors.New("remove error"))
 
 	handler.RemoveFromCartHandler(w, req)
 
@@ -232,3 +250,63 @@ func TestCheckOutHandler_Error(t *testing.T) {
 		t.Errorf("expected 500, got %d", w.Code)
 	}
 }
+
+func TestCheckOutHandler_EmailNotVerified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mocks.NewMockCartServiceManager(ctrl)
+	handler := NewCartHandler(mockCartService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/checkout", nil)
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockCartService.EXPECT().Checkout("user123", "").Return(float32(0.0), cartService.ErrEmailNotVerified)
+
+	handler.CheckOutHandler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestCheckOutHandler_Unavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mocks.NewMockCartServiceManager(ctrl)
+	handler := NewCartHandler(mockCartService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/checkout", nil)
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockCartService.EXPECT().Checkout("user123", "").Return(float32(0.0), fmt.Errorf("%w: remove Lamp from the cart", cartService.ErrUnavailable))
+
+	handler.CheckOutHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestCheckOutHandler_EmptyCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartService := mocks.NewMockCartServiceManager(ctrl)
+	handler := NewCartHandler(mockCartService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/checkout", nil)
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockCartService.EXPECT().Checkout("user123", "").Return(float32(0.0), cartService.ErrEmptyCart)
+
+	handler.CheckOutHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
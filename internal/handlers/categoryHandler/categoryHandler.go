@@ -0,0 +1,165 @@
+package categoryHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/categoryService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type CategoryHandler struct {
+	categoryService categoryService.CategoryServiceManager
+}
+
+func NewCategoryHandler(categoryService categoryService.CategoryServiceManager) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+// api/v1/categories [GET]
+func (ch *CategoryHandler) GetCategoryTreeHandler(w http.ResponseWriter, r *http.Request) {
+	tree, err := ch.categoryService.GetCategoryTree()
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "categories fetched successfully", tree)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/products/{prodID}/categories [GET]
+func (ch *CategoryHandler) GetProductCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	categories, err := ch.categoryService.GetProductCategories(r.PathValue("prodID"))
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "categories fetched successfully", categories)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/categories [GET]
+func (ch *CategoryHandler) ListCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	categories, err := ch.categoryService.ListCategories()
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "categories fetched successfully", categories)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/categories/{categoryID} [GET]
+func (ch *CategoryHandler) GetCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	category, err := ch.categoryService.GetCategory(r.PathValue("categoryID"))
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "category fetched successfully", category)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/categories [POST]
+func (ch *CategoryHandler) CreateCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CategoryDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	category, err := ch.categoryService.CreateCategory(req)
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusCreated, "category created successfully", category)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/categories/{categoryID} [PUT]
+func (ch *CategoryHandler) UpdateCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.CategoryDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	category, err := ch.categoryService.UpdateCategory(r.PathValue("categoryID"), req)
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "category updated successfully", category)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/categories/{categoryID} [DELETE]
+func (ch *CategoryHandler) DeleteCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	err := ch.categoryService.DeleteCategory(r.PathValue("categoryID"))
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "category deleted successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/categories [PUT]
+func (ch *CategoryHandler) SetProductCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.ProductCategoriesDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	categories, err := ch.categoryService.SetProductCategories(r.PathValue("prodID"), req.Categories)
+	if errors.Is(err, categoryService.ErrCategoryNotFound) {
+		// an unknown slug in the body is a bad request, not a missing resource
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if err != nil {
+		writeCategoryError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "product categories updated successfully", categories)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeCategoryError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, categoryService.ErrCategoryNotFound), errors.Is(err, categoryService.ErrProductNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, categoryService.ErrCategoryExists), errors.Is(err, categoryService.ErrCategoryHasChildren):
+		code = http.StatusConflict
+	}
+	resp := webResponse.NewErrorResponse(code, err.Error())
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
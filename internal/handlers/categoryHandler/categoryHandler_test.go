@@ -0,0 +1,135 @@
+package categoryHandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/categoryService"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCategoryTreeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCategoryServiceManager(ctrl)
+	handler := NewCategoryHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/categories", nil)
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().GetCategoryTree().Return([]models.Category{
+		{ID: "c1", Name: "Computers", Slug: "computers", Children: []models.Category{{ID: "c2", Name: "Laptops", Slug: "laptops"}}},
+	}, nil)
+
+	handler.GetCategoryTreeHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestCreateCategoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCategoryServiceManager(ctrl)
+	handler := NewCategoryHandler(mockService)
+
+	body, _ := json.Marshal(dto.CategoryDTO{Name: "Books"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/categories", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().CreateCategory(dto.CategoryDTO{Name: "Books"}).Return(models.Category{ID: "c1", Name: "Books", Slug: "books"}, nil)
+
+	handler.CreateCategoryHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/categories", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+
+	mockService.EXPECT().CreateCategory(gomock.Any()).Return(models.Category{}, categoryService.ErrCategoryExists)
+
+	handler.CreateCategoryHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestCreateCategoryHandler_InvalidJSON(t *testing.T) {
+	handler := NewCategoryHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/categories", bytes.NewReader([]byte("{")))
+	w := httptest.NewRecorder()
+
+	handler.CreateCategoryHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestDeleteCategoryHandler_HasChildren(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCategoryServiceManager(ctrl)
+	handler := NewCategoryHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/categories/c1", nil)
+	req.SetPathValue("categoryID", "c1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().DeleteCategory("c1").Return(categoryService.ErrCategoryHasChildren)
+
+	handler.DeleteCategoryHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestSetProductCategoriesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCategoryServiceManager(ctrl)
+	handler := NewCategoryHandler(mockService)
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "Success", wantCode: http.StatusOK},
+		{name: "Unknown category", err: fmt.Errorf("%w: nope", categoryService.ErrCategoryNotFound), wantCode: http.StatusBadRequest},
+		{name: "Unknown product", err: categoryService.ErrProductNotFound, wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(dto.ProductCategoriesDTO{Categories: []string{"books"}})
+			req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1/categories", bytes.NewReader(body))
+			req.SetPathValue("prodID", "p1")
+			w := httptest.NewRecorder()
+
+			mockService.EXPECT().SetProductCategories("p1", []string{"books"}).Return([]models.Category{}, tt.err)
+
+			handler.SetProductCategoriesHandler(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package imageHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/imageService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+// multipartOverhead allows for the form's boundaries and part headers on top
+// of the image itself.
+const multipartOverhead = 64 << 10
+
+type ImageHandler struct {
+	imageService imageService.ImageServiceManager
+}
+
+func NewImageHandler(imageService imageService.ImageServiceManager) *ImageHandler {
+	return &ImageHandler{
+		imageService: imageService,
+	}
+}
+
+// api/v1/admin/products/{prodID}/images [POST]
+func (ih *ImageHandler) UploadImageHandler(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, config.MaxImageUploadBytes+multipartOverhead)
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeImageError(w, imageService.ErrImageTooLarge)
+			return
+		}
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "expected a multipart form with an image file")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, config.MaxImageUploadBytes+1))
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "can not read the image file")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	image, err := ih.imageService.UploadImage(r.PathValue("prodID"), data)
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusCreated, "image uploaded successfully", image)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/products/{prodID}/images [GET]
+func (ih *ImageHandler) ListImagesHandler(w http.ResponseWriter, r *http.Request) {
+	images, err := ih.imageService.ListImages(r.PathValue("prodID"))
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "images fetched successfully", images)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/images/order [PUT]
+func (ih *ImageHandler) ReorderImagesHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.ImageOrderDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	images, err := ih.imageService.ReorderImages(r.PathValue("prodID"), req.ImageIDs)
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "images reordered successfully", images)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/images/{imageID}/primary [PUT]
+func (ih *ImageHandler) SetPrimaryImageHandler(w http.ResponseWriter, r *http.Request) {
+	images, err := ih.imageService.SetPrimaryImage(r.PathValue("prodID"), r.PathValue("imageID"))
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "primary image updated successfully", images)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/images/{imageID} [DELETE]
+func (ih *ImageHandler) DeleteImageHandler(w http.ResponseWriter, r *http.Request) {
+	err := ih.imageService.DeleteImage(r.PathValue("prodID"), r.PathValue("imageID"))
+	if err != nil {
+		writeImageError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "image deleted successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeImageError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, imageService.ErrProductNotFound), errors.Is(err, imageService.ErrImageNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, imageService.ErrTooManyImages):
+		code = http.StatusConflict
+	case errors.Is(err, imageService.ErrImageTooLarge):
+		code = http.StatusRequestEntityTooLarge
+	case errors.Is(err, imageService.ErrUnsupportedType):
+		code = http.StatusUnsupportedMediaType
+	}
+	resp := webResponse.NewErrorResponse(code, err.Error())
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
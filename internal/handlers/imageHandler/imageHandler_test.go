@@ -0,0 +1,157 @@
+package imageHandler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/imageService"
+	"go.uber.org/mock/gomock"
+)
+
+func uploadRequest(t *testing.T, field string, data []byte) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile(field, "photo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	form.Close()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/p1/images", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.SetPathValue("prodID", "p1")
+	return req
+}
+
+func TestUploadImageHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockImageServiceManager(ctrl)
+	handler := NewImageHandler(mockService)
+
+	mockService.EXPECT().UploadImage("p1", []byte("png data")).Return(models.ProductImage{ID: "i1", ProductID: "p1"}, nil)
+	w := httptest.NewRecorder()
+	handler.UploadImageHandler(w, uploadRequest(t, "image", []byte("png data")))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", w.Code)
+	}
+
+	mockService.EXPECT().UploadImage("p1", []byte("hello")).Return(models.ProductImage{}, imageService.ErrUnsupportedType)
+	w = httptest.NewRecorder()
+	handler.UploadImageHandler(w, uploadRequest(t, "image", []byte("hello")))
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.UploadImageHandler(w, uploadRequest(t, "file", []byte("png data")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without an image field, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	handler.UploadImageHandler(w, uploadRequest(t, "image", make([]byte, config.MaxImageUploadBytes+multipartOverhead)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d", w.Code)
+	}
+}
+
+func TestListImagesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockImageServiceManager(ctrl)
+	handler := NewImageHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/404/images", nil)
+	req.SetPathValue("prodID", "404")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ListImages("404").Return(nil, imageService.ErrProductNotFound)
+
+	handler.ListImagesHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestReorderImagesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockImageServiceManager(ctrl)
+	handler := NewImageHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1/images/order", strings.NewReader(`{"image_ids":["i2","i1"]}`))
+	req.SetPathValue("prodID", "p1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ReorderImages("p1", []string{"i2", "i1"}).Return([]models.ProductImage{{ID: "i2"}, {ID: "i1"}}, nil)
+
+	handler.ReorderImagesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1/images/order", strings.NewReader(`{"image_ids":`))
+	req.SetPathValue("prodID", "p1")
+	w = httptest.NewRecorder()
+
+	handler.ReorderImagesHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestSetPrimaryImageHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockImageServiceManager(ctrl)
+	handler := NewImageHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1/images/i2/primary", nil)
+	req.SetPathValue("prodID", "p1")
+	req.SetPathValue("imageID", "i2")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().SetPrimaryImage("p1", "i2").Return([]models.ProductImage{{ID: "i2", Primary: true}}, nil)
+
+	handler.SetPrimaryImageHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestDeleteImageHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockImageServiceManager(ctrl)
+	handler := NewImageHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/products/p1/images/404", nil)
+	req.SetPathValue("prodID", "p1")
+	req.SetPathValue("imageID", "404")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().DeleteImage("p1", "404").Return(imageService.ErrImageNotFound)
+
+	handler.DeleteImageHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
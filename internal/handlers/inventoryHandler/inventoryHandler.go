@@ -0,0 +1,114 @@
+package inventoryHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/inventoryService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+const (
+	defaultMovementPageSize = 50
+	maxMovementPageSize     = 200
+)
+
+type InventoryHandler struct {
+	inventoryService inventoryService.InventoryServiceManager
+}
+
+func NewInventoryHandler(inventoryService inventoryService.InventoryServiceManager) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// api/v1/admin/products/{prodID}/stock-movements?variant_id=&page=&limit= [GET]
+func (ih *InventoryHandler) ListStockMovementsHandler(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseMovementFilter(r.URL.Query())
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	movements, err := ih.inventoryService.ListStockMovements(r.PathValue("prodID"), filter)
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "stock movements fetched successfully", movements)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/stock-adjustments [POST]
+func (ih *InventoryHandler) AdjustStockHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.StockAdjustmentDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	movement, err := ih.inventoryService.AdjustStock(userClaims.UserID, r.PathValue("prodID"), req)
+	if err != nil {
+		writeInventoryError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusCreated, "stock adjusted successfully", movement)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func parseMovementFilter(query url.Values) (models.StockMovementFilter, error) {
+	filter := models.StockMovementFilter{
+		VariantID: query.Get("variant_id"),
+		Limit:     defaultMovementPageSize,
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid limit")
+		}
+		filter.Limit = min(n, maxMovementPageSize)
+	}
+	page := 1
+	if p := query.Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			return filter, fmt.Errorf("invalid page")
+		}
+		page = n
+	}
+	filter.Offset = (page - 1) * filter.Limit
+	return filter, nil
+}
+
+func writeInventoryError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, inventoryService.ErrProductNotFound), errors.Is(err, inventoryService.ErrVariantNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, inventoryService.ErrInsufficientStock):
+		code = http.StatusConflict
+	}
+	resp := webResponse.NewErrorResponse(code, err.Error())
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
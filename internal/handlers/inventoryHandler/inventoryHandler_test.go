@@ -0,0 +1,105 @@
+package inventoryHandler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/inventoryService"
+	"go.uber.org/mock/gomock"
+)
+
+func getAdminContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "admin1", Role: models.Admin})
+}
+
+func TestListStockMovementsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockInventoryServiceManager(ctrl)
+	handler := NewInventoryHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/p1/stock-movements?variant_id=v1&page=3&limit=10", nil)
+	req.SetPathValue("prodID", "p1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ListStockMovements("p1", models.StockMovementFilter{VariantID: "v1", Limit: 10, Offset: 20}).
+		Return(dto.StockMovementListDTO{Movements: []models.StockMovement{}, Page: 3, Limit: 10}, nil)
+
+	handler.ListStockMovementsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	for _, query := range []string{"page=0", "limit=-1", "limit=ten"} {
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/p1/stock-movements?"+query, nil)
+		req.SetPathValue("prodID", "p1")
+		w = httptest.NewRecorder()
+		handler.ListStockMovementsHandler(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/404/stock-movements", nil)
+	req.SetPathValue("prodID", "404")
+	w = httptest.NewRecorder()
+
+	mockService.EXPECT().ListStockMovements("404", gomock.Any()).Return(dto.StockMovementListDTO{}, inventoryService.ErrProductNotFound)
+
+	handler.ListStockMovementsHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestAdjustStockHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockInventoryServiceManager(ctrl)
+	handler := NewInventoryHandler(mockService)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{nil, http.StatusCreated},
+		{inventoryService.ErrInsufficientStock, http.StatusConflict},
+		{inventoryService.ErrVariantRequired, http.StatusBadRequest},
+		{inventoryService.ErrVariantNotFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/p1/stock-adjustments",
+			bytes.NewBufferString(`{"delta":-2,"reason":"return","note":"damaged"}`))
+		req = req.WithContext(getAdminContext())
+		req.SetPathValue("prodID", "p1")
+		w := httptest.NewRecorder()
+
+		mockService.EXPECT().AdjustStock("admin1", "p1", dto.StockAdjustmentDTO{Delta: -2, Reason: "return", Note: "damaged"}).
+			Return(models.StockMovement{ID: "m1"}, tt.err)
+
+		handler.AdjustStockHandler(w, req)
+
+		if w.Code != tt.code {
+			t.Errorf("error %v: expected %d, got %d", tt.err, tt.code, w.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/p1/stock-adjustments", bytes.NewBufferString("{"))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+	handler.AdjustStockHandler(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an invalid body, got %d", w.Code)
+	}
+}
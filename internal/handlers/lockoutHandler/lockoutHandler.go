@@ -0,0 +1,44 @@
+package lockoutHandler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type LockoutHandler struct {
+	lockoutService lockoutService.LockoutServiceManager
+}
+
+func NewLockoutHandler(lockoutService lockoutService.LockoutServiceManager) *LockoutHandler {
+	return &LockoutHandler{
+		lockoutService: lockoutService,
+	}
+}
+
+// api/v1/admin/users/{userID}/unlock [POST]
+func (lh *LockoutHandler) UnlockUserHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	userID := r.PathValue("userID")
+	err := lh.lockoutService.Unlock(userClaims.UserID, userID)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "user unlocked successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,73 @@
+package lockoutHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+func getAdminContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "admin1", Role: models.Admin})
+}
+
+func TestUnlockUserHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockLockoutServiceManager(ctrl)
+	handler := NewLockoutHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/unlock", nil)
+	req.SetPathValue("userID", "u1")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().Unlock("admin1", "u1").Return(nil)
+
+	handler.UnlockUserHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestUnlockUserHandler_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockLockoutServiceManager(ctrl)
+	handler := NewLockoutHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/missing/unlock", nil)
+	req.SetPathValue("userID", "missing")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().Unlock("admin1", "missing").Return(errors.New("user not found"))
+
+	handler.UnlockUserHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestUnlockUserHandler_Unauthorized(t *testing.T) {
+	handler := NewLockoutHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/unlock", nil)
+	w := httptest.NewRecorder()
+
+	handler.UnlockUserHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,145 @@
+package mfaHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/mfaService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type MFAHandler struct {
+	mfaService mfaService.MFAServiceManager
+}
+
+func NewMFAHandler(mfaService mfaService.MFAServiceManager) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+	}
+}
+
+// api/v1/me/mfa/enroll [POST]
+func (mh *MFAHandler) EnrollHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	enrollment, err := mh.mfaService.BeginEnrollment(userClaims.UserID)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Scan the QR code with an authenticator app and confirm with a code", enrollment)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me/mfa/confirm [POST]
+func (mh *MFAHandler) ConfirmHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.MFACodeDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.Code == "" {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	codes, err := mh.mfaService.ConfirmEnrollment(userClaims.UserID, req.Code)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Two-factor authentication enabled, store the recovery codes safely", dto.MFASetupResultDTO{RecoveryCodes: codes})
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me/mfa/recovery-codes [POST]
+func (mh *MFAHandler) RegenerateRecoveryCodesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.MFACodeDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.Code == "" {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	codes, err := mh.mfaService.RegenerateRecoveryCodes(userClaims.UserID, req.Code)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "New recovery codes generated, the old ones no longer work", dto.MFASetupResultDTO{RecoveryCodes: codes})
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me/mfa [DELETE]
+func (mh *MFAHandler) DisableHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.MFACodeDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.Code == "" {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	err = mh.mfaService.Disable(userClaims.UserID, req.Code)
+	if errors.Is(err, mfaService.ErrMFARequired) {
+		resp := webResponse.NewErrorResponse(http.StatusForbidden, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Two-factor authentication disabled", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,113 @@
+package mfaHandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/mfaService"
+	"go.uber.org/mock/gomock"
+)
+
+func getCustomerContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "user123", Role: models.Customer})
+}
+
+func TestEnrollHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMFAServiceManager(ctrl)
+	handler := NewMFAHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/me/mfa/enroll", nil).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().BeginEnrollment("user123").Return(dto.MFAEnrollmentDTO{Secret: "SECRET", OTPAuthURI: "otpauth://totp/x", QRPayload: "otpauth://totp/x"}, nil)
+
+	handler.EnrollHandler(w, req)
+
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte(`"qr_payload":"otpauth://totp/x"`)) {
+		t.Errorf("expected enrollment payload, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestEnrollHandler_Unauthorized(t *testing.T) {
+	handler := NewMFAHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/me/mfa/enroll", nil)
+	w := httptest.NewRecorder()
+
+	handler.EnrollHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestConfirmHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMFAServiceManager(ctrl)
+	handler := NewMFAHandler(mockService)
+
+	body, _ := json.Marshal(dto.MFACodeDTO{Code: "123456"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/me/mfa/confirm", bytes.NewReader(body)).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ConfirmEnrollment("user123", "123456").Return([]string{"abcde-fghij"}, nil)
+
+	handler.ConfirmHandler(w, req)
+
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte("abcde-fghij")) {
+		t.Errorf("expected recovery codes, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestDisableHandler_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMFAServiceManager(ctrl)
+	handler := NewMFAHandler(mockService)
+
+	body, _ := json.Marshal(dto.MFACodeDTO{Code: "123456"})
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/me/mfa", bytes.NewReader(body)).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().Disable("user123", "123456").Return(mfaService.ErrMFARequired)
+
+	handler.DisableHandler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestRegenerateRecoveryCodesHandler_InvalidCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockMFAServiceManager(ctrl)
+	handler := NewMFAHandler(mockService)
+
+	body, _ := json.Marshal(dto.MFACodeDTO{Code: "000000"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/me/mfa/recovery-codes", bytes.NewReader(body)).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().RegenerateRecoveryCodes("user123", "000000").Return(nil, mfaService.ErrInvalidMFACode)
+
+	handler.RegenerateRecoveryCodesHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
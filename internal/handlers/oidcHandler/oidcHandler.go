@@ -0,0 +1,81 @@
+package oidcHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/oidcService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/userService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type OIDCHandler struct {
+	oidcService oidcService.OIDCServiceManager
+}
+
+func NewOIDCHandler(oidcService oidcService.OIDCServiceManager) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+	}
+}
+
+// api/v1/login/oidc [GET]
+func (oh *OIDCHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	authURL, err := oh.oidcService.BeginLogin()
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, oidcService.ErrOIDCDisabled) {
+			code = http.StatusNotFound
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// api/v1/login/oidc/callback?state=...&code=... [GET]
+func (oh *OIDCHandler) CallbackHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "identity provider returned "+providerErr)
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	result, err := oh.oidcService.CompleteLogin(query.Get("state"), query.Get("code"), utils.ClientInfo(r))
+	if err != nil {
+		code := http.StatusUnauthorized
+		switch {
+		case errors.Is(err, oidcService.ErrOIDCDisabled):
+			code = http.StatusNotFound
+		case errors.Is(err, oidcService.ErrInvalidLoginState):
+			code = http.StatusBadRequest
+		case errors.Is(err, oidcService.ErrUnverifiedEmail), errors.Is(err, oidcService.ErrUnverifiedAccount):
+			code = http.StatusConflict
+		case errors.Is(err, userService.ErrAccountSuspended):
+			code = http.StatusForbidden
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if result.MFARequired || result.MFASetupRequired {
+		message := "Two-factor authentication required"
+		if result.MFASetupRequired {
+			message = "Two-factor authentication must be set up before logging in"
+		}
+		resp := webResponse.NewSuccessResponse(http.StatusOK, message, result)
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Login successful", result)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,100 @@
+package oidcHandler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/oidcService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/userService"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLoginHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		authURL  string
+		err      error
+		wantCode int
+	}{
+		{name: "Redirects to provider", authURL: "https://idp.example/authorize?state=s", wantCode: http.StatusFound},
+		{name: "Not configured", err: oidcService.ErrOIDCDisabled, wantCode: http.StatusNotFound},
+		{name: "Provider unreachable", err: errors.New("oidc discovery: connection refused"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockOIDCServiceManager(ctrl)
+			handler := NewOIDCHandler(mockService)
+
+			mockService.EXPECT().BeginLogin().Return(tt.authURL, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/login/oidc", nil)
+			w := httptest.NewRecorder()
+			handler.LoginHandler(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d", tt.wantCode, w.Code)
+			}
+			if tt.authURL != "" && w.Header().Get("Location") != tt.authURL {
+				t.Errorf("unexpected location %q", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   dto.LoginResultDTO
+		err      error
+		wantCode int
+		wantBody string
+	}{
+		{name: "Success", result: dto.LoginResultDTO{Token: "jwt"}, wantCode: http.StatusOK, wantBody: `"data":{"token":"jwt"}`},
+		{name: "MFA required", result: dto.LoginResultDTO{MFARequired: true, MFAToken: "mfa"}, wantCode: http.StatusOK, wantBody: "mfa_token"},
+		{name: "Invalid state", err: oidcService.ErrInvalidLoginState, wantCode: http.StatusBadRequest},
+		{name: "Unverified email", err: oidcService.ErrUnverifiedEmail, wantCode: http.StatusConflict},
+		{name: "Unverified account", err: oidcService.ErrUnverifiedAccount, wantCode: http.StatusConflict},
+		{name: "Suspended", err: userService.ErrAccountSuspended, wantCode: http.StatusForbidden},
+		{name: "Bad token", err: errors.New("invalid id token"), wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockService := mocks.NewMockOIDCServiceManager(ctrl)
+			handler := NewOIDCHandler(mockService)
+
+			mockService.EXPECT().CompleteLogin("st", "cd", gomock.Any()).Return(tt.result, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/login/oidc/callback?state=st&code=cd", nil)
+			w := httptest.NewRecorder()
+			handler.CallbackHandler(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("expected %d, got %d", tt.wantCode, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("expected body to contain %q, got %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestCallbackHandler_ProviderError(t *testing.T) {
+	handler := NewOIDCHandler(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/login/oidc/callback?error=access_denied&state=st", nil)
+	w := httptest.NewRecorder()
+	handler.CallbackHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
@@ -0,0 +1,165 @@
+package passwordHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/passwordService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type PasswordHandler struct {
+	passwordService passwordService.PasswordServiceManager
+}
+
+func NewPasswordHandler(passwordService passwordService.PasswordServiceManager) *PasswordHandler {
+	return &PasswordHandler{
+		passwordService: passwordService,
+	}
+}
+
+// api/v1/password/forgot [POST]
+func (ph *PasswordHandler) ForgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.ForgotPasswordDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	err = validators.ValidateEmail(email)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	err = ph.passwordService.ForgotPassword(email)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "If the email is registered, a reset link has been sent", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/password/reset [POST]
+func (ph *PasswordHandler) ResetPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResetPasswordDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.Token == "" {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	password := strings.TrimSpace(req.NewPassword)
+	err = validators.ValidatePassword(password)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	err = ph.passwordService.ResetPassword(req.Token, password)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Password reset successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me/password [POST]
+func (ph *PasswordHandler) ChangePasswordHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.ChangePasswordDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	password := strings.TrimSpace(req.NewPassword)
+	err = validators.ValidatePassword(password)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	err = ph.passwordService.ChangePassword(userClaims.UserID, req.OldPassword, password, utils.ClientInfo(r))
+	var locked *lockoutService.LockedError
+	if errors.As(err, &locked) {
+		w.Header().Set("Retry-After", fmt.Sprintf("%.0f", locked.RetryAfter.Seconds()))
+		resp := webResponse.NewErrorResponse(http.StatusTooManyRequests, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Password changed successfully, please log in again", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/users/{userID}/password-reset [POST]
+func (ph *PasswordHandler) ForceResetHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err := ph.passwordService.ForceReset(userClaims.UserID, r.PathValue("userID"))
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, passwordService.ErrOutranked) {
+			code = http.StatusForbidden
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "password reset, a reset link was sent to the user", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,196 @@
+package passwordHandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/passwordService"
+	"go.uber.org/mock/gomock"
+)
+
+func getCustomerContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "user123", Role: models.Customer})
+}
+
+func getAdminContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "admin1", Role: models.Admin})
+}
+
+func TestForgotPasswordHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordServiceManager(ctrl)
+	handler := NewPasswordHandler(mockService)
+
+	body, _ := json.Marshal(dto.ForgotPasswordDTO{Email: " User@Example.com "})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/password/forgot", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ForgotPassword("user@example.com").Return(nil)
+
+	handler.ForgotPasswordHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestForgotPasswordHandler_InvalidEmail(t *testing.T) {
+	handler := NewPasswordHandler(nil)
+
+	body, _ := json.Marshal(dto.ForgotPasswordDTO{Email: "invalid"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/password/forgot", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.ForgotPasswordHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestResetPasswordHandler_WeakPassword(t *testing.T) {
+	handler := NewPasswordHandler(nil)
+
+	body, _ := json.Marshal(dto.ResetPasswordDTO{Token: "abc", NewPassword: "123"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/password/reset", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.ResetPasswordHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestResetPasswordHandler_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordServiceManager(ctrl)
+	handler := NewPasswordHandler(mockService)
+
+	body, _ := json.Marshal(dto.ResetPasswordDTO{Token: "abc", NewPassword: "NewPass@123"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/password/reset", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ResetPassword("abc", "NewPass@123").Return(errors.New("invalid or expired reset token"))
+
+	handler.ResetPasswordHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestChangePasswordHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordServiceManager(ctrl)
+	handler := NewPasswordHandler(mockService)
+
+	body, _ := json.Marshal(dto.ChangePasswordDTO{OldPassword: "OldPass@123", NewPassword: "NewPass@123"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/me/password", bytes.NewReader(body))
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ChangePassword("user123", "OldPass@123", "NewPass@123", gomock.Any()).Return(nil)
+
+	handler.ChangePasswordHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestChangePasswordHandler_Locked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordServiceManager(ctrl)
+	handler := NewPasswordHandler(mockService)
+
+	body, _ := json.Marshal(dto.ChangePasswordDTO{OldPassword: "OldPass@123", NewPassword: "NewPass@123"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/me/password", bytes.NewReader(body))
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ChangePassword("user123", "OldPass@123", "NewPass@123", gomock.Any()).Return(&lockoutService.LockedError{RetryAfter: 90 * time.Second})
+
+	handler.ChangePasswordHandler(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "90" {
+		t.Errorf("expected Retry-After 90, got %q", w.Header().Get("Retry-After"))
+	}
+}
+
+func TestChangePasswordHandler_Unauthorized(t *testing.T) {
+	handler := NewPasswordHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/me/password", nil)
+	w := httptest.NewRecorder()
+
+	handler.ChangePasswordHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestForceResetHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordServiceManager(ctrl)
+	handler := NewPasswordHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/user123/password-reset", nil)
+	req.SetPathValue("userID", "user123")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ForceReset("admin1", "user123").Return(nil)
+
+	handler.ForceResetHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestForceResetHandler_Outranked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPasswordServiceManager(ctrl)
+	handler := NewPasswordHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/admin2/password-reset", nil)
+	req.SetPathValue("userID", "admin2")
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ForceReset("admin1", "admin2").Return(passwordService.ErrOutranked)
+
+	handler.ForceResetHandler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
@@ -0,0 +1,87 @@
+package priceHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/priceService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type PriceHandler struct {
+	priceService priceService.PriceServiceManager
+}
+
+func NewPriceHandler(priceService priceService.PriceServiceManager) *PriceHandler {
+	return &PriceHandler{
+		priceService: priceService,
+	}
+}
+
+// api/v1/admin/products/{prodID}/prices/timeline [GET]
+func (ph *PriceHandler) GetPriceTimelineHandler(w http.ResponseWriter, r *http.Request) {
+	timeline, err := ph.priceService.GetPriceTimeline(r.PathValue("prodID"))
+	if err != nil {
+		writePriceError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "price timeline fetched successfully", timeline)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/prices/schedules [POST]
+func (ph *PriceHandler) SchedulePriceHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.PriceScheduleDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	schedule, err := ph.priceService.SchedulePrice(userClaims.UserID, r.PathValue("prodID"), req)
+	if err != nil {
+		writePriceError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusCreated, "price scheduled successfully", schedule)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/prices/schedules/{scheduleID} [DELETE]
+func (ph *PriceHandler) CancelScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	schedule, err := ph.priceService.CancelSchedule(r.PathValue("prodID"), r.PathValue("scheduleID"))
+	if err != nil {
+		writePriceError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "price schedule cancelled successfully", schedule)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writePriceError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, priceService.ErrProductNotFound), errors.Is(err, priceService.ErrScheduleNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, priceService.ErrScheduleOverlap), errors.Is(err, priceService.ErrScheduleClosed):
+		code = http.StatusConflict
+	}
+	resp := webResponse.NewErrorResponse(code, err.Error())
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
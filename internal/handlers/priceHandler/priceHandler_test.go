@@ -0,0 +1,134 @@
+package priceHandler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/priceService"
+	"go.uber.org/mock/gomock"
+)
+
+func getAdminContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "admin1", Role: models.Admin})
+}
+
+func TestGetPriceTimelineHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPriceServiceManager(ctrl)
+	handler := NewPriceHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/p1/prices", nil)
+	req.SetPathValue("prodID", "p1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().GetPriceTimeline("p1").Return(dto.PriceTimelineDTO{ProductID: "p1", Price: 100}, nil)
+
+	handler.GetPriceTimelineHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/404/prices", nil)
+	req.SetPathValue("prodID", "404")
+	w = httptest.NewRecorder()
+
+	mockService.EXPECT().GetPriceTimeline("404").Return(dto.PriceTimelineDTO{}, priceService.ErrProductNotFound)
+
+	handler.GetPriceTimelineHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestSchedulePriceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPriceServiceManager(ctrl)
+	handler := NewPriceHandler(mockService)
+
+	tests := []struct {
+		name     string
+		body     string
+		err      error
+		expected int
+	}{
+		{"scheduled", `{"price":80,"starts_at":"2026-11-20T00:00:00Z","ends_at":"2026-11-23T00:00:00Z"}`, nil, http.StatusCreated},
+		{"overlap", `{"price":80,"starts_at":"2026-11-20T00:00:00Z"}`, priceService.ErrScheduleOverlap, http.StatusConflict},
+		{"not a sale", `{"price":180,"starts_at":"2026-11-20T00:00:00Z","ends_at":"2026-11-23T00:00:00Z"}`, priceService.ErrNotASale, http.StatusBadRequest},
+		{"invalid", `{"price":80`, nil, http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/p1/prices/schedules", bytes.NewBufferString(test.body)).WithContext(getAdminContext())
+		req.SetPathValue("prodID", "p1")
+		w := httptest.NewRecorder()
+
+		if test.name != "invalid" {
+			mockService.EXPECT().SchedulePrice("admin1", "p1", gomock.Any()).DoAndReturn(func(adminID, productID string, req dto.PriceScheduleDTO) (models.PriceSchedule, error) {
+				if req.StartsAt == nil || !req.StartsAt.Equal(time.Date(2026, 11, 20, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("%s: unexpected request: %+v", test.name, req)
+				}
+				return models.PriceSchedule{ID: "s1"}, test.err
+			})
+		}
+
+		handler.SchedulePriceHandler(w, req)
+
+		if w.Code != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.name, test.expected, w.Code)
+		}
+	}
+
+	// Without a signed-in user
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/p1/prices/schedules", bytes.NewBufferString(`{}`))
+	w := httptest.NewRecorder()
+	handler.SchedulePriceHandler(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestCancelScheduleHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockPriceServiceManager(ctrl)
+	handler := NewPriceHandler(mockService)
+
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{nil, http.StatusOK},
+		{priceService.ErrScheduleNotFound, http.StatusNotFound},
+		{priceService.ErrScheduleClosed, http.StatusConflict},
+		{errors.New("can not cancel price schedule: disk full"), http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/products/p1/prices/schedules/s1", nil)
+		req.SetPathValue("prodID", "p1")
+		req.SetPathValue("scheduleID", "s1")
+		w := httptest.NewRecorder()
+
+		mockService.EXPECT().CancelSchedule("p1", "s1").Return(models.PriceSchedule{ID: "s1", Status: models.ScheduleCancelled}, test.err)
+
+		handler.CancelScheduleHandler(w, req)
+
+		if w.Code != test.expected {
+			t.Errorf("error %v: expected %d, got %d", test.err, test.expected, w.Code)
+		}
+	}
+}
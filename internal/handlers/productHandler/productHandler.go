@@ -2,59 +2,329 @@ package productHandler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/productService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/suggestService"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
 )
 
+const (
+	defaultProductPageSize = 20
+	maxProductPageSize     = 100
+	maxSpecFilters         = 10
+)
+
 type ProductHandler struct {
 	productService productService.ProductServiceManager
+	suggestService suggestService.SuggestServiceManager
 }
 
-func NewProductHandler(productService productService.ProductServiceManager) *ProductHandler {
+func NewProductHandler(productService productService.ProductServiceManager, suggestService suggestService.SuggestServiceManager) *ProductHandler {
 	return &ProductHandler{
 		productService: productService,
+		suggestService: suggestService,
 	}
 }
 
-// api/v1/products [GET] also support "name" query param for searching by name
+// api/v1/products [GET] supports "name", "category", "brand", "min_price",
+// "max_price", "in_stock", "spec.{key}", "spec.{key}.min", "spec.{key}.max",
+// "sort", "page" and "limit" query params
 func (ph *ProductHandler) GetAllProducts(w http.ResponseWriter, r *http.Request) {
-	name := r.URL.Query().Get("name")
-	name = strings.TrimSpace(name)
-	var products []models.Product
+	query, err := parseProductQuery(r.URL.Query())
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	ph.listProducts(w, r, query, http.StatusBadRequest)
+}
+
+// api/v1/admin/products [GET] takes the same query params as the product
+// listing along with "status": draft, published or archived. Without it,
+// drafts and published products are listed but archived ones are not.
+func (ph *ProductHandler) GetAdminProducts(w http.ResponseWriter, r *http.Request) {
+	query, err := parseProductQuery(r.URL.Query())
+	if err == nil {
+		query.Statuses, query.Archived, err = parseStatusFilter(r.URL.Query().Get("status"))
+	}
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	ph.listProducts(w, r, query, http.StatusBadRequest)
+}
+
+func parseStatusFilter(status string) ([]models.ProductStatus, bool, error) {
+	all := []models.ProductStatus{models.ProductDraft, models.ProductPublished}
+	switch status {
+	case "":
+		return all, false, nil
+	case "archived":
+		return all, true, nil
+	}
+	parsed, err := models.ParseProductStatus(status)
+	if err != nil {
+		return nil, false, fmt.Errorf("unknown status %q, expected draft, published or archived", status)
+	}
+	return []models.ProductStatus{parsed}, false, nil
+}
+
+// api/v1/categories/{slug}/products [GET] takes the same query params as the
+// product listing apart from "category"
+func (ph *ProductHandler) GetCategoryProducts(w http.ResponseWriter, r *http.Request) {
+	query, err := parseProductQuery(r.URL.Query())
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	query.Category = r.PathValue("slug")
+	ph.listProducts(w, r, query, http.StatusNotFound)
+}
+
+func (ph *ProductHandler) listProducts(w http.ResponseWriter, r *http.Request, query models.ProductQuery, unknownCategory int) {
+	list, err := ph.productService.ListProducts(query)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, productService.ErrCategoryNotFound) {
+			code = unknownCategory
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if link := pageLinks(r.URL, list.Page, list.Limit, list.Total); link != "" {
+		w.Header().Set("Link", link)
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Products retrieved successfully", list)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/search [GET] takes the search terms in "q" along with "page" and "limit"
+func (ph *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := parsePage(r.URL.Query())
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	search, err := ph.productService.SearchProducts(r.URL.Query().Get("q"), limit, offset)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, productService.ErrEmptySearch) || errors.Is(err, productService.ErrSearchTooLong) {
+			code = http.StatusBadRequest
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if link := pageLinks(r.URL, search.Page, search.Limit, search.Total); link != "" {
+		w.Header().Set("Link", link)
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Search results retrieved successfully", search)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/search/suggest [GET] completes the "q" query param and suggests a
+// correction when it has misspelled words
+func (ph *ProductHandler) SuggestHandler(w http.ResponseWriter, r *http.Request) {
+	suggestion, err := ph.suggestService.Suggest(r.URL.Query().Get("q"))
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, suggestService.ErrEmptyQuery) || errors.Is(err, suggestService.ErrQueryTooLong) {
+			code = http.StatusBadRequest
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Suggestions retrieved successfully", suggestion)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func parseProductQuery(values url.Values) (models.ProductQuery, error) {
+	query := models.ProductQuery{
+		Name:     strings.TrimSpace(values.Get("name")),
+		Category: strings.TrimSpace(values.Get("category")),
+		Brand:    strings.TrimSpace(values.Get("brand")),
+	}
 	var err error
-	if name != "" {
-		products, err = ph.productService.GetProductByName(&name)
+	if query.Specs, err = parseSpecFilters(values); err != nil {
+		return query, err
+	}
+	if query.MinPrice, err = parsePrice(values, "min_price"); err != nil {
+		return query, err
+	}
+	if query.MaxPrice, err = parsePrice(values, "max_price"); err != nil {
+		return query, err
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return query, fmt.Errorf("min_price can not be greater than max_price")
+	}
+	if inStock := values.Get("in_stock"); inStock != "" {
+		query.InStock, err = strconv.ParseBool(inStock)
 		if err != nil {
-			resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
-			w.WriteHeader(resp.Code)
-			json.NewEncoder(w).Encode(resp)
-			return
+			return query, fmt.Errorf("invalid in_stock")
 		}
-	}else{
-		products, err = ph.productService.GetAllProducts()
+	}
+	if sort := values.Get("sort"); sort != "" {
+		query.Sort, query.Desc, err = models.ParseProductSort(sort)
 		if err != nil {
-			resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
-			w.WriteHeader(resp.Code)
-			json.NewEncoder(w).Encode(resp)
-			return
+			return query, err
 		}
 	}
-	resp := webResponse.NewSuccessResponse(http.StatusOK, "Products retrieved successfully", products)
-	w.WriteHeader(resp.Code)
-	json.NewEncoder(w).Encode(resp)
+	query.Limit, query.Offset, err = parsePage(values)
+	return query, err
+}
+
+// parsePage reads the "page" and "limit" params as a limit and offset.
+func parsePage(values url.Values) (int, int, error) {
+	limit := defaultProductPageSize
+	if l := values.Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 {
+			return 0, 0, fmt.Errorf("invalid limit")
+		}
+		limit = min(n, maxProductPageSize)
+	}
+	page := 1
+	if p := values.Get("page"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 1 {
+			return 0, 0, fmt.Errorf("invalid page")
+		}
+		page = n
+	}
+	return limit, (page - 1) * limit, nil
+}
+
+// parseSpecFilters reads "spec.{key}=value", "spec.{key}.min" and
+// "spec.{key}.max" params into one filter per spec key, in key order.
+func parseSpecFilters(values url.Values) ([]models.SpecFilter, error) {
+	filters := make(map[string]*models.SpecFilter)
+	for param := range values {
+		name, ok := strings.CutPrefix(param, "spec.")
+		if !ok {
+			continue
+		}
+		key, bound, _ := strings.Cut(name, ".")
+		key = strings.ToLower(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid %s", param)
+		}
+		filter, ok := filters[key]
+		if !ok {
+			filter = &models.SpecFilter{Key: key}
+			filters[key] = filter
+		}
+		value := strings.TrimSpace(values.Get(param))
+		switch bound {
+		case "":
+			filter.Value = value
+		case "min", "max":
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s", param)
+			}
+			if bound == "min" {
+				filter.Min = &number
+			} else {
+				filter.Max = &number
+			}
+		default:
+			return nil, fmt.Errorf("invalid %s, expected spec.{key}, spec.{key}.min or spec.{key}.max", param)
+		}
+	}
+	if len(filters) > maxSpecFilters {
+		return nil, fmt.Errorf("at most %d spec filters are allowed", maxSpecFilters)
+	}
+	var specs []models.SpecFilter
+	for _, filter := range filters {
+		if filter.Min != nil && filter.Max != nil && *filter.Min > *filter.Max {
+			return nil, fmt.Errorf("spec.%s.min can not be greater than spec.%s.max", filter.Key, filter.Key)
+		}
+		specs = append(specs, *filter)
+	}
+	slices.SortFunc(specs, func(a, b models.SpecFilter) int { return strings.Compare(a.Key, b.Key) })
+	return specs, nil
+}
+
+func parsePrice(values url.Values, key string) (*float32, error) {
+	value := values.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(value, 32)
+	if err != nil || price < 0 {
+		return nil, fmt.Errorf("invalid %s", key)
+	}
+	p := float32(price)
+	return &p, nil
+}
+
+// pageLinks builds an RFC 8288 Link header pointing at the first, previous,
+// next and last pages, keeping the request's other query params.
+func pageLinks(u *url.URL, page, limit, total int) string {
+	if limit < 1 {
+		return ""
+	}
+	last := max((total+limit-1)/limit, 1)
+	link := func(p int, rel string) string {
+		values := u.Query()
+		values.Set("page", strconv.Itoa(p))
+		values.Set("limit", strconv.Itoa(limit))
+		target := url.URL{Path: u.Path, RawQuery: values.Encode()}
+		return fmt.Sprintf("<%s>; rel=%q", target.String(), rel)
+	}
+	links := []string{link(1, "first")}
+	if page > 1 {
+		links = append(links, link(min(page-1, last), "prev"))
+	}
+	if page < last {
+		links = append(links, link(page+1, "next"))
+	}
+	links = append(links, link(last, "last"))
+	return strings.Join(links, ", ")
 }
 
 // api/v1/products/{prodID} [GET]
 func (ph *ProductHandler) GetProductByID(w http.ResponseWriter, r *http.Request) {
-	prodID := r.PathValue("prodID")
+	product, err := ph.productService.GetProductByID(r.PathValue("prodID"))
+	writeProduct(w, product, err)
+}
 
-	product, err := ph.productService.GetProductByID(prodID)
+// api/v1/admin/products/{prodID} [GET] also finds drafts and archived products
+func (ph *ProductHandler) GetAdminProductByID(w http.ResponseWriter, r *http.Request) {
+	product, err := ph.productService.GetAdminProduct(r.PathValue("prodID"))
+	writeProduct(w, product, err)
+}
+
+func writeProduct(w http.ResponseWriter, product models.Product, err error) {
 	if err != nil {
-		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		code := http.StatusInternalServerError
+		if errors.Is(err, productService.ErrProductNotFound) {
+			code = http.StatusNotFound
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
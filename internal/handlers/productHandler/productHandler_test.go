@@ -4,10 +4,15 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/productService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/suggestService"
 	"go.uber.org/mock/gomock"
 )
 
@@ -16,13 +21,14 @@ func TestGetAllProducts_NoQuery_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockProductService := mocks.NewMockProductServiceManager(ctrl)
-	handler := NewProductHandler(mockProductService)
+	handler := NewProductHandler(mockProductService, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
 	w := httptest.NewRecorder()
 
-	mockProductService.EXPECT().GetAllProducts().Return([]models.Product{
-		{ID: "p1", Name: "Laptop", Price: 1000, Stock: 10},
+	mockProductService.EXPECT().ListProducts(models.ProductQuery{Limit: 20}).Return(dto.ProductListDTO{
+		Products: []models.Product{{ID: "p1", Name: "Laptop", Price: 1000, Stock: 10}},
+		Total:    1, Page: 1, Limit: 20,
 	}, nil)
 
 	handler.GetAllProducts(w, req)
@@ -37,14 +43,18 @@ func TestGetAllProducts_WithQuery_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockProductService := mocks.NewMockProductServiceManager(ctrl)
-	handler := NewProductHandler(mockProductService)
+	handler := NewProductHandler(mockProductService, nil)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?name=Laptop", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?name=Lap&category=computers&min_price=10&max_price=2000&in_stock=true&sort=-price&page=2&limit=10", nil)
 	w := httptest.NewRecorder()
 
-	name := "Laptop"
-	mockProductService.EXPECT().GetProductByName(&name).Return([]models.Product{
-		{ID: "p1", Name: "Laptop", Price: 1000, Stock: 10},
+	min, max := float32(10), float32(2000)
+	mockProductService.EXPECT().ListProducts(models.ProductQuery{
+		Name: "Lap", Category: "computers", MinPrice: &min, MaxPrice: &max, InStock: true,
+		Sort: models.SortByPrice, Desc: true, Limit: 10, Offset: 10,
+	}).Return(dto.ProductListDTO{
+		Products: []models.Product{{ID: "p1", Name: "Laptop", Price: 1000, Stock: 10}},
+		Total:    35, Page: 2, Limit: 10,
 	}, nil)
 
 	handler.GetAllProducts(w, req)
@@ -52,39 +62,88 @@ func TestGetAllProducts_WithQuery_Success(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Errorf("expected 200, got %d", w.Code)
 	}
+	link := w.Header().Get("Link")
+	for _, rel := range []string{`page=1&sort=-price>; rel="first"`, `page=1&sort=-price>; rel="prev"`, `page=3&sort=-price>; rel="next"`, `page=4&sort=-price>; rel="last"`} {
+		if !strings.Contains(link, rel) {
+			t.Errorf("Link %q is missing %s", link, rel)
+		}
+	}
+}
+
+func TestGetAllProducts_InvalidQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductService := mocks.NewMockProductServiceManager(ctrl)
+	handler := NewProductHandler(mockProductService, nil)
+
+	for _, query := range []string{"sort=stock", "min_price=abc", "min_price=50&max_price=10", "in_stock=maybe", "page=0", "limit=-1",
+		"spec.ram.min=lots", "spec.ram.avg=4", "spec..min=1", "spec.ram.min=16&spec.ram.max=8"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products?"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.GetAllProducts(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+	}
 }
 
-func TestGetAllProducts_WithQuery_Error(t *testing.T) {
+func TestGetAllProducts_SpecFilters(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockProductService := mocks.NewMockProductServiceManager(ctrl)
-	handler := NewProductHandler(mockProductService)
+	handler := NewProductHandler(mockProductService, nil)
 
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?name=Phone", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?brand=Lenovo&spec.wireless=true&spec.RAM.min=8&spec.ram.max=32", nil)
 	w := httptest.NewRecorder()
 
-	name := "Phone"
-	mockProductService.EXPECT().GetProductByName(&name).Return(nil, errors.New("db error"))
+	min, max := 8.0, 32.0
+	mockProductService.EXPECT().ListProducts(models.ProductQuery{
+		Brand: "Lenovo",
+		Specs: []models.SpecFilter{{Key: "ram", Min: &min, Max: &max}, {Key: "wireless", Value: "true"}},
+		Limit: 20,
+	}).Return(dto.ProductListDTO{Products: []models.Product{}, Page: 1, Limit: 20}, nil)
 
 	handler.GetAllProducts(w, req)
 
-	if w.Code != http.StatusInternalServerError {
-		t.Errorf("expected 500, got %d", w.Code)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestGetAllProducts_UnknownCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductService := mocks.NewMockProductServiceManager(ctrl)
+	handler := NewProductHandler(mockProductService, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products?category=missing", nil)
+	w := httptest.NewRecorder()
+
+	mockProductService.EXPECT().ListProducts(gomock.Any()).Return(dto.ProductListDTO{}, productService.ErrCategoryNotFound)
+
+	handler.GetAllProducts(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
 	}
 }
 
-func TestGetAllProducts_NoQuery_Error(t *testing.T) {
+func TestGetAllProducts_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockProductService := mocks.NewMockProductServiceManager(ctrl)
-	handler := NewProductHandler(mockProductService)
+	handler := NewProductHandler(mockProductService, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
 	w := httptest.NewRecorder()
 
-	mockProductService.EXPECT().GetAllProducts().Return(nil, errors.New("db error"))
+	mockProductService.EXPECT().ListProducts(gomock.Any()).Return(dto.ProductListDTO{}, errors.New("db error"))
 
 	handler.GetAllProducts(w, req)
 
@@ -93,12 +152,45 @@ func TestGetAllProducts_NoQuery_Error(t *testing.T) {
 	}
 }
 
+func TestGetCategoryProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductService := mocks.NewMockProductServiceManager(ctrl)
+	handler := NewProductHandler(mockProductService, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/categories/computers/products?sort=name", nil)
+	req.SetPathValue("slug", "computers")
+	w := httptest.NewRecorder()
+
+	mockProductService.EXPECT().ListProducts(models.ProductQuery{Category: "computers", Sort: models.SortByName, Limit: 20}).
+		Return(dto.ProductListDTO{Products: []models.Product{{ID: "p1", Name: "Laptop"}}, Total: 1, Page: 1, Limit: 20}, nil)
+
+	handler.GetCategoryProducts(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/categories/missing/products", nil)
+	req.SetPathValue("slug", "missing")
+	w = httptest.NewRecorder()
+
+	mockProductService.EXPECT().ListProducts(gomock.Any()).Return(dto.ProductListDTO{}, productService.ErrCategoryNotFound)
+
+	handler.GetCategoryProducts(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
 func TestGetProductByID_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockProductService := mocks.NewMockProductServiceManager(ctrl)
-	handler := NewProductHandler(mockProductService)
+	handler := NewProductHandler(mockProductService, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/p1", nil)
 	req.SetPathValue("prodID", "p1")
@@ -120,17 +212,152 @@ func TestGetProductByID_Error(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockProductService := mocks.NewMockProductServiceManager(ctrl)
-	handler := NewProductHandler(mockProductService)
+	handler := NewProductHandler(mockProductService, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/p1", nil)
 	req.SetPathValue("prodID", "p1")
 	w := httptest.NewRecorder()
 
-	mockProductService.EXPECT().GetProductByID("p1").Return(models.Product{}, errors.New("not found"))
+	mockProductService.EXPECT().GetProductByID("p1").Return(models.Product{}, errors.New("db error"))
 
 	handler.GetProductByID(w, req)
 
 	if w.Code != http.StatusInternalServerError {
 		t.Errorf("expected 500, got %d", w.Code)
 	}
+
+	mockProductService.EXPECT().GetProductByID("p1").Return(models.Product{}, productService.ErrProductNotFound)
+	w = httptest.NewRecorder()
+	handler.GetProductByID(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestGetAdminProductByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductService := mocks.NewMockProductServiceManager(ctrl)
+	handler := NewProductHandler(mockProductService, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/products/p1", nil)
+	req.SetPathValue("prodID", "p1")
+	w := httptest.NewRecorder()
+
+	mockProductService.EXPECT().GetAdminProduct("p1").Return(models.Product{ID: "p1", Status: models.ProductDraft}, nil)
+	handler.GetAdminProductByID(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"status":"draft"`) {
+		t.Errorf("unexpected response %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetAdminProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductService := mocks.NewMockProductServiceManager(ctrl)
+	handler := NewProductHandler(mockProductService, nil)
+
+	live := []models.ProductStatus{models.ProductDraft, models.ProductPublished}
+	tests := []struct {
+		status   string
+		statuses []models.ProductStatus
+		archived bool
+	}{
+		{"", live, false},
+		{"draft", []models.ProductStatus{models.ProductDraft}, false},
+		{"published", []models.ProductStatus{models.ProductPublished}, false},
+		{"archived", live, true},
+	}
+	for _, test := range tests {
+		mockProductService.EXPECT().ListProducts(gomock.Any()).DoAndReturn(func(query models.ProductQuery) (dto.ProductListDTO, error) {
+			if !slices.Equal(query.Statuses, test.statuses) || query.Archived != test.archived || query.Limit != 20 {
+				t.Errorf("status %q: unexpected query %+v", test.status, query)
+			}
+			return dto.ProductListDTO{Products: []models.Product{}}, nil
+		})
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/products?status="+test.status, nil)
+		w := httptest.NewRecorder()
+		handler.GetAdminProducts(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("status %q: expected 200, got %d", test.status, w.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/products?status=deleted", nil)
+	w := httptest.NewRecorder()
+	handler.GetAdminProducts(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown status, got %d", w.Code)
+	}
+}
+
+func TestSearchProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductService := mocks.NewMockProductServiceManager(ctrl)
+	handler := NewProductHandler(mockProductService, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=head&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	mockProductService.EXPECT().SearchProducts("head", 1, 0).Return(dto.ProductSearchDTO{
+		Query:   "head",
+		Results: []models.ProductSearchResult{{Product: models.Product{ID: "p3", Name: "Headphones"}, Highlight: "<mark>Head</mark>phones"}},
+		Total:   2, Page: 1, Limit: 1,
+	}, nil)
+
+	handler.SearchProducts(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+	if link := w.Header().Get("Link"); !strings.Contains(link, `page=2&q=head>; rel="next"`) {
+		t.Errorf("unexpected Link %q", link)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/search", nil)
+	w = httptest.NewRecorder()
+
+	mockProductService.EXPECT().SearchProducts("", 20, 0).Return(dto.ProductSearchDTO{}, productService.ErrEmptySearch)
+
+	handler.SearchProducts(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestSuggestHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSuggestService := mocks.NewMockSuggestServiceManager(ctrl)
+	handler := NewProductHandler(nil, mockSuggestService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/search/suggest?q=hedphones", nil)
+	w := httptest.NewRecorder()
+
+	mockSuggestService.EXPECT().Suggest("hedphones").Return(dto.SuggestionDTO{
+		Query: "hedphones", Completions: []string{"headphones"}, DidYouMean: "headphones",
+	}, nil)
+
+	handler.SuggestHandler(w, req)
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "did_you_mean") {
+		t.Errorf("expected 200 with a correction, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/search/suggest", nil)
+	w = httptest.NewRecorder()
+
+	mockSuggestService.EXPECT().Suggest("").Return(dto.SuggestionDTO{}, suggestService.ErrEmptyQuery)
+
+	handler.SuggestHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
 }
@@ -0,0 +1,200 @@
+package roleHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authzService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type RoleHandler struct {
+	authzService authzService.AuthzServiceManager
+}
+
+func NewRoleHandler(authzService authzService.AuthzServiceManager) *RoleHandler {
+	return &RoleHandler{
+		authzService: authzService,
+	}
+}
+
+// api/v1/admin/permissions [GET]
+func (rh *RoleHandler) ListPermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "permissions fetched successfully", rh.authzService.ListPermissions())
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/roles [GET]
+func (rh *RoleHandler) ListRolesHandler(w http.ResponseWriter, r *http.Request) {
+	roles, err := rh.authzService.ListRoles()
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "roles fetched successfully", roles)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/roles/{name} [GET]
+func (rh *RoleHandler) GetRoleHandler(w http.ResponseWriter, r *http.Request) {
+	role, err := rh.authzService.GetRole(r.PathValue("name"))
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "role fetched successfully", role)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/roles [POST]
+func (rh *RoleHandler) CreateRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.RoleDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	role, err := rh.authzService.CreateRole(userClaims.UserID, req.Name, req.Description, req.Permissions)
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusCreated, "role created successfully", role)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/roles/{name} [PUT]
+func (rh *RoleHandler) UpdateRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.RoleDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	role, err := rh.authzService.UpdateRole(userClaims.UserID, r.PathValue("name"), req.Description, req.Permissions)
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "role updated successfully", role)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/roles/{name} [DELETE]
+func (rh *RoleHandler) DeleteRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err := rh.authzService.DeleteRole(userClaims.UserID, r.PathValue("name"))
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "role deleted successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/users/{userID}/roles [GET]
+func (rh *RoleHandler) GetUserRolesHandler(w http.ResponseWriter, r *http.Request) {
+	roles, err := rh.authzService.GetUserRoles(r.PathValue("userID"))
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "user roles fetched successfully", roles)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/users/{userID}/roles [POST]
+func (rh *RoleHandler) AssignRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.AssignRoleDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.Role == "" {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err = rh.authzService.AssignRole(userClaims.UserID, r.PathValue("userID"), req.Role)
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "role assigned successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/users/{userID}/roles/{name} [DELETE]
+func (rh *RoleHandler) UnassignRoleHandler(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	err := rh.authzService.UnassignRole(userClaims.UserID, r.PathValue("userID"), r.PathValue("name"))
+	if err != nil {
+		writeRoleError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "role removed successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeRoleError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, authzService.ErrRoleNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, authzService.ErrRoleExists):
+		code = http.StatusConflict
+	case errors.Is(err, authzService.ErrBuiltInRole), errors.Is(err, authzService.ErrOutranked), errors.Is(err, authzService.ErrNotGrantable):
+		code = http.StatusForbidden
+	}
+	resp := webResponse.NewErrorResponse(code, err.Error())
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,236 @@
+package roleHandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authzService"
+	"go.uber.org/mock/gomock"
+)
+
+func getAdminContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "admin1", Role: models.Admin})
+}
+
+func TestCreateRoleHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthzServiceManager(ctrl)
+	handler := NewRoleHandler(mockService)
+
+	body, _ := json.Marshal(dto.RoleDTO{Name: "auditor", Description: "Reads orders", Permissions: []string{"orders:read"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/roles", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().CreateRole("admin1", "auditor", "Reads orders", []string{"orders:read"}).
+		Return(models.Role{Name: "auditor", Permissions: []models.Permission{models.PermOrdersRead}}, nil)
+
+	handler.CreateRoleHandler(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", w.Code)
+	}
+}
+
+func TestCreateRoleHandler_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthzServiceManager(ctrl)
+	handler := NewRoleHandler(mockService)
+
+	body, _ := json.Marshal(dto.RoleDTO{Name: "finance"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/roles", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().CreateRole("admin1", "finance", "", nil).Return(models.Role{}, authzService.ErrRoleExists)
+
+	handler.CreateRoleHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestCreateRoleHandler_InvalidJSON(t *testing.T) {
+	handler := NewRoleHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/roles", bytes.NewReader([]byte("{")))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	handler.CreateRoleHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestUpdateRoleHandler_BuiltIn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthzServiceManager(ctrl)
+	handler := NewRoleHandler(mockService)
+
+	body, _ := json.Marshal(dto.RoleDTO{Permissions: []string{"roles:manage"}})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/roles/finance", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	req.SetPathValue("name", "finance")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().UpdateRole("admin1", "finance", "", []string{"roles:manage"}).Return(models.Role{}, authzService.ErrBuiltInRole)
+
+	handler.UpdateRoleHandler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestDeleteRoleHandler_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthzServiceManager(ctrl)
+	handler := NewRoleHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/roles/missing", nil)
+	req = req.WithContext(getAdminContext())
+	req.SetPathValue("name", "missing")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().DeleteRole("admin1", "missing").Return(authzService.ErrRoleNotFound)
+
+	handler.DeleteRoleHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestListRolesHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthzServiceManager(ctrl)
+	handler := NewRoleHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/roles", nil)
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ListRoles().Return(models.BuiltInRoles, nil)
+
+	handler.ListRolesHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestAssignRoleHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthzServiceManager(ctrl)
+	handler := NewRoleHandler(mockService)
+
+	body, _ := json.Marshal(dto.AssignRoleDTO{Role: "finance"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/roles", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	req.SetPathValue("userID", "u1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().AssignRole("admin1", "u1", "finance").Return(nil)
+
+	handler.AssignRoleHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestAssignRoleHandler_MissingRole(t *testing.T) {
+	handler := NewRoleHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/roles", bytes.NewReader([]byte("{}")))
+	req = req.WithContext(getAdminContext())
+	w := httptest.NewRecorder()
+
+	handler.AssignRoleHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestUnassignRoleHandler_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthzServiceManager(ctrl)
+	handler := NewRoleHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/users/u1/roles/finance", nil)
+	req = req.WithContext(getAdminContext())
+	req.SetPathValue("userID", "u1")
+	req.SetPathValue("name", "finance")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().UnassignRole("admin1", "u1", "finance").Return(errors.New(`user does not have role "finance"`))
+
+	handler.UnassignRoleHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestAssignRoleHandler_NotGrantable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockAuthzServiceManager(ctrl)
+	handler := NewRoleHandler(mockService)
+
+	body, _ := json.Marshal(dto.AssignRoleDTO{Role: "finance"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/roles", bytes.NewReader(body))
+	req = req.WithContext(getAdminContext())
+	req.SetPathValue("userID", "u1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().AssignRole("admin1", "u1", "finance").Return(authzService.ErrNotGrantable)
+
+	handler.AssignRoleHandler(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestAssignRoleHandler_Unauthorized(t *testing.T) {
+	handler := NewRoleHandler(nil)
+
+	body, _ := json.Marshal(dto.AssignRoleDTO{Role: "finance"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/users/u1/roles", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.AssignRoleHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
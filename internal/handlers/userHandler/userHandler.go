@@ -2,12 +2,19 @@ package userHandler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/userService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
 )
@@ -92,14 +99,282 @@ func (uh *UserHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	email := strings.TrimSpace(req.Email)
 	email = strings.ToLower(email)
 
-	token, err := uh.userService.Login(email, req.Password)
+	result, err := uh.userService.Login(email, req.Password, utils.ClientInfo(r))
+	if writeLocked(w, err) {
+		return
+	}
+	if err != nil {
+		code := http.StatusUnauthorized
+		if errors.Is(err, userService.ErrAccountSuspended) {
+			code = http.StatusForbidden
+		}
+		resp := webResponse.NewErrorResponse(code, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if result.MFARequired || result.MFASetupRequired {
+		message := "Two-factor authentication required"
+		if result.MFASetupRequired {
+			message = "Two-factor authentication must be set up before logging in"
+		}
+		resp := webResponse.NewSuccessResponse(http.StatusOK, message, result)
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Login successful", result)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/login/mfa [POST]
+func (uh *UserHandler) MFALoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.MFACodeDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.MFAToken == "" || req.Code == "" {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	token, err := uh.userService.VerifyMFALogin(req.MFAToken, req.Code, utils.ClientInfo(r))
+	if writeLocked(w, err) {
+		return
+	}
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Login successful", dto.LoginResultDTO{Token: token})
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/login/mfa/setup [POST]
+func (uh *UserHandler) MFASetupHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.MFACodeDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.MFAToken == "" {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	enrollment, err := uh.userService.BeginMFASetup(req.MFAToken)
 	if err != nil {
 		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, err.Error())
 		w.WriteHeader(resp.Code)
 		json.NewEncoder(w).Encode(resp)
 		return
 	}
-	resp := webResponse.NewSuccessResponse(http.StatusOK, "Login successful", `{"token":"`+token+`"}`)
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Scan the QR code with an authenticator app and confirm with a code", enrollment)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/login/mfa/setup/confirm [POST]
+func (uh *UserHandler) MFASetupConfirmHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.MFACodeDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.MFAToken == "" || req.Code == "" {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	result, err := uh.userService.ConfirmMFASetup(req.MFAToken, req.Code, utils.ClientInfo(r))
+	if writeLocked(w, err) {
+		return
+	}
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Two-factor authentication enabled, store the recovery codes safely", result)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me [GET]
+func (uh *UserHandler) GetProfileHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	profile, err := uh.userService.GetProfile(userClaims.UserID)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusNotFound, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Profile fetched successfully", profile)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me [PATCH]
+func (uh *UserHandler) UpdateProfileHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var req dto.UpdateProfileDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || (req.Name == nil && req.Email == nil) {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		err = validators.ValidateName(name)
+		if err != nil {
+			resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		req.Name = &name
+	}
+	if req.Email != nil {
+		email := strings.ToLower(strings.TrimSpace(*req.Email))
+		err = validators.ValidateEmail(email)
+		if err != nil {
+			resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		req.Email = &email
+	}
+
+	profile, err := uh.userService.UpdateProfile(userClaims.UserID, req)
+	if errors.Is(err, userService.ErrEmailTaken) {
+		resp := webResponse.NewErrorResponse(http.StatusConflict, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if errors.Is(err, userService.ErrWrongPassword) {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	message := "Profile updated successfully"
+	if profile.PendingEmail != "" {
+		message = "Profile updated, open the link sent to the new email to confirm it"
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, message, profile)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me [DELETE]
+func (uh *UserHandler) DeleteAccountHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	// the body is optional: without a password a recent login is enough
+	var req dto.DeleteAccountDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	var loggedInAt time.Time
+	if userClaims.IssuedAt != nil {
+		loggedInAt = userClaims.IssuedAt.Time
+	}
+
+	err = uh.userService.DeleteAccount(userClaims.UserID, req.Password, loggedInAt, utils.ClientInfo(r))
+	if writeLocked(w, err) {
+		return
+	}
+	if errors.Is(err, userService.ErrWrongPassword) || errors.Is(err, userService.ErrReauthRequired) {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "Account closed", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/me/export [GET]
+func (uh *UserHandler) ExportDataHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userClaims, ok := ctx.Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	export, err := uh.userService.ExportData(userClaims.UserID)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	// the archive is sent as a plain JSON file rather than wrapped in a response
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%s.json"`, userClaims.UserID))
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(export)
+}
+
+// writeLocked answers 429 with Retry-After when err is a lockout.
+func writeLocked(w http.ResponseWriter, err error) bool {
+	var locked *lockoutService.LockedError
+	if !errors.As(err, &locked) {
+		return false
+	}
+	w.Header().Set("Retry-After", fmt.Sprintf("%.0f", locked.RetryAfter.Seconds()))
+	resp := webResponse.NewErrorResponse(http.StatusTooManyRequests, err.Error())
 	w.WriteHeader(resp.Code)
 	json.NewEncoder(w).Encode(resp)
+	return true
 }
@@ -2,15 +2,21 @@ package userHandler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/userService"
 	"go.uber.org/mock/gomock"
 )
 
@@ -143,12 +149,12 @@ func TestLoginHandler_Success(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
-	mockUserService.EXPECT().Login("shyam@example.com", "StrongPass@123").Return("token123", nil)
+	mockUserService.EXPECT().Login("shyam@example.com", "StrongPass@123", gomock.Any()).Return(dto.LoginResultDTO{Token: "token123"}, nil)
 
 	handler.LoginHandler(w, req)
 
-	if w.Code != http.StatusOK {
-		t.Errorf("expected 200, got %d", w.Code)
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte(`"data":{"token":"token123"}`)) {
+		t.Errorf("expected the token in a data object, got %d: %s", w.Code, w.Body.String())
 	}
 }
 
@@ -168,7 +174,7 @@ func TestLoginHandler_Unauthorized(t *testing.T) {
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 
-	mockUserService.EXPECT().Login("shyam@example.com", "wrongpass").Return("", errors.New("invalid credentials"))
+	mockUserService.EXPECT().Login("shyam@example.com", "wrongpass", gomock.Any()).Return(dto.LoginResultDTO{}, errors.New("invalid credentials"))
 
 	handler.LoginHandler(w, req)
 
@@ -176,3 +182,292 @@ func TestLoginHandler_Unauthorized(t *testing.T) {
 		t.Errorf("expected 401, got %d", w.Code)
 	}
 }
+
+func TestLoginHandler_MFARequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	body, _ := json.Marshal(dto.LoginRequestDTO{Email: "shyam@example.com", Password: "StrongPass@123"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().Login("shyam@example.com", "StrongPass@123", gomock.Any()).Return(dto.LoginResultDTO{MFARequired: true, MFAToken: "challenge"}, nil)
+
+	handler.LoginHandler(w, req)
+
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte(`"mfa_token":"challenge"`)) {
+		t.Errorf("expected mfa challenge, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMFALoginHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	body, _ := json.Marshal(dto.MFACodeDTO{MFAToken: "challenge", Code: "123456"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login/mfa", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().VerifyMFALogin("challenge", "123456", gomock.Any()).Return("token123", nil)
+
+	handler.MFALoginHandler(w, req)
+
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte(`"data":{"token":"token123"}`)) {
+		t.Errorf("expected the token in a data object, got %d: %s", w.Code, w.Body.String())
+	}
+
+	body, _ = json.Marshal(dto.MFACodeDTO{MFAToken: "challenge", Code: "000000"})
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/login/mfa", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+
+	mockUserService.EXPECT().VerifyMFALogin("challenge", "000000", gomock.Any()).Return("", errors.New("invalid authentication code"))
+
+	handler.MFALoginHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestMFALoginHandler_MissingCode(t *testing.T) {
+	handler := NewUserHandler(nil)
+
+	body, _ := json.Marshal(dto.MFACodeDTO{MFAToken: "challenge"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login/mfa", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.MFALoginHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestMFASetupConfirmHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	body, _ := json.Marshal(dto.MFACodeDTO{MFAToken: "setup", Code: "123456"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login/mfa/setup/confirm", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().ConfirmMFASetup("setup", "123456", gomock.Any()).Return(dto.MFASetupResultDTO{Token: "token123", RecoveryCodes: []string{"abcde-fghij"}}, nil)
+
+	handler.MFASetupConfirmHandler(w, req)
+
+	if w.Code != http.StatusOK || !bytes.Contains(w.Body.Bytes(), []byte("abcde-fghij")) {
+		t.Errorf("expected recovery codes, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestLoginHandler_Locked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	body, _ := json.Marshal(dto.LoginRequestDTO{Email: "shyam@example.com", Password: "wrongpass"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewReader(body))
+	req.RemoteAddr = "1.2.3.4:5555"
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().Login("shyam@example.com", "wrongpass", models.ClientInfo{IP: "1.2.3.4"}).Return(dto.LoginResultDTO{}, &lockoutService.LockedError{RetryAfter: 8 * time.Second})
+
+	handler.LoginHandler(w, req)
+
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "8" {
+		t.Errorf("expected 429 with Retry-After 8, got %d %q", w.Code, w.Header().Get("Retry-After"))
+	}
+}
+
+func getCustomerContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "user123", Role: models.Customer})
+}
+
+func TestGetProfileHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().GetProfile("user123").Return(dto.ProfileDTO{ID: "user123", Email: "shyam@example.com", Role: "customer"}, nil)
+
+	handler.GetProfileHandler(w, req)
+
+	if w.Code != http.StatusOK || bytes.Contains(w.Body.Bytes(), []byte("password")) {
+		t.Errorf("expected profile without password, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestUpdateProfileHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/me", bytes.NewReader([]byte(`{"email":" New@Example.com ","current_password":"secret"}`))).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().UpdateProfile("user123", gomock.Any()).DoAndReturn(func(userID string, update dto.UpdateProfileDTO) (dto.ProfileDTO, error) {
+		if update.Name != nil || update.Email == nil || *update.Email != "new@example.com" || update.CurrentPassword != "secret" {
+			t.Errorf("unexpected update: %+v", update)
+		}
+		return dto.ProfileDTO{ID: userID, Email: *update.Email}, nil
+	})
+
+	handler.UpdateProfileHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestUpdateProfileHandler_EmailTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/me", bytes.NewReader([]byte(`{"email":"taken@example.com"}`))).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().UpdateProfile("user123", gomock.Any()).Return(dto.ProfileDTO{}, userService.ErrEmailTaken)
+
+	handler.UpdateProfileHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
+
+func TestUpdateProfileHandler_WrongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/me", bytes.NewReader([]byte(`{"email":"new@example.com","current_password":"wrong"}`))).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().UpdateProfile("user123", gomock.Any()).Return(dto.ProfileDTO{}, userService.ErrWrongPassword)
+
+	handler.UpdateProfileHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestUpdateProfileHandler_InvalidName(t *testing.T) {
+	handler := NewUserHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/me", bytes.NewReader([]byte(`{"name":""}`))).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	handler.UpdateProfileHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestDeleteAccountHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/me", bytes.NewReader([]byte(`{"password":"wrong"}`))).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().DeleteAccount("user123", "wrong", gomock.Any(), gomock.Any()).Return(userService.ErrWrongPassword)
+
+	handler.DeleteAccountHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/me", bytes.NewReader([]byte(`{"password":"StrongPass@123"}`))).WithContext(getCustomerContext())
+	w = httptest.NewRecorder()
+
+	mockUserService.EXPECT().DeleteAccount("user123", "StrongPass@123", gomock.Any(), gomock.Any()).Return(nil)
+
+	handler.DeleteAccountHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/v1/me", nil).WithContext(getCustomerContext())
+	w = httptest.NewRecorder()
+
+	mockUserService.EXPECT().DeleteAccount("user123", "", gomock.Any(), gomock.Any()).Return(userService.ErrReauthRequired)
+
+	handler.DeleteAccountHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a password or a fresh login, got %d", w.Code)
+	}
+}
+
+func TestExportDataHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserService := mocks.NewMockUserServiceManager(ctrl)
+	handler := NewUserHandler(mockUserService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/me/export", nil).WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockUserService.EXPECT().ExportData("user123").Return(dto.UserExportDTO{
+		Profile: dto.ProfileDTO{ID: "user123", Email: "shyam@example.com"},
+		Orders:  []models.Order{{ID: "o1", UserID: "user123", Total: 10}},
+	}, nil)
+
+	handler.ExportDataHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Header().Get("Content-Disposition"), "export-user123.json") {
+		t.Errorf("expected attachment header, got %q", w.Header().Get("Content-Disposition"))
+	}
+	var export dto.UserExportDTO
+	if err := json.NewDecoder(w.Body).Decode(&export); err != nil {
+		t.Fatalf("invalid archive: %v", err)
+	}
+	if export.Profile.Email != "shyam@example.com" || len(export.Orders) != 1 {
+		t.Errorf("unexpected archive: %+v", export)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/me/export", nil).WithContext(getCustomerContext())
+	w = httptest.NewRecorder()
+
+	mockUserService.EXPECT().ExportData("user123").Return(dto.UserExportDTO{}, errors.New("db down"))
+
+	handler.ExportDataHandler(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d", w.Code)
+	}
+}
@@ -0,0 +1,127 @@
+package variantHandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/variantService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
+)
+
+type VariantHandler struct {
+	variantService variantService.VariantServiceManager
+}
+
+func NewVariantHandler(variantService variantService.VariantServiceManager) *VariantHandler {
+	return &VariantHandler{
+		variantService: variantService,
+	}
+}
+
+// api/v1/products/{prodID}/variants [GET]
+func (vh *VariantHandler) ListVariantsHandler(w http.ResponseWriter, r *http.Request) {
+	variants, err := vh.variantService.ListVariants(r.PathValue("prodID"))
+	if err != nil {
+		writeVariantError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "variants fetched successfully", variants)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/options [PUT]
+func (vh *VariantHandler) SetOptionsHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.ProductOptionsDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	options, err := vh.variantService.SetOptions(r.PathValue("prodID"), req.Options)
+	if err != nil {
+		writeVariantError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "product options updated successfully", options)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/variants [POST]
+func (vh *VariantHandler) CreateVariantHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.VariantDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	userClaims, ok := r.Context().Value(config.User).(models.UserJWT)
+	if !ok {
+		resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	variant, err := vh.variantService.CreateVariant(userClaims.UserID, r.PathValue("prodID"), req)
+	if err != nil {
+		writeVariantError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusCreated, "variant created successfully", variant)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/variants/{variantID} [PUT]
+func (vh *VariantHandler) UpdateVariantHandler(w http.ResponseWriter, r *http.Request) {
+	var req dto.VariantDTO
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		resp := webResponse.NewErrorResponse(http.StatusBadRequest, "invalid request body")
+		w.WriteHeader(resp.Code)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+	variant, err := vh.variantService.UpdateVariant(r.PathValue("prodID"), r.PathValue("variantID"), req)
+	if err != nil {
+		writeVariantError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "variant updated successfully", variant)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// api/v1/admin/products/{prodID}/variants/{variantID} [DELETE]
+func (vh *VariantHandler) DeleteVariantHandler(w http.ResponseWriter, r *http.Request) {
+	err := vh.variantService.DeleteVariant(r.PathValue("prodID"), r.PathValue("variantID"))
+	if err != nil {
+		writeVariantError(w, err)
+		return
+	}
+	resp := webResponse.NewSuccessResponse(http.StatusOK, "variant deleted successfully", nil)
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func writeVariantError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	switch {
+	case errors.Is(err, variantService.ErrProductNotFound), errors.Is(err, variantService.ErrVariantNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, variantService.ErrSKUExists), errors.Is(err, variantService.ErrVariantExists), errors.Is(err, variantService.ErrOptionsInUse):
+		code = http.StatusConflict
+	}
+	resp := webResponse.NewErrorResponse(code, err.Error())
+	w.WriteHeader(resp.Code)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,179 @@
+package variantHandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/variantService"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListVariantsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVariantServiceManager(ctrl)
+	handler := NewVariantHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/p1/variants", nil)
+	req.SetPathValue("prodID", "p1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ListVariants("p1").Return(dto.ProductVariantsDTO{
+		Options:  []models.ProductOption{{Name: "size", Values: []string{"S"}}},
+		Variants: []models.Variant{{ID: "v1", ProductID: "p1", SKU: "P1-S", Options: map[string]string{"size": "S"}}},
+	}, nil)
+
+	handler.ListVariantsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/products/404/variants", nil)
+	req.SetPathValue("prodID", "404")
+	w = httptest.NewRecorder()
+
+	mockService.EXPECT().ListVariants("404").Return(dto.ProductVariantsDTO{}, variantService.ErrProductNotFound)
+
+	handler.ListVariantsHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestSetOptionsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVariantServiceManager(ctrl)
+	handler := NewVariantHandler(mockService)
+
+	options := []models.ProductOption{{Name: "size", Values: []string{"S", "M"}}}
+	body, _ := json.Marshal(dto.ProductOptionsDTO{Options: options})
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1/options", bytes.NewReader(body))
+	req.SetPathValue("prodID", "p1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().SetOptions("p1", options).Return(options, nil)
+
+	handler.SetOptionsHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1/options", bytes.NewReader(body))
+	req.SetPathValue("prodID", "p1")
+	w = httptest.NewRecorder()
+
+	mockService.EXPECT().SetOptions("p1", options).Return(nil, variantService.ErrOptionsInUse)
+
+	handler.SetOptionsHandler(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1/options", bytes.NewReader([]byte("{")))
+	req.SetPathValue("prodID", "p1")
+	w = httptest.NewRecorder()
+
+	handler.SetOptionsHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestCreateVariantHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVariantServiceManager(ctrl)
+	handler := NewVariantHandler(mockService)
+
+	stock := 3
+	variant := dto.VariantDTO{SKU: "P1-S", Options: map[string]string{"size": "S"}, Stock: &stock}
+	body, _ := json.Marshal(variant)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{nil, http.StatusCreated},
+		{variantService.ErrSKUExists, http.StatusConflict},
+		{variantService.ErrVariantExists, http.StatusConflict},
+		{variantService.ErrNoOptions, http.StatusBadRequest},
+		{fmt.Errorf("missing value for option %q", "colour"), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products/p1/variants", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "admin1", Role: models.Admin}))
+		req.SetPathValue("prodID", "p1")
+		w := httptest.NewRecorder()
+
+		mockService.EXPECT().CreateVariant("admin1", "p1", variant).Return(models.Variant{ID: "v1"}, tt.err)
+
+		handler.CreateVariantHandler(w, req)
+
+		if w.Code != tt.code {
+			t.Errorf("error %v: expected %d, got %d", tt.err, tt.code, w.Code)
+		}
+	}
+}
+
+func TestUpdateVariantHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVariantServiceManager(ctrl)
+	handler := NewVariantHandler(mockService)
+
+	stock := 7
+	variant := dto.VariantDTO{SKU: "P1-S", Options: map[string]string{"size": "S"}, Stock: &stock}
+	body, _ := json.Marshal(variant)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1/variants/v1", bytes.NewReader(body))
+	req.SetPathValue("prodID", "p1")
+	req.SetPathValue("variantID", "v1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().UpdateVariant("p1", "v1", variant).Return(models.Variant{ID: "v1", Stock: 7}, nil)
+
+	handler.UpdateVariantHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestDeleteVariantHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVariantServiceManager(ctrl)
+	handler := NewVariantHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/admin/products/p1/variants/v1", nil)
+	req.SetPathValue("prodID", "p1")
+	req.SetPathValue("variantID", "v1")
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().DeleteVariant("p1", "v1").Return(variantService.ErrVariantNotFound)
+
+	handler.DeleteVariantHandler(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
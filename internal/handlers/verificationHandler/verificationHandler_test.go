@@ -0,0 +1,133 @@
+package verificationHandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/verificationService"
+	"go.uber.org/mock/gomock"
+)
+
+func getCustomerContext() context.Context {
+	return context.WithValue(context.Background(), config.User, models.UserJWT{UserID: "user123", Role: models.Customer})
+}
+
+func TestVerifyEmailHandler_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVerificationServiceManager(ctrl)
+	handler := NewVerificationHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/verify-email?token=abc", nil)
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().VerifyEmail("abc").Return(nil)
+
+	handler.VerifyEmailHandler(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestVerifyEmailHandler_MissingToken(t *testing.T) {
+	handler := NewVerificationHandler(nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/verify-email", nil)
+	w := httptest.NewRecorder()
+
+	handler.VerifyEmailHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestConfirmEmailChangeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVerificationServiceManager(ctrl)
+	handler := NewVerificationHandler(mockService)
+
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{nil, http.StatusOK},
+		{verificationService.ErrEmailTaken, http.StatusConflict},
+		{errors.New("invalid or expired confirmation link"), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/verify-email/change?token=abc", nil)
+		w := httptest.NewRecorder()
+
+		mockService.EXPECT().ConfirmEmailChange("abc").Return(tt.err)
+
+		handler.ConfirmEmailChangeHandler(w, req)
+
+		if w.Code != tt.code {
+			t.Errorf("error %v: expected %d, got %d", tt.err, tt.code, w.Code)
+		}
+	}
+}
+
+func TestResendVerificationHandler_Throttled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVerificationServiceManager(ctrl)
+	handler := NewVerificationHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/verify-email/resend", nil)
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ResendVerification("user123").Return(verificationService.ErrResendThrottled)
+
+	handler.ResendVerificationHandler(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429, got %d", w.Code)
+	}
+}
+
+func TestResendVerificationHandler_AlreadyVerified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockVerificationServiceManager(ctrl)
+	handler := NewVerificationHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/verify-email/resend", nil)
+	req = req.WithContext(getCustomerContext())
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().ResendVerification("user123").Return(errors.New("email is already verified"))
+
+	handler.ResendVerificationHandler(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestResendVerificationHandler_Unauthorized(t *testing.T) {
+	handler := NewVerificationHandler(nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/verify-email/resend", nil)
+	w := httptest.NewRecorder()
+
+	handler.ResendVerificationHandler(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
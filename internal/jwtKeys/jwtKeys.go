@@ -0,0 +1,232 @@
+package jwtKeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+// Key is a single JWT key identified by its kid. SignKey is nil for keys that
+// are only kept around to verify tokens issued before a rotation.
+type Key struct {
+	ID        string
+	Method    jwt.SigningMethod
+	SignKey   any
+	VerifyKey any
+}
+
+type KeySet struct {
+	activeID string
+	keys     map[string]Key
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func NewKeySet(activeID string, keys ...Key) (*KeySet, error) {
+	ks := &KeySet{activeID: activeID, keys: make(map[string]Key)}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, fmt.Errorf("key id can not be empty")
+		}
+		if _, ok := ks.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key id %s", key.ID)
+		}
+		ks.keys[key.ID] = key
+	}
+	active, ok := ks.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %s is not configured", activeID)
+	}
+	if active.SignKey == nil {
+		return nil, fmt.Errorf("active key %s has no private key", activeID)
+	}
+	return ks, nil
+}
+
+// NewRandomKeySet returns a key set holding a single freshly generated HS256
+// secret, so tokens only survive as long as the process does.
+func NewRandomKeySet() *KeySet {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+	ks, _ := NewKeySet("default", NewHMACKey("default", secret))
+	return ks
+}
+
+func NewHMACKey(id string, secret []byte) Key {
+	return Key{ID: id, Method: jwt.SigningMethodHS256, SignKey: secret, VerifyKey: secret}
+}
+
+func (ks *KeySet) SigningKey() Key {
+	return ks.keys[ks.activeID]
+}
+
+func (ks *KeySet) Lookup(kid string) (Key, bool) {
+	key, ok := ks.keys[kid]
+	return key, ok
+}
+
+// JWKS publishes the public halves of the asymmetric keys. HMAC secrets are
+// never included.
+func (ks *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range ks.keys {
+		switch pub := key.VerifyKey.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: RS256,
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: EdDSA,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	return jwks
+}
+
+// Key turns a published JWK back into a verification-only key.
+func (jwk JWK) Key() (Key, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil || len(n) == 0 {
+			return Key{}, fmt.Errorf("key %s: invalid RSA modulus", jwk.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return Key{}, fmt.Errorf("key %s: invalid RSA exponent", jwk.Kid)
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		return Key{ID: jwk.Kid, Method: jwt.SigningMethodRS256, VerifyKey: pub}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return Key{}, fmt.Errorf("key %s: unsupported curve %s", jwk.Kid, jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return Key{}, fmt.Errorf("key %s: invalid Ed25519 key", jwk.Kid)
+		}
+		return Key{ID: jwk.Kid, Method: jwt.SigningMethodEdDSA, VerifyKey: ed25519.PublicKey(x)}, nil
+	}
+	return Key{}, fmt.Errorf("key %s: unsupported key type %s", jwk.Kid, jwk.Kty)
+}
+
+// ParseKey builds a key from its algorithm and PEM (or raw secret) material.
+// A public key PEM yields a verification-only key.
+func ParseKey(id, alg string, material []byte) (Key, error) {
+	switch alg {
+	case HS256:
+		secret := []byte(strings.TrimSpace(string(material)))
+		if len(secret) < 32 {
+			return Key{}, fmt.Errorf("key %s: HS256 secret must be at least 32 bytes", id)
+		}
+		return NewHMACKey(id, secret), nil
+	case RS256:
+		if priv, err := jwt.ParseRSAPrivateKeyFromPEM(material); err == nil {
+			return Key{ID: id, Method: jwt.SigningMethodRS256, SignKey: priv, VerifyKey: &priv.PublicKey}, nil
+		}
+		pub, err := jwt.ParseRSAPublicKeyFromPEM(material)
+		if err != nil {
+			return Key{}, fmt.Errorf("key %s: invalid RSA key: %v", id, err)
+		}
+		return Key{ID: id, Method: jwt.SigningMethodRS256, VerifyKey: pub}, nil
+	case EdDSA:
+		if priv, err := jwt.ParseEdPrivateKeyFromPEM(material); err == nil {
+			edPriv := priv.(ed25519.PrivateKey)
+			return Key{ID: id, Method: jwt.SigningMethodEdDSA, SignKey: edPriv, VerifyKey: edPriv.Public()}, nil
+		}
+		pub, err := jwt.ParseEdPublicKeyFromPEM(material)
+		if err != nil {
+			return Key{}, fmt.Errorf("key %s: invalid Ed25519 key: %v", id, err)
+		}
+		return Key{ID: id, Method: jwt.SigningMethodEdDSA, VerifyKey: pub}, nil
+	}
+	return Key{}, fmt.Errorf("key %s: unsupported algorithm %s", id, alg)
+}
+
+// LoadFromEnv reads keys from the environment:
+//
+//	JWT_KEYS        comma separated "kid=ALG:path" entries, ALG being HS256, RS256 or EdDSA
+//	JWT_ACTIVE_KID  kid used to sign new tokens, defaults to the first entry
+//	JWT_SECRET      shorthand for a single HS256 key when JWT_KEYS is unset
+//
+// It returns nil when nothing is configured.
+func LoadFromEnv() (*KeySet, error) {
+	spec := strings.TrimSpace(os.Getenv("JWT_KEYS"))
+	if spec == "" {
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return nil, nil
+		}
+		key, err := ParseKey("default", HS256, []byte(secret))
+		if err != nil {
+			return nil, err
+		}
+		return NewKeySet("default", key)
+	}
+
+	var keys []Key
+	for _, entry := range strings.Split(spec, ",") {
+		id, rest, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid JWT_KEYS entry %q, expected kid=ALG:path", entry)
+		}
+		alg, path, ok := strings.Cut(rest, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid JWT_KEYS entry %q, expected kid=ALG:path", entry)
+		}
+		material, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %v", id, err)
+		}
+		key, err := ParseKey(id, alg, material)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	activeID := os.Getenv("JWT_ACTIVE_KID")
+	if activeID == "" {
+		activeID = keys[0].ID
+	}
+	return NewKeySet(activeID, keys...)
+}
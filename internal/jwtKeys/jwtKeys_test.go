@@ -0,0 +1,170 @@
+package jwtKeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return path
+}
+
+func TestNewKeySet(t *testing.T) {
+	hmacKey := NewHMACKey("k1", []byte("secret"))
+
+	_, err := NewKeySet("missing", hmacKey)
+	if err == nil {
+		t.Error("expected error for missing active key")
+	}
+
+	_, err = NewKeySet("k1", hmacKey, hmacKey)
+	if err == nil {
+		t.Error("expected error for duplicate key id")
+	}
+
+	verifyOnly := Key{ID: "k2", Method: hmacKey.Method, VerifyKey: []byte("secret")}
+	_, err = NewKeySet("k2", hmacKey, verifyOnly)
+	if err == nil {
+		t.Error("expected error for active key without private key")
+	}
+
+	ks, err := NewKeySet("k1", hmacKey, verifyOnly)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ks.SigningKey().ID != "k1" {
+		t.Errorf("expected signing key k1, got %s", ks.SigningKey().ID)
+	}
+	if _, ok := ks.Lookup("k2"); !ok {
+		t.Error("expected verification key k2 to be found")
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	rsaPriv, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	ks, err := NewKeySet("rsa",
+		Key{ID: "rsa", Method: jwt.SigningMethodRS256, SignKey: rsaPriv, VerifyKey: &rsaPriv.PublicKey},
+		Key{ID: "ed", Method: jwt.SigningMethodEdDSA, VerifyKey: edPub},
+		NewHMACKey("hmac", []byte("secret")),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	jwks := ks.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("expected 2 public keys, got %d", len(jwks.Keys))
+	}
+	for _, jwk := range jwks.Keys {
+		if jwk.Kid == "hmac" {
+			t.Error("HMAC secret must not be published")
+		}
+		if jwk.Kid == "rsa" && (jwk.Kty != "RSA" || jwk.E != "AQAB") {
+			t.Errorf("unexpected RSA jwk: %+v", jwk)
+		}
+		if jwk.Kid == "ed" && (jwk.Kty != "OKP" || jwk.Crv != "Ed25519") {
+			t.Errorf("unexpected Ed25519 jwk: %+v", jwk)
+		}
+	}
+}
+
+func TestJWKKey(t *testing.T) {
+	rsaPriv, _ := rsa.GenerateKey(rand.Reader, 2048)
+	edPub, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+
+	ks, err := NewKeySet("rsa",
+		Key{ID: "rsa", Method: jwt.SigningMethodRS256, SignKey: rsaPriv, VerifyKey: &rsaPriv.PublicKey},
+		Key{ID: "ed", Method: jwt.SigningMethodEdDSA, SignKey: edPriv, VerifyKey: edPub},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, jwk := range ks.JWKS().Keys {
+		key, err := jwk.Key()
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", jwk.Kid, err)
+		}
+		original, _ := ks.Lookup(jwk.Kid)
+		signed, err := jwt.NewWithClaims(original.Method, jwt.MapClaims{"sub": "u1"}).SignedString(original.SignKey)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		_, err = jwt.Parse(signed, func(*jwt.Token) (interface{}, error) { return key.VerifyKey, nil })
+		if err != nil {
+			t.Errorf("key %s does not verify its own tokens: %v", jwk.Kid, err)
+		}
+		if key.SignKey != nil {
+			t.Errorf("key %s must be verification-only", jwk.Kid)
+		}
+	}
+
+	_, err = JWK{Kid: "bad", Kty: "oct"}.Key()
+	if err == nil {
+		t.Error("expected error for unsupported key type")
+	}
+	_, err = JWK{Kid: "bad", Kty: "OKP", Crv: "Ed25519", X: "short"}.Key()
+	if err == nil {
+		t.Error("expected error for malformed key")
+	}
+}
+
+func TestLoadFromEnv(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaPriv, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rsaPath := writePEM(t, dir, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPriv))
+
+	edPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	edDer, _ := x509.MarshalPKIXPublicKey(edPub)
+	edPath := writePEM(t, dir, "ed.pub.pem", "PUBLIC KEY", edDer)
+
+	t.Setenv("JWT_KEYS", "2025="+RS256+":"+rsaPath+", 2024="+EdDSA+":"+edPath)
+	t.Setenv("JWT_ACTIVE_KID", "2025")
+
+	ks, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ks.SigningKey().ID != "2025" {
+		t.Errorf("expected active key 2025, got %s", ks.SigningKey().ID)
+	}
+	old, ok := ks.Lookup("2024")
+	if !ok || old.SignKey != nil {
+		t.Errorf("expected verification-only key 2024, got %+v", old)
+	}
+
+	t.Setenv("JWT_ACTIVE_KID", "2024")
+	_, err = LoadFromEnv()
+	if err == nil {
+		t.Error("expected error when active key has no private key")
+	}
+
+	t.Setenv("JWT_KEYS", "")
+	t.Setenv("JWT_SECRET", "short")
+	_, err = LoadFromEnv()
+	if err == nil {
+		t.Error("expected error for short HS256 secret")
+	}
+
+	t.Setenv("JWT_SECRET", "")
+	ks, err = LoadFromEnv()
+	if err != nil || ks != nil {
+		t.Errorf("expected nothing configured, got %v, err: %v", ks, err)
+	}
+}
@@ -0,0 +1,94 @@
+package mailer
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
+
+// LogMailer writes messages to a writer instead of delivering them. It is
+// meant for development, where the log file doubles as an inbox.
+type LogMailer struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func NewLogMailer(out io.Writer) *LogMailer {
+	return &LogMailer{out: out}
+}
+
+func (lm *LogMailer) Send(to, subject, body string) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	_, err := fmt.Fprintf(lm.out, "---\nDate: %s\nTo: %s\nSubject: %s\n\n%s\n", time.Now().Format(time.RFC1123Z), to, subject, body)
+	return err
+}
+
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer returns a mailer delivering through addr ("host:port"). Auth is
+// skipped when username is empty.
+func NewSMTPMailer(addr, from, username, password string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPMailer{addr: addr, from: from, auth: auth}
+}
+
+func (sm *SMTPMailer) Send(to, subject, body string) error {
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return fmt.Errorf("invalid mail header")
+	}
+	msg := "From: " + sm.from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + strings.ReplaceAll(body, "\n", "\r\n") + "\r\n"
+	return smtp.SendMail(sm.addr, sm.auth, sm.from, []string{to}, []byte(msg))
+}
+
+// NewFromEnv picks the mailer from the environment:
+//
+//	MAILER          "log" (default) or "smtp"
+//	MAIL_LOG_FILE   file the log mailer appends to, stdout when unset
+//	SMTP_ADDR       host:port of the SMTP server
+//	SMTP_FROM       sender address
+//	SMTP_USERNAME   optional, enables PLAIN auth together with SMTP_PASSWORD
+func NewFromEnv() (Mailer, error) {
+	switch os.Getenv("MAILER") {
+	case "", "log":
+		path := os.Getenv("MAIL_LOG_FILE")
+		if path == "" {
+			return NewLogMailer(os.Stdout), nil
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return NewLogMailer(file), nil
+	case "smtp":
+		addr := os.Getenv("SMTP_ADDR")
+		from := os.Getenv("SMTP_FROM")
+		if addr == "" || from == "" {
+			return nil, fmt.Errorf("SMTP_ADDR and SMTP_FROM are required for the smtp mailer")
+		}
+		return NewSMTPMailer(addr, from, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD")), nil
+	}
+	return nil, fmt.Errorf("unknown mailer %q", os.Getenv("MAILER"))
+}
@@ -0,0 +1,119 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeSMTPServer accepts a single session and returns the DATA section it
+// received on the channel.
+func fakeSMTPServer(t *testing.T) (string, <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	received := make(chan string, 1)
+	go func() {
+		defer ln.Close()
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		write := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		write("220 localhost fake smtp")
+		var data strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				write("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM"), strings.HasPrefix(cmd, "RCPT TO"):
+				write("250 OK")
+			case cmd == "DATA":
+				write("354 send data")
+				for {
+					dataLine, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				write("250 OK")
+				received <- data.String()
+			case cmd == "QUIT":
+				write("221 bye")
+				return
+			default:
+				write("250 OK")
+			}
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+func TestLogMailer(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewLogMailer(&buf)
+
+	if err := m.Send("user@example.com", "Hello", "body text"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "To: user@example.com") || !strings.Contains(out, "Subject: Hello") || !strings.Contains(out, "body text") {
+		t.Errorf("unexpected log output: %s", out)
+	}
+}
+
+func TestSMTPMailer(t *testing.T) {
+	addr, received := fakeSMTPServer(t)
+	m := NewSMTPMailer(addr, "shop@example.com", "", "")
+
+	if err := m.Send("user@example.com", "Reset your password", "token: abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	msg := <-received
+	if !strings.Contains(msg, "To: user@example.com") || !strings.Contains(msg, "Subject: Reset your password") || !strings.Contains(msg, "token: abc") {
+		t.Errorf("unexpected message: %s", msg)
+	}
+}
+
+func TestSMTPMailer_HeaderInjection(t *testing.T) {
+	m := NewSMTPMailer("127.0.0.1:1", "shop@example.com", "", "")
+
+	err := m.Send("user@example.com\r\nBcc: evil@example.com", "Hi", "body")
+	if err == nil {
+		t.Error("expected error for header injection")
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("MAILER", "smtp")
+	t.Setenv("SMTP_ADDR", "")
+	if _, err := NewFromEnv(); err == nil {
+		t.Error("expected error for missing SMTP settings")
+	}
+
+	t.Setenv("MAILER", "pigeon")
+	if _, err := NewFromEnv(); err == nil {
+		t.Error("expected error for unknown mailer")
+	}
+
+	t.Setenv("MAILER", "")
+	if m, err := NewFromEnv(); err != nil || m == nil {
+		t.Errorf("expected log mailer, got %v, err: %v", m, err)
+	}
+}
@@ -3,16 +3,40 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authzService"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/webResponse"
 )
 
-func AuthMiddleware(next http.Handler) http.Handler {
+// AuthMiddleware accepts either a bearer token or an API key in
+// config.APIKeyHeader.
+func AuthMiddleware(authServ authService.AuthServiceManager, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiKey := r.Header.Get(config.APIKeyHeader); apiKey != "" {
+			claims, err := authServ.AuthenticateAPIKey(apiKey)
+			if err != nil {
+				code := http.StatusUnauthorized
+				if errors.Is(err, authService.ErrUserSuspended) {
+					code = http.StatusForbidden
+				}
+				resp := webResponse.NewErrorResponse(code, err.Error())
+				w.WriteHeader(resp.Code)
+				json.NewEncoder(w).Encode(resp)
+				return
+			}
+			ctx := context.WithValue(r.Context(), config.User, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
 			resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "missing authorization header")
@@ -20,7 +44,7 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			json.NewEncoder(w).Encode(resp)
 			return
 		}
-		tokenStr := authHeader[len("Bearer "):]
+		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 
 		var claims models.UserJWT
 
@@ -32,8 +56,79 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		err = authServ.ValidateToken(claims)
+		if err != nil {
+			code := http.StatusUnauthorized
+			if errors.Is(err, authService.ErrUserSuspended) {
+				code = http.StatusForbidden
+			}
+			resp := webResponse.NewErrorResponse(code, err.Error())
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+
 		ctx := context.WithValue(r.Context(), config.User, claims)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// RequirePermission must run after AuthMiddleware; it rejects callers whose
+// account role and assigned roles do not grant perm. API keys additionally
+// need perm among their scopes.
+func RequirePermission(authzServ authzService.AuthzServiceManager, perm models.Permission, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(config.User).(models.UserJWT)
+		if !ok {
+			resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+
+		if claims.IsAPIKey() && !slices.Contains(claims.Scopes, perm) {
+			resp := webResponse.NewErrorResponse(http.StatusForbidden, "api key is missing scope "+string(perm))
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+
+		allowed, err := authzServ.HasPermission(claims.UserID, claims.Role, perm)
+		if err != nil {
+			resp := webResponse.NewErrorResponse(http.StatusInternalServerError, err.Error())
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		if !allowed {
+			resp := webResponse.NewErrorResponse(http.StatusForbidden, "missing permission "+string(perm))
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireSession must run after AuthMiddleware; it keeps API keys away from
+// routes that act on the caller's own account.
+func RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(config.User).(models.UserJWT)
+		if !ok {
+			resp := webResponse.NewErrorResponse(http.StatusUnauthorized, "unauthorized")
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		if claims.IsAPIKey() {
+			resp := webResponse.NewErrorResponse(http.StatusForbidden, "api keys can not be used for this endpoint")
+			w.WriteHeader(resp.Code)
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
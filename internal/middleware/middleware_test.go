@@ -1,2 +1,212 @@
 package middleware
 
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestAuthMiddleware_MissingHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cart", nil)
+	w := httptest.NewRecorder()
+
+	AuthMiddleware(nil, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_RevokedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+	token, _ := utils.GenerateJWT(models.UserJWT{UserID: "user1", Role: models.Customer})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cart", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().ValidateToken(gomock.Any()).Return(errors.New("token has been revoked"))
+
+	AuthMiddleware(mockAuthService, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_SuspendedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+	token, _ := utils.GenerateJWT(models.UserJWT{UserID: "user1", Role: models.Customer})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cart", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().ValidateToken(gomock.Any()).Return(authService.ErrUserSuspended)
+
+	AuthMiddleware(mockAuthService, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_ValidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+	token, _ := utils.GenerateJWT(models.UserJWT{UserID: "user1", Role: models.Customer})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cart", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().ValidateToken(gomock.Any()).Return(nil)
+
+	AuthMiddleware(mockAuthService, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestRequirePermission_NoClaims(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products", nil)
+	w := httptest.NewRecorder()
+
+	RequirePermission(nil, models.PermProductsWrite, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestRequirePermission_Forbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthzService := mocks.NewMockAuthzServiceManager(ctrl)
+	claims := models.UserJWT{UserID: "user1", Role: models.Customer}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products", nil)
+	req = req.WithContext(context.WithValue(req.Context(), config.User, claims))
+	w := httptest.NewRecorder()
+
+	mockAuthzService.EXPECT().HasPermission("user1", models.Customer, models.PermProductsWrite).Return(false, nil)
+
+	RequirePermission(mockAuthzService, models.PermProductsWrite, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestRequirePermission_Allowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthzService := mocks.NewMockAuthzServiceManager(ctrl)
+	claims := models.UserJWT{UserID: "user1", Role: models.Customer}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/products", nil)
+	req = req.WithContext(context.WithValue(req.Context(), config.User, claims))
+	w := httptest.NewRecorder()
+
+	mockAuthzService.EXPECT().HasPermission("user1", models.Customer, models.PermProductsWrite).Return(true, nil)
+
+	RequirePermission(mockAuthzService, models.PermProductsWrite, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1", nil)
+	req.Header.Set(config.APIKeyHeader, "osk_secret")
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().AuthenticateAPIKey("osk_secret").Return(models.UserJWT{UserID: "admin1", APIKeyID: "k1"}, nil)
+
+	var got models.UserJWT
+	AuthMiddleware(mockAuthService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = r.Context().Value(config.User).(models.UserJWT)
+	})).ServeHTTP(w, req)
+
+	if got.APIKeyID != "k1" {
+		t.Errorf("expected api key claims in context, got %+v", got)
+	}
+}
+
+func TestAuthMiddleware_InvalidAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := mocks.NewMockAuthServiceManager(ctrl)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1", nil)
+	req.Header.Set(config.APIKeyHeader, "osk_wrong")
+	w := httptest.NewRecorder()
+
+	mockAuthService.EXPECT().AuthenticateAPIKey("osk_wrong").Return(models.UserJWT{}, errors.New("invalid api key"))
+
+	AuthMiddleware(mockAuthService, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestRequirePermission_APIKeyMissingScope(t *testing.T) {
+	claims := models.UserJWT{UserID: "admin1", Role: models.Admin, APIKeyID: "k1", Scopes: []models.Permission{models.PermOrdersRead}}
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/products/p1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), config.User, claims))
+	w := httptest.NewRecorder()
+
+	RequirePermission(nil, models.PermProductsWrite, http.HandlerFunc(okHandler)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", w.Code)
+	}
+}
+
+func TestRequireSession(t *testing.T) {
+	apiKeyReq := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	apiKeyReq = apiKeyReq.WithContext(context.WithValue(apiKeyReq.Context(), config.User, models.UserJWT{UserID: "admin1", APIKeyID: "k1"}))
+	w := httptest.NewRecorder()
+	RequireSession(http.HandlerFunc(okHandler)).ServeHTTP(w, apiKeyReq)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for api key, got %d", w.Code)
+	}
+
+	sessionReq := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	sessionReq = sessionReq.WithContext(context.WithValue(sessionReq.Context(), config.User, models.UserJWT{UserID: "user1"}))
+	w = httptest.NewRecorder()
+	RequireSession(http.HandlerFunc(okHandler)).ServeHTTP(w, sessionReq)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 for session, got %d", w.Code)
+	}
+}
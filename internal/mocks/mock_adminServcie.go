@@ -10,8 +10,11 @@
 package mocks
 
 import (
+	io "io"
 	reflect "reflect"
 
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -54,17 +57,190 @@ func (mr *MockAdminServiceManagerMockRecorder) AddCoupon(code, discount any) *go
 }
 
 // AddProduct mocks base method.
-func (m *MockAdminServiceManager) AddProduct(name string, price float32, stock int) error {
+func (m *MockAdminServiceManager) AddProduct(adminID string, req dto.ProductDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProduct", name, price, stock)
+	ret := m.ctrl.Call(m, "AddProduct", adminID, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddProduct indicates an expected call of AddProduct.
-func (mr *MockAdminServiceManagerMockRecorder) AddProduct(name, price, stock any) *gomock.Call {
+func (mr *MockAdminServiceManagerMockRecorder) AddProduct(adminID, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockAdminServiceManager)(nil).AddProduct), name, price, stock)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockAdminServiceManager)(nil).AddProduct), adminID, req)
+}
+
+// ArchiveProduct mocks base method.
+func (m *MockAdminServiceManager) ArchiveProduct(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveProduct", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchiveProduct indicates an expected call of ArchiveProduct.
+func (mr *MockAdminServiceManagerMockRecorder) ArchiveProduct(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveProduct", reflect.TypeOf((*MockAdminServiceManager)(nil).ArchiveProduct), id)
+}
+
+// ChangeUserRole mocks base method.
+func (m *MockAdminServiceManager) ChangeUserRole(adminID, userID string, role models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeUserRole", adminID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeUserRole indicates an expected call of ChangeUserRole.
+func (mr *MockAdminServiceManagerMockRecorder) ChangeUserRole(adminID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUserRole", reflect.TypeOf((*MockAdminServiceManager)(nil).ChangeUserRole), adminID, userID, role)
+}
+
+// ExportProducts mocks base method.
+func (m *MockAdminServiceManager) ExportProducts(w io.Writer, format string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportProducts", w, format)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockAdminServiceManagerMockRecorder) ExportProducts(w, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockAdminServiceManager)(nil).ExportProducts), w, format)
+}
+
+// FailInterruptedImports mocks base method.
+func (m *MockAdminServiceManager) FailInterruptedImports() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "FailInterruptedImports")
+}
+
+// FailInterruptedImports indicates an expected call of FailInterruptedImports.
+func (mr *MockAdminServiceManagerMockRecorder) FailInterruptedImports() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailInterruptedImports", reflect.TypeOf((*MockAdminServiceManager)(nil).FailInterruptedImports))
+}
+
+// GetImportJob mocks base method.
+func (m *MockAdminServiceManager) GetImportJob(id string) (models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", id)
+	ret0, _ := ret[0].(models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockAdminServiceManagerMockRecorder) GetImportJob(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockAdminServiceManager)(nil).GetImportJob), id)
+}
+
+// GetUser mocks base method.
+func (m *MockAdminServiceManager) GetUser(userID string) (dto.AdminUserDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", userID)
+	ret0, _ := ret[0].(dto.AdminUserDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockAdminServiceManagerMockRecorder) GetUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAdminServiceManager)(nil).GetUser), userID)
+}
+
+// GetUserCart mocks base method.
+func (m *MockAdminServiceManager) GetUserCart(userID string) ([]dto.CartItemsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCart", userID)
+	ret0, _ := ret[0].([]dto.CartItemsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCart indicates an expected call of GetUserCart.
+func (mr *MockAdminServiceManagerMockRecorder) GetUserCart(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCart", reflect.TypeOf((*MockAdminServiceManager)(nil).GetUserCart), userID)
+}
+
+// GetUserOrders mocks base method.
+func (m *MockAdminServiceManager) GetUserOrders(userID string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrders", userID)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrders indicates an expected call of GetUserOrders.
+func (mr *MockAdminServiceManagerMockRecorder) GetUserOrders(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrders", reflect.TypeOf((*MockAdminServiceManager)(nil).GetUserOrders), userID)
+}
+
+// ImportProducts mocks base method.
+func (m *MockAdminServiceManager) ImportProducts(adminID, format string, body io.Reader, dryRun bool) (models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportProducts", adminID, format, body, dryRun)
+	ret0, _ := ret[0].(models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportProducts indicates an expected call of ImportProducts.
+func (mr *MockAdminServiceManagerMockRecorder) ImportProducts(adminID, format, body, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportProducts", reflect.TypeOf((*MockAdminServiceManager)(nil).ImportProducts), adminID, format, body, dryRun)
+}
+
+// ListOrders mocks base method.
+func (m *MockAdminServiceManager) ListOrders(filter models.OrderFilter) (dto.OrderListDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", filter)
+	ret0, _ := ret[0].(dto.OrderListDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockAdminServiceManagerMockRecorder) ListOrders(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockAdminServiceManager)(nil).ListOrders), filter)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdminServiceManager) ListUsers(filter models.UserFilter) (dto.UserListDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", filter)
+	ret0, _ := ret[0].(dto.UserListDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminServiceManagerMockRecorder) ListUsers(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdminServiceManager)(nil).ListUsers), filter)
+}
+
+// PurgeProduct mocks base method.
+func (m *MockAdminServiceManager) PurgeProduct(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeProduct", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeProduct indicates an expected call of PurgeProduct.
+func (mr *MockAdminServiceManagerMockRecorder) PurgeProduct(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeProduct", reflect.TypeOf((*MockAdminServiceManager)(nil).PurgeProduct), id)
 }
 
 // RemoveCoupon mocks base method.
@@ -81,30 +257,44 @@ func (mr *MockAdminServiceManagerMockRecorder) RemoveCoupon(code any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCoupon", reflect.TypeOf((*MockAdminServiceManager)(nil).RemoveCoupon), code)
 }
 
-// RemoveProduct mocks base method.
-func (m *MockAdminServiceManager) RemoveProduct(code string) error {
+// RestoreProduct mocks base method.
+func (m *MockAdminServiceManager) RestoreProduct(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreProduct", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreProduct indicates an expected call of RestoreProduct.
+func (mr *MockAdminServiceManagerMockRecorder) RestoreProduct(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreProduct", reflect.TypeOf((*MockAdminServiceManager)(nil).RestoreProduct), id)
+}
+
+// SetUserStatus mocks base method.
+func (m *MockAdminServiceManager) SetUserStatus(adminID, userID string, status models.UserStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveProduct", code)
+	ret := m.ctrl.Call(m, "SetUserStatus", adminID, userID, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RemoveProduct indicates an expected call of RemoveProduct.
-func (mr *MockAdminServiceManagerMockRecorder) RemoveProduct(code any) *gomock.Call {
+// SetUserStatus indicates an expected call of SetUserStatus.
+func (mr *MockAdminServiceManagerMockRecorder) SetUserStatus(adminID, userID, status any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProduct", reflect.TypeOf((*MockAdminServiceManager)(nil).RemoveProduct), code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserStatus", reflect.TypeOf((*MockAdminServiceManager)(nil).SetUserStatus), adminID, userID, status)
 }
 
 // UpdateProduct mocks base method.
-func (m *MockAdminServiceManager) UpdateProduct(id, name string, price float32, stock int) error {
+func (m *MockAdminServiceManager) UpdateProduct(id string, req dto.ProductDTO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProduct", id, name, price, stock)
+	ret := m.ctrl.Call(m, "UpdateProduct", id, req)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateProduct indicates an expected call of UpdateProduct.
-func (mr *MockAdminServiceManagerMockRecorder) UpdateProduct(id, name, price, stock any) *gomock.Call {
+func (mr *MockAdminServiceManagerMockRecorder) UpdateProduct(id, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockAdminServiceManager)(nil).UpdateProduct), id, name, price, stock)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockAdminServiceManager)(nil).UpdateProduct), id, req)
 }
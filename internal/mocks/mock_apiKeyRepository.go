@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_apiKeyRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyManager is a mock of APIKeyManager interface.
+type MockAPIKeyManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyManagerMockRecorder is the mock recorder for MockAPIKeyManager.
+type MockAPIKeyManagerMockRecorder struct {
+	mock *MockAPIKeyManager
+}
+
+// NewMockAPIKeyManager creates a new mock instance.
+func NewMockAPIKeyManager(ctrl *gomock.Controller) *MockAPIKeyManager {
+	mock := &MockAPIKeyManager{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyManager) EXPECT() *MockAPIKeyManagerMockRecorder {
+	return m.recorder
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockAPIKeyManager) GetAPIKeyByHash(keyHash string) (models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", keyHash)
+	ret0, _ := ret[0].(models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockAPIKeyManagerMockRecorder) GetAPIKeyByHash(keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockAPIKeyManager)(nil).GetAPIKeyByHash), keyHash)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockAPIKeyManager) ListAPIKeys() ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys")
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockAPIKeyManagerMockRecorder) ListAPIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockAPIKeyManager)(nil).ListAPIKeys))
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyManager) RevokeAPIKey(id string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyManagerMockRecorder) RevokeAPIKey(id, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyManager)(nil).RevokeAPIKey), id, revokedAt)
+}
+
+// SaveAPIKey mocks base method.
+func (m *MockAPIKeyManager) SaveAPIKey(key models.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAPIKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAPIKey indicates an expected call of SaveAPIKey.
+func (mr *MockAPIKeyManagerMockRecorder) SaveAPIKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAPIKey", reflect.TypeOf((*MockAPIKeyManager)(nil).SaveAPIKey), key)
+}
+
+// UpdateAPIKeyLastUsed mocks base method.
+func (m *MockAPIKeyManager) UpdateAPIKeyLastUsed(id string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPIKeyLastUsed", id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPIKeyLastUsed indicates an expected call of UpdateAPIKeyLastUsed.
+func (mr *MockAPIKeyManagerMockRecorder) UpdateAPIKeyLastUsed(id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyLastUsed", reflect.TypeOf((*MockAPIKeyManager)(nil).UpdateAPIKeyLastUsed), id, usedAt)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_apiKeyService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyServiceManager is a mock of APIKeyServiceManager interface.
+type MockAPIKeyServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyServiceManagerMockRecorder is the mock recorder for MockAPIKeyServiceManager.
+type MockAPIKeyServiceManagerMockRecorder struct {
+	mock *MockAPIKeyServiceManager
+}
+
+// NewMockAPIKeyServiceManager creates a new mock instance.
+func NewMockAPIKeyServiceManager(ctrl *gomock.Controller) *MockAPIKeyServiceManager {
+	mock := &MockAPIKeyServiceManager{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyServiceManager) EXPECT() *MockAPIKeyServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockAPIKeyServiceManager) CreateAPIKey(ownerID string, ownerRole models.UserRole, name string, scopes []string) (dto.APIKeyCreatedDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ownerID, ownerRole, name, scopes)
+	ret0, _ := ret[0].(dto.APIKeyCreatedDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockAPIKeyServiceManagerMockRecorder) CreateAPIKey(ownerID, ownerRole, name, scopes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockAPIKeyServiceManager)(nil).CreateAPIKey), ownerID, ownerRole, name, scopes)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockAPIKeyServiceManager) ListAPIKeys() ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys")
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockAPIKeyServiceManagerMockRecorder) ListAPIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockAPIKeyServiceManager)(nil).ListAPIKeys))
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyServiceManager) RevokeAPIKey(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyServiceManagerMockRecorder) RevokeAPIKey(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyServiceManager)(nil).RevokeAPIKey), id)
+}
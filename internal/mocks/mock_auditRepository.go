@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_auditRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditManager is a mock of AuditManager interface.
+type MockAuditManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditManagerMockRecorder is the mock recorder for MockAuditManager.
+type MockAuditManagerMockRecorder struct {
+	mock *MockAuditManager
+}
+
+// NewMockAuditManager creates a new mock instance.
+func NewMockAuditManager(ctrl *gomock.Controller) *MockAuditManager {
+	mock := &MockAuditManager{ctrl: ctrl}
+	mock.recorder = &MockAuditManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditManager) EXPECT() *MockAuditManagerMockRecorder {
+	return m.recorder
+}
+
+// SaveAuditEntry mocks base method.
+func (m *MockAuditManager) SaveAuditEntry(entry models.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuditEntry", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuditEntry indicates an expected call of SaveAuditEntry.
+func (mr *MockAuditManagerMockRecorder) SaveAuditEntry(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuditEntry", reflect.TypeOf((*MockAuditManager)(nil).SaveAuditEntry), entry)
+}
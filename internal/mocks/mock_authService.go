@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_authService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	jwtKeys "github.com/meshyampratap01/OnlineShoppingCart/internal/jwtKeys"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthServiceManager is a mock of AuthServiceManager interface.
+type MockAuthServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthServiceManagerMockRecorder is the mock recorder for MockAuthServiceManager.
+type MockAuthServiceManagerMockRecorder struct {
+	mock *MockAuthServiceManager
+}
+
+// NewMockAuthServiceManager creates a new mock instance.
+func NewMockAuthServiceManager(ctrl *gomock.Controller) *MockAuthServiceManager {
+	mock := &MockAuthServiceManager{ctrl: ctrl}
+	mock.recorder = &MockAuthServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthServiceManager) EXPECT() *MockAuthServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// AuthenticateAPIKey mocks base method.
+func (m *MockAuthServiceManager) AuthenticateAPIKey(key string) (models.UserJWT, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticateAPIKey", key)
+	ret0, _ := ret[0].(models.UserJWT)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticateAPIKey indicates an expected call of AuthenticateAPIKey.
+func (mr *MockAuthServiceManagerMockRecorder) AuthenticateAPIKey(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateAPIKey", reflect.TypeOf((*MockAuthServiceManager)(nil).AuthenticateAPIKey), key)
+}
+
+// GetJWKS mocks base method.
+func (m *MockAuthServiceManager) GetJWKS() jwtKeys.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS")
+	ret0, _ := ret[0].(jwtKeys.JWKS)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockAuthServiceManagerMockRecorder) GetJWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockAuthServiceManager)(nil).GetJWKS))
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthServiceManager) ListSessions(userID, currentID string) ([]dto.SessionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", userID, currentID)
+	ret0, _ := ret[0].([]dto.SessionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServiceManagerMockRecorder) ListSessions(userID, currentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthServiceManager)(nil).ListSessions), userID, currentID)
+}
+
+// Logout mocks base method.
+func (m *MockAuthServiceManager) Logout(claims models.UserJWT) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthServiceManagerMockRecorder) Logout(claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthServiceManager)(nil).Logout), claims)
+}
+
+// LogoutAll mocks base method.
+func (m *MockAuthServiceManager) LogoutAll(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockAuthServiceManagerMockRecorder) LogoutAll(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*MockAuthServiceManager)(nil).LogoutAll), userID)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockAuthServiceManager) RevokeUserSessions(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockAuthServiceManagerMockRecorder) RevokeUserSessions(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockAuthServiceManager)(nil).RevokeUserSessions), userID)
+}
+
+// TerminateSession mocks base method.
+func (m *MockAuthServiceManager) TerminateSession(userID, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerminateSession", userID, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TerminateSession indicates an expected call of TerminateSession.
+func (mr *MockAuthServiceManagerMockRecorder) TerminateSession(userID, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerminateSession", reflect.TypeOf((*MockAuthServiceManager)(nil).TerminateSession), userID, sessionID)
+}
+
+// ValidateToken mocks base method.
+func (m *MockAuthServiceManager) ValidateToken(claims models.UserJWT) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateToken", claims)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateToken indicates an expected call of ValidateToken.
+func (mr *MockAuthServiceManagerMockRecorder) ValidateToken(claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockAuthServiceManager)(nil).ValidateToken), claims)
+}
@@ -0,0 +1,247 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_authzService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthzServiceManager is a mock of AuthzServiceManager interface.
+type MockAuthzServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthzServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthzServiceManagerMockRecorder is the mock recorder for MockAuthzServiceManager.
+type MockAuthzServiceManagerMockRecorder struct {
+	mock *MockAuthzServiceManager
+}
+
+// NewMockAuthzServiceManager creates a new mock instance.
+func NewMockAuthzServiceManager(ctrl *gomock.Controller) *MockAuthzServiceManager {
+	mock := &MockAuthzServiceManager{ctrl: ctrl}
+	mock.recorder = &MockAuthzServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthzServiceManager) EXPECT() *MockAuthzServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// AssignRole mocks base method.
+func (m *MockAuthzServiceManager) AssignRole(actorID, userID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", actorID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockAuthzServiceManagerMockRecorder) AssignRole(actorID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockAuthzServiceManager)(nil).AssignRole), actorID, userID, role)
+}
+
+// CanGrant mocks base method.
+func (m *MockAuthzServiceManager) CanGrant(actorID string, perms []models.Permission) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanGrant", actorID, perms)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanGrant indicates an expected call of CanGrant.
+func (mr *MockAuthzServiceManagerMockRecorder) CanGrant(actorID, perms any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanGrant", reflect.TypeOf((*MockAuthzServiceManager)(nil).CanGrant), actorID, perms)
+}
+
+// CanManage mocks base method.
+func (m *MockAuthzServiceManager) CanManage(actorID string, target models.User) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanManage", actorID, target)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanManage indicates an expected call of CanManage.
+func (mr *MockAuthzServiceManagerMockRecorder) CanManage(actorID, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanManage", reflect.TypeOf((*MockAuthzServiceManager)(nil).CanManage), actorID, target)
+}
+
+// CreateRole mocks base method.
+func (m *MockAuthzServiceManager) CreateRole(actorID, name, description string, permissions []string) (models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", actorID, name, description, permissions)
+	ret0, _ := ret[0].(models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockAuthzServiceManagerMockRecorder) CreateRole(actorID, name, description, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockAuthzServiceManager)(nil).CreateRole), actorID, name, description, permissions)
+}
+
+// DeleteRole mocks base method.
+func (m *MockAuthzServiceManager) DeleteRole(actorID, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", actorID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockAuthzServiceManagerMockRecorder) DeleteRole(actorID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockAuthzServiceManager)(nil).DeleteRole), actorID, name)
+}
+
+// GetRole mocks base method.
+func (m *MockAuthzServiceManager) GetRole(name string) (models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", name)
+	ret0, _ := ret[0].(models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockAuthzServiceManagerMockRecorder) GetRole(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockAuthzServiceManager)(nil).GetRole), name)
+}
+
+// GetUserRoles mocks base method.
+func (m *MockAuthzServiceManager) GetUserRoles(userID string) ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", userID)
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockAuthzServiceManagerMockRecorder) GetUserRoles(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockAuthzServiceManager)(nil).GetUserRoles), userID)
+}
+
+// HasPermission mocks base method.
+func (m *MockAuthzServiceManager) HasPermission(userID string, role models.UserRole, perm models.Permission) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", userID, role, perm)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockAuthzServiceManagerMockRecorder) HasPermission(userID, role, perm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockAuthzServiceManager)(nil).HasPermission), userID, role, perm)
+}
+
+// IsStaff mocks base method.
+func (m *MockAuthzServiceManager) IsStaff(userID string, role models.UserRole) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsStaff", userID, role)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsStaff indicates an expected call of IsStaff.
+func (mr *MockAuthzServiceManagerMockRecorder) IsStaff(userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsStaff", reflect.TypeOf((*MockAuthzServiceManager)(nil).IsStaff), userID, role)
+}
+
+// ListPermissions mocks base method.
+func (m *MockAuthzServiceManager) ListPermissions() []models.Permission {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions")
+	ret0, _ := ret[0].([]models.Permission)
+	return ret0
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockAuthzServiceManagerMockRecorder) ListPermissions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockAuthzServiceManager)(nil).ListPermissions))
+}
+
+// ListRoles mocks base method.
+func (m *MockAuthzServiceManager) ListRoles() ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles")
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockAuthzServiceManagerMockRecorder) ListRoles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockAuthzServiceManager)(nil).ListRoles))
+}
+
+// UnassignRole mocks base method.
+func (m *MockAuthzServiceManager) UnassignRole(actorID, userID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignRole", actorID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignRole indicates an expected call of UnassignRole.
+func (mr *MockAuthzServiceManagerMockRecorder) UnassignRole(actorID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignRole", reflect.TypeOf((*MockAuthzServiceManager)(nil).UnassignRole), actorID, userID, role)
+}
+
+// UpdateRole mocks base method.
+func (m *MockAuthzServiceManager) UpdateRole(actorID, name, description string, permissions []string) (models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", actorID, name, description, permissions)
+	ret0, _ := ret[0].(models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockAuthzServiceManagerMockRecorder) UpdateRole(actorID, name, description, permissions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockAuthzServiceManager)(nil).UpdateRole), actorID, name, description, permissions)
+}
+
+// UserPermissions mocks base method.
+func (m *MockAuthzServiceManager) UserPermissions(userID string, role models.UserRole) ([]models.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserPermissions", userID, role)
+	ret0, _ := ret[0].([]models.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserPermissions indicates an expected call of UserPermissions.
+func (mr *MockAuthzServiceManagerMockRecorder) UserPermissions(userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserPermissions", reflect.TypeOf((*MockAuthzServiceManager)(nil).UserPermissions), userID, role)
+}
@@ -42,17 +42,17 @@ func (m *MockCartManager) EXPECT() *MockCartManagerMockRecorder {
 }
 
 // AddToCart mocks base method.
-func (m *MockCartManager) AddToCart(userID string, product models.Product) error {
+func (m *MockCartManager) AddToCart(userID string, product models.Product, variantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToCart", userID, product)
+	ret := m.ctrl.Call(m, "AddToCart", userID, product, variantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddToCart indicates an expected call of AddToCart.
-func (mr *MockCartManagerMockRecorder) AddToCart(userID, product any) *gomock.Call {
+func (mr *MockCartManagerMockRecorder) AddToCart(userID, product, variantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartManager)(nil).AddToCart), userID, product)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartManager)(nil).AddToCart), userID, product, variantID)
 }
 
 // CreateCart mocks base method.
@@ -99,18 +99,18 @@ func (mr *MockCartManagerMockRecorder) GetCartIDByUserID(userID any) *gomock.Cal
 }
 
 // GetCartItemQuantity mocks base method.
-func (m *MockCartManager) GetCartItemQuantity(cartID, prodID string) (int, error) {
+func (m *MockCartManager) GetCartItemQuantity(cartID, prodID, variantID string) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartItemQuantity", cartID, prodID)
+	ret := m.ctrl.Call(m, "GetCartItemQuantity", cartID, prodID, variantID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCartItemQuantity indicates an expected call of GetCartItemQuantity.
-func (mr *MockCartManagerMockRecorder) GetCartItemQuantity(cartID, prodID any) *gomock.Call {
+func (mr *MockCartManagerMockRecorder) GetCartItemQuantity(cartID, prodID, variantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItemQuantity", reflect.TypeOf((*MockCartManager)(nil).GetCartItemQuantity), cartID, prodID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartItemQuantity", reflect.TypeOf((*MockCartManager)(nil).GetCartItemQuantity), cartID, prodID, variantID)
 }
 
 // GetCartItems mocks base method.
@@ -129,15 +129,15 @@ func (mr *MockCartManagerMockRecorder) GetCartItems(cartID any) *gomock.Call {
 }
 
 // RemoveFromCart mocks base method.
-func (m *MockCartManager) RemoveFromCart(cartID, prodID string) error {
+func (m *MockCartManager) RemoveFromCart(cartID, prodID, variantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveFromCart", cartID, prodID)
+	ret := m.ctrl.Call(m, "RemoveFromCart", cartID, prodID, variantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveFromCart indicates an expected call of RemoveFromCart.
-func (mr *MockCartManagerMockRecorder) RemoveFromCart(cartID, prodID any) *gomock.Call {
+func (mr *MockCartManagerMockRecorder) RemoveFromCart(cartID, prodID, variantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCartManager)(nil).RemoveFromCart), cartID, prodID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCartManager)(nil).RemoveFromCart), cartID, prodID, variantID)
 }
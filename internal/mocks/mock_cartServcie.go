@@ -41,17 +41,17 @@ func (m *MockCartServiceManager) EXPECT() *MockCartServiceManagerMockRecorder {
 }
 
 // AddToCart mocks base method.
-func (m *MockCartServiceManager) AddToCart(userID, prodID string) error {
+func (m *MockCartServiceManager) AddToCart(userID, prodID, variantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToCart", userID, prodID)
+	ret := m.ctrl.Call(m, "AddToCart", userID, prodID, variantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddToCart indicates an expected call of AddToCart.
-func (mr *MockCartServiceManagerMockRecorder) AddToCart(userID, prodID any) *gomock.Call {
+func (mr *MockCartServiceManagerMockRecorder) AddToCart(userID, prodID, variantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartServiceManager)(nil).AddToCart), userID, prodID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToCart", reflect.TypeOf((*MockCartServiceManager)(nil).AddToCart), userID, prodID, variantID)
 }
 
 // Checkout mocks base method.
@@ -85,15 +85,15 @@ func (mr *MockCartServiceManagerMockRecorder) GetCartItems(userID any) *gomock.C
 }
 
 // RemoveFromCart mocks base method.
-func (m *MockCartServiceManager) RemoveFromCart(userID, prodID string) error {
+func (m *MockCartServiceManager) RemoveFromCart(userID, prodID, variantID string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveFromCart", userID, prodID)
+	ret := m.ctrl.Call(m, "RemoveFromCart", userID, prodID, variantID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveFromCart indicates an expected call of RemoveFromCart.
-func (mr *MockCartServiceManagerMockRecorder) RemoveFromCart(userID, prodID any) *gomock.Call {
+func (mr *MockCartServiceManagerMockRecorder) RemoveFromCart(userID, prodID, variantID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCartServiceManager)(nil).RemoveFromCart), userID, prodID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromCart", reflect.TypeOf((*MockCartServiceManager)(nil).RemoveFromCart), userID, prodID, variantID)
 }
@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_categoryRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCategoryManager is a mock of CategoryManager interface.
+type MockCategoryManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockCategoryManagerMockRecorder is the mock recorder for MockCategoryManager.
+type MockCategoryManagerMockRecorder struct {
+	mock *MockCategoryManager
+}
+
+// NewMockCategoryManager creates a new mock instance.
+func NewMockCategoryManager(ctrl *gomock.Controller) *MockCategoryManager {
+	mock := &MockCategoryManager{ctrl: ctrl}
+	mock.recorder = &MockCategoryManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryManager) EXPECT() *MockCategoryManagerMockRecorder {
+	return m.recorder
+}
+
+// CountChildren mocks base method.
+func (m *MockCategoryManager) CountChildren(id string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildren", id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockCategoryManagerMockRecorder) CountChildren(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockCategoryManager)(nil).CountChildren), id)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryManager) DeleteCategory(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryManagerMockRecorder) DeleteCategory(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryManager)(nil).DeleteCategory), id)
+}
+
+// GetCategoryByID mocks base method.
+func (m *MockCategoryManager) GetCategoryByID(id string) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryByID", id)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryByID indicates an expected call of GetCategoryByID.
+func (mr *MockCategoryManagerMockRecorder) GetCategoryByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryByID", reflect.TypeOf((*MockCategoryManager)(nil).GetCategoryByID), id)
+}
+
+// GetCategoryBySlug mocks base method.
+func (m *MockCategoryManager) GetCategoryBySlug(slug string) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryBySlug", slug)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryBySlug indicates an expected call of GetCategoryBySlug.
+func (mr *MockCategoryManagerMockRecorder) GetCategoryBySlug(slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryBySlug", reflect.TypeOf((*MockCategoryManager)(nil).GetCategoryBySlug), slug)
+}
+
+// GetProductCategories mocks base method.
+func (m *MockCategoryManager) GetProductCategories(productID string) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductCategories", productID)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductCategories indicates an expected call of GetProductCategories.
+func (mr *MockCategoryManagerMockRecorder) GetProductCategories(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCategories", reflect.TypeOf((*MockCategoryManager)(nil).GetProductCategories), productID)
+}
+
+// ListCategories mocks base method.
+func (m *MockCategoryManager) ListCategories() ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories")
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockCategoryManagerMockRecorder) ListCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryManager)(nil).ListCategories))
+}
+
+// SaveCategory mocks base method.
+func (m *MockCategoryManager) SaveCategory(category models.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCategory", category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCategory indicates an expected call of SaveCategory.
+func (mr *MockCategoryManagerMockRecorder) SaveCategory(category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCategory", reflect.TypeOf((*MockCategoryManager)(nil).SaveCategory), category)
+}
+
+// SetProductCategories mocks base method.
+func (m *MockCategoryManager) SetProductCategories(productID string, categoryIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductCategories", productID, categoryIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProductCategories indicates an expected call of SetProductCategories.
+func (mr *MockCategoryManagerMockRecorder) SetProductCategories(productID, categoryIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductCategories", reflect.TypeOf((*MockCategoryManager)(nil).SetProductCategories), productID, categoryIDs)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryManager) UpdateCategory(category models.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryManagerMockRecorder) UpdateCategory(category any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryManager)(nil).UpdateCategory), category)
+}
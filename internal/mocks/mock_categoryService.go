@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_categoryService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCategoryServiceManager is a mock of CategoryServiceManager interface.
+type MockCategoryServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockCategoryServiceManagerMockRecorder is the mock recorder for MockCategoryServiceManager.
+type MockCategoryServiceManagerMockRecorder struct {
+	mock *MockCategoryServiceManager
+}
+
+// NewMockCategoryServiceManager creates a new mock instance.
+func NewMockCategoryServiceManager(ctrl *gomock.Controller) *MockCategoryServiceManager {
+	mock := &MockCategoryServiceManager{ctrl: ctrl}
+	mock.recorder = &MockCategoryServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryServiceManager) EXPECT() *MockCategoryServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockCategoryServiceManager) CreateCategory(req dto.CategoryDTO) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", req)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockCategoryServiceManagerMockRecorder) CreateCategory(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockCategoryServiceManager)(nil).CreateCategory), req)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockCategoryServiceManager) DeleteCategory(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockCategoryServiceManagerMockRecorder) DeleteCategory(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockCategoryServiceManager)(nil).DeleteCategory), id)
+}
+
+// GetCategory mocks base method.
+func (m *MockCategoryServiceManager) GetCategory(id string) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", id)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockCategoryServiceManagerMockRecorder) GetCategory(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockCategoryServiceManager)(nil).GetCategory), id)
+}
+
+// GetCategoryTree mocks base method.
+func (m *MockCategoryServiceManager) GetCategoryTree() ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryTree")
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryTree indicates an expected call of GetCategoryTree.
+func (mr *MockCategoryServiceManagerMockRecorder) GetCategoryTree() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryTree", reflect.TypeOf((*MockCategoryServiceManager)(nil).GetCategoryTree))
+}
+
+// GetProductCategories mocks base method.
+func (m *MockCategoryServiceManager) GetProductCategories(productID string) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductCategories", productID)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductCategories indicates an expected call of GetProductCategories.
+func (mr *MockCategoryServiceManagerMockRecorder) GetProductCategories(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductCategories", reflect.TypeOf((*MockCategoryServiceManager)(nil).GetProductCategories), productID)
+}
+
+// ListCategories mocks base method.
+func (m *MockCategoryServiceManager) ListCategories() ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories")
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockCategoryServiceManagerMockRecorder) ListCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockCategoryServiceManager)(nil).ListCategories))
+}
+
+// SetProductCategories mocks base method.
+func (m *MockCategoryServiceManager) SetProductCategories(productID string, slugs []string) ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProductCategories", productID, slugs)
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetProductCategories indicates an expected call of SetProductCategories.
+func (mr *MockCategoryServiceManagerMockRecorder) SetProductCategories(productID, slugs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProductCategories", reflect.TypeOf((*MockCategoryServiceManager)(nil).SetProductCategories), productID, slugs)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockCategoryServiceManager) UpdateCategory(id string, req dto.CategoryDTO) (models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", id, req)
+	ret0, _ := ret[0].(models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockCategoryServiceManagerMockRecorder) UpdateCategory(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockCategoryServiceManager)(nil).UpdateCategory), id, req)
+}
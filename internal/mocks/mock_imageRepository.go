@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_imageRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImageManager is a mock of ImageManager interface.
+type MockImageManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockImageManagerMockRecorder is the mock recorder for MockImageManager.
+type MockImageManagerMockRecorder struct {
+	mock *MockImageManager
+}
+
+// NewMockImageManager creates a new mock instance.
+func NewMockImageManager(ctrl *gomock.Controller) *MockImageManager {
+	mock := &MockImageManager{ctrl: ctrl}
+	mock.recorder = &MockImageManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageManager) EXPECT() *MockImageManagerMockRecorder {
+	return m.recorder
+}
+
+// DeleteImage mocks base method.
+func (m *MockImageManager) DeleteImage(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockImageManagerMockRecorder) DeleteImage(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockImageManager)(nil).DeleteImage), id)
+}
+
+// GetImage mocks base method.
+func (m *MockImageManager) GetImage(id string) (models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImage", id)
+	ret0, _ := ret[0].(models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImage indicates an expected call of GetImage.
+func (mr *MockImageManagerMockRecorder) GetImage(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImage", reflect.TypeOf((*MockImageManager)(nil).GetImage), id)
+}
+
+// ListImages mocks base method.
+func (m *MockImageManager) ListImages(productID string) ([]models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImages", productID)
+	ret0, _ := ret[0].([]models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImages indicates an expected call of ListImages.
+func (mr *MockImageManagerMockRecorder) ListImages(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockImageManager)(nil).ListImages), productID)
+}
+
+// ListImagesForProducts mocks base method.
+func (m *MockImageManager) ListImagesForProducts(productIDs []string) (map[string][]models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImagesForProducts", productIDs)
+	ret0, _ := ret[0].(map[string][]models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImagesForProducts indicates an expected call of ListImagesForProducts.
+func (mr *MockImageManagerMockRecorder) ListImagesForProducts(productIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesForProducts", reflect.TypeOf((*MockImageManager)(nil).ListImagesForProducts), productIDs)
+}
+
+// SaveImage mocks base method.
+func (m *MockImageManager) SaveImage(image models.ProductImage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveImage", image)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveImage indicates an expected call of SaveImage.
+func (mr *MockImageManagerMockRecorder) SaveImage(image any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveImage", reflect.TypeOf((*MockImageManager)(nil).SaveImage), image)
+}
+
+// SetPrimary mocks base method.
+func (m *MockImageManager) SetPrimary(productID, imageID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimary", productID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrimary indicates an expected call of SetPrimary.
+func (mr *MockImageManagerMockRecorder) SetPrimary(productID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimary", reflect.TypeOf((*MockImageManager)(nil).SetPrimary), productID, imageID)
+}
+
+// UpdatePositions mocks base method.
+func (m *MockImageManager) UpdatePositions(productID string, imageIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePositions", productID, imageIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePositions indicates an expected call of UpdatePositions.
+func (mr *MockImageManagerMockRecorder) UpdatePositions(productID, imageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePositions", reflect.TypeOf((*MockImageManager)(nil).UpdatePositions), productID, imageIDs)
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_imageService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImageServiceManager is a mock of ImageServiceManager interface.
+type MockImageServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockImageServiceManagerMockRecorder is the mock recorder for MockImageServiceManager.
+type MockImageServiceManagerMockRecorder struct {
+	mock *MockImageServiceManager
+}
+
+// NewMockImageServiceManager creates a new mock instance.
+func NewMockImageServiceManager(ctrl *gomock.Controller) *MockImageServiceManager {
+	mock := &MockImageServiceManager{ctrl: ctrl}
+	mock.recorder = &MockImageServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageServiceManager) EXPECT() *MockImageServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// DeleteImage mocks base method.
+func (m *MockImageServiceManager) DeleteImage(productID, imageID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteImage", productID, imageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteImage indicates an expected call of DeleteImage.
+func (mr *MockImageServiceManagerMockRecorder) DeleteImage(productID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImage", reflect.TypeOf((*MockImageServiceManager)(nil).DeleteImage), productID, imageID)
+}
+
+// DeleteImageBlobs mocks base method.
+func (m *MockImageServiceManager) DeleteImageBlobs(images []models.ProductImage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteImageBlobs", images)
+}
+
+// DeleteImageBlobs indicates an expected call of DeleteImageBlobs.
+func (mr *MockImageServiceManagerMockRecorder) DeleteImageBlobs(images any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteImageBlobs", reflect.TypeOf((*MockImageServiceManager)(nil).DeleteImageBlobs), images)
+}
+
+// ImagesFor mocks base method.
+func (m *MockImageServiceManager) ImagesFor(productIDs []string) (map[string][]models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImagesFor", productIDs)
+	ret0, _ := ret[0].(map[string][]models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImagesFor indicates an expected call of ImagesFor.
+func (mr *MockImageServiceManagerMockRecorder) ImagesFor(productIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImagesFor", reflect.TypeOf((*MockImageServiceManager)(nil).ImagesFor), productIDs)
+}
+
+// ListImages mocks base method.
+func (m *MockImageServiceManager) ListImages(productID string) ([]models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImages", productID)
+	ret0, _ := ret[0].([]models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImages indicates an expected call of ListImages.
+func (mr *MockImageServiceManagerMockRecorder) ListImages(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImages", reflect.TypeOf((*MockImageServiceManager)(nil).ListImages), productID)
+}
+
+// ReorderImages mocks base method.
+func (m *MockImageServiceManager) ReorderImages(productID string, imageIDs []string) ([]models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderImages", productID, imageIDs)
+	ret0, _ := ret[0].([]models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderImages indicates an expected call of ReorderImages.
+func (mr *MockImageServiceManagerMockRecorder) ReorderImages(productID, imageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderImages", reflect.TypeOf((*MockImageServiceManager)(nil).ReorderImages), productID, imageIDs)
+}
+
+// SetPrimaryImage mocks base method.
+func (m *MockImageServiceManager) SetPrimaryImage(productID, imageID string) ([]models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrimaryImage", productID, imageID)
+	ret0, _ := ret[0].([]models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetPrimaryImage indicates an expected call of SetPrimaryImage.
+func (mr *MockImageServiceManagerMockRecorder) SetPrimaryImage(productID, imageID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrimaryImage", reflect.TypeOf((*MockImageServiceManager)(nil).SetPrimaryImage), productID, imageID)
+}
+
+// UploadImage mocks base method.
+func (m *MockImageServiceManager) UploadImage(productID string, data []byte) (models.ProductImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadImage", productID, data)
+	ret0, _ := ret[0].(models.ProductImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadImage indicates an expected call of UploadImage.
+func (mr *MockImageServiceManagerMockRecorder) UploadImage(productID, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadImage", reflect.TypeOf((*MockImageServiceManager)(nil).UploadImage), productID, data)
+}
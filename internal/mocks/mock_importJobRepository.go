@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_importJobRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportJobManager is a mock of ImportJobManager interface.
+type MockImportJobManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportJobManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockImportJobManagerMockRecorder is the mock recorder for MockImportJobManager.
+type MockImportJobManagerMockRecorder struct {
+	mock *MockImportJobManager
+}
+
+// NewMockImportJobManager creates a new mock instance.
+func NewMockImportJobManager(ctrl *gomock.Controller) *MockImportJobManager {
+	mock := &MockImportJobManager{ctrl: ctrl}
+	mock.recorder = &MockImportJobManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportJobManager) EXPECT() *MockImportJobManagerMockRecorder {
+	return m.recorder
+}
+
+// FailRunningJobs mocks base method.
+func (m *MockImportJobManager) FailRunningJobs() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailRunningJobs")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailRunningJobs indicates an expected call of FailRunningJobs.
+func (mr *MockImportJobManagerMockRecorder) FailRunningJobs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailRunningJobs", reflect.TypeOf((*MockImportJobManager)(nil).FailRunningJobs))
+}
+
+// GetJob mocks base method.
+func (m *MockImportJobManager) GetJob(id string) (models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", id)
+	ret0, _ := ret[0].(models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockImportJobManagerMockRecorder) GetJob(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockImportJobManager)(nil).GetJob), id)
+}
+
+// SaveJob mocks base method.
+func (m *MockImportJobManager) SaveJob(job models.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveJob", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveJob indicates an expected call of SaveJob.
+func (mr *MockImportJobManagerMockRecorder) SaveJob(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveJob", reflect.TypeOf((*MockImportJobManager)(nil).SaveJob), job)
+}
+
+// UpdateJob mocks base method.
+func (m *MockImportJobManager) UpdateJob(job models.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJob", job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJob indicates an expected call of UpdateJob.
+func (mr *MockImportJobManagerMockRecorder) UpdateJob(job any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJob", reflect.TypeOf((*MockImportJobManager)(nil).UpdateJob), job)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_inventoryRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInventoryManager is a mock of InventoryManager interface.
+type MockInventoryManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryManagerMockRecorder is the mock recorder for MockInventoryManager.
+type MockInventoryManagerMockRecorder struct {
+	mock *MockInventoryManager
+}
+
+// NewMockInventoryManager creates a new mock instance.
+func NewMockInventoryManager(ctrl *gomock.Controller) *MockInventoryManager {
+	mock := &MockInventoryManager{ctrl: ctrl}
+	mock.recorder = &MockInventoryManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryManager) EXPECT() *MockInventoryManagerMockRecorder {
+	return m.recorder
+}
+
+// ListMovements mocks base method.
+func (m *MockInventoryManager) ListMovements(productID string, filter models.StockMovementFilter) ([]models.StockMovement, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", productID, filter)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockInventoryManagerMockRecorder) ListMovements(productID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockInventoryManager)(nil).ListMovements), productID, filter)
+}
+
+// MoveStock mocks base method.
+func (m *MockInventoryManager) MoveStock(movements []models.StockMovement) ([]models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveStock", movements)
+	ret0, _ := ret[0].([]models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveStock indicates an expected call of MoveStock.
+func (mr *MockInventoryManagerMockRecorder) MoveStock(movements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveStock", reflect.TypeOf((*MockInventoryManager)(nil).MoveStock), movements)
+}
+
+// RecordBalance mocks base method.
+func (m *MockInventoryManager) RecordBalance(movement models.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordBalance", movement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordBalance indicates an expected call of RecordBalance.
+func (mr *MockInventoryManagerMockRecorder) RecordBalance(movement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBalance", reflect.TypeOf((*MockInventoryManager)(nil).RecordBalance), movement)
+}
+
+// SetStock mocks base method.
+func (m *MockInventoryManager) SetStock(movement models.StockMovement, count int) (models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStock", movement, count)
+	ret0, _ := ret[0].(models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStock indicates an expected call of SetStock.
+func (mr *MockInventoryManagerMockRecorder) SetStock(movement, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStock", reflect.TypeOf((*MockInventoryManager)(nil).SetStock), movement, count)
+}
+
+// StockDrift mocks base method.
+func (m *MockInventoryManager) StockDrift() ([]models.StockDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StockDrift")
+	ret0, _ := ret[0].([]models.StockDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StockDrift indicates an expected call of StockDrift.
+func (mr *MockInventoryManagerMockRecorder) StockDrift() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StockDrift", reflect.TypeOf((*MockInventoryManager)(nil).StockDrift))
+}
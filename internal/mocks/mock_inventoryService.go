@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_inventoryService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInventoryServiceManager is a mock of InventoryServiceManager interface.
+type MockInventoryServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockInventoryServiceManagerMockRecorder is the mock recorder for MockInventoryServiceManager.
+type MockInventoryServiceManagerMockRecorder struct {
+	mock *MockInventoryServiceManager
+}
+
+// NewMockInventoryServiceManager creates a new mock instance.
+func NewMockInventoryServiceManager(ctrl *gomock.Controller) *MockInventoryServiceManager {
+	mock := &MockInventoryServiceManager{ctrl: ctrl}
+	mock.recorder = &MockInventoryServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryServiceManager) EXPECT() *MockInventoryServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockInventoryServiceManager) AdjustStock(adminID, productID string, req dto.StockAdjustmentDTO) (models.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", adminID, productID, req)
+	ret0, _ := ret[0].(models.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockInventoryServiceManagerMockRecorder) AdjustStock(adminID, productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockInventoryServiceManager)(nil).AdjustStock), adminID, productID, req)
+}
+
+// ListStockMovements mocks base method.
+func (m *MockInventoryServiceManager) ListStockMovements(productID string, filter models.StockMovementFilter) (dto.StockMovementListDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockMovements", productID, filter)
+	ret0, _ := ret[0].(dto.StockMovementListDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockMovements indicates an expected call of ListStockMovements.
+func (mr *MockInventoryServiceManagerMockRecorder) ListStockMovements(productID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockMovements", reflect.TypeOf((*MockInventoryServiceManager)(nil).ListStockMovements), productID, filter)
+}
+
+// ReconcileStock mocks base method.
+func (m *MockInventoryServiceManager) ReconcileStock() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileStock")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileStock indicates an expected call of ReconcileStock.
+func (mr *MockInventoryServiceManagerMockRecorder) ReconcileStock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileStock", reflect.TypeOf((*MockInventoryServiceManager)(nil).ReconcileStock))
+}
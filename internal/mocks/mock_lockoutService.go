@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_lockoutService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLockoutServiceManager is a mock of LockoutServiceManager interface.
+type MockLockoutServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockoutServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockLockoutServiceManagerMockRecorder is the mock recorder for MockLockoutServiceManager.
+type MockLockoutServiceManagerMockRecorder struct {
+	mock *MockLockoutServiceManager
+}
+
+// NewMockLockoutServiceManager creates a new mock instance.
+func NewMockLockoutServiceManager(ctrl *gomock.Controller) *MockLockoutServiceManager {
+	mock := &MockLockoutServiceManager{ctrl: ctrl}
+	mock.recorder = &MockLockoutServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockoutServiceManager) EXPECT() *MockLockoutServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLockoutServiceManager) Check(email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLockoutServiceManagerMockRecorder) Check(email, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLockoutServiceManager)(nil).Check), email, ip)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLockoutServiceManager) RecordFailure(email, ip string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", email, ip)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLockoutServiceManagerMockRecorder) RecordFailure(email, ip any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLockoutServiceManager)(nil).RecordFailure), email, ip)
+}
+
+// RecordSuccess mocks base method.
+func (m *MockLockoutServiceManager) RecordSuccess(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSuccess", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordSuccess indicates an expected call of RecordSuccess.
+func (mr *MockLockoutServiceManagerMockRecorder) RecordSuccess(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSuccess", reflect.TypeOf((*MockLockoutServiceManager)(nil).RecordSuccess), email)
+}
+
+// Unlock mocks base method.
+func (m *MockLockoutServiceManager) Unlock(adminID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", adminID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockLockoutServiceManagerMockRecorder) Unlock(adminID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLockoutServiceManager)(nil).Unlock), adminID, userID)
+}
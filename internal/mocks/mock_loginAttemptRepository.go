@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_loginAttemptRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLoginAttemptManager is a mock of LoginAttemptManager interface.
+type MockLoginAttemptManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockLoginAttemptManagerMockRecorder is the mock recorder for MockLoginAttemptManager.
+type MockLoginAttemptManagerMockRecorder struct {
+	mock *MockLoginAttemptManager
+}
+
+// NewMockLoginAttemptManager creates a new mock instance.
+func NewMockLoginAttemptManager(ctrl *gomock.Controller) *MockLoginAttemptManager {
+	mock := &MockLoginAttemptManager{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptManager) EXPECT() *MockLoginAttemptManagerMockRecorder {
+	return m.recorder
+}
+
+// DeleteLoginAttempt mocks base method.
+func (m *MockLoginAttemptManager) DeleteLoginAttempt(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginAttempt", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginAttempt indicates an expected call of DeleteLoginAttempt.
+func (mr *MockLoginAttemptManagerMockRecorder) DeleteLoginAttempt(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginAttempt", reflect.TypeOf((*MockLoginAttemptManager)(nil).DeleteLoginAttempt), key)
+}
+
+// DeleteStaleLoginAttempts mocks base method.
+func (m *MockLoginAttemptManager) DeleteStaleLoginAttempts(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleLoginAttempts", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStaleLoginAttempts indicates an expected call of DeleteStaleLoginAttempts.
+func (mr *MockLoginAttemptManagerMockRecorder) DeleteStaleLoginAttempts(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleLoginAttempts", reflect.TypeOf((*MockLoginAttemptManager)(nil).DeleteStaleLoginAttempts), before)
+}
+
+// GetLoginAttempt mocks base method.
+func (m *MockLoginAttemptManager) GetLoginAttempt(key string) (models.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempt", key)
+	ret0, _ := ret[0].(models.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttempt indicates an expected call of GetLoginAttempt.
+func (mr *MockLoginAttemptManagerMockRecorder) GetLoginAttempt(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempt", reflect.TypeOf((*MockLoginAttemptManager)(nil).GetLoginAttempt), key)
+}
+
+// SaveLoginAttempt mocks base method.
+func (m *MockLoginAttemptManager) SaveLoginAttempt(attempt models.LoginAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLoginAttempt", attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLoginAttempt indicates an expected call of SaveLoginAttempt.
+func (mr *MockLoginAttemptManagerMockRecorder) SaveLoginAttempt(attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLoginAttempt", reflect.TypeOf((*MockLoginAttemptManager)(nil).SaveLoginAttempt), attempt)
+}
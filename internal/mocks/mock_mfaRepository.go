@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_mfaRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMFAManager is a mock of MFAManager interface.
+type MockMFAManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockMFAManagerMockRecorder is the mock recorder for MockMFAManager.
+type MockMFAManagerMockRecorder struct {
+	mock *MockMFAManager
+}
+
+// NewMockMFAManager creates a new mock instance.
+func NewMockMFAManager(ctrl *gomock.Controller) *MockMFAManager {
+	mock := &MockMFAManager{ctrl: ctrl}
+	mock.recorder = &MockMFAManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAManager) EXPECT() *MockMFAManagerMockRecorder {
+	return m.recorder
+}
+
+// DeleteMFA mocks base method.
+func (m *MockMFAManager) DeleteMFA(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMFA", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMFA indicates an expected call of DeleteMFA.
+func (mr *MockMFAManagerMockRecorder) DeleteMFA(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMFA", reflect.TypeOf((*MockMFAManager)(nil).DeleteMFA), userID)
+}
+
+// EnableMFA mocks base method.
+func (m *MockMFAManager) EnableMFA(userID string, enabledAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableMFA", userID, enabledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableMFA indicates an expected call of EnableMFA.
+func (mr *MockMFAManagerMockRecorder) EnableMFA(userID, enabledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableMFA", reflect.TypeOf((*MockMFAManager)(nil).EnableMFA), userID, enabledAt)
+}
+
+// GetMFAByUserID mocks base method.
+func (m *MockMFAManager) GetMFAByUserID(userID string) (models.UserMFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMFAByUserID", userID)
+	ret0, _ := ret[0].(models.UserMFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMFAByUserID indicates an expected call of GetMFAByUserID.
+func (mr *MockMFAManagerMockRecorder) GetMFAByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMFAByUserID", reflect.TypeOf((*MockMFAManager)(nil).GetMFAByUserID), userID)
+}
+
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockMFAManager) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", userID, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockMFAManagerMockRecorder) ReplaceRecoveryCodes(userID, codeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockMFAManager)(nil).ReplaceRecoveryCodes), userID, codeHashes)
+}
+
+// SaveMFASecret mocks base method.
+func (m *MockMFAManager) SaveMFASecret(userID, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMFASecret", userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMFASecret indicates an expected call of SaveMFASecret.
+func (mr *MockMFAManagerMockRecorder) SaveMFASecret(userID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMFASecret", reflect.TypeOf((*MockMFAManager)(nil).SaveMFASecret), userID, secret)
+}
+
+// UpdateLastUsedStep mocks base method.
+func (m *MockMFAManager) UpdateLastUsedStep(userID string, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsedStep", userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsedStep indicates an expected call of UpdateLastUsedStep.
+func (mr *MockMFAManagerMockRecorder) UpdateLastUsedStep(userID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedStep", reflect.TypeOf((*MockMFAManager)(nil).UpdateLastUsedStep), userID, step)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMFAManager) UseRecoveryCode(userID, codeHash string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", userID, codeHash, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMFAManagerMockRecorder) UseRecoveryCode(userID, codeHash, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMFAManager)(nil).UseRecoveryCode), userID, codeHash, usedAt)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_mfaService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMFAServiceManager is a mock of MFAServiceManager interface.
+type MockMFAServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockMFAServiceManagerMockRecorder is the mock recorder for MockMFAServiceManager.
+type MockMFAServiceManagerMockRecorder struct {
+	mock *MockMFAServiceManager
+}
+
+// NewMockMFAServiceManager creates a new mock instance.
+func NewMockMFAServiceManager(ctrl *gomock.Controller) *MockMFAServiceManager {
+	mock := &MockMFAServiceManager{ctrl: ctrl}
+	mock.recorder = &MockMFAServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAServiceManager) EXPECT() *MockMFAServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// BeginEnrollment mocks base method.
+func (m *MockMFAServiceManager) BeginEnrollment(userID string) (dto.MFAEnrollmentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginEnrollment", userID)
+	ret0, _ := ret[0].(dto.MFAEnrollmentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginEnrollment indicates an expected call of BeginEnrollment.
+func (mr *MockMFAServiceManagerMockRecorder) BeginEnrollment(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginEnrollment", reflect.TypeOf((*MockMFAServiceManager)(nil).BeginEnrollment), userID)
+}
+
+// ConfirmEnrollment mocks base method.
+func (m *MockMFAServiceManager) ConfirmEnrollment(userID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEnrollment", userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEnrollment indicates an expected call of ConfirmEnrollment.
+func (mr *MockMFAServiceManagerMockRecorder) ConfirmEnrollment(userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEnrollment", reflect.TypeOf((*MockMFAServiceManager)(nil).ConfirmEnrollment), userID, code)
+}
+
+// Disable mocks base method.
+func (m *MockMFAServiceManager) Disable(userID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockMFAServiceManagerMockRecorder) Disable(userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockMFAServiceManager)(nil).Disable), userID, code)
+}
+
+// IsEnabled mocks base method.
+func (m *MockMFAServiceManager) IsEnabled(userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled", userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockMFAServiceManagerMockRecorder) IsEnabled(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockMFAServiceManager)(nil).IsEnabled), userID)
+}
+
+// IsRequired mocks base method.
+func (m *MockMFAServiceManager) IsRequired(user models.User) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRequired", user)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsRequired indicates an expected call of IsRequired.
+func (mr *MockMFAServiceManagerMockRecorder) IsRequired(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRequired", reflect.TypeOf((*MockMFAServiceManager)(nil).IsRequired), user)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockMFAServiceManager) RegenerateRecoveryCodes(userID, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", userID, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockMFAServiceManagerMockRecorder) RegenerateRecoveryCodes(userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockMFAServiceManager)(nil).RegenerateRecoveryCodes), userID, code)
+}
+
+// VerifyCode mocks base method.
+func (m *MockMFAServiceManager) VerifyCode(userID, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCode", userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyCode indicates an expected call of VerifyCode.
+func (mr *MockMFAServiceManagerMockRecorder) VerifyCode(userID, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCode", reflect.TypeOf((*MockMFAServiceManager)(nil).VerifyCode), userID, code)
+}
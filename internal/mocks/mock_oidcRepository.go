@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_oidcRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOIDCManager is a mock of OIDCManager interface.
+type MockOIDCManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockOIDCManagerMockRecorder is the mock recorder for MockOIDCManager.
+type MockOIDCManagerMockRecorder struct {
+	mock *MockOIDCManager
+}
+
+// NewMockOIDCManager creates a new mock instance.
+func NewMockOIDCManager(ctrl *gomock.Controller) *MockOIDCManager {
+	mock := &MockOIDCManager{ctrl: ctrl}
+	mock.recorder = &MockOIDCManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCManager) EXPECT() *MockOIDCManagerMockRecorder {
+	return m.recorder
+}
+
+// ConsumeLoginState mocks base method.
+func (m *MockOIDCManager) ConsumeLoginState(stateHash string) (models.OIDCLoginState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeLoginState", stateHash)
+	ret0, _ := ret[0].(models.OIDCLoginState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeLoginState indicates an expected call of ConsumeLoginState.
+func (mr *MockOIDCManagerMockRecorder) ConsumeLoginState(stateHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeLoginState", reflect.TypeOf((*MockOIDCManager)(nil).ConsumeLoginState), stateHash)
+}
+
+// DeleteExpiredLoginStates mocks base method.
+func (m *MockOIDCManager) DeleteExpiredLoginStates(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredLoginStates", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredLoginStates indicates an expected call of DeleteExpiredLoginStates.
+func (mr *MockOIDCManagerMockRecorder) DeleteExpiredLoginStates(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredLoginStates", reflect.TypeOf((*MockOIDCManager)(nil).DeleteExpiredLoginStates), before)
+}
+
+// GetIdentity mocks base method.
+func (m *MockOIDCManager) GetIdentity(issuer, subject string) (models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", issuer, subject)
+	ret0, _ := ret[0].(models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockOIDCManagerMockRecorder) GetIdentity(issuer, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockOIDCManager)(nil).GetIdentity), issuer, subject)
+}
+
+// SaveIdentity mocks base method.
+func (m *MockOIDCManager) SaveIdentity(identity models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdentity", identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdentity indicates an expected call of SaveIdentity.
+func (mr *MockOIDCManagerMockRecorder) SaveIdentity(identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdentity", reflect.TypeOf((*MockOIDCManager)(nil).SaveIdentity), identity)
+}
+
+// SaveLoginState mocks base method.
+func (m *MockOIDCManager) SaveLoginState(state models.OIDCLoginState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLoginState", state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLoginState indicates an expected call of SaveLoginState.
+func (mr *MockOIDCManagerMockRecorder) SaveLoginState(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLoginState", reflect.TypeOf((*MockOIDCManager)(nil).SaveLoginState), state)
+}
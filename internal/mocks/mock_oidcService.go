@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_oidcService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOIDCServiceManager is a mock of OIDCServiceManager interface.
+type MockOIDCServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockOIDCServiceManagerMockRecorder is the mock recorder for MockOIDCServiceManager.
+type MockOIDCServiceManagerMockRecorder struct {
+	mock *MockOIDCServiceManager
+}
+
+// NewMockOIDCServiceManager creates a new mock instance.
+func NewMockOIDCServiceManager(ctrl *gomock.Controller) *MockOIDCServiceManager {
+	mock := &MockOIDCServiceManager{ctrl: ctrl}
+	mock.recorder = &MockOIDCServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDCServiceManager) EXPECT() *MockOIDCServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// BeginLogin mocks base method.
+func (m *MockOIDCServiceManager) BeginLogin() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginLogin")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginLogin indicates an expected call of BeginLogin.
+func (mr *MockOIDCServiceManagerMockRecorder) BeginLogin() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginLogin", reflect.TypeOf((*MockOIDCServiceManager)(nil).BeginLogin))
+}
+
+// CompleteLogin mocks base method.
+func (m *MockOIDCServiceManager) CompleteLogin(state, code string, client models.ClientInfo) (dto.LoginResultDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteLogin", state, code, client)
+	ret0, _ := ret[0].(dto.LoginResultDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteLogin indicates an expected call of CompleteLogin.
+func (mr *MockOIDCServiceManagerMockRecorder) CompleteLogin(state, code, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteLogin", reflect.TypeOf((*MockOIDCServiceManager)(nil).CompleteLogin), state, code, client)
+}
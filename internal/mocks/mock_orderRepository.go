@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_orderRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderManager is a mock of OrderManager interface.
+type MockOrderManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderManagerMockRecorder is the mock recorder for MockOrderManager.
+type MockOrderManagerMockRecorder struct {
+	mock *MockOrderManager
+}
+
+// NewMockOrderManager creates a new mock instance.
+func NewMockOrderManager(ctrl *gomock.Controller) *MockOrderManager {
+	mock := &MockOrderManager{ctrl: ctrl}
+	mock.recorder = &MockOrderManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderManager) EXPECT() *MockOrderManagerMockRecorder {
+	return m.recorder
+}
+
+// CountProductOrders mocks base method.
+func (m *MockOrderManager) CountProductOrders(productID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductOrders", productID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductOrders indicates an expected call of CountProductOrders.
+func (mr *MockOrderManagerMockRecorder) CountProductOrders(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductOrders", reflect.TypeOf((*MockOrderManager)(nil).CountProductOrders), productID)
+}
+
+// GetOrdersByUserID mocks base method.
+func (m *MockOrderManager) GetOrdersByUserID(userID string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByUserID", userID)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByUserID indicates an expected call of GetOrdersByUserID.
+func (mr *MockOrderManagerMockRecorder) GetOrdersByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUserID", reflect.TypeOf((*MockOrderManager)(nil).GetOrdersByUserID), userID)
+}
+
+// ListOrders mocks base method.
+func (m *MockOrderManager) ListOrders(filter models.OrderFilter) ([]models.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", filter)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderManagerMockRecorder) ListOrders(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderManager)(nil).ListOrders), filter)
+}
+
+// SaveOrder mocks base method.
+func (m *MockOrderManager) SaveOrder(order models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrder", order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrder indicates an expected call of SaveOrder.
+func (mr *MockOrderManagerMockRecorder) SaveOrder(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockOrderManager)(nil).SaveOrder), order)
+}
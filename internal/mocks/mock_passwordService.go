@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_passwordService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPasswordServiceManager is a mock of PasswordServiceManager interface.
+type MockPasswordServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordServiceManagerMockRecorder is the mock recorder for MockPasswordServiceManager.
+type MockPasswordServiceManagerMockRecorder struct {
+	mock *MockPasswordServiceManager
+}
+
+// NewMockPasswordServiceManager creates a new mock instance.
+func NewMockPasswordServiceManager(ctrl *gomock.Controller) *MockPasswordServiceManager {
+	mock := &MockPasswordServiceManager{ctrl: ctrl}
+	mock.recorder = &MockPasswordServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordServiceManager) EXPECT() *MockPasswordServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockPasswordServiceManager) ChangePassword(userID, oldPassword, newPassword string, client models.ClientInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", userID, oldPassword, newPassword, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockPasswordServiceManagerMockRecorder) ChangePassword(userID, oldPassword, newPassword, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockPasswordServiceManager)(nil).ChangePassword), userID, oldPassword, newPassword, client)
+}
+
+// ForceReset mocks base method.
+func (m *MockPasswordServiceManager) ForceReset(adminID, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceReset", adminID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceReset indicates an expected call of ForceReset.
+func (mr *MockPasswordServiceManagerMockRecorder) ForceReset(adminID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceReset", reflect.TypeOf((*MockPasswordServiceManager)(nil).ForceReset), adminID, userID)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockPasswordServiceManager) ForgotPassword(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockPasswordServiceManagerMockRecorder) ForgotPassword(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockPasswordServiceManager)(nil).ForgotPassword), email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordServiceManager) ResetPassword(token, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", token, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordServiceManagerMockRecorder) ResetPassword(token, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordServiceManager)(nil).ResetPassword), token, newPassword)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_priceRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPriceManager is a mock of PriceManager interface.
+type MockPriceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceManagerMockRecorder is the mock recorder for MockPriceManager.
+type MockPriceManagerMockRecorder struct {
+	mock *MockPriceManager
+}
+
+// NewMockPriceManager creates a new mock instance.
+func NewMockPriceManager(ctrl *gomock.Controller) *MockPriceManager {
+	mock := &MockPriceManager{ctrl: ctrl}
+	mock.recorder = &MockPriceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceManager) EXPECT() *MockPriceManagerMockRecorder {
+	return m.recorder
+}
+
+// AddPriceChange mocks base method.
+func (m *MockPriceManager) AddPriceChange(change models.PriceChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPriceChange", change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPriceChange indicates an expected call of AddPriceChange.
+func (mr *MockPriceManagerMockRecorder) AddPriceChange(change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPriceChange", reflect.TypeOf((*MockPriceManager)(nil).AddPriceChange), change)
+}
+
+// ApplyScheduledPrice mocks base method.
+func (m *MockPriceManager) ApplyScheduledPrice(change models.PriceChange, status models.ScheduleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyScheduledPrice", change, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyScheduledPrice indicates an expected call of ApplyScheduledPrice.
+func (mr *MockPriceManagerMockRecorder) ApplyScheduledPrice(change, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyScheduledPrice", reflect.TypeOf((*MockPriceManager)(nil).ApplyScheduledPrice), change, status)
+}
+
+// DueSchedules mocks base method.
+func (m *MockPriceManager) DueSchedules(now time.Time) ([]models.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DueSchedules", now)
+	ret0, _ := ret[0].([]models.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DueSchedules indicates an expected call of DueSchedules.
+func (mr *MockPriceManagerMockRecorder) DueSchedules(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DueSchedules", reflect.TypeOf((*MockPriceManager)(nil).DueSchedules), now)
+}
+
+// GetSchedule mocks base method.
+func (m *MockPriceManager) GetSchedule(id string) (models.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", id)
+	ret0, _ := ret[0].(models.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockPriceManagerMockRecorder) GetSchedule(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockPriceManager)(nil).GetSchedule), id)
+}
+
+// ListPriceChanges mocks base method.
+func (m *MockPriceManager) ListPriceChanges(productID string) ([]models.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPriceChanges", productID)
+	ret0, _ := ret[0].([]models.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPriceChanges indicates an expected call of ListPriceChanges.
+func (mr *MockPriceManagerMockRecorder) ListPriceChanges(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPriceChanges", reflect.TypeOf((*MockPriceManager)(nil).ListPriceChanges), productID)
+}
+
+// ListSchedules mocks base method.
+func (m *MockPriceManager) ListSchedules(productID string) ([]models.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", productID)
+	ret0, _ := ret[0].([]models.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockPriceManagerMockRecorder) ListSchedules(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockPriceManager)(nil).ListSchedules), productID)
+}
+
+// SaveSchedule mocks base method.
+func (m *MockPriceManager) SaveSchedule(schedule models.PriceSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSchedule", schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSchedule indicates an expected call of SaveSchedule.
+func (mr *MockPriceManagerMockRecorder) SaveSchedule(schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSchedule", reflect.TypeOf((*MockPriceManager)(nil).SaveSchedule), schedule)
+}
+
+// UpdateScheduleStatus mocks base method.
+func (m *MockPriceManager) UpdateScheduleStatus(id string, status models.ScheduleStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduleStatus", id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduleStatus indicates an expected call of UpdateScheduleStatus.
+func (mr *MockPriceManagerMockRecorder) UpdateScheduleStatus(id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduleStatus", reflect.TypeOf((*MockPriceManager)(nil).UpdateScheduleStatus), id, status)
+}
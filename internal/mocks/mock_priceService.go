@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_priceService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPriceServiceManager is a mock of PriceServiceManager interface.
+type MockPriceServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceServiceManagerMockRecorder is the mock recorder for MockPriceServiceManager.
+type MockPriceServiceManagerMockRecorder struct {
+	mock *MockPriceServiceManager
+}
+
+// NewMockPriceServiceManager creates a new mock instance.
+func NewMockPriceServiceManager(ctrl *gomock.Controller) *MockPriceServiceManager {
+	mock := &MockPriceServiceManager{ctrl: ctrl}
+	mock.recorder = &MockPriceServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceServiceManager) EXPECT() *MockPriceServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// ApplyDueSchedules mocks base method.
+func (m *MockPriceServiceManager) ApplyDueSchedules(now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDueSchedules", now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyDueSchedules indicates an expected call of ApplyDueSchedules.
+func (mr *MockPriceServiceManagerMockRecorder) ApplyDueSchedules(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDueSchedules", reflect.TypeOf((*MockPriceServiceManager)(nil).ApplyDueSchedules), now)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockPriceServiceManager) CancelSchedule(productID, scheduleID string) (models.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", productID, scheduleID)
+	ret0, _ := ret[0].(models.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockPriceServiceManagerMockRecorder) CancelSchedule(productID, scheduleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockPriceServiceManager)(nil).CancelSchedule), productID, scheduleID)
+}
+
+// GetPriceTimeline mocks base method.
+func (m *MockPriceServiceManager) GetPriceTimeline(productID string) (dto.PriceTimelineDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceTimeline", productID)
+	ret0, _ := ret[0].(dto.PriceTimelineDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceTimeline indicates an expected call of GetPriceTimeline.
+func (mr *MockPriceServiceManagerMockRecorder) GetPriceTimeline(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceTimeline", reflect.TypeOf((*MockPriceServiceManager)(nil).GetPriceTimeline), productID)
+}
+
+// RunScheduler mocks base method.
+func (m *MockPriceServiceManager) RunScheduler(interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RunScheduler", interval)
+}
+
+// RunScheduler indicates an expected call of RunScheduler.
+func (mr *MockPriceServiceManagerMockRecorder) RunScheduler(interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunScheduler", reflect.TypeOf((*MockPriceServiceManager)(nil).RunScheduler), interval)
+}
+
+// SchedulePrice mocks base method.
+func (m *MockPriceServiceManager) SchedulePrice(adminID, productID string, req dto.PriceScheduleDTO) (models.PriceSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchedulePrice", adminID, productID, req)
+	ret0, _ := ret[0].(models.PriceSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchedulePrice indicates an expected call of SchedulePrice.
+func (mr *MockPriceServiceManagerMockRecorder) SchedulePrice(adminID, productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchedulePrice", reflect.TypeOf((*MockPriceServiceManager)(nil).SchedulePrice), adminID, productID, req)
+}
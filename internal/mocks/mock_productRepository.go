@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -41,32 +42,32 @@ func (m *MockProductManager) EXPECT() *MockProductManagerMockRecorder {
 }
 
 // AddProduct mocks base method.
-func (m *MockProductManager) AddProduct(arg0 models.Product) error {
+func (m *MockProductManager) AddProduct(product models.Product, opening *models.StockMovement) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddProduct", arg0)
+	ret := m.ctrl.Call(m, "AddProduct", product, opening)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddProduct indicates an expected call of AddProduct.
-func (mr *MockProductManagerMockRecorder) AddProduct(arg0 any) *gomock.Call {
+func (mr *MockProductManagerMockRecorder) AddProduct(product, opening any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockProductManager)(nil).AddProduct), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockProductManager)(nil).AddProduct), product, opening)
 }
 
-// GetAllProducts mocks base method.
-func (m *MockProductManager) GetAllProducts() ([]models.Product, error) {
+// ExportProducts mocks base method.
+func (m *MockProductManager) ExportProducts(afterID string, limit int) ([]models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProducts")
+	ret := m.ctrl.Call(m, "ExportProducts", afterID, limit)
 	ret0, _ := ret[0].([]models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllProducts indicates an expected call of GetAllProducts.
-func (mr *MockProductManagerMockRecorder) GetAllProducts() *gomock.Call {
+// ExportProducts indicates an expected call of ExportProducts.
+func (mr *MockProductManagerMockRecorder) ExportProducts(afterID, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockProductManager)(nil).GetAllProducts))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportProducts", reflect.TypeOf((*MockProductManager)(nil).ExportProducts), afterID, limit)
 }
 
 // GetProductByID mocks base method.
@@ -84,19 +85,49 @@ func (mr *MockProductManagerMockRecorder) GetProductByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductManager)(nil).GetProductByID), id)
 }
 
-// GetProductByName mocks base method.
-func (m *MockProductManager) GetProductByName(name *string) ([]models.Product, error) {
+// GetProductBySKU mocks base method.
+func (m *MockProductManager) GetProductBySKU(sku string) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductByName", name)
-	ret0, _ := ret[0].([]models.Product)
+	ret := m.ctrl.Call(m, "GetProductBySKU", sku)
+	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductByName indicates an expected call of GetProductByName.
-func (mr *MockProductManagerMockRecorder) GetProductByName(name any) *gomock.Call {
+// GetProductBySKU indicates an expected call of GetProductBySKU.
+func (mr *MockProductManagerMockRecorder) GetProductBySKU(sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBySKU", reflect.TypeOf((*MockProductManager)(nil).GetProductBySKU), sku)
+}
+
+// ListProducts mocks base method.
+func (m *MockProductManager) ListProducts(query models.ProductQuery) ([]models.Product, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", query)
+	ret0, _ := ret[0].([]models.Product)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockProductManagerMockRecorder) ListProducts(query any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByName", reflect.TypeOf((*MockProductManager)(nil).GetProductByName), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductManager)(nil).ListProducts), query)
+}
+
+// RebuildSearchIndex mocks base method.
+func (m *MockProductManager) RebuildSearchIndex() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildSearchIndex")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildSearchIndex indicates an expected call of RebuildSearchIndex.
+func (mr *MockProductManagerMockRecorder) RebuildSearchIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildSearchIndex", reflect.TypeOf((*MockProductManager)(nil).RebuildSearchIndex))
 }
 
 // RemoveProduct mocks base method.
@@ -113,6 +144,36 @@ func (mr *MockProductManagerMockRecorder) RemoveProduct(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveProduct", reflect.TypeOf((*MockProductManager)(nil).RemoveProduct), id)
 }
 
+// SearchProducts mocks base method.
+func (m *MockProductManager) SearchProducts(terms string, limit, offset int) ([]models.ProductSearchResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchProducts", terms, limit, offset)
+	ret0, _ := ret[0].([]models.ProductSearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchProducts indicates an expected call of SearchProducts.
+func (mr *MockProductManagerMockRecorder) SearchProducts(terms, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockProductManager)(nil).SearchProducts), terms, limit, offset)
+}
+
+// SetArchivedAt mocks base method.
+func (m *MockProductManager) SetArchivedAt(id string, archivedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArchivedAt", id, archivedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArchivedAt indicates an expected call of SetArchivedAt.
+func (mr *MockProductManagerMockRecorder) SetArchivedAt(id, archivedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArchivedAt", reflect.TypeOf((*MockProductManager)(nil).SetArchivedAt), id, archivedAt)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockProductManager) UpdateProduct(arg0 models.Product) error {
 	m.ctrl.T.Helper()
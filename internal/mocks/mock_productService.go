@@ -12,6 +12,7 @@ package mocks
 import (
 	reflect "reflect"
 
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,19 +41,19 @@ func (m *MockProductServiceManager) EXPECT() *MockProductServiceManagerMockRecor
 	return m.recorder
 }
 
-// GetAllProducts mocks base method.
-func (m *MockProductServiceManager) GetAllProducts() ([]models.Product, error) {
+// GetAdminProduct mocks base method.
+func (m *MockProductServiceManager) GetAdminProduct(id string) (models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllProducts")
-	ret0, _ := ret[0].([]models.Product)
+	ret := m.ctrl.Call(m, "GetAdminProduct", id)
+	ret0, _ := ret[0].(models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllProducts indicates an expected call of GetAllProducts.
-func (mr *MockProductServiceManagerMockRecorder) GetAllProducts() *gomock.Call {
+// GetAdminProduct indicates an expected call of GetAdminProduct.
+func (mr *MockProductServiceManagerMockRecorder) GetAdminProduct(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProducts", reflect.TypeOf((*MockProductServiceManager)(nil).GetAllProducts))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminProduct", reflect.TypeOf((*MockProductServiceManager)(nil).GetAdminProduct), id)
 }
 
 // GetProductByID mocks base method.
@@ -70,17 +71,32 @@ func (mr *MockProductServiceManagerMockRecorder) GetProductByID(id any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockProductServiceManager)(nil).GetProductByID), id)
 }
 
-// GetProductByName mocks base method.
-func (m *MockProductServiceManager) GetProductByName(name *string) ([]models.Product, error) {
+// ListProducts mocks base method.
+func (m *MockProductServiceManager) ListProducts(query models.ProductQuery) (dto.ProductListDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProducts", query)
+	ret0, _ := ret[0].(dto.ProductListDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockProductServiceManagerMockRecorder) ListProducts(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockProductServiceManager)(nil).ListProducts), query)
+}
+
+// SearchProducts mocks base method.
+func (m *MockProductServiceManager) SearchProducts(terms string, limit, offset int) (dto.ProductSearchDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProductByName", name)
-	ret0, _ := ret[0].([]models.Product)
+	ret := m.ctrl.Call(m, "SearchProducts", terms, limit, offset)
+	ret0, _ := ret[0].(dto.ProductSearchDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProductByName indicates an expected call of GetProductByName.
-func (mr *MockProductServiceManagerMockRecorder) GetProductByName(name any) *gomock.Call {
+// SearchProducts indicates an expected call of SearchProducts.
+func (mr *MockProductServiceManagerMockRecorder) SearchProducts(terms, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByName", reflect.TypeOf((*MockProductServiceManager)(nil).GetProductByName), name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchProducts", reflect.TypeOf((*MockProductServiceManager)(nil).SearchProducts), terms, limit, offset)
 }
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_resetTokenRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockResetTokenManager is a mock of ResetTokenManager interface.
+type MockResetTokenManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockResetTokenManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockResetTokenManagerMockRecorder is the mock recorder for MockResetTokenManager.
+type MockResetTokenManagerMockRecorder struct {
+	mock *MockResetTokenManager
+}
+
+// NewMockResetTokenManager creates a new mock instance.
+func NewMockResetTokenManager(ctrl *gomock.Controller) *MockResetTokenManager {
+	mock := &MockResetTokenManager{ctrl: ctrl}
+	mock.recorder = &MockResetTokenManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResetTokenManager) EXPECT() *MockResetTokenManagerMockRecorder {
+	return m.recorder
+}
+
+// DeleteResetTokensByUserID mocks base method.
+func (m *MockResetTokenManager) DeleteResetTokensByUserID(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteResetTokensByUserID", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteResetTokensByUserID indicates an expected call of DeleteResetTokensByUserID.
+func (mr *MockResetTokenManagerMockRecorder) DeleteResetTokensByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResetTokensByUserID", reflect.TypeOf((*MockResetTokenManager)(nil).DeleteResetTokensByUserID), userID)
+}
+
+// GetLatestResetToken mocks base method.
+func (m *MockResetTokenManager) GetLatestResetToken(userID string) (models.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestResetToken", userID)
+	ret0, _ := ret[0].(models.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestResetToken indicates an expected call of GetLatestResetToken.
+func (mr *MockResetTokenManagerMockRecorder) GetLatestResetToken(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestResetToken", reflect.TypeOf((*MockResetTokenManager)(nil).GetLatestResetToken), userID)
+}
+
+// GetResetTokenByHash mocks base method.
+func (m *MockResetTokenManager) GetResetTokenByHash(tokenHash string) (models.PasswordResetToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResetTokenByHash", tokenHash)
+	ret0, _ := ret[0].(models.PasswordResetToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResetTokenByHash indicates an expected call of GetResetTokenByHash.
+func (mr *MockResetTokenManagerMockRecorder) GetResetTokenByHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResetTokenByHash", reflect.TypeOf((*MockResetTokenManager)(nil).GetResetTokenByHash), tokenHash)
+}
+
+// MarkResetTokenUsed mocks base method.
+func (m *MockResetTokenManager) MarkResetTokenUsed(id string, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkResetTokenUsed", id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkResetTokenUsed indicates an expected call of MarkResetTokenUsed.
+func (mr *MockResetTokenManagerMockRecorder) MarkResetTokenUsed(id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkResetTokenUsed", reflect.TypeOf((*MockResetTokenManager)(nil).MarkResetTokenUsed), id, usedAt)
+}
+
+// SaveResetToken mocks base method.
+func (m *MockResetTokenManager) SaveResetToken(arg0 models.PasswordResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveResetToken", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveResetToken indicates an expected call of SaveResetToken.
+func (mr *MockResetTokenManagerMockRecorder) SaveResetToken(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveResetToken", reflect.TypeOf((*MockResetTokenManager)(nil).SaveResetToken), arg0)
+}
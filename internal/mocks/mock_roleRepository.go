@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_roleRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRoleManager is a mock of RoleManager interface.
+type MockRoleManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockRoleManagerMockRecorder is the mock recorder for MockRoleManager.
+type MockRoleManagerMockRecorder struct {
+	mock *MockRoleManager
+}
+
+// NewMockRoleManager creates a new mock instance.
+func NewMockRoleManager(ctrl *gomock.Controller) *MockRoleManager {
+	mock := &MockRoleManager{ctrl: ctrl}
+	mock.recorder = &MockRoleManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleManager) EXPECT() *MockRoleManagerMockRecorder {
+	return m.recorder
+}
+
+// AssignRole mocks base method.
+func (m *MockRoleManager) AssignRole(userID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignRole", userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignRole indicates an expected call of AssignRole.
+func (mr *MockRoleManagerMockRecorder) AssignRole(userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignRole", reflect.TypeOf((*MockRoleManager)(nil).AssignRole), userID, role)
+}
+
+// DeleteRole mocks base method.
+func (m *MockRoleManager) DeleteRole(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockRoleManagerMockRecorder) DeleteRole(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockRoleManager)(nil).DeleteRole), name)
+}
+
+// GetRole mocks base method.
+func (m *MockRoleManager) GetRole(name string) (models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", name)
+	ret0, _ := ret[0].(models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockRoleManagerMockRecorder) GetRole(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockRoleManager)(nil).GetRole), name)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockRoleManager) GetUserPermissions(userID string) ([]models.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPermissions", userID)
+	ret0, _ := ret[0].([]models.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockRoleManagerMockRecorder) GetUserPermissions(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockRoleManager)(nil).GetUserPermissions), userID)
+}
+
+// GetUserRoles mocks base method.
+func (m *MockRoleManager) GetUserRoles(userID string) ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", userID)
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockRoleManagerMockRecorder) GetUserRoles(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockRoleManager)(nil).GetUserRoles), userID)
+}
+
+// ListRoles mocks base method.
+func (m *MockRoleManager) ListRoles() ([]models.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoles")
+	ret0, _ := ret[0].([]models.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockRoleManagerMockRecorder) ListRoles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockRoleManager)(nil).ListRoles))
+}
+
+// SaveRole mocks base method.
+func (m *MockRoleManager) SaveRole(role models.Role) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRole", role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRole indicates an expected call of SaveRole.
+func (mr *MockRoleManagerMockRecorder) SaveRole(role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRole", reflect.TypeOf((*MockRoleManager)(nil).SaveRole), role)
+}
+
+// UnassignRole mocks base method.
+func (m *MockRoleManager) UnassignRole(userID, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignRole", userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnassignRole indicates an expected call of UnassignRole.
+func (mr *MockRoleManagerMockRecorder) UnassignRole(userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignRole", reflect.TypeOf((*MockRoleManager)(nil).UnassignRole), userID, role)
+}
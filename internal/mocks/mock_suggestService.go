@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_suggestService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSuggestServiceManager is a mock of SuggestServiceManager interface.
+type MockSuggestServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockSuggestServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockSuggestServiceManagerMockRecorder is the mock recorder for MockSuggestServiceManager.
+type MockSuggestServiceManagerMockRecorder struct {
+	mock *MockSuggestServiceManager
+}
+
+// NewMockSuggestServiceManager creates a new mock instance.
+func NewMockSuggestServiceManager(ctrl *gomock.Controller) *MockSuggestServiceManager {
+	mock := &MockSuggestServiceManager{ctrl: ctrl}
+	mock.recorder = &MockSuggestServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSuggestServiceManager) EXPECT() *MockSuggestServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockSuggestServiceManager) Refresh() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSuggestServiceManagerMockRecorder) Refresh() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSuggestServiceManager)(nil).Refresh))
+}
+
+// Suggest mocks base method.
+func (m *MockSuggestServiceManager) Suggest(query string) (dto.SuggestionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", query)
+	ret0, _ := ret[0].(dto.SuggestionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockSuggestServiceManagerMockRecorder) Suggest(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockSuggestServiceManager)(nil).Suggest), query)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_tokenRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTokenManager is a mock of TokenManager interface.
+type MockTokenManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenManagerMockRecorder is the mock recorder for MockTokenManager.
+type MockTokenManagerMockRecorder struct {
+	mock *MockTokenManager
+}
+
+// NewMockTokenManager creates a new mock instance.
+func NewMockTokenManager(ctrl *gomock.Controller) *MockTokenManager {
+	mock := &MockTokenManager{ctrl: ctrl}
+	mock.recorder = &MockTokenManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenManager) EXPECT() *MockTokenManagerMockRecorder {
+	return m.recorder
+}
+
+// DeleteExpiredTokens mocks base method.
+func (m *MockTokenManager) DeleteExpiredTokens(now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredTokens", now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredTokens indicates an expected call of DeleteExpiredTokens.
+func (mr *MockTokenManagerMockRecorder) DeleteExpiredTokens(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredTokens", reflect.TypeOf((*MockTokenManager)(nil).DeleteExpiredTokens), now)
+}
+
+// IncrementTokenVersion mocks base method.
+func (m *MockTokenManager) IncrementTokenVersion(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementTokenVersion", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementTokenVersion indicates an expected call of IncrementTokenVersion.
+func (mr *MockTokenManagerMockRecorder) IncrementTokenVersion(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementTokenVersion", reflect.TypeOf((*MockTokenManager)(nil).IncrementTokenVersion), userID)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockTokenManager) IsTokenRevoked(jti string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", jti)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockTokenManagerMockRecorder) IsTokenRevoked(jti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockTokenManager)(nil).IsTokenRevoked), jti)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenManager) RevokeToken(jti, userID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", jti, userID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenManagerMockRecorder) RevokeToken(jti, userID, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenManager)(nil).RevokeToken), jti, userID, expiresAt)
+}
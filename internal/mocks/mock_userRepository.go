@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,20 @@ func (m *MockUserManager) EXPECT() *MockUserManagerMockRecorder {
 	return m.recorder
 }
 
+// EraseUser mocks base method.
+func (m *MockUserManager) EraseUser(user models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockUserManagerMockRecorder) EraseUser(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockUserManager)(nil).EraseUser), user)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserManager) GetUserByEmail(email string) (models.User, error) {
 	m.ctrl.T.Helper()
@@ -70,6 +85,51 @@ func (mr *MockUserManagerMockRecorder) GetUserByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserManager)(nil).GetUserByID), id)
 }
 
+// GetUsersByRole mocks base method.
+func (m *MockUserManager) GetUsersByRole(role models.UserRole) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByRole", role)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByRole indicates an expected call of GetUsersByRole.
+func (mr *MockUserManagerMockRecorder) GetUsersByRole(role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByRole", reflect.TypeOf((*MockUserManager)(nil).GetUsersByRole), role)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserManager) ListUsers(filter models.UserFilter) ([]models.User, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", filter)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserManagerMockRecorder) ListUsers(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserManager)(nil).ListUsers), filter)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockUserManager) MarkEmailVerified(id string, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", id, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockUserManagerMockRecorder) MarkEmailVerified(id, verifiedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockUserManager)(nil).MarkEmailVerified), id, verifiedAt)
+}
+
 // SaveUser mocks base method.
 func (m *MockUserManager) SaveUser(arg0 models.User) error {
 	m.ctrl.T.Helper()
@@ -83,3 +143,73 @@ func (mr *MockUserManagerMockRecorder) SaveUser(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUser", reflect.TypeOf((*MockUserManager)(nil).SaveUser), arg0)
 }
+
+// SetVerificationSentAt mocks base method.
+func (m *MockUserManager) SetVerificationSentAt(id string, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVerificationSentAt", id, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVerificationSentAt indicates an expected call of SetVerificationSentAt.
+func (mr *MockUserManagerMockRecorder) SetVerificationSentAt(id, sentAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVerificationSentAt", reflect.TypeOf((*MockUserManager)(nil).SetVerificationSentAt), id, sentAt)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserManager) UpdatePassword(id, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", id, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserManagerMockRecorder) UpdatePassword(id, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserManager)(nil).UpdatePassword), id, password)
+}
+
+// UpdateUser mocks base method.
+func (m *MockUserManager) UpdateUser(arg0 models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockUserManagerMockRecorder) UpdateUser(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserManager)(nil).UpdateUser), arg0)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUserManager) UpdateUserRole(id string, role models.UserRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", id, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserManagerMockRecorder) UpdateUserRole(id, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserManager)(nil).UpdateUserRole), id, role)
+}
+
+// UpdateUserStatus mocks base method.
+func (m *MockUserManager) UpdateUserStatus(id string, status models.UserStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserStatus", id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserStatus indicates an expected call of UpdateUserStatus.
+func (mr *MockUserManagerMockRecorder) UpdateUserStatus(id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockUserManager)(nil).UpdateUserStatus), id, status)
+}
@@ -11,7 +11,9 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,19 +42,93 @@ func (m *MockUserServiceManager) EXPECT() *MockUserServiceManagerMockRecorder {
 	return m.recorder
 }
 
+// BeginMFASetup mocks base method.
+func (m *MockUserServiceManager) BeginMFASetup(mfaToken string) (dto.MFAEnrollmentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginMFASetup", mfaToken)
+	ret0, _ := ret[0].(dto.MFAEnrollmentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginMFASetup indicates an expected call of BeginMFASetup.
+func (mr *MockUserServiceManagerMockRecorder) BeginMFASetup(mfaToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginMFASetup", reflect.TypeOf((*MockUserServiceManager)(nil).BeginMFASetup), mfaToken)
+}
+
+// ConfirmMFASetup mocks base method.
+func (m *MockUserServiceManager) ConfirmMFASetup(mfaToken, code string, client models.ClientInfo) (dto.MFASetupResultDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFASetup", mfaToken, code, client)
+	ret0, _ := ret[0].(dto.MFASetupResultDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmMFASetup indicates an expected call of ConfirmMFASetup.
+func (mr *MockUserServiceManagerMockRecorder) ConfirmMFASetup(mfaToken, code, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFASetup", reflect.TypeOf((*MockUserServiceManager)(nil).ConfirmMFASetup), mfaToken, code, client)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockUserServiceManager) DeleteAccount(userID, password string, loggedInAt time.Time, client models.ClientInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", userID, password, loggedInAt, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockUserServiceManagerMockRecorder) DeleteAccount(userID, password, loggedInAt, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockUserServiceManager)(nil).DeleteAccount), userID, password, loggedInAt, client)
+}
+
+// ExportData mocks base method.
+func (m *MockUserServiceManager) ExportData(userID string) (dto.UserExportDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportData", userID)
+	ret0, _ := ret[0].(dto.UserExportDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportData indicates an expected call of ExportData.
+func (mr *MockUserServiceManagerMockRecorder) ExportData(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportData", reflect.TypeOf((*MockUserServiceManager)(nil).ExportData), userID)
+}
+
+// GetProfile mocks base method.
+func (m *MockUserServiceManager) GetProfile(userID string) (dto.ProfileDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", userID)
+	ret0, _ := ret[0].(dto.ProfileDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockUserServiceManagerMockRecorder) GetProfile(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockUserServiceManager)(nil).GetProfile), userID)
+}
+
 // Login mocks base method.
-func (m *MockUserServiceManager) Login(email, password string) (string, error) {
+func (m *MockUserServiceManager) Login(email, password string, client models.ClientInfo) (dto.LoginResultDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", email, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "Login", email, password, client)
+	ret0, _ := ret[0].(dto.LoginResultDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUserServiceManagerMockRecorder) Login(email, password any) *gomock.Call {
+func (mr *MockUserServiceManagerMockRecorder) Login(email, password, client any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServiceManager)(nil).Login), email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServiceManager)(nil).Login), email, password, client)
 }
 
 // RegisterUser mocks base method.
@@ -68,3 +144,48 @@ func (mr *MockUserServiceManagerMockRecorder) RegisterUser(name, email, password
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserServiceManager)(nil).RegisterUser), name, email, password, role)
 }
+
+// StartSession mocks base method.
+func (m *MockUserServiceManager) StartSession(user models.User, client models.ClientInfo) (dto.LoginResultDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSession", user, client)
+	ret0, _ := ret[0].(dto.LoginResultDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSession indicates an expected call of StartSession.
+func (mr *MockUserServiceManagerMockRecorder) StartSession(user, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSession", reflect.TypeOf((*MockUserServiceManager)(nil).StartSession), user, client)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockUserServiceManager) UpdateProfile(userID string, update dto.UpdateProfileDTO) (dto.ProfileDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", userID, update)
+	ret0, _ := ret[0].(dto.ProfileDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockUserServiceManagerMockRecorder) UpdateProfile(userID, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserServiceManager)(nil).UpdateProfile), userID, update)
+}
+
+// VerifyMFALogin mocks base method.
+func (m *MockUserServiceManager) VerifyMFALogin(mfaToken, code string, client models.ClientInfo) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFALogin", mfaToken, code, client)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFALogin indicates an expected call of VerifyMFALogin.
+func (mr *MockUserServiceManagerMockRecorder) VerifyMFALogin(mfaToken, code, client any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFALogin", reflect.TypeOf((*MockUserServiceManager)(nil).VerifyMFALogin), mfaToken, code, client)
+}
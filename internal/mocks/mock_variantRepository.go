@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_variantRepository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVariantManager is a mock of VariantManager interface.
+type MockVariantManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockVariantManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockVariantManagerMockRecorder is the mock recorder for MockVariantManager.
+type MockVariantManagerMockRecorder struct {
+	mock *MockVariantManager
+}
+
+// NewMockVariantManager creates a new mock instance.
+func NewMockVariantManager(ctrl *gomock.Controller) *MockVariantManager {
+	mock := &MockVariantManager{ctrl: ctrl}
+	mock.recorder = &MockVariantManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVariantManager) EXPECT() *MockVariantManagerMockRecorder {
+	return m.recorder
+}
+
+// DeleteVariant mocks base method.
+func (m *MockVariantManager) DeleteVariant(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant.
+func (mr *MockVariantManagerMockRecorder) DeleteVariant(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockVariantManager)(nil).DeleteVariant), id)
+}
+
+// GetOptions mocks base method.
+func (m *MockVariantManager) GetOptions(productID string) ([]models.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptions", productID)
+	ret0, _ := ret[0].([]models.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptions indicates an expected call of GetOptions.
+func (mr *MockVariantManagerMockRecorder) GetOptions(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptions", reflect.TypeOf((*MockVariantManager)(nil).GetOptions), productID)
+}
+
+// GetVariant mocks base method.
+func (m *MockVariantManager) GetVariant(id string) (models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariant", id)
+	ret0, _ := ret[0].(models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariant indicates an expected call of GetVariant.
+func (mr *MockVariantManagerMockRecorder) GetVariant(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariant", reflect.TypeOf((*MockVariantManager)(nil).GetVariant), id)
+}
+
+// GetVariantBySKU mocks base method.
+func (m *MockVariantManager) GetVariantBySKU(sku string) (models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVariantBySKU", sku)
+	ret0, _ := ret[0].(models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVariantBySKU indicates an expected call of GetVariantBySKU.
+func (mr *MockVariantManagerMockRecorder) GetVariantBySKU(sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVariantBySKU", reflect.TypeOf((*MockVariantManager)(nil).GetVariantBySKU), sku)
+}
+
+// ListVariants mocks base method.
+func (m *MockVariantManager) ListVariants(productID string) ([]models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVariants", productID)
+	ret0, _ := ret[0].([]models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVariants indicates an expected call of ListVariants.
+func (mr *MockVariantManagerMockRecorder) ListVariants(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVariants", reflect.TypeOf((*MockVariantManager)(nil).ListVariants), productID)
+}
+
+// SaveVariant mocks base method.
+func (m *MockVariantManager) SaveVariant(variant models.Variant, opening *models.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveVariant", variant, opening)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveVariant indicates an expected call of SaveVariant.
+func (mr *MockVariantManagerMockRecorder) SaveVariant(variant, opening any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveVariant", reflect.TypeOf((*MockVariantManager)(nil).SaveVariant), variant, opening)
+}
+
+// SetOptions mocks base method.
+func (m *MockVariantManager) SetOptions(productID string, options []models.ProductOption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOptions", productID, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOptions indicates an expected call of SetOptions.
+func (mr *MockVariantManagerMockRecorder) SetOptions(productID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOptions", reflect.TypeOf((*MockVariantManager)(nil).SetOptions), productID, options)
+}
+
+// UpdateVariant mocks base method.
+func (m *MockVariantManager) UpdateVariant(variant models.Variant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", variant)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVariant indicates an expected call of UpdateVariant.
+func (mr *MockVariantManagerMockRecorder) UpdateVariant(variant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockVariantManager)(nil).UpdateVariant), variant)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_variantService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	dto "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVariantServiceManager is a mock of VariantServiceManager interface.
+type MockVariantServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockVariantServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockVariantServiceManagerMockRecorder is the mock recorder for MockVariantServiceManager.
+type MockVariantServiceManagerMockRecorder struct {
+	mock *MockVariantServiceManager
+}
+
+// NewMockVariantServiceManager creates a new mock instance.
+func NewMockVariantServiceManager(ctrl *gomock.Controller) *MockVariantServiceManager {
+	mock := &MockVariantServiceManager{ctrl: ctrl}
+	mock.recorder = &MockVariantServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVariantServiceManager) EXPECT() *MockVariantServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// CreateVariant mocks base method.
+func (m *MockVariantServiceManager) CreateVariant(adminID, productID string, req dto.VariantDTO) (models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariant", adminID, productID, req)
+	ret0, _ := ret[0].(models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVariant indicates an expected call of CreateVariant.
+func (mr *MockVariantServiceManagerMockRecorder) CreateVariant(adminID, productID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariant", reflect.TypeOf((*MockVariantServiceManager)(nil).CreateVariant), adminID, productID, req)
+}
+
+// DeleteVariant mocks base method.
+func (m *MockVariantServiceManager) DeleteVariant(productID, variantID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVariant", productID, variantID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVariant indicates an expected call of DeleteVariant.
+func (mr *MockVariantServiceManagerMockRecorder) DeleteVariant(productID, variantID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVariant", reflect.TypeOf((*MockVariantServiceManager)(nil).DeleteVariant), productID, variantID)
+}
+
+// ListVariants mocks base method.
+func (m *MockVariantServiceManager) ListVariants(productID string) (dto.ProductVariantsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVariants", productID)
+	ret0, _ := ret[0].(dto.ProductVariantsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVariants indicates an expected call of ListVariants.
+func (mr *MockVariantServiceManagerMockRecorder) ListVariants(productID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVariants", reflect.TypeOf((*MockVariantServiceManager)(nil).ListVariants), productID)
+}
+
+// SetOptions mocks base method.
+func (m *MockVariantServiceManager) SetOptions(productID string, options []models.ProductOption) ([]models.ProductOption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOptions", productID, options)
+	ret0, _ := ret[0].([]models.ProductOption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOptions indicates an expected call of SetOptions.
+func (mr *MockVariantServiceManagerMockRecorder) SetOptions(productID, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOptions", reflect.TypeOf((*MockVariantServiceManager)(nil).SetOptions), productID, options)
+}
+
+// UpdateVariant mocks base method.
+func (m *MockVariantServiceManager) UpdateVariant(productID, variantID string, req dto.VariantDTO) (models.Variant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVariant", productID, variantID, req)
+	ret0, _ := ret[0].(models.Variant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVariant indicates an expected call of UpdateVariant.
+func (mr *MockVariantServiceManagerMockRecorder) UpdateVariant(productID, variantID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVariant", reflect.TypeOf((*MockVariantServiceManager)(nil).UpdateVariant), productID, variantID, req)
+}
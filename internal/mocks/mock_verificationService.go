@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interface.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interface.go -destination=../../mocks/mock_verificationService.go -package mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockVerificationServiceManager is a mock of VerificationServiceManager interface.
+type MockVerificationServiceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerificationServiceManagerMockRecorder
+	isgomock struct{}
+}
+
+// MockVerificationServiceManagerMockRecorder is the mock recorder for MockVerificationServiceManager.
+type MockVerificationServiceManagerMockRecorder struct {
+	mock *MockVerificationServiceManager
+}
+
+// NewMockVerificationServiceManager creates a new mock instance.
+func NewMockVerificationServiceManager(ctrl *gomock.Controller) *MockVerificationServiceManager {
+	mock := &MockVerificationServiceManager{ctrl: ctrl}
+	mock.recorder = &MockVerificationServiceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerificationServiceManager) EXPECT() *MockVerificationServiceManagerMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEmailChange mocks base method.
+func (m *MockVerificationServiceManager) ConfirmEmailChange(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEmailChange", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmEmailChange indicates an expected call of ConfirmEmailChange.
+func (mr *MockVerificationServiceManagerMockRecorder) ConfirmEmailChange(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmailChange", reflect.TypeOf((*MockVerificationServiceManager)(nil).ConfirmEmailChange), token)
+}
+
+// ResendVerification mocks base method.
+func (m *MockVerificationServiceManager) ResendVerification(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockVerificationServiceManagerMockRecorder) ResendVerification(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockVerificationServiceManager)(nil).ResendVerification), userID)
+}
+
+// SendEmailChange mocks base method.
+func (m *MockVerificationServiceManager) SendEmailChange(user models.User, newEmail string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailChange", user, newEmail)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailChange indicates an expected call of SendEmailChange.
+func (mr *MockVerificationServiceManagerMockRecorder) SendEmailChange(user, newEmail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailChange", reflect.TypeOf((*MockVerificationServiceManager)(nil).SendEmailChange), user, newEmail)
+}
+
+// SendVerification mocks base method.
+func (m *MockVerificationServiceManager) SendVerification(user models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockVerificationServiceManagerMockRecorder) SendVerification(user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockVerificationServiceManager)(nil).SendVerification), user)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockVerificationServiceManager) VerifyEmail(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockVerificationServiceManagerMockRecorder) VerifyEmail(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockVerificationServiceManager)(nil).VerifyEmail), token)
+}
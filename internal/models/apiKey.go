@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// APIKey lets an integration act for the admin who created it, limited to
+// Scopes. Only the hash of the key is stored; Prefix identifies it in listings.
+type APIKey struct {
+	ID         string       `json:"id"`
+	UserID     string       `json:"user_id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	KeyHash    string       `json:"-"`
+	Scopes     []Permission `json:"scopes"`
+	CreatedAt  time.Time    `json:"created_at"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+}
+
+func (k APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	AuditAccountLocked   = "account_locked"
+	AuditIPLocked        = "ip_locked"
+	AuditAccountUnlocked = "account_unlocked"
+	AuditAccountErased   = "account_erased"
+)
+
+type AuditEntry struct {
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	UserID    string    `json:"user_id,omitempty"`
+	ActorID   string    `json:"actor_id,omitempty"`
+	IP        string    `json:"ip,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -9,5 +9,6 @@ type CartItems struct {
 	ID        string `json:"id"`
 	CartID    string `json:"cart_id"`
 	ProductID string `json:"product_id"`
+	VariantID string `json:"variant_id,omitempty"`
 	Quantity  int    `json:"quantity"`
 }
@@ -0,0 +1,12 @@
+package models
+
+// Category is a node of the catalogue tree. Top-level categories have no
+// ParentID. Children is only filled in when the tree is built.
+type Category struct {
+	ID        string     `json:"id"`
+	ParentID  *string    `json:"parent_id,omitempty"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	SortOrder int        `json:"sort_order"`
+	Children  []Category `json:"children,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ProductImage is an uploaded picture of a product. The original and its
+// thumbnails live in the blob store; URL and Thumbnails are filled in when
+// the image is returned.
+type ProductImage struct {
+	ID          string            `json:"id"`
+	ProductID   string            `json:"product_id"`
+	Position    int               `json:"position"`
+	Primary     bool              `json:"primary"`
+	ContentType string            `json:"content_type"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	SizeBytes   int               `json:"size_bytes"`
+	CreatedAt   time.Time         `json:"created_at"`
+	URL         string            `json:"url"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Catalogue files are CSV or JSON lines, one product per line.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+type ImportStatus string
+
+const (
+	ImportRunning   ImportStatus = "running"
+	ImportSucceeded ImportStatus = "succeeded"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// ImportJob tracks a catalogue import. Errors holds the rejected rows, capped
+// so that a badly broken file does not produce a huge report; Failed counts
+// all of them.
+type ImportJob struct {
+	ID            string           `json:"id"`
+	CreatedBy     string           `json:"created_by"`
+	Format        string           `json:"format"`
+	DryRun        bool             `json:"dry_run"`
+	Status        ImportStatus     `json:"status"`
+	TotalRows     int              `json:"total_rows"`
+	ProcessedRows int              `json:"processed_rows"`
+	Created       int              `json:"created"`
+	Updated       int              `json:"updated"`
+	Failed        int              `json:"failed"`
+	Errors        []ImportRowError `json:"errors"`
+	CreatedAt     time.Time        `json:"created_at"`
+	FinishedAt    *time.Time       `json:"finished_at,omitempty"`
+}
+
+// ImportRowError lists what is wrong with one row of an import file. Line is
+// the line of the file the row starts on.
+type ImportRowError struct {
+	Line   int      `json:"line"`
+	SKU    string   `json:"sku,omitempty"`
+	Errors []string `json:"errors"`
+}
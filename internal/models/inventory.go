@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// StockReason is why a product's stock changed.
+type StockReason string
+
+const (
+	StockOpening      StockReason = "opening"
+	StockSale         StockReason = "sale"
+	StockCancellation StockReason = "cancellation"
+	StockReturn       StockReason = "return"
+	StockAdjustment   StockReason = "adjustment"
+	StockImport       StockReason = "import"
+	// StockReconciliation records stock found to differ from the ledger, so
+	// the ledger explains it again.
+	StockReconciliation StockReason = "reconciliation"
+)
+
+// ParseAdjustmentReason reads the reason of a manual stock adjustment. The
+// other reasons are recorded by the shop itself.
+func ParseAdjustmentReason(reason string) (StockReason, error) {
+	switch StockReason(reason) {
+	case "":
+		return StockAdjustment, nil
+	case StockAdjustment, StockReturn, StockCancellation:
+		return StockReason(reason), nil
+	}
+	return "", fmt.Errorf("unknown reason %q, expected adjustment, return or cancellation", reason)
+}
+
+// StockMovement is one entry of the inventory ledger. Entries are never
+// changed or removed; the stock of a product or variant is the sum of its
+// deltas, and Balance is that sum once the entry was made. ActorID is the
+// user behind the change and Reference the order or import job it belongs
+// to, when there is one.
+type StockMovement struct {
+	ID        string      `json:"id"`
+	ProductID string      `json:"product_id"`
+	VariantID string      `json:"variant_id,omitempty"`
+	Delta     int         `json:"delta"`
+	Balance   int         `json:"balance"`
+	Reason    StockReason `json:"reason"`
+	ActorID   string      `json:"actor_id,omitempty"`
+	Reference string      `json:"reference,omitempty"`
+	Note      string      `json:"note,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// StockMovementFilter selects a product's ledger entries, newest first.
+type StockMovementFilter struct {
+	VariantID string
+	Limit     int
+	Offset    int
+}
+
+// StockDrift is a product or variant whose stock does not match its ledger.
+type StockDrift struct {
+	ProductID   string
+	VariantID   string
+	Stock       int
+	LedgerStock int
+	Entries     int
+}
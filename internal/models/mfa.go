@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type UserMFA struct {
+	UserID       string     `json:"user_id"`
+	Secret       string     `json:"-"`
+	EnabledAt    *time.Time `json:"enabled_at,omitempty"`
+	LastUsedStep int64      `json:"-"`
+}
+
+// IsEnabled reports whether enrolment was confirmed; an unconfirmed secret is
+// not used for login.
+func (m UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil
+}
+
+const (
+	MFAChallengePurpose = "mfa_challenge"
+	MFASetupPurpose     = "mfa_setup"
+)
+
+// MFAChallengeClaims is issued after a correct password when a second factor
+// is still needed, and is only accepted by the MFA login endpoints.
+type MFAChallengeClaims struct {
+	Purpose      string `json:"purpose"`
+	TokenVersion int    `json:"ver"`
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// OIDCLoginState is kept between sending the browser to the identity provider
+// and its callback. The state value itself is only stored hashed.
+type OIDCLoginState struct {
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// UserIdentity links an account at an external identity provider to a user.
+type UserIdentity struct {
+	Issuer    string    `json:"issuer"`
+	Subject   string    `json:"subject"`
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
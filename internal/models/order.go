@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+type Order struct {
+	ID         string      `json:"id"`
+	UserID     string      `json:"user_id"`
+	Total      float32     `json:"total"`
+	CouponCode string      `json:"coupon_code,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Items      []OrderItem `json:"items"`
+}
+
+// OrderItem keeps the name and price at the time of purchase, so later product
+// changes do not rewrite order history.
+type OrderItem struct {
+	ProductID   string            `json:"product_id"`
+	ProductName string            `json:"product_name"`
+	VariantID   string            `json:"variant_id,omitempty"`
+	SKU         string            `json:"sku,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+	Price       float32           `json:"price"`
+	Quantity    int               `json:"quantity"`
+}
+
+// OrderFilter selects orders for the admin listing. Since, when set, keeps
+// orders placed at or after it.
+type OrderFilter struct {
+	UserID string
+	Since  *time.Time
+	Limit  int
+	Offset int
+}
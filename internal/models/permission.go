@@ -0,0 +1,87 @@
+package models
+
+import "fmt"
+
+type Permission string
+
+const (
+	PermCartUse       Permission = "cart:use"
+	PermProductsWrite Permission = "products:write"
+	PermCouponsWrite  Permission = "coupons:write"
+	PermOrdersRead    Permission = "orders:read"
+	PermOrdersRefund  Permission = "orders:refund"
+	PermUsersRead     Permission = "users:read"
+	PermUsersManage   Permission = "users:manage"
+	PermRolesManage   Permission = "roles:manage"
+	PermAPIKeysManage Permission = "api_keys:manage"
+)
+
+var AllPermissions = []Permission{
+	PermCartUse,
+	PermProductsWrite,
+	PermCouponsWrite,
+	PermOrdersRead,
+	PermOrdersRefund,
+	PermUsersRead,
+	PermUsersManage,
+	PermRolesManage,
+	PermAPIKeysManage,
+}
+
+func ParsePermission(permission string) (Permission, error) {
+	for _, p := range AllPermissions {
+		if string(p) == permission {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown permission %q", permission)
+}
+
+// Permissions is what the account role grants on its own. Admins get every
+// staff permission; shopping is left to customers.
+func (ur UserRole) Permissions() []Permission {
+	switch ur {
+	case Admin:
+		var perms []Permission
+		for _, p := range AllPermissions {
+			if p != PermCartUse {
+				perms = append(perms, p)
+			}
+		}
+		return perms
+	case Customer:
+		return []Permission{PermCartUse}
+	}
+	return nil
+}
+
+// Role bundles permissions. Roles are assigned to users on top of their
+// account role.
+type Role struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+	BuiltIn     bool         `json:"built_in"`
+}
+
+// BuiltInRoles are seeded at startup and can not be changed through the API.
+var BuiltInRoles = []Role{
+	{
+		Name:        "catalog_manager",
+		Description: "Manages products and coupons",
+		Permissions: []Permission{PermProductsWrite, PermCouponsWrite},
+		BuiltIn:     true,
+	},
+	{
+		Name:        "support_agent",
+		Description: "Helps customers with their accounts, carts and orders",
+		Permissions: []Permission{PermUsersRead, PermUsersManage, PermOrdersRead},
+		BuiltIn:     true,
+	},
+	{
+		Name:        "finance",
+		Description: "Reviews orders and issues refunds",
+		Permissions: []Permission{PermOrdersRead, PermOrdersRefund, PermCouponsWrite},
+		BuiltIn:     true,
+	},
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// PriceReason is what caused a price change.
+type PriceReason string
+
+const (
+	PriceCreated       PriceReason = "created"
+	PriceUpdated       PriceReason = "updated"
+	PriceImported      PriceReason = "imported"
+	PriceScheduled     PriceReason = "scheduled"
+	PriceSaleStarted   PriceReason = "sale_started"
+	PriceSaleEnded     PriceReason = "sale_ended"
+	PriceSaleCancelled PriceReason = "sale_cancelled"
+)
+
+// PriceChange is one entry of a product's price history: the prices the
+// product had from ChangedAt until the next entry.
+type PriceChange struct {
+	ID             string      `json:"id"`
+	ProductID      string      `json:"product_id"`
+	Price          float32     `json:"price"`
+	CompareAtPrice *float32    `json:"compare_at_price,omitempty"`
+	Reason         PriceReason `json:"reason"`
+	ScheduleID     string      `json:"schedule_id,omitempty"`
+	ChangedAt      time.Time   `json:"changed_at"`
+}
+
+type ScheduleStatus string
+
+const (
+	SchedulePending   ScheduleStatus = "scheduled"
+	ScheduleActive    ScheduleStatus = "active"
+	ScheduleEnded     ScheduleStatus = "ended"
+	ScheduleCancelled ScheduleStatus = "cancelled"
+)
+
+// PriceSchedule is a price that takes effect at StartsAt. With an end it is a
+// sale: the product's regular price is shown as its compare-at price and
+// comes back at EndsAt. Without one it is a lasting price change.
+type PriceSchedule struct {
+	ID        string         `json:"id"`
+	ProductID string         `json:"product_id"`
+	Price     float32        `json:"price"`
+	StartsAt  time.Time      `json:"starts_at"`
+	EndsAt    *time.Time     `json:"ends_at,omitempty"`
+	Status    ScheduleStatus `json:"status"`
+	CreatedBy string         `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// Open reports whether the schedule has still to start or end.
+func (s PriceSchedule) Open() bool {
+	return s.Status == SchedulePending || s.Status == ScheduleActive
+}
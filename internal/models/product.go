@@ -1,8 +1,149 @@
 package models
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Product is an item of the catalogue. While a sale is on, Price is the sale
+// price and CompareAtPrice the regular price.
 type Product struct {
-	ID    string  `json:"id"`
-	Name  string  `json:"name"`
-	Price float32 `json:"price"`
-	Stock int     `json:"stock"`
+	ID             string          `json:"id"`
+	SKU            string          `json:"sku,omitempty"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	Tags           []string        `json:"tags"`
+	Brand          string          `json:"brand"`
+	WeightGrams    int             `json:"weight_grams"`
+	Dimensions     *Dimensions     `json:"dimensions,omitempty"`
+	Specs          []ProductSpec   `json:"specs"`
+	Price          float32         `json:"price"`
+	CompareAtPrice *float32        `json:"compare_at_price,omitempty"`
+	Stock          int             `json:"stock"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Status         ProductStatus   `json:"status"`
+	ArchivedAt     *time.Time      `json:"archived_at,omitempty"`
+	Options        []ProductOption `json:"options,omitempty"`
+	Variants       []Variant       `json:"variants,omitempty"`
+	Images         []ProductImage  `json:"images,omitempty"`
+}
+
+// ProductStatus is whether a product is shown in the storefront. Archiving is
+// separate, so a restored product comes back in the status it had.
+type ProductStatus string
+
+const (
+	ProductDraft     ProductStatus = "draft"
+	ProductPublished ProductStatus = "published"
+)
+
+func ParseProductStatus(status string) (ProductStatus, error) {
+	switch ProductStatus(status) {
+	case ProductDraft, ProductPublished:
+		return ProductStatus(status), nil
+	}
+	return "", fmt.Errorf("unknown status %q, expected draft or published", status)
+}
+
+// RegularPrice is the product's price outside of any sale.
+func (p Product) RegularPrice() float32 {
+	if p.CompareAtPrice != nil {
+		return *p.CompareAtPrice
+	}
+	return p.Price
+}
+
+// Buyable reports whether the storefront shows the product and it can be
+// ordered: it is published and not archived.
+func (p Product) Buyable() bool {
+	return p.Status == ProductPublished && p.ArchivedAt == nil
+}
+
+// Dimensions are the size of a packed product in millimetres.
+type Dimensions struct {
+	LengthMM int `json:"length_mm"`
+	WidthMM  int `json:"width_mm"`
+	HeightMM int `json:"height_mm"`
+}
+
+type SpecType string
+
+const (
+	SpecText   SpecType = "text"
+	SpecNumber SpecType = "number"
+	SpecBool   SpecType = "bool"
+)
+
+// ProductSpec is one line of a product's specification, such as a screen
+// size. Value holds a string, a float64 or a bool to match Type.
+type ProductSpec struct {
+	Key   string   `json:"key"`
+	Type  SpecType `json:"type"`
+	Value any      `json:"value"`
+	Unit  string   `json:"unit,omitempty"`
+}
+
+// SpecFilter keeps products whose spec with Key equals Value, compared
+// case-insensitively for text, or lies within Min and Max for numbers.
+type SpecFilter struct {
+	Key   string
+	Value string
+	Min   *float64
+	Max   *float64
+}
+
+// Search results mark matched terms with these control characters, which can
+// not appear in product text, until they are rendered.
+const (
+	SearchMarkStart = "\x02"
+	SearchMarkEnd   = "\x03"
+)
+
+// ProductSearchResult is a full-text match. Highlight is the product name and
+// Snippet the best matching fragment, both HTML escaped with the matched terms
+// wrapped in <mark>. A lower Rank is a better match.
+type ProductSearchResult struct {
+	Product
+	Highlight string  `json:"highlight"`
+	Snippet   string  `json:"snippet"`
+	Rank      float64 `json:"rank"`
+}
+
+type ProductSort string
+
+const (
+	SortByName    ProductSort = "name"
+	SortByPrice   ProductSort = "price"
+	SortByCreated ProductSort = "created_at"
+)
+
+// ParseProductSort reads a sort field, prefixed with "-" for descending order.
+func ParseProductSort(sort string) (ProductSort, bool, error) {
+	field, desc := strings.CutPrefix(strings.TrimSpace(sort), "-")
+	switch ProductSort(field) {
+	case SortByName, SortByPrice, SortByCreated:
+		return ProductSort(field), desc, nil
+	}
+	return "", false, fmt.Errorf("unknown sort %q, expected name, price or created_at", sort)
+}
+
+// ProductQuery selects products for the catalogue listings. Name matches a
+// substring and Category a category slug together with its descendants;
+// empty fields do not filter. Statuses defaults to published products only,
+// and Archived lists archived products instead of live ones.
+type ProductQuery struct {
+	Name     string
+	Category string
+	Brand    string
+	Specs    []SpecFilter
+	MinPrice *float32
+	MaxPrice *float32
+	InStock  bool
+	Statuses []ProductStatus
+	Archived bool
+	Sort     ProductSort
+	Desc     bool
+	Limit    int
+	Offset   int
 }
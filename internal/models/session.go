@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// ClientInfo describes where a login came from.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// Session tracks one issued token; its ID is the token's jti. A session ends
+// when it is terminated, when it expires or when the user's token version
+// moves past the one it was issued with.
+type Session struct {
+	ID           string     `json:"id"`
+	UserID       string     `json:"user_id"`
+	UserAgent    string     `json:"user_agent"`
+	IP           string     `json:"ip"`
+	TokenVersion int        `json:"-"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastSeenAt   time.Time  `json:"last_seen_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+}
+
+func (s Session) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
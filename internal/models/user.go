@@ -1,6 +1,12 @@
 package models
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 type UserRole int
 
@@ -19,17 +25,95 @@ func (ur UserRole) String() string {
 	return "Unknown"
 }
 
+func ParseUserRole(role string) (UserRole, error) {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "admin":
+		return Admin, nil
+	case "customer":
+		return Customer, nil
+	}
+	return 0, fmt.Errorf("unknown role %q", role)
+}
+
+type UserStatus string
+
+const (
+	UserActive    UserStatus = "active"
+	UserSuspended UserStatus = "suspended"
+	// UserErased marks an account whose personal data was anonymised. The row
+	// is kept so its orders still have an owner.
+	UserErased UserStatus = "erased"
+)
+
+func ParseUserStatus(status string) (UserStatus, error) {
+	switch UserStatus(strings.ToLower(status)) {
+	case UserActive:
+		return UserActive, nil
+	case UserSuspended:
+		return UserSuspended, nil
+	case UserErased:
+		return UserErased, nil
+	}
+	return "", fmt.Errorf("unknown status %q", status)
+}
+
 type User struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Role     UserRole `json:"role"`
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Email        string   `json:"email"`
+	Password     string   `json:"-"`
+	Role         UserRole `json:"role"`
+	TokenVersion int      `json:"-"`
+
+	EmailVerifiedAt    *time.Time `json:"email_verified_at,omitempty"`
+	VerificationSentAt *time.Time `json:"-"`
+	Status             UserStatus `json:"status"`
+}
+
+// UserFilter selects users for the admin listing. Query matches name or email.
+type UserFilter struct {
+	Query  string
+	Role   *UserRole
+	Status UserStatus
+	Limit  int
+	Offset int
+}
+
+func (u User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
 
 type UserJWT struct {
-	UserID string   `json:"user_id"`
-	Email  string   `json:"email"`
-	Role   UserRole `json:"role"`
+	UserID       string   `json:"user_id"`
+	Email        string   `json:"email"`
+	Role         UserRole `json:"role"`
+	TokenVersion int      `json:"ver"`
+	jwt.RegisteredClaims
+
+	// Set instead of a token when the caller authenticated with an API key.
+	APIKeyID string       `json:"-"`
+	Scopes   []Permission `json:"-"`
+}
+
+func (u UserJWT) IsAPIKey() bool {
+	return u.APIKeyID != ""
+}
+
+const EmailVerificationPurpose = "verify_email"
+
+type EmailVerificationClaims struct {
+	Email   string `json:"email"`
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+const EmailChangePurpose = "change_email"
+
+// EmailChangeClaims confirm a move from CurrentEmail to Email. Binding them to
+// the current address makes a link void once any change has gone through.
+type EmailChangeClaims struct {
+	Email        string `json:"email"`
+	CurrentEmail string `json:"current_email"`
+	Purpose      string `json:"purpose"`
 	jwt.RegisteredClaims
 }
\ No newline at end of file
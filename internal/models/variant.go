@@ -0,0 +1,27 @@
+package models
+
+// ProductOption is an option type such as size or colour, with the values a
+// variant of the product can pick from.
+type ProductOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// Variant is a purchasable version of a product with one value for each of
+// the product's options. A nil Price means the product's price applies.
+type Variant struct {
+	ID        string            `json:"id"`
+	ProductID string            `json:"product_id"`
+	SKU       string            `json:"sku"`
+	Options   map[string]string `json:"options"`
+	Price     *float32          `json:"price,omitempty"`
+	Stock     int               `json:"stock"`
+}
+
+// PriceFor returns the variant's price, falling back to the product's.
+func (v Variant) PriceFor(product Product) float32 {
+	if v.Price != nil {
+		return *v.Price
+	}
+	return product.Price
+}
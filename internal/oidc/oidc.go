@@ -0,0 +1,298 @@
+// Package oidc is a minimal OpenID Connect relying party for the
+// authorization code flow with PKCE.
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/jwtKeys"
+)
+
+const (
+	// ClockSkew is the leeway allowed when checking ID token times.
+	ClockSkew = time.Minute
+	// KeyRefreshInterval is the least time between two JWKS fetches.
+	KeyRefreshInterval = time.Minute
+)
+
+var ErrInvalidIDToken = errors.New("invalid id token")
+
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// GroupsClaim names the ID token claim listing the user's groups.
+	GroupsClaim string
+}
+
+// Claims are the parts of a verified ID token the shop uses.
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Groups        []string
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type Provider struct {
+	cfg      Config
+	client   *http.Client
+	authURL  string
+	tokenURL string
+	jwksURL  string
+
+	mu        sync.Mutex
+	keys      map[string]jwtKeys.Key
+	fetchedAt time.Time
+}
+
+// Discover reads the provider's metadata from its well-known endpoint. The
+// issuer it reports has to match the configured one exactly.
+func Discover(cfg Config, client *http.Client) (*Provider, error) {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	var meta discovery
+	err := getJSON(client, strings.TrimSuffix(cfg.Issuer, "/")+"/.well-known/openid-configuration", &meta)
+	if err != nil {
+		return nil, fmt.Errorf("oidc discovery: %v", err)
+	}
+	if meta.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf("oidc discovery: issuer %q does not match %q", meta.Issuer, cfg.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery: incomplete provider metadata")
+	}
+	return &Provider{
+		cfg:      cfg,
+		client:   client,
+		authURL:  meta.AuthorizationEndpoint,
+		tokenURL: meta.TokenEndpoint,
+		jwksURL:  meta.JWKSURI,
+	}, nil
+}
+
+// NewVerifier returns a PKCE code verifier.
+func NewVerifier() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Challenge derives the S256 code challenge sent with the authorization
+// request.
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(p.cfg.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {Challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(p.authURL, "?") {
+		sep = "&"
+	}
+	return p.authURL + sep + params.Encode()
+}
+
+// Exchange redeems an authorization code and returns the raw ID token.
+func (p *Provider) Exchange(code, verifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequest(http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return "", fmt.Errorf("invalid token response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return "", fmt.Errorf("token request rejected: %s %s", body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("token response has no id_token")
+	}
+	return body.IDToken, nil
+}
+
+// VerifyIDToken checks the signature against the provider's keys, the issuer,
+// audience, expiry and nonce.
+func (p *Provider) VerifyIDToken(raw, nonce string) (Claims, error) {
+	mapClaims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, mapClaims, p.keyFunc,
+		jwt.WithValidMethods([]string{jwtKeys.RS256, jwtKeys.EdDSA}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(ClockSkew),
+	)
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if got, _ := mapClaims["nonce"].(string); got == "" || got != nonce {
+		return Claims{}, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	claims := Claims{}
+	claims.Subject, _ = mapClaims.GetSubject()
+	if claims.Subject == "" {
+		return Claims{}, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	claims.Email, _ = mapClaims["email"].(string)
+	claims.Name, _ = mapClaims["name"].(string)
+	// some providers send the flag as a string
+	switch verified := mapClaims["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = verified
+	case string:
+		claims.EmailVerified = verified == "true"
+	}
+	if p.cfg.GroupsClaim != "" {
+		claims.Groups = stringList(mapClaims[p.cfg.GroupsClaim])
+	}
+	return claims, nil
+}
+
+// keyFunc looks the token's kid up in the cached JWKS, refetching it when
+// the kid is unknown so key rotation at the provider is picked up.
+func (p *Provider) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := p.key(kid)
+	if !ok {
+		err := p.refreshKeys()
+		if err != nil {
+			return nil, err
+		}
+		key, ok = p.key(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+	}
+	if key.Method.Alg() != token.Method.Alg() {
+		return nil, fmt.Errorf("signing key %q is not a %s key", kid, token.Method.Alg())
+	}
+	return key.VerifyKey, nil
+}
+
+func (p *Provider) key(kid string) (jwtKeys.Key, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// refreshKeys fetches the JWKS at most once per KeyRefreshInterval, so tokens
+// with made-up kids can not make the shop hammer the provider. The fetch runs
+// outside the lock; tokens with known keys are verified meanwhile.
+func (p *Provider) refreshKeys() error {
+	p.mu.Lock()
+	if time.Since(p.fetchedAt) < KeyRefreshInterval {
+		p.mu.Unlock()
+		return nil
+	}
+	p.fetchedAt = time.Now()
+	p.mu.Unlock()
+
+	var set jwtKeys.JWKS
+	err := getJSON(p.client, p.jwksURL, &set)
+	if err != nil {
+		return fmt.Errorf("can not fetch provider keys: %v", err)
+	}
+	keys := make(map[string]jwtKeys.Key, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.Key()
+		if err != nil {
+			// keys of other types may be published alongside ours
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+	return nil
+}
+
+func getJSON(client *http.Client, url string, v any) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func stringList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/oidc"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/oidc/oidctest"
+)
+
+func newProvider(t *testing.T) (*oidctest.Provider, *oidc.Provider) {
+	mock, srv := oidctest.NewServer("shop", "s3cret")
+	t.Cleanup(srv.Close)
+	mock.SetUser(oidctest.User{
+		Subject:       "sub-1",
+		Email:         "alice@corp.example",
+		EmailVerified: true,
+		Name:          "Alice",
+		Groups:        []string{"staff", "shop-admins"},
+	})
+
+	provider, err := oidc.Discover(oidc.Config{
+		Issuer:       mock.Issuer,
+		ClientID:     "shop",
+		ClientSecret: "s3cret",
+		RedirectURL:  "http://localhost:8080/api/v1/login/oidc/callback",
+		Scopes:       []string{"openid", "email"},
+		GroupsClaim:  "groups",
+	}, nil)
+	if err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+	return mock, provider
+}
+
+func TestChallenge(t *testing.T) {
+	// base64url(sha256(verifier)) without padding
+	got := oidc.Challenge("dBjftJeZ4CVP-mJ92IFbQVWsOGcPdlhn2Sz2M8uw-cM")
+	if got != "5hTLMK_NJVNcDJkaT34Ev32ONxYugSwSaEXmX2Es9uM" {
+		t.Errorf("unexpected challenge %s", got)
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	_, provider := newProvider(t)
+
+	authURL, err := url.Parse(provider.AuthCodeURL("st", "no", "verifier"))
+	if err != nil {
+		t.Fatalf("invalid url: %v", err)
+	}
+	query := authURL.Query()
+	if query.Get("code_challenge") != oidc.Challenge("verifier") || query.Get("code_challenge_method") != "S256" {
+		t.Errorf("missing PKCE parameters: %v", query)
+	}
+	if query.Get("state") != "st" || query.Get("nonce") != "no" || query.Get("scope") != "openid email" {
+		t.Errorf("unexpected parameters: %v", query)
+	}
+}
+
+func TestFlow(t *testing.T) {
+	tests := []struct {
+		name           string
+		exchangeVerify string
+		verifyNonce    string
+		wantExchange   bool
+		wantErr        error
+	}{
+		{name: "Success", verifyNonce: "nonce-1", wantExchange: true},
+		{name: "Wrong verifier", exchangeVerify: "other", wantExchange: false},
+		{name: "Wrong nonce", verifyNonce: "nonce-2", wantExchange: true, wantErr: oidc.ErrInvalidIDToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock, provider := newProvider(t)
+			verifier, err := oidc.NewVerifier()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			code, state, err := mock.Authorize(provider.AuthCodeURL("state-1", "nonce-1", verifier))
+			if err != nil || state != "state-1" || code == "" {
+				t.Fatalf("authorize failed: code=%q state=%q err=%v", code, state, err)
+			}
+
+			exchangeVerifier := verifier
+			if tt.exchangeVerify != "" {
+				exchangeVerifier = tt.exchangeVerify
+			}
+			idToken, err := provider.Exchange(code, exchangeVerifier)
+			if !tt.wantExchange {
+				if err == nil {
+					t.Error("expected exchange to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("exchange failed: %v", err)
+			}
+
+			claims, err := provider.VerifyIDToken(idToken, tt.verifyNonce)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify failed: %v", err)
+			}
+			if claims.Subject != "sub-1" || claims.Email != "alice@corp.example" || !claims.EmailVerified {
+				t.Errorf("unexpected claims: %+v", claims)
+			}
+			if !slices.Equal(claims.Groups, []string{"staff", "shop-admins"}) {
+				t.Errorf("unexpected groups: %v", claims.Groups)
+			}
+
+			_, err = provider.Exchange(code, verifier)
+			if err == nil {
+				t.Error("expected a code to be usable only once")
+			}
+		})
+	}
+}
+
+func TestVerifyIDToken_OtherAudience(t *testing.T) {
+	mock, _ := newProvider(t)
+	other, err := oidc.Discover(oidc.Config{
+		Issuer:      mock.Issuer,
+		ClientID:    "another-app",
+		RedirectURL: "http://localhost/cb",
+	}, nil)
+	if err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+	mockProvider, err := oidc.Discover(oidc.Config{
+		Issuer:       mock.Issuer,
+		ClientID:     "shop",
+		ClientSecret: "s3cret",
+		RedirectURL:  "http://localhost/cb",
+	}, nil)
+	if err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+
+	code, _, err := mock.Authorize(mockProvider.AuthCodeURL("s", "n", "verifier-value"))
+	if err != nil {
+		t.Fatalf("authorize failed: %v", err)
+	}
+	idToken, err := mockProvider.Exchange(code, "verifier-value")
+	if err != nil {
+		t.Fatalf("exchange failed: %v", err)
+	}
+
+	_, err = other.VerifyIDToken(idToken, "n")
+	if !errors.Is(err, oidc.ErrInvalidIDToken) {
+		t.Errorf("expected a token for another client to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyIDToken_KeyRefreshIsLimited(t *testing.T) {
+	mock, provider := newProvider(t)
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   mock.Issuer,
+		"sub":   "sub-1",
+		"aud":   "shop",
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": "n",
+	})
+	token.Header["kid"] = "made-up"
+	forged, err := token.SignedString(priv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range 3 {
+		_, err = provider.VerifyIDToken(forged, "n")
+		if !errors.Is(err, oidc.ErrInvalidIDToken) {
+			t.Errorf("expected an unknown key to be rejected, got %v", err)
+		}
+	}
+	if fetches := mock.JWKSFetches(); fetches != 1 {
+		t.Errorf("expected one key fetch, got %d", fetches)
+	}
+}
+
+func TestDiscover_IssuerMismatch(t *testing.T) {
+	mock, _ := newProvider(t)
+	_, err := oidc.Discover(oidc.Config{Issuer: mock.Issuer + "/"}, nil)
+	if err == nil {
+		t.Error("expected error for mismatched issuer")
+	}
+}
@@ -0,0 +1,110 @@
+package apiKeyRepository
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const apiKeyColumns = "id, user_id, name, prefix, key_hash, scopes, created_at, last_used_at, revoked_at"
+
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) APIKeyManager {
+	return &APIKeyRepository{db: db}
+}
+
+func (ar *APIKeyRepository) SaveAPIKey(key models.APIKey) error {
+	_, err := ar.db.Exec("INSERT INTO api_keys ("+apiKeyColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		key.ID, key.UserID, key.Name, key.Prefix, key.KeyHash, joinScopes(key.Scopes), key.CreatedAt, key.LastUsedAt, key.RevokedAt)
+	return err
+}
+
+func (ar *APIKeyRepository) GetAPIKeyByHash(keyHash string) (models.APIKey, error) {
+	row := ar.db.QueryRow("SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = ?", keyHash)
+	return scanAPIKey(row)
+}
+
+func (ar *APIKeyRepository) ListAPIKeys() ([]models.APIKey, error) {
+	rows, err := ar.db.Query("SELECT " + apiKeyColumns + " FROM api_keys ORDER BY created_at DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []models.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey fails with sql.ErrNoRows when the key is unknown or already
+// revoked.
+func (ar *APIKeyRepository) RevokeAPIKey(id string, revokedAt time.Time) error {
+	result, err := ar.db.Exec("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", revokedAt, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (ar *APIKeyRepository) UpdateAPIKeyLastUsed(id string, usedAt time.Time) error {
+	_, err := ar.db.Exec("UPDATE api_keys SET last_used_at = ? WHERE id = ?", usedAt, id)
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row scanner) (models.APIKey, error) {
+	var key models.APIKey
+	var scopes string
+	var lastUsedAt, revokedAt sql.NullTime
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &key.KeyHash, &scopes, &key.CreatedAt, &lastUsedAt, &revokedAt)
+	if err != nil {
+		return models.APIKey{}, err
+	}
+	key.Scopes = splitScopes(scopes)
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return key, nil
+}
+
+func joinScopes(scopes []models.Permission) string {
+	parts := make([]string, len(scopes))
+	for i, scope := range scopes {
+		parts[i] = string(scope)
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitScopes(scopes string) []models.Permission {
+	perms := []models.Permission{}
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope != "" {
+			perms = append(perms, models.Permission(scope))
+		}
+	}
+	return perms
+}
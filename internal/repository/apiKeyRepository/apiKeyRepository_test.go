@@ -0,0 +1,109 @@
+package apiKeyRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, APIKeyManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &APIKeyRepository{db: db}
+}
+
+var columns = []string{"id", "user_id", "name", "prefix", "key_hash", "scopes", "created_at", "last_used_at", "revoked_at"}
+
+func TestSaveAPIKey(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO api_keys (" + apiKeyColumns + ")")).
+		WithArgs("k1", "admin1", "ERP", "osk_abcd", "hash", "products:write,orders:read", createdAt, nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SaveAPIKey(models.APIKey{
+		ID: "k1", UserID: "admin1", Name: "ERP", Prefix: "osk_abcd", KeyHash: "hash",
+		Scopes:    []models.Permission{models.PermProductsWrite, models.PermOrdersRead},
+		CreatedAt: createdAt,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetAPIKeyByHash(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	usedAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + apiKeyColumns + " FROM api_keys WHERE key_hash = ?")).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("k1", "admin1", "ERP", "osk_abcd", "hash", "products:write,orders:read", time.Now(), usedAt, nil))
+
+	key, err := repo.GetAPIKeyByHash("hash")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(key.Scopes) != 2 || key.Scopes[1] != models.PermOrdersRead || key.LastUsedAt == nil || key.IsRevoked() {
+		t.Errorf("unexpected key: %+v", key)
+	}
+}
+
+func TestListAPIKeys(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + apiKeyColumns + " FROM api_keys ORDER BY created_at DESC")).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("k2", "admin1", "Old", "osk_efgh", "hash2", "", time.Now(), nil, time.Now()).
+			AddRow("k1", "admin1", "ERP", "osk_abcd", "hash1", "orders:read", time.Now(), nil, nil))
+
+	keys, err := repo.ListAPIKeys()
+	if err != nil || len(keys) != 2 {
+		t.Fatalf("unexpected keys: %+v, err: %v", keys, err)
+	}
+	if !keys[0].IsRevoked() || len(keys[0].Scopes) != 0 {
+		t.Errorf("unexpected revoked key: %+v", keys[0])
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL")
+	now := time.Now()
+	mock.ExpectExec(query).WithArgs(now, "k1").WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.RevokeAPIKey("k1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectExec(query).WithArgs(now, "k1").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := repo.RevokeAPIKey("k1", now); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows for revoked key, got %v", err)
+	}
+}
+
+func TestUpdateAPIKeyLastUsed(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE api_keys SET last_used_at = ? WHERE id = ?")).
+		WithArgs(now, "k1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpdateAPIKeyLastUsed("k1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_apiKeyRepository.go -package=mocks
+package apiKeyRepository
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type APIKeyManager interface {
+	SaveAPIKey(key models.APIKey) error
+	GetAPIKeyByHash(keyHash string) (models.APIKey, error)
+	ListAPIKeys() ([]models.APIKey, error)
+	RevokeAPIKey(id string, revokedAt time.Time) error
+	UpdateAPIKeyLastUsed(id string, usedAt time.Time) error
+}
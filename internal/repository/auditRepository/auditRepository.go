@@ -0,0 +1,21 @@
+package auditRepository
+
+import (
+	"database/sql"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) AuditManager {
+	return &AuditRepository{db: db}
+}
+
+func (ar *AuditRepository) SaveAuditEntry(entry models.AuditEntry) error {
+	_, err := ar.db.Exec("INSERT INTO audit_log (id, event, user_id, actor_id, ip, detail, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		entry.ID, entry.Event, entry.UserID, entry.ActorID, entry.IP, entry.Detail, entry.CreatedAt)
+	return err
+}
@@ -0,0 +1,29 @@
+package auditRepository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func TestSaveAuditEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	defer db.Close()
+	repo := &AuditRepository{db: db}
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_log (id, event, user_id, actor_id, ip, detail, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("a1", models.AuditAccountLocked, "u1", "", "1.2.3.4", "10 failed attempts", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	entry := models.AuditEntry{ID: "a1", Event: models.AuditAccountLocked, UserID: "u1", IP: "1.2.3.4", Detail: "10 failed attempts", CreatedAt: now}
+	if err := repo.SaveAuditEntry(entry); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
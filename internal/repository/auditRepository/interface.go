@@ -0,0 +1,8 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_auditRepository.go -package=mocks
+package auditRepository
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type AuditManager interface {
+	SaveAuditEntry(entry models.AuditEntry) error
+}
@@ -2,6 +2,7 @@ package cartRepository
 
 import (
 	"database/sql"
+	"encoding/json"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
@@ -20,37 +21,39 @@ func (cr *CartRepository) CreateCart(cartID, userID string) error {
 	return err
 }
 
-func (cr *CartRepository) AddToCart(userID string, product models.Product) error {
+// AddToCart adds one of the product to the user's cart. An empty variantID is
+// the plain product.
+func (cr *CartRepository) AddToCart(userID string, product models.Product, variantID string) error {
 	cartID, err := cr.GetCartIDByUserID(userID)
 	if err != nil {
 		return err
 	}
-	row := cr.db.QueryRow("SELECT product_id FROM cart_items WHERE cart_id=? AND product_id=?", cartID, product.ID)
+	row := cr.db.QueryRow("SELECT product_id FROM cart_items WHERE cart_id = ? AND product_id = ? AND variant_id IS ?", cartID, product.ID, nullable(variantID))
 	var product_id string
 	if err := row.Scan(&product_id); err != nil {
 		if err == sql.ErrNoRows {
-			_, err = cr.db.Exec("INSERT INTO cart_items (cart_id, product_id, quantity) VALUES (?, ?, ?)", cartID, product.ID, 1)
+			_, err = cr.db.Exec("INSERT INTO cart_items (cart_id, product_id, variant_id, quantity) VALUES (?, ?, ?, ?)", cartID, product.ID, nullable(variantID), 1)
 			return err
 		}
 		return err
 	}
-	_, err = cr.db.Exec("UPDATE cart_items SET quantity = quantity + 1 WHERE product_id = ?", product_id)
+	_, err = cr.db.Exec("UPDATE cart_items SET quantity = quantity + 1 WHERE cart_id = ? AND product_id = ? AND variant_id IS ?", cartID, product_id, nullable(variantID))
 	return err
 }
 
-func (cr *CartRepository) RemoveFromCart(cartID string, prodID string) error {
-	row := cr.db.QueryRow(`SELECT quantity FROM cart_items WHERE cart_id = ? AND product_id = ?`, cartID, prodID)
+func (cr *CartRepository) RemoveFromCart(cartID, prodID, variantID string) error {
+	row := cr.db.QueryRow(`SELECT quantity FROM cart_items WHERE cart_id = ? AND product_id = ? AND variant_id IS ?`, cartID, prodID, nullable(variantID))
 	var quantity int
 	err := row.Scan(&quantity)
 	if err != nil {
 		return err
 	}
 	if quantity > 1 {
-		_, err := cr.db.Exec(`UPDATE cart_items SET quantity = quantity - 1 WHERE cart_id = ? AND product_id = ?`, cartID, prodID)
+		_, err := cr.db.Exec(`UPDATE cart_items SET quantity = quantity - 1 WHERE cart_id = ? AND product_id = ? AND variant_id IS ?`, cartID, prodID, nullable(variantID))
 		return err
 	}
 	if quantity == 1 {
-		_, err := cr.db.Exec(`DELETE FROM cart_items WHERE cart_id = ? AND product_id = ?`, cartID, prodID)
+		_, err := cr.db.Exec(`DELETE FROM cart_items WHERE cart_id = ? AND product_id = ? AND variant_id IS ?`, cartID, prodID, nullable(variantID))
 		return err
 	}
 	return nil
@@ -75,18 +78,22 @@ func (cr *CartRepository) EmptyCart(userID string) error {
 	return err
 }
 
-func (cr *CartRepository) GetCartItemQuantity(cartID, prodID string) (int, error) {
-	row := cr.db.QueryRow(`SELECT quantity FROM cart_items WHERE cart_id = ? AND product_id = ?`, cartID, prodID)
+func (cr *CartRepository) GetCartItemQuantity(cartID, prodID, variantID string) (int, error) {
+	row := cr.db.QueryRow(`SELECT quantity FROM cart_items WHERE cart_id = ? AND product_id = ? AND variant_id IS ?`, cartID, prodID, nullable(variantID))
 	var quantity int
 	_ = row.Scan(&quantity)
 	return quantity, nil
 }
 
+// GetCartItems lists the cart's items at their current prices. A variant's
+// own price overrides the product's.
 func (cr *CartRepository) GetCartItems(cartID string) ([]dto.CartItemsDTO, error) {
 	rows, err := cr.db.Query(`
-		SELECT p.id, p.name, p.price, ci.quantity
+		SELECT p.id, p.name, COALESCE(v.price, p.price), ci.quantity, p.weight_grams, v.id, v.sku, v.options,
+			p.status = 'published' AND p.archived_at IS NULL
 		FROM cart_items ci
 		JOIN products p ON ci.product_id = p.id
+		LEFT JOIN product_variants v ON ci.variant_id = v.id
 		WHERE ci.cart_id = ?`, cartID)
 	if err != nil {
 		return nil, err
@@ -96,11 +103,28 @@ func (cr *CartRepository) GetCartItems(cartID string) ([]dto.CartItemsDTO, error
 	var cartItems []dto.CartItemsDTO
 	for rows.Next() {
 		var item dto.CartItemsDTO
-		err := rows.Scan(&item.ProductID, &item.ProductName, &item.Price, &item.Quantity)
+		var variantID, sku, options sql.NullString
+		err := rows.Scan(&item.ProductID, &item.ProductName, &item.Price, &item.Quantity, &item.WeightGrams, &variantID, &sku, &options, &item.Available)
 		if err != nil {
 			return nil, err
 		}
+		if variantID.Valid {
+			item.VariantID = variantID.String
+			item.SKU = sku.String
+			err = json.Unmarshal([]byte(options.String), &item.Options)
+			if err != nil {
+				return nil, err
+			}
+		}
 		cartItems = append(cartItems, item)
 	}
 	return cartItems, nil
 }
+
+// nullable stores an empty id as NULL.
+func nullable(id string) any {
+	if id == "" {
+		return nil
+	}
+	return id
+}
@@ -2,6 +2,7 @@ package cartRepository
 
 import (
 	"database/sql"
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -44,15 +45,15 @@ func TestAddToCart_NewItem(t *testing.T) {
 
 	// No existing product
 	mock.ExpectQuery("SELECT product_id FROM cart_items").
-		WithArgs("cart1", "p1").
+		WithArgs("cart1", "p1", nil).
 		WillReturnError(sql.ErrNoRows)
 
 	// Insert new
 	mock.ExpectExec("INSERT INTO cart_items").
-		WithArgs("cart1", "p1", 1).
+		WithArgs("cart1", "p1", nil, 1).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	if err := repo.AddToCart("user1", product); err != nil {
+	if err := repo.AddToCart("user1", product, ""); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
@@ -70,15 +71,15 @@ func TestAddToCart_ExistingItem(t *testing.T) {
 
 	// Product already exists
 	mock.ExpectQuery("SELECT product_id FROM cart_items").
-		WithArgs("cart2", "p2").
+		WithArgs("cart2", "p2", "v1").
 		WillReturnRows(sqlmock.NewRows([]string{"product_id"}).AddRow("p2"))
 
 	// Update quantity
 	mock.ExpectExec("UPDATE cart_items SET quantity = quantity \\+ 1").
-		WithArgs("p2").
+		WithArgs("cart2", "p2", "v1").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	if err := repo.AddToCart("user2", product); err != nil {
+	if err := repo.AddToCart("user2", product, "v1"); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
@@ -88,14 +89,14 @@ func TestRemoveFromCart_QuantityMoreThanOne(t *testing.T) {
 	defer db.Close()
 
 	mock.ExpectQuery("SELECT quantity FROM cart_items").
-		WithArgs("cart1", "p1").
+		WithArgs("cart1", "p1", nil).
 		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(3))
 
 	mock.ExpectExec("UPDATE cart_items SET quantity = quantity - 1").
-		WithArgs("cart1", "p1").
+		WithArgs("cart1", "p1", nil).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	if err := repo.RemoveFromCart("cart1", "p1"); err != nil {
+	if err := repo.RemoveFromCart("cart1", "p1", ""); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
@@ -105,14 +106,14 @@ func TestRemoveFromCart_QuantityEqualOne(t *testing.T) {
 	defer db.Close()
 
 	mock.ExpectQuery("SELECT quantity FROM cart_items").
-		WithArgs("cart1", "p1").
+		WithArgs("cart1", "p1", "v1").
 		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(1))
 
 	mock.ExpectExec("DELETE FROM cart_items").
-		WithArgs("cart1", "p1").
+		WithArgs("cart1", "p1", "v1").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	if err := repo.RemoveFromCart("cart1", "p1"); err != nil {
+	if err := repo.RemoveFromCart("cart1", "p1", "v1"); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
@@ -155,10 +156,10 @@ func TestGetCartItemQuantity(t *testing.T) {
 	defer db.Close()
 
 	mock.ExpectQuery("SELECT quantity FROM cart_items").
-		WithArgs("cartY", "prodY").
+		WithArgs("cartY", "prodY", nil).
 		WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(4))
 
-	q, err := repo.GetCartItemQuantity("cartY", "prodY")
+	q, err := repo.GetCartItemQuantity("cartY", "prodY", "")
 	if err != nil || q != 4 {
 		t.Errorf("expected 4 got %d, err=%v", q, err)
 	}
@@ -168,11 +169,11 @@ func TestGetCartItems(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
 
-	rows := sqlmock.NewRows([]string{"id", "name", "price", "quantity"}).
-		AddRow("p1", "prod1", 100, 2).
-		AddRow("p2", "prod2", 200, 1)
+	rows := sqlmock.NewRows([]string{"id", "name", "price", "quantity", "weight_grams", "variant_id", "sku", "options", "available"}).
+		AddRow("p1", "prod1", 100, 2, 250, nil, nil, nil, true).
+		AddRow("p2", "prod2", 200, 1, 900, "v1", "KB-UK", `{"Layout":"UK"}`, false)
 
-	mock.ExpectQuery("SELECT p.id, p.name, p.price, ci.quantity").
+	mock.ExpectQuery("SELECT p.id, p.name, COALESCE\\(v.price, p.price\\), ci.quantity").
 		WithArgs("cart123").
 		WillReturnRows(rows)
 
@@ -183,7 +184,13 @@ func TestGetCartItems(t *testing.T) {
 	if len(items) != 2 {
 		t.Errorf("expected 2 items, got %d", len(items))
 	}
-	if items[0] != (dto.CartItemsDTO{ProductID: "p1", ProductName: "prod1", Price: 100, Quantity: 2}) {
+	if !reflect.DeepEqual(items[0], dto.CartItemsDTO{ProductID: "p1", ProductName: "prod1", Price: 100, Quantity: 2, WeightGrams: 250, Available: true}) {
 		t.Errorf("unexpected item: %+v", items[0])
 	}
+	if items[1].VariantID != "v1" || items[1].SKU != "KB-UK" || items[1].Options["Layout"] != "UK" || items[1].Available {
+		t.Errorf("unexpected item: %+v", items[1])
+	}
+	if weight := dto.CartWeightGrams(items); weight != 1400 {
+		t.Errorf("expected cart weight 1400, got %d", weight)
+	}
 }
@@ -9,9 +9,9 @@ import (
 type CartManager interface {
 	CreateCart(cartID, userID string) error
 	GetCartIDByUserID(userID string) (string, error)
-	AddToCart(userID string, product models.Product) error
-	RemoveFromCart(cartID string, prodID string) error
+	AddToCart(userID string, product models.Product, variantID string) error
+	RemoveFromCart(cartID, prodID, variantID string) error
 	EmptyCart(userID string) error
-	GetCartItemQuantity(cartID,prodID string) (int,error)
+	GetCartItemQuantity(cartID, prodID, variantID string) (int, error)
 	GetCartItems(cartID string) ([]dto.CartItemsDTO, error)
 }
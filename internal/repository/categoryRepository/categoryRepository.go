@@ -0,0 +1,140 @@
+package categoryRepository
+
+import (
+	"database/sql"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const categoryColumns = "id, parent_id, name, slug, sort_order"
+
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+func NewCategoryRepository(db *sql.DB) CategoryManager {
+	return &CategoryRepository{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCategory(row rowScanner) (models.Category, error) {
+	var category models.Category
+	var parentID sql.NullString
+	err := row.Scan(&category.ID, &parentID, &category.Name, &category.Slug, &category.SortOrder)
+	if err != nil {
+		return models.Category{}, err
+	}
+	if parentID.Valid {
+		category.ParentID = &parentID.String
+	}
+	return category, nil
+}
+
+func scanCategories(rows *sql.Rows) ([]models.Category, error) {
+	var categories []models.Category
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+func (cr *CategoryRepository) SaveCategory(category models.Category) error {
+	_, err := cr.db.Exec("INSERT INTO categories ("+categoryColumns+") VALUES (?, ?, ?, ?, ?)",
+		category.ID, category.ParentID, category.Name, category.Slug, category.SortOrder)
+	return err
+}
+
+func (cr *CategoryRepository) UpdateCategory(category models.Category) error {
+	result, err := cr.db.Exec("UPDATE categories SET parent_id = ?, name = ?, slug = ?, sort_order = ? WHERE id = ?",
+		category.ParentID, category.Name, category.Slug, category.SortOrder, category.ID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+// DeleteCategory removes the category and its product assignments. Categories
+// that still have children are refused by the foreign key.
+func (cr *CategoryRepository) DeleteCategory(id string) error {
+	result, err := cr.db.Exec("DELETE FROM categories WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func (cr *CategoryRepository) GetCategoryByID(id string) (models.Category, error) {
+	row := cr.db.QueryRow("SELECT "+categoryColumns+" FROM categories WHERE id = ?", id)
+	return scanCategory(row)
+}
+
+func (cr *CategoryRepository) GetCategoryBySlug(slug string) (models.Category, error) {
+	row := cr.db.QueryRow("SELECT "+categoryColumns+" FROM categories WHERE slug = ?", slug)
+	return scanCategory(row)
+}
+
+// ListCategories returns every category in display order.
+func (cr *CategoryRepository) ListCategories() ([]models.Category, error) {
+	rows, err := cr.db.Query("SELECT " + categoryColumns + " FROM categories ORDER BY sort_order, name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCategories(rows)
+}
+
+func (cr *CategoryRepository) CountChildren(id string) (int, error) {
+	var count int
+	err := cr.db.QueryRow("SELECT COUNT(*) FROM categories WHERE parent_id = ?", id).Scan(&count)
+	return count, err
+}
+
+func (cr *CategoryRepository) GetProductCategories(productID string) ([]models.Category, error) {
+	rows, err := cr.db.Query(`SELECT c.id, c.parent_id, c.name, c.slug, c.sort_order FROM categories c
+		JOIN product_categories pc ON pc.category_id = c.id
+		WHERE pc.product_id = ? ORDER BY c.sort_order, c.name`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanCategories(rows)
+}
+
+// SetProductCategories replaces the product's categories.
+func (cr *CategoryRepository) SetProductCategories(productID string, categoryIDs []string) error {
+	tx, err := cr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM product_categories WHERE product_id = ?", productID)
+	if err != nil {
+		return err
+	}
+	for _, categoryID := range categoryIDs {
+		_, err = tx.Exec("INSERT OR IGNORE INTO product_categories (product_id, category_id) VALUES (?, ?)", productID, categoryID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func checkAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
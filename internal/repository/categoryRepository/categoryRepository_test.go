@@ -0,0 +1,144 @@
+package categoryRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, CategoryManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &CategoryRepository{db: db}
+}
+
+var categoryRow = []string{"id", "parent_id", "name", "slug", "sort_order"}
+
+func TestSaveCategory(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	parentID := "c1"
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO categories (id, parent_id, name, slug, sort_order) VALUES (?, ?, ?, ?, ?)")).
+		WithArgs("c2", &parentID, "Laptops", "laptops", 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SaveCategory(models.Category{ID: "c2", ParentID: &parentID, Name: "Laptops", Slug: "laptops", SortOrder: 1})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE categories SET parent_id = ?, name = ?, slug = ?, sort_order = ? WHERE id = ?")).
+		WithArgs(nil, "Computers", "computers", 0, "404").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.UpdateCategory(models.Category{ID: "404", Name: "Computers", Slug: "computers"})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM categories WHERE id = ?")).
+		WithArgs("c1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.DeleteCategory("c1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetCategoryBySlug(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, parent_id, name, slug, sort_order FROM categories WHERE slug = ?")).
+		WithArgs("laptops").
+		WillReturnRows(sqlmock.NewRows(categoryRow).AddRow("c2", "c1", "Laptops", "laptops", 1))
+
+	category, err := repo.GetCategoryBySlug("laptops")
+	if err != nil || category.ID != "c2" || category.ParentID == nil || *category.ParentID != "c1" {
+		t.Errorf("unexpected category: %+v, err: %v", category, err)
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, parent_id, name, slug, sort_order FROM categories ORDER BY sort_order, name")).
+		WillReturnRows(sqlmock.NewRows(categoryRow).
+			AddRow("c1", nil, "Computers", "computers", 0).
+			AddRow("c2", "c1", "Laptops", "laptops", 1))
+
+	categories, err := repo.ListCategories()
+	if err != nil || len(categories) != 2 || categories[0].ParentID != nil {
+		t.Errorf("unexpected categories: %+v, err: %v", categories, err)
+	}
+}
+
+func TestCountChildren(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM categories WHERE parent_id = ?")).
+		WithArgs("c1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.CountChildren("c1")
+	if err != nil || count != 2 {
+		t.Errorf("expected 2 children, got %d, err: %v", count, err)
+	}
+}
+
+func TestGetProductCategories(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("FROM categories c\\s+JOIN product_categories pc").
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows(categoryRow).AddRow("c2", "c1", "Laptops", "laptops", 1))
+
+	categories, err := repo.GetProductCategories("p1")
+	if err != nil || len(categories) != 1 || categories[0].Slug != "laptops" {
+		t.Errorf("unexpected categories: %+v, err: %v", categories, err)
+	}
+}
+
+func TestSetProductCategories(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_categories WHERE product_id = ?")).
+		WithArgs("p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT OR IGNORE INTO product_categories (product_id, category_id) VALUES (?, ?)")).
+		WithArgs("p1", "c1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT OR IGNORE INTO product_categories (product_id, category_id) VALUES (?, ?)")).
+		WithArgs("p1", "c2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SetProductCategories("p1", []string{"c1", "c2"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_categoryRepository.go -package=mocks
+package categoryRepository
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type CategoryManager interface {
+	SaveCategory(category models.Category) error
+	UpdateCategory(category models.Category) error
+	DeleteCategory(id string) error
+	GetCategoryByID(id string) (models.Category, error)
+	GetCategoryBySlug(slug string) (models.Category, error)
+	ListCategories() ([]models.Category, error)
+	CountChildren(id string) (int, error)
+	GetProductCategories(productID string) ([]models.Category, error)
+	SetProductCategories(productID string, categoryIDs []string) error
+}
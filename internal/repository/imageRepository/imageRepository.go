@@ -0,0 +1,148 @@
+package imageRepository
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const imageColumns = "id, product_id, position, is_primary, content_type, width, height, size_bytes, created_at"
+
+type ImageRepository struct {
+	db *sql.DB
+}
+
+func NewImageRepository(db *sql.DB) ImageManager {
+	return &ImageRepository{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanImage(row rowScanner) (models.ProductImage, error) {
+	var image models.ProductImage
+	err := row.Scan(&image.ID, &image.ProductID, &image.Position, &image.Primary, &image.ContentType,
+		&image.Width, &image.Height, &image.SizeBytes, &image.CreatedAt)
+	return image, err
+}
+
+func (ir *ImageRepository) SaveImage(image models.ProductImage) error {
+	_, err := ir.db.Exec("INSERT INTO product_images ("+imageColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		image.ID, image.ProductID, image.Position, image.Primary, image.ContentType,
+		image.Width, image.Height, image.SizeBytes, image.CreatedAt)
+	return err
+}
+
+func (ir *ImageRepository) GetImage(id string) (models.ProductImage, error) {
+	row := ir.db.QueryRow("SELECT "+imageColumns+" FROM product_images WHERE id = ?", id)
+	return scanImage(row)
+}
+
+func (ir *ImageRepository) ListImages(productID string) ([]models.ProductImage, error) {
+	rows, err := ir.db.Query("SELECT "+imageColumns+" FROM product_images WHERE product_id = ? ORDER BY position", productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var images []models.ProductImage
+	for rows.Next() {
+		image, err := scanImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	return images, rows.Err()
+}
+
+// ListImagesForProducts loads the images of a page of products at once,
+// keyed by product id.
+func (ir *ImageRepository) ListImagesForProducts(productIDs []string) (map[string][]models.ProductImage, error) {
+	images := make(map[string][]models.ProductImage)
+	if len(productIDs) == 0 {
+		return images, nil
+	}
+	args := make([]any, len(productIDs))
+	for i, id := range productIDs {
+		args[i] = id
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(productIDs)), ", ")
+	rows, err := ir.db.Query("SELECT "+imageColumns+" FROM product_images WHERE product_id IN ("+placeholders+") ORDER BY product_id, position", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		image, err := scanImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		images[image.ProductID] = append(images[image.ProductID], image)
+	}
+	return images, rows.Err()
+}
+
+// UpdatePositions numbers the product's images in the order given.
+func (ir *ImageRepository) UpdatePositions(productID string, imageIDs []string) error {
+	tx, err := ir.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, id := range imageIDs {
+		result, err := tx.Exec("UPDATE product_images SET position = ? WHERE id = ? AND product_id = ?", i, id, productID)
+		if err != nil {
+			return err
+		}
+		if err := checkAffected(result); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// SetPrimary makes the image the product's only primary image.
+func (ir *ImageRepository) SetPrimary(productID, imageID string) error {
+	tx, err := ir.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE product_images SET is_primary = 0 WHERE product_id = ?", productID)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec("UPDATE product_images SET is_primary = 1 WHERE id = ? AND product_id = ?", imageID, productID)
+	if err != nil {
+		return err
+	}
+	if err := checkAffected(result); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (ir *ImageRepository) DeleteImage(id string) error {
+	result, err := ir.db.Exec("DELETE FROM product_images WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func checkAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package imageRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+var imageRowColumns = []string{"id", "product_id", "position", "is_primary", "content_type", "width", "height", "size_bytes", "created_at"}
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, ImageManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &ImageRepository{db: db}
+}
+
+func TestSaveImage(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_images (id, product_id, position, is_primary, content_type, width, height, size_bytes, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("i1", "p1", 0, true, "image/png", 640, 480, 2048, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	image := models.ProductImage{ID: "i1", ProductID: "p1", Primary: true, ContentType: "image/png", Width: 640, Height: 480, SizeBytes: 2048, CreatedAt: now}
+	if err := repo.SaveImage(image); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetImage(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, product_id, position, is_primary, content_type, width, height, size_bytes, created_at FROM product_images WHERE id = ?")).
+		WithArgs("i1").
+		WillReturnRows(sqlmock.NewRows(imageRowColumns).AddRow("i1", "p1", 2, true, "image/jpeg", 800, 600, 4096, time.Now()))
+
+	image, err := repo.GetImage("i1")
+	if err != nil || image.ProductID != "p1" || image.Position != 2 || !image.Primary || image.Width != 800 {
+		t.Errorf("unexpected image: %+v, err: %v", image, err)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM product_images WHERE id = ?").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.GetImage("missing"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestListImages(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, product_id, position, is_primary, content_type, width, height, size_bytes, created_at FROM product_images WHERE product_id = ? ORDER BY position")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows(imageRowColumns).
+			AddRow("i1", "p1", 0, true, "image/png", 10, 10, 100, time.Now()).
+			AddRow("i2", "p1", 1, false, "image/png", 10, 10, 100, time.Now()))
+
+	images, err := repo.ListImages("p1")
+	if err != nil || len(images) != 2 || images[1].ID != "i2" {
+		t.Errorf("unexpected images: %+v, err: %v", images, err)
+	}
+}
+
+func TestListImagesForProducts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM product_images WHERE product_id IN (?, ?) ORDER BY product_id, position")).
+		WithArgs("p1", "p2").
+		WillReturnRows(sqlmock.NewRows(imageRowColumns).
+			AddRow("i1", "p1", 0, true, "image/png", 10, 10, 100, time.Now()).
+			AddRow("i3", "p2", 0, true, "image/png", 10, 10, 100, time.Now()).
+			AddRow("i4", "p2", 1, false, "image/png", 10, 10, 100, time.Now()))
+
+	images, err := repo.ListImagesForProducts([]string{"p1", "p2"})
+	if err != nil || len(images["p1"]) != 1 || len(images["p2"]) != 2 {
+		t.Errorf("unexpected images: %+v, err: %v", images, err)
+	}
+
+	images, err = repo.ListImagesForProducts(nil)
+	if err != nil || len(images) != 0 {
+		t.Errorf("expected no images without a query, got %+v, err: %v", images, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdatePositions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta("UPDATE product_images SET position = ? WHERE id = ? AND product_id = ?")
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs(0, "i2", "p1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(1, "i1", "p1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.UpdatePositions("p1", []string{"i2", "i1"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(query).WithArgs(0, "other", "p1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	if err := repo.UpdatePositions("p1", []string{"other"}); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestSetPrimary(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product_images SET is_primary = 0 WHERE product_id = ?")).
+		WithArgs("p1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product_images SET is_primary = 1 WHERE id = ? AND product_id = ?")).
+		WithArgs("i2", "p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SetPrimary("p1", "i2"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestDeleteImage(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_images WHERE id = ?")).
+		WithArgs("404").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DeleteImage("404"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
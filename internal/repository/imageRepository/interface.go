@@ -0,0 +1,14 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_imageRepository.go -package=mocks
+package imageRepository
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type ImageManager interface {
+	SaveImage(image models.ProductImage) error
+	GetImage(id string) (models.ProductImage, error)
+	ListImages(productID string) ([]models.ProductImage, error)
+	ListImagesForProducts(productIDs []string) (map[string][]models.ProductImage, error)
+	UpdatePositions(productID string, imageIDs []string) error
+	SetPrimary(productID, imageID string) error
+	DeleteImage(id string) error
+}
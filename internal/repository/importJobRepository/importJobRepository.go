@@ -0,0 +1,83 @@
+package importJobRepository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const jobColumns = "id, created_by, format, dry_run, status, total_rows, processed_rows, created, updated, failed, errors, created_at, finished_at"
+
+type ImportJobRepository struct {
+	db *sql.DB
+}
+
+func NewImportJobRepository(db *sql.DB) ImportJobManager {
+	return &ImportJobRepository{db: db}
+}
+
+func (jr *ImportJobRepository) SaveJob(job models.ImportJob) error {
+	errs, err := encodeErrors(job.Errors)
+	if err != nil {
+		return err
+	}
+	_, err = jr.db.Exec("INSERT INTO import_jobs ("+jobColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		job.ID, job.CreatedBy, job.Format, job.DryRun, job.Status, job.TotalRows, job.ProcessedRows,
+		job.Created, job.Updated, job.Failed, errs, job.CreatedAt, job.FinishedAt)
+	return err
+}
+
+// UpdateJob records the progress and outcome of a job.
+func (jr *ImportJobRepository) UpdateJob(job models.ImportJob) error {
+	errs, err := encodeErrors(job.Errors)
+	if err != nil {
+		return err
+	}
+	_, err = jr.db.Exec(`UPDATE import_jobs SET status = ?, total_rows = ?, processed_rows = ?, created = ?, updated = ?,
+		failed = ?, errors = ?, finished_at = ? WHERE id = ?`,
+		job.Status, job.TotalRows, job.ProcessedRows, job.Created, job.Updated, job.Failed, errs, job.FinishedAt, job.ID)
+	return err
+}
+
+func (jr *ImportJobRepository) GetJob(id string) (models.ImportJob, error) {
+	var job models.ImportJob
+	var errs string
+	var finishedAt sql.NullTime
+	err := jr.db.QueryRow("SELECT "+jobColumns+" FROM import_jobs WHERE id = ?", id).Scan(
+		&job.ID, &job.CreatedBy, &job.Format, &job.DryRun, &job.Status, &job.TotalRows, &job.ProcessedRows,
+		&job.Created, &job.Updated, &job.Failed, &errs, &job.CreatedAt, &finishedAt)
+	if err != nil {
+		return models.ImportJob{}, err
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	job.Errors = []models.ImportRowError{}
+	err = json.Unmarshal([]byte(errs), &job.Errors)
+	if err != nil {
+		return models.ImportJob{}, err
+	}
+	return job, nil
+}
+
+// FailRunningJobs marks jobs that were still running as failed. Jobs run in
+// the server process, so at startup any such job was cut short by a restart.
+func (jr *ImportJobRepository) FailRunningJobs() (int, error) {
+	result, err := jr.db.Exec("UPDATE import_jobs SET status = ?, finished_at = ? WHERE status = ?",
+		models.ImportFailed, time.Now().UTC(), models.ImportRunning)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
+
+func encodeErrors(errs []models.ImportRowError) (string, error) {
+	if errs == nil {
+		errs = []models.ImportRowError{}
+	}
+	encoded, err := json.Marshal(errs)
+	return string(encoded), err
+}
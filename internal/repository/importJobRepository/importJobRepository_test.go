@@ -0,0 +1,94 @@
+package importJobRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+var jobRowColumns = []string{"id", "created_by", "format", "dry_run", "status", "total_rows", "processed_rows", "created", "updated", "failed", "errors", "created_at", "finished_at"}
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, ImportJobManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &ImportJobRepository{db: db}
+}
+
+func TestSaveJob(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO import_jobs ("+jobColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("j1", "u1", "csv", false, models.ImportRunning, 5000, 0, 0, 0, 0, "[]", now, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	job := models.ImportJob{ID: "j1", CreatedBy: "u1", Format: "csv", Status: models.ImportRunning, TotalRows: 5000, CreatedAt: now}
+	if err := repo.SaveJob(job); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateJob(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	finished := time.Now()
+	mock.ExpectExec("UPDATE import_jobs SET status = (.+) WHERE id = ?").
+		WithArgs(models.ImportSucceeded, 3, 3, 1, 1, 1, `[{"line":4,"sku":"X-1","errors":["price must be greater than zero"]}]`, &finished, "j1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	job := models.ImportJob{ID: "j1", Status: models.ImportSucceeded, TotalRows: 3, ProcessedRows: 3, Created: 1, Updated: 1, Failed: 1,
+		Errors:     []models.ImportRowError{{Line: 4, SKU: "X-1", Errors: []string{"price must be greater than zero"}}},
+		FinishedAt: &finished}
+	if err := repo.UpdateJob(job); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetJob(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	created := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + jobColumns + " FROM import_jobs WHERE id = ?")).
+		WithArgs("j1").
+		WillReturnRows(sqlmock.NewRows(jobRowColumns).
+			AddRow("j1", "u1", "jsonl", true, "running", 10, 4, 2, 1, 1, `[{"line":2,"errors":["sku is required"]}]`, created, nil))
+
+	job, err := repo.GetJob("j1")
+	if err != nil || job.Format != "jsonl" || !job.DryRun || job.Status != models.ImportRunning || job.ProcessedRows != 4 {
+		t.Fatalf("unexpected job: %+v, err: %v", job, err)
+	}
+	if len(job.Errors) != 1 || job.Errors[0].Line != 2 || job.FinishedAt != nil {
+		t.Errorf("unexpected job: %+v", job)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM import_jobs WHERE id = ?").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+	if _, err := repo.GetJob("missing"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestFailRunningJobs(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE import_jobs SET status = ?, finished_at = ? WHERE status = ?")).
+		WithArgs(models.ImportFailed, sqlmock.AnyArg(), models.ImportRunning).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	count, err := repo.FailRunningJobs()
+	if err != nil || count != 2 {
+		t.Errorf("expected 2 jobs failed, got %d, err: %v", count, err)
+	}
+}
@@ -0,0 +1,11 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_importJobRepository.go -package=mocks
+package importJobRepository
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type ImportJobManager interface {
+	SaveJob(job models.ImportJob) error
+	UpdateJob(job models.ImportJob) error
+	GetJob(id string) (models.ImportJob, error)
+	FailRunningJobs() (int, error)
+}
@@ -0,0 +1,12 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_inventoryRepository.go -package=mocks
+package inventoryRepository
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type InventoryManager interface {
+	MoveStock(movements []models.StockMovement) ([]models.StockMovement, error)
+	SetStock(movement models.StockMovement, count int) (models.StockMovement, error)
+	ListMovements(productID string, filter models.StockMovementFilter) ([]models.StockMovement, int, error)
+	StockDrift() ([]models.StockDrift, error)
+	RecordBalance(movement models.StockMovement) error
+}
@@ -0,0 +1,194 @@
+package inventoryRepository
+
+import (
+	"database/sql"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const movementColumns = "id, product_id, variant_id, delta, balance, reason, actor_id, reference, note, created_at"
+
+// InsufficientStockError is returned when a movement would take stock below
+// zero.
+type InsufficientStockError struct {
+	Movement models.StockMovement
+}
+
+func (e *InsufficientStockError) Error() string {
+	return "insufficient stock"
+}
+
+type InventoryRepository struct {
+	db *sql.DB
+}
+
+func NewInventoryRepository(db *sql.DB) InventoryManager {
+	return &InventoryRepository{db: db}
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// MoveStock applies the movements to the stock of their products or
+// variants and records them, all or none. The returned movements carry
+// their balances.
+func (ir *InventoryRepository) MoveStock(movements []models.StockMovement) ([]models.StockMovement, error) {
+	tx, err := ir.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	recorded := make([]models.StockMovement, 0, len(movements))
+	for _, movement := range movements {
+		table, where, args := stockRow(movement.ProductID, movement.VariantID)
+		result, err := tx.Exec("UPDATE "+table+" SET stock = stock + ? WHERE "+where+" AND stock + ? >= 0",
+			append(append([]any{movement.Delta}, args...), movement.Delta)...)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		err = tx.QueryRow("SELECT stock FROM "+table+" WHERE "+where, args...).Scan(&movement.Balance)
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, &InsufficientStockError{Movement: movement}
+		}
+		err = addMovement(tx, movement)
+		if err != nil {
+			return nil, err
+		}
+		recorded = append(recorded, movement)
+	}
+	return recorded, tx.Commit()
+}
+
+// SetStock sets the stock of the movement's product or variant to count and
+// records the difference. When the stock already is count nothing is
+// recorded and the returned movement has a zero delta.
+func (ir *InventoryRepository) SetStock(movement models.StockMovement, count int) (models.StockMovement, error) {
+	tx, err := ir.db.Begin()
+	if err != nil {
+		return models.StockMovement{}, err
+	}
+	defer tx.Rollback()
+
+	table, where, args := stockRow(movement.ProductID, movement.VariantID)
+	var stock int
+	err = tx.QueryRow("SELECT stock FROM "+table+" WHERE "+where, args...).Scan(&stock)
+	if err != nil {
+		return models.StockMovement{}, err
+	}
+	movement.Delta, movement.Balance = count-stock, count
+	if movement.Delta == 0 {
+		return movement, nil
+	}
+	_, err = tx.Exec("UPDATE "+table+" SET stock = ? WHERE "+where, append([]any{count}, args...)...)
+	if err != nil {
+		return models.StockMovement{}, err
+	}
+	err = addMovement(tx, movement)
+	if err != nil {
+		return models.StockMovement{}, err
+	}
+	return movement, tx.Commit()
+}
+
+// stockRow returns the table and condition that select the stock a movement
+// changes: the variant's when it has one, else the product's.
+func stockRow(productID, variantID string) (string, string, []any) {
+	if variantID != "" {
+		return "product_variants", "id = ? AND product_id = ?", []any{variantID, productID}
+	}
+	return "products", "id = ?", []any{productID}
+}
+
+func addMovement(db execer, movement models.StockMovement) error {
+	_, err := db.Exec("INSERT INTO stock_movements ("+movementColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		movement.ID, movement.ProductID, nullString(movement.VariantID), movement.Delta, movement.Balance, movement.Reason,
+		nullString(movement.ActorID), nullString(movement.Reference), movement.Note, movement.CreatedAt)
+	return err
+}
+
+func nullString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// ListMovements returns a page of the product's ledger, newest first, and
+// the number of entries in all.
+func (ir *InventoryRepository) ListMovements(productID string, filter models.StockMovementFilter) ([]models.StockMovement, int, error) {
+	where := " WHERE product_id = ?"
+	args := []any{productID}
+	if filter.VariantID != "" {
+		where += " AND variant_id = ?"
+		args = append(args, filter.VariantID)
+	}
+	var total int
+	err := ir.db.QueryRow("SELECT COUNT(*) FROM stock_movements"+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+	rows, err := ir.db.Query("SELECT "+movementColumns+" FROM stock_movements"+where+" ORDER BY created_at DESC, rowid DESC LIMIT ? OFFSET ?",
+		append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var movements []models.StockMovement
+	for rows.Next() {
+		var movement models.StockMovement
+		var variantID, actorID, reference sql.NullString
+		err := rows.Scan(&movement.ID, &movement.ProductID, &variantID, &movement.Delta, &movement.Balance, &movement.Reason,
+			&actorID, &reference, &movement.Note, &movement.CreatedAt)
+		if err != nil {
+			return nil, 0, err
+		}
+		movement.VariantID, movement.ActorID, movement.Reference = variantID.String, actorID.String, reference.String
+		movements = append(movements, movement)
+	}
+	return movements, total, rows.Err()
+}
+
+// StockDrift returns the products and variants whose stock is not the sum of
+// their ledger entries.
+func (ir *InventoryRepository) StockDrift() ([]models.StockDrift, error) {
+	rows, err := ir.db.Query(`SELECT p.id, '', p.stock, COALESCE(SUM(m.delta), 0), COUNT(m.id) FROM products p
+		LEFT JOIN stock_movements m ON m.product_id = p.id AND m.variant_id IS NULL
+		GROUP BY p.id HAVING p.stock <> COALESCE(SUM(m.delta), 0)
+		UNION ALL
+		SELECT v.product_id, v.id, v.stock, COALESCE(SUM(m.delta), 0), COUNT(m.id) FROM product_variants v
+		LEFT JOIN stock_movements m ON m.variant_id = v.id
+		GROUP BY v.id HAVING v.stock <> COALESCE(SUM(m.delta), 0)`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var drifts []models.StockDrift
+	for rows.Next() {
+		var drift models.StockDrift
+		err := rows.Scan(&drift.ProductID, &drift.VariantID, &drift.Stock, &drift.LedgerStock, &drift.Entries)
+		if err != nil {
+			return nil, err
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, rows.Err()
+}
+
+// RecordBalance records stock that is already held but missing from the
+// ledger, for products and variants that predate it or drifted from it. The
+// stock itself is left as it is.
+func (ir *InventoryRepository) RecordBalance(movement models.StockMovement) error {
+	return addMovement(ir.db, movement)
+}
@@ -0,0 +1,191 @@
+package inventoryRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+var movementRowColumns = []string{"id", "product_id", "variant_id", "delta", "balance", "reason", "actor_id", "reference", "note", "created_at"}
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, InventoryManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &InventoryRepository{db: db}
+}
+
+func TestMoveStock(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET stock = stock + ? WHERE id = ? AND stock + ? >= 0")).
+		WithArgs(-2, "p1", -2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT stock FROM products WHERE id = ?")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(8))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO stock_movements ("+movementColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("m1", "p1", nil, -2, 8, models.StockSale, "u1", "o1", "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product_variants SET stock = stock + ? WHERE id = ? AND product_id = ? AND stock + ? >= 0")).
+		WithArgs(-1, "v1", "p2", -1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT stock FROM product_variants WHERE id = ? AND product_id = ?")).
+		WithArgs("v1", "p2").
+		WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(0))
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs("m2", "p2", "v1", -1, 0, models.StockSale, "u1", "o1", "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	movements, err := repo.MoveStock([]models.StockMovement{
+		{ID: "m1", ProductID: "p1", Delta: -2, Reason: models.StockSale, ActorID: "u1", Reference: "o1", CreatedAt: now},
+		{ID: "m2", ProductID: "p2", VariantID: "v1", Delta: -1, Reason: models.StockSale, ActorID: "u1", Reference: "o1", CreatedAt: now},
+	})
+	if err != nil || len(movements) != 2 || movements[0].Balance != 8 || movements[1].Balance != 0 {
+		t.Errorf("unexpected result: %+v, err: %v", movements, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestMoveStockInsufficient(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products SET stock").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT stock FROM products").WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(3))
+	mock.ExpectExec("INSERT INTO stock_movements").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE products SET stock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT stock FROM products").WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(1))
+	mock.ExpectRollback()
+
+	_, err := repo.MoveStock([]models.StockMovement{
+		{ID: "m1", ProductID: "p1", Delta: -1},
+		{ID: "m2", ProductID: "p2", Delta: -2},
+	})
+	var insufficient *InsufficientStockError
+	if !errors.As(err, &insufficient) || insufficient.Movement.ID != "m2" || insufficient.Movement.Balance != 1 {
+		t.Errorf("expected an InsufficientStockError for m2, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestMoveStockMissingProduct(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products SET stock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT stock FROM products").WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, err := repo.MoveStock([]models.StockMovement{{ID: "m1", ProductID: "gone", Delta: 5}})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestSetStock(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT stock FROM products WHERE id = ?")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(10))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET stock = ? WHERE id = ?")).
+		WithArgs(4, "p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs("m1", "p1", nil, -6, 4, models.StockImport, "u1", "j1", "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	movement, err := repo.SetStock(models.StockMovement{ID: "m1", ProductID: "p1", Reason: models.StockImport, ActorID: "u1", Reference: "j1", CreatedAt: now}, 4)
+	if err != nil || movement.Delta != -6 || movement.Balance != 4 {
+		t.Errorf("unexpected result: %+v, err: %v", movement, err)
+	}
+
+	// An unchanged count records nothing
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT stock FROM products").WillReturnRows(sqlmock.NewRows([]string{"stock"}).AddRow(4))
+	mock.ExpectRollback()
+	movement, err = repo.SetStock(models.StockMovement{ID: "m2", ProductID: "p1"}, 4)
+	if err != nil || movement.Delta != 0 {
+		t.Errorf("unexpected result: %+v, err: %v", movement, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestListMovements(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM stock_movements WHERE product_id = ? AND variant_id = ?")).
+		WithArgs("p1", "v1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM stock_movements WHERE product_id = ? AND variant_id = ? ORDER BY created_at DESC, rowid DESC LIMIT ? OFFSET ?")).
+		WithArgs("p1", "v1", 2, 0).
+		WillReturnRows(sqlmock.NewRows(movementRowColumns).
+			AddRow("m3", "p1", "v1", -1, 4, "sale", "u1", "o1", "", now).
+			AddRow("m2", "p1", "v1", 5, 5, "adjustment", "admin1", nil, "restocked", now))
+
+	movements, total, err := repo.ListMovements("p1", models.StockMovementFilter{VariantID: "v1", Limit: 2})
+	if err != nil || total != 3 || len(movements) != 2 {
+		t.Fatalf("unexpected result: %+v, total %d, err: %v", movements, total, err)
+	}
+	if movements[0].Reference != "o1" || movements[1].Reference != "" || movements[1].Note != "restocked" || movements[1].VariantID != "v1" {
+		t.Errorf("unexpected movements: %+v", movements)
+	}
+}
+
+func TestStockDrift(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT p.id, '', p.stock, COALESCE\\(SUM\\(m.delta\\), 0\\), COUNT\\(m.id\\) FROM products p (.+) UNION ALL").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "variant_id", "stock", "ledger_stock", "entries"}).
+			AddRow("p1", "", 10, 0, 0).
+			AddRow("p2", "v1", 3, 5, 2))
+
+	drifts, err := repo.StockDrift()
+	if err != nil || len(drifts) != 2 || drifts[1].VariantID != "v1" || drifts[1].LedgerStock != 5 || drifts[1].Entries != 2 {
+		t.Errorf("unexpected result: %+v, err: %v", drifts, err)
+	}
+}
+
+func TestRecordBalance(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs("m1", "p1", nil, 10, 10, models.StockOpening, nil, nil, "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.RecordBalance(models.StockMovement{ID: "m1", ProductID: "p1", Delta: 10, Balance: 10, Reason: models.StockOpening, CreatedAt: now})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_loginAttemptRepository.go -package=mocks
+package loginAttemptRepository
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type LoginAttemptManager interface {
+	GetLoginAttempt(key string) (models.LoginAttempt, error)
+	SaveLoginAttempt(attempt models.LoginAttempt) error
+	DeleteLoginAttempt(key string) error
+	DeleteStaleLoginAttempts(before time.Time) error
+}
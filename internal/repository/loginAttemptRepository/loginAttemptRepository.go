@@ -0,0 +1,49 @@
+package loginAttemptRepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type LoginAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewLoginAttemptRepository(db *sql.DB) LoginAttemptManager {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (lr *LoginAttemptRepository) GetLoginAttempt(key string) (models.LoginAttempt, error) {
+	row := lr.db.QueryRow("SELECT key, failures, last_failure_at, locked_until FROM login_attempts WHERE key = ?", key)
+	var attempt models.LoginAttempt
+	var lockedUntil sql.NullTime
+	err := row.Scan(&attempt.Key, &attempt.Failures, &attempt.LastFailureAt, &lockedUntil)
+	if err != nil {
+		return models.LoginAttempt{}, err
+	}
+	if lockedUntil.Valid {
+		attempt.LockedUntil = &lockedUntil.Time
+	}
+	return attempt, nil
+}
+
+func (lr *LoginAttemptRepository) SaveLoginAttempt(attempt models.LoginAttempt) error {
+	_, err := lr.db.Exec(`INSERT INTO login_attempts (key, failures, last_failure_at, locked_until) VALUES (?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET failures = excluded.failures, last_failure_at = excluded.last_failure_at, locked_until = excluded.locked_until`,
+		attempt.Key, attempt.Failures, attempt.LastFailureAt, attempt.LockedUntil)
+	return err
+}
+
+func (lr *LoginAttemptRepository) DeleteLoginAttempt(key string) error {
+	_, err := lr.db.Exec("DELETE FROM login_attempts WHERE key = ?", key)
+	return err
+}
+
+// DeleteStaleLoginAttempts drops counters whose last failure is older than
+// before and that are not currently locked.
+func (lr *LoginAttemptRepository) DeleteStaleLoginAttempts(before time.Time) error {
+	_, err := lr.db.Exec("DELETE FROM login_attempts WHERE last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, before)
+	return err
+}
@@ -0,0 +1,76 @@
+package loginAttemptRepository
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, LoginAttemptManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &LoginAttemptRepository{db: db}
+}
+
+func TestGetLoginAttempt(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT key, failures, last_failure_at, locked_until FROM login_attempts WHERE key = ?")).
+		WithArgs("account:a@b.com").
+		WillReturnRows(sqlmock.NewRows([]string{"key", "failures", "last_failure_at", "locked_until"}).
+			AddRow("account:a@b.com", 4, now, now.Add(time.Minute)))
+
+	attempt, err := repo.GetLoginAttempt("account:a@b.com")
+	if err != nil || attempt.Failures != 4 || !attempt.IsLocked(now) {
+		t.Errorf("unexpected attempt: %+v, err: %v", attempt, err)
+	}
+}
+
+func TestSaveLoginAttempt(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO login_attempts (key, failures, last_failure_at, locked_until) VALUES (?, ?, ?, ?)")).
+		WithArgs("ip:1.2.3.4", 1, now, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.SaveLoginAttempt(models.LoginAttempt{Key: "ip:1.2.3.4", Failures: 1, LastFailureAt: now}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteLoginAttempt(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM login_attempts WHERE key = ?")).
+		WithArgs("account:a@b.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.DeleteLoginAttempt("account:a@b.com"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteStaleLoginAttempts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	before := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM login_attempts WHERE last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)")).
+		WithArgs(before, before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	if err := repo.DeleteStaleLoginAttempts(before); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
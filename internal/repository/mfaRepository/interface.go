@@ -0,0 +1,18 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_mfaRepository.go -package=mocks
+package mfaRepository
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type MFAManager interface {
+	SaveMFASecret(userID, secret string) error
+	GetMFAByUserID(userID string) (models.UserMFA, error)
+	EnableMFA(userID string, enabledAt time.Time) error
+	UpdateLastUsedStep(userID string, step int64) error
+	DeleteMFA(userID string) error
+	ReplaceRecoveryCodes(userID string, codeHashes []string) error
+	UseRecoveryCode(userID, codeHash string, usedAt time.Time) error
+}
@@ -0,0 +1,113 @@
+package mfaRepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+type MFARepository struct {
+	db *sql.DB
+}
+
+func NewMFARepository(db *sql.DB) MFAManager {
+	return &MFARepository{db: db}
+}
+
+// SaveMFASecret stores a new pending secret, replacing any previous one.
+func (mr *MFARepository) SaveMFASecret(userID, secret string) error {
+	_, err := mr.db.Exec(`INSERT INTO user_mfa (user_id, secret, enabled_at, last_used_step) VALUES (?, ?, NULL, 0)
+		ON CONFLICT(user_id) DO UPDATE SET secret = excluded.secret, enabled_at = NULL, last_used_step = 0`, userID, secret)
+	return err
+}
+
+func (mr *MFARepository) GetMFAByUserID(userID string) (models.UserMFA, error) {
+	row := mr.db.QueryRow("SELECT user_id, secret, enabled_at, last_used_step FROM user_mfa WHERE user_id = ?", userID)
+	var mfa models.UserMFA
+	var enabledAt sql.NullTime
+	err := row.Scan(&mfa.UserID, &mfa.Secret, &enabledAt, &mfa.LastUsedStep)
+	if err != nil {
+		return models.UserMFA{}, err
+	}
+	if enabledAt.Valid {
+		mfa.EnabledAt = &enabledAt.Time
+	}
+	return mfa, nil
+}
+
+func (mr *MFARepository) EnableMFA(userID string, enabledAt time.Time) error {
+	result, err := mr.db.Exec("UPDATE user_mfa SET enabled_at = ? WHERE user_id = ?", enabledAt, userID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+// UpdateLastUsedStep only moves forward, so a code can be used at most once
+// even when two requests race with it.
+func (mr *MFARepository) UpdateLastUsedStep(userID string, step int64) error {
+	result, err := mr.db.Exec("UPDATE user_mfa SET last_used_step = ? WHERE user_id = ? AND last_used_step < ?", step, userID, step)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func (mr *MFARepository) DeleteMFA(userID string) error {
+	tx, err := mr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM mfa_recovery_codes WHERE user_id = ?", userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM user_mfa WHERE user_id = ?", userID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (mr *MFARepository) ReplaceRecoveryCodes(userID string, codeHashes []string) error {
+	tx, err := mr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM mfa_recovery_codes WHERE user_id = ?", userID)
+	if err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		_, err = tx.Exec("INSERT INTO mfa_recovery_codes (id, user_id, code_hash) VALUES (?, ?, ?)", utils.NewUUID(), userID, hash)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (mr *MFARepository) UseRecoveryCode(userID, codeHash string, usedAt time.Time) error {
+	result, err := mr.db.Exec("UPDATE mfa_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL", usedAt, userID, codeHash)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func checkAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
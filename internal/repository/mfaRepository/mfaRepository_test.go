@@ -0,0 +1,129 @@
+package mfaRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, MFAManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &MFARepository{db: db}
+}
+
+func TestSaveMFASecret(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_mfa (user_id, secret, enabled_at, last_used_step) VALUES (?, ?, NULL, 0)")).
+		WithArgs("u1", "SECRET").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.SaveMFASecret("u1", "SECRET"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetMFAByUserID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	enabledAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, secret, enabled_at, last_used_step FROM user_mfa WHERE user_id = ?")).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "secret", "enabled_at", "last_used_step"}).
+			AddRow("u1", "SECRET", enabledAt, 42))
+
+	mfa, err := repo.GetMFAByUserID("u1")
+	if err != nil || mfa.Secret != "SECRET" || !mfa.IsEnabled() || mfa.LastUsedStep != 42 {
+		t.Errorf("unexpected mfa: %+v, err: %v", mfa, err)
+	}
+}
+
+func TestUpdateLastUsedStep(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta("UPDATE user_mfa SET last_used_step = ? WHERE user_id = ? AND last_used_step < ?")
+	mock.ExpectExec(query).WithArgs(int64(10), "u1", int64(10)).WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.UpdateLastUsedStep("u1", 10); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectExec(query).WithArgs(int64(10), "u1", int64(10)).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := repo.UpdateLastUsedStep("u1", 10); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected ErrNoRows for replayed step, got %v", err)
+	}
+}
+
+func TestEnableMFA(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE user_mfa SET enabled_at = ? WHERE user_id = ?")).
+		WithArgs(now, "u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.EnableMFA("u1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteMFA(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM mfa_recovery_codes WHERE user_id = ?")).WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_mfa WHERE user_id = ?")).WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := repo.DeleteMFA("u1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReplaceRecoveryCodes(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM mfa_recovery_codes WHERE user_id = ?")).WithArgs("u1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO mfa_recovery_codes (id, user_id, code_hash) VALUES (?, ?, ?)")).
+		WithArgs(sqlmock.AnyArg(), "u1", "h1").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO mfa_recovery_codes (id, user_id, code_hash) VALUES (?, ?, ?)")).
+		WithArgs(sqlmock.AnyArg(), "u1", "h2").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.ReplaceRecoveryCodes("u1", []string{"h1", "h2"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	query := regexp.QuoteMeta("UPDATE mfa_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL")
+	mock.ExpectExec(query).WithArgs(now, "u1", "h1").WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.UseRecoveryCode("u1", "h1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectExec(query).WithArgs(now, "u1", "h1").WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := repo.UseRecoveryCode("u1", "h1", now); err == nil {
+		t.Error("expected error for used code")
+	}
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_oidcRepository.go -package=mocks
+package oidcRepository
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type OIDCManager interface {
+	SaveLoginState(state models.OIDCLoginState) error
+	ConsumeLoginState(stateHash string) (models.OIDCLoginState, error)
+	DeleteExpiredLoginStates(before time.Time) error
+	GetIdentity(issuer, subject string) (models.UserIdentity, error)
+	SaveIdentity(identity models.UserIdentity) error
+}
@@ -0,0 +1,55 @@
+package oidcRepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type OIDCRepository struct {
+	db *sql.DB
+}
+
+func NewOIDCRepository(db *sql.DB) OIDCManager {
+	return &OIDCRepository{db: db}
+}
+
+func (or *OIDCRepository) SaveLoginState(state models.OIDCLoginState) error {
+	_, err := or.db.Exec("INSERT INTO oidc_login_states (state_hash, nonce, code_verifier, expires_at) VALUES (?, ?, ?, ?)",
+		state.StateHash, state.Nonce, state.CodeVerifier, state.ExpiresAt)
+	return err
+}
+
+// ConsumeLoginState deletes the state while reading it, so a callback can only
+// be completed once. It fails with sql.ErrNoRows for unknown states.
+func (or *OIDCRepository) ConsumeLoginState(stateHash string) (models.OIDCLoginState, error) {
+	state := models.OIDCLoginState{StateHash: stateHash}
+	err := or.db.QueryRow("DELETE FROM oidc_login_states WHERE state_hash = ? RETURNING nonce, code_verifier, expires_at", stateHash).
+		Scan(&state.Nonce, &state.CodeVerifier, &state.ExpiresAt)
+	if err != nil {
+		return models.OIDCLoginState{}, err
+	}
+	return state, nil
+}
+
+func (or *OIDCRepository) DeleteExpiredLoginStates(before time.Time) error {
+	_, err := or.db.Exec("DELETE FROM oidc_login_states WHERE expires_at < ?", before)
+	return err
+}
+
+func (or *OIDCRepository) GetIdentity(issuer, subject string) (models.UserIdentity, error) {
+	identity := models.UserIdentity{Issuer: issuer, Subject: subject}
+	err := or.db.QueryRow("SELECT user_id, email, created_at FROM user_identities WHERE issuer = ? AND subject = ?", issuer, subject).
+		Scan(&identity.UserID, &identity.Email, &identity.CreatedAt)
+	if err != nil {
+		return models.UserIdentity{}, err
+	}
+	return identity, nil
+}
+
+func (or *OIDCRepository) SaveIdentity(identity models.UserIdentity) error {
+	_, err := or.db.Exec("INSERT INTO user_identities (issuer, subject, user_id, email, created_at) VALUES (?, ?, ?, ?, ?)",
+		identity.Issuer, identity.Subject, identity.UserID, identity.Email, identity.CreatedAt)
+	return err
+}
@@ -0,0 +1,134 @@
+package oidcRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, OIDCManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &OIDCRepository{db: db}
+}
+
+func TestSaveLoginState(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	expiresAt := time.Now().Add(10 * time.Minute)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO oidc_login_states")).
+		WithArgs("hash", "nonce", "verifier", expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SaveLoginState(models.OIDCLoginState{StateHash: "hash", Nonce: "nonce", CodeVerifier: "verifier", ExpiresAt: expiresAt})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConsumeLoginState(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "Success",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM oidc_login_states WHERE state_hash = ? RETURNING")).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows([]string{"nonce", "code_verifier", "expires_at"}).
+						AddRow("nonce", "verifier", time.Now()))
+			},
+		},
+		{
+			name: "Unknown state",
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM oidc_login_states")).
+					WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows([]string{"nonce", "code_verifier", "expires_at"}))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMockDB(t)
+			defer db.Close()
+			tt.setup(mock)
+
+			state, err := repo.ConsumeLoginState("hash")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if state.StateHash != "hash" || state.Nonce != "nonce" || state.CodeVerifier != "verifier" {
+				t.Errorf("unexpected state: %+v", state)
+			}
+		})
+	}
+}
+
+func TestDeleteExpiredLoginStates(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM oidc_login_states WHERE expires_at < ?")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	err := repo.DeleteExpiredLoginStates(now)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetIdentity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_id, email, created_at FROM user_identities WHERE issuer = ? AND subject = ?")).
+		WithArgs("https://idp", "sub-1").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "created_at"}).
+			AddRow("u1", "alice@corp.example", time.Now()))
+
+	identity, err := repo.GetIdentity("https://idp", "sub-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if identity.UserID != "u1" || identity.Issuer != "https://idp" || identity.Subject != "sub-1" {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+}
+
+func TestSaveIdentity(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	createdAt := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO user_identities")).
+		WithArgs("https://idp", "sub-1", "u1", "alice@corp.example", createdAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.SaveIdentity(models.UserIdentity{
+		Issuer: "https://idp", Subject: "sub-1", UserID: "u1", Email: "alice@corp.example", CreatedAt: createdAt,
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
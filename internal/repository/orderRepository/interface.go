@@ -0,0 +1,11 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_orderRepository.go -package=mocks
+package orderRepository
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type OrderManager interface {
+	SaveOrder(order models.Order) error
+	GetOrdersByUserID(userID string) ([]models.Order, error)
+	ListOrders(filter models.OrderFilter) ([]models.Order, int, error)
+	CountProductOrders(productID string) (int, error)
+}
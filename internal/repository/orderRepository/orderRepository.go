@@ -0,0 +1,147 @@
+package orderRepository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type OrderRepository struct {
+	db *sql.DB
+}
+
+func NewOrderRepository(db *sql.DB) OrderManager {
+	return &OrderRepository{db: db}
+}
+
+func (or *OrderRepository) SaveOrder(order models.Order) error {
+	tx, err := or.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("INSERT INTO orders (id, user_id, total, coupon_code, created_at) VALUES (?, ?, ?, ?, ?)",
+		order.ID, order.UserID, order.Total, order.CouponCode, order.CreatedAt)
+	if err != nil {
+		return err
+	}
+	for _, item := range order.Items {
+		var variantID, sku, options any
+		if item.VariantID != "" {
+			encoded, err := json.Marshal(item.Options)
+			if err != nil {
+				return err
+			}
+			variantID, sku, options = item.VariantID, item.SKU, string(encoded)
+		}
+		_, err = tx.Exec("INSERT INTO order_items (order_id, product_id, product_name, price, quantity, variant_id, sku, options) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			order.ID, item.ProductID, item.ProductName, item.Price, item.Quantity, variantID, sku, options)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetOrdersByUserID returns the user's orders, newest first, with their items.
+func (or *OrderRepository) GetOrdersByUserID(userID string) ([]models.Order, error) {
+	rows, err := or.db.Query(`SELECT o.id, o.user_id, o.total, COALESCE(o.coupon_code, ''), o.created_at,
+		i.product_id, i.product_name, i.price, i.quantity, i.variant_id, i.sku, i.options
+		FROM orders o LEFT JOIN order_items i ON i.order_id = o.id
+		WHERE o.user_id = ? ORDER BY o.created_at DESC, o.id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanOrders(rows)
+}
+
+// ListOrders returns one page of orders matching the filter, newest first,
+// with their items, along with the total number of matches.
+func (or *OrderRepository) ListOrders(filter models.OrderFilter) ([]models.Order, int, error) {
+	var conditions []string
+	var args []any
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.Since)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	err := or.db.QueryRow("SELECT COUNT(*) FROM orders"+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := or.db.Query(`SELECT o.id, o.user_id, o.total, COALESCE(o.coupon_code, ''), o.created_at,
+		i.product_id, i.product_name, i.price, i.quantity, i.variant_id, i.sku, i.options
+		FROM (SELECT * FROM orders`+where+` ORDER BY created_at DESC, id LIMIT ? OFFSET ?) o
+		LEFT JOIN order_items i ON i.order_id = o.id
+		ORDER BY o.created_at DESC, o.id`, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	orders, err := scanOrders(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// scanOrders folds order rows joined with their items, one row per item, into
+// orders. Rows of the same order must be adjacent.
+func scanOrders(rows *sql.Rows) ([]models.Order, error) {
+	var orders []models.Order
+	for rows.Next() {
+		var order models.Order
+		var productID, productName, variantID, sku, options sql.NullString
+		var price sql.NullFloat64
+		var quantity sql.NullInt64
+		err := rows.Scan(&order.ID, &order.UserID, &order.Total, &order.CouponCode, &order.CreatedAt,
+			&productID, &productName, &price, &quantity, &variantID, &sku, &options)
+		if err != nil {
+			return nil, err
+		}
+		if len(orders) == 0 || orders[len(orders)-1].ID != order.ID {
+			orders = append(orders, order)
+		}
+		if productID.Valid {
+			item := models.OrderItem{
+				ProductID:   productID.String,
+				ProductName: productName.String,
+				VariantID:   variantID.String,
+				SKU:         sku.String,
+				Price:       float32(price.Float64),
+				Quantity:    int(quantity.Int64),
+			}
+			if options.Valid {
+				err = json.Unmarshal([]byte(options.String), &item.Options)
+				if err != nil {
+					return nil, err
+				}
+			}
+			last := &orders[len(orders)-1]
+			last.Items = append(last.Items, item)
+		}
+	}
+	return orders, rows.Err()
+}
+
+// CountProductOrders returns how many orders include the product.
+func (or *OrderRepository) CountProductOrders(productID string) (int, error) {
+	var count int
+	err := or.db.QueryRow("SELECT COUNT(DISTINCT order_id) FROM order_items WHERE product_id = ?", productID).Scan(&count)
+	return count, err
+}
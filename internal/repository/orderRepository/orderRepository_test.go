@@ -0,0 +1,110 @@
+package orderRepository
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, OrderManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &OrderRepository{db: db}
+}
+
+func TestSaveOrder(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	order := models.Order{ID: "o1", UserID: "u1", Total: 180, CouponCode: "SAVE10", CreatedAt: now,
+		Items: []models.OrderItem{
+			{ProductID: "p1", ProductName: "Item1", Price: 100, Quantity: 2},
+			{ProductID: "p2", ProductName: "Keyboard", VariantID: "v1", SKU: "KB-UK", Options: map[string]string{"Layout": "UK"}, Price: 1300, Quantity: 1},
+		}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO orders (id, user_id, total, coupon_code, created_at) VALUES (?, ?, ?, ?, ?)")).
+		WithArgs("o1", "u1", float32(180), "SAVE10", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO order_items (order_id, product_id, product_name, price, quantity, variant_id, sku, options) VALUES (?, ?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("o1", "p1", "Item1", float32(100), 2, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO order_items (order_id, product_id, product_name, price, quantity, variant_id, sku, options) VALUES (?, ?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("o1", "p2", "Keyboard", float32(1300), 1, "v1", "KB-UK", `{"Layout":"UK"}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SaveOrder(order); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestGetOrdersByUserID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT o.id, o.user_id, o.total").
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total", "coupon_code", "created_at", "product_id", "product_name", "price", "quantity", "variant_id", "sku", "options"}).
+			AddRow("o2", "u1", 50, "", now, "p2", "Item2", 50, 1, "v1", "KB-UK", `{"Layout":"UK"}`).
+			AddRow("o1", "u1", 180, "SAVE10", now.Add(-time.Hour), "p1", "Item1", 100, 1, nil, nil, nil).
+			AddRow("o1", "u1", 180, "SAVE10", now.Add(-time.Hour), "p3", "Item3", 100, 1, nil, nil, nil))
+
+	orders, err := repo.GetOrdersByUserID("u1")
+	if err != nil || len(orders) != 2 || len(orders[0].Items) != 1 || len(orders[1].Items) != 2 || orders[1].CouponCode != "SAVE10" {
+		t.Errorf("unexpected orders: %+v, err: %v", orders, err)
+	}
+	if item := orders[0].Items[0]; item.SKU != "KB-UK" || item.Options["Layout"] != "UK" {
+		t.Errorf("unexpected variant item: %+v", item)
+	}
+}
+
+func TestListOrders(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	since := now.Add(-24 * time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM orders WHERE user_id = ? AND created_at >= ?")).
+		WithArgs("u1", since).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM (SELECT * FROM orders WHERE user_id = ? AND created_at >= ? ORDER BY created_at DESC, id LIMIT ? OFFSET ?) o")).
+		WithArgs("u1", since, 2, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "total", "coupon_code", "created_at", "product_id", "product_name", "price", "quantity", "variant_id", "sku", "options"}).
+			AddRow("o3", "u1", 50, "", now, "p2", "Item2", 50, 1, nil, nil, nil).
+			AddRow("o2", "u1", 180, "", now.Add(-time.Hour), "p1", "Item1", 100, 1, nil, nil, nil).
+			AddRow("o2", "u1", 180, "", now.Add(-time.Hour), "p3", "Item3", 80, 1, nil, nil, nil))
+
+	orders, total, err := repo.ListOrders(models.OrderFilter{UserID: "u1", Since: &since, Limit: 2})
+	if err != nil || total != 3 || len(orders) != 2 || len(orders[1].Items) != 2 {
+		t.Errorf("unexpected orders: %+v, total: %d, err: %v", orders, total, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestCountProductOrders(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(DISTINCT order_id) FROM order_items WHERE product_id = ?")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.CountProductOrders("p1")
+	if err != nil || count != 2 {
+		t.Errorf("unexpected count %d, err: %v", count, err)
+	}
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_priceRepository.go -package=mocks
+package priceRepository
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type PriceManager interface {
+	AddPriceChange(change models.PriceChange) error
+	ListPriceChanges(productID string) ([]models.PriceChange, error)
+	SaveSchedule(schedule models.PriceSchedule) error
+	GetSchedule(id string) (models.PriceSchedule, error)
+	ListSchedules(productID string) ([]models.PriceSchedule, error)
+	DueSchedules(now time.Time) ([]models.PriceSchedule, error)
+	UpdateScheduleStatus(id string, status models.ScheduleStatus) error
+	ApplyScheduledPrice(change models.PriceChange, status models.ScheduleStatus) error
+}
@@ -0,0 +1,159 @@
+package priceRepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const (
+	changeColumns   = "id, product_id, price, compare_at_price, reason, schedule_id, changed_at"
+	scheduleColumns = "id, product_id, price, starts_at, ends_at, status, created_by, created_at"
+)
+
+type PriceRepository struct {
+	db *sql.DB
+}
+
+func NewPriceRepository(db *sql.DB) PriceManager {
+	return &PriceRepository{db: db}
+}
+
+func (pr *PriceRepository) AddPriceChange(change models.PriceChange) error {
+	return addPriceChange(pr.db, change)
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func addPriceChange(db execer, change models.PriceChange) error {
+	var scheduleID any
+	if change.ScheduleID != "" {
+		scheduleID = change.ScheduleID
+	}
+	_, err := db.Exec("INSERT INTO price_history ("+changeColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		change.ID, change.ProductID, change.Price, change.CompareAtPrice, change.Reason, scheduleID, change.ChangedAt)
+	return err
+}
+
+// ListPriceChanges returns the product's price history, oldest first.
+func (pr *PriceRepository) ListPriceChanges(productID string) ([]models.PriceChange, error) {
+	rows, err := pr.db.Query("SELECT "+changeColumns+" FROM price_history WHERE product_id = ? ORDER BY changed_at, rowid", productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []models.PriceChange
+	for rows.Next() {
+		var change models.PriceChange
+		var compareAt sql.NullFloat64
+		var scheduleID sql.NullString
+		err := rows.Scan(&change.ID, &change.ProductID, &change.Price, &compareAt, &change.Reason, &scheduleID, &change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+		if compareAt.Valid {
+			price := float32(compareAt.Float64)
+			change.CompareAtPrice = &price
+		}
+		change.ScheduleID = scheduleID.String
+		changes = append(changes, change)
+	}
+	return changes, rows.Err()
+}
+
+func (pr *PriceRepository) SaveSchedule(schedule models.PriceSchedule) error {
+	_, err := pr.db.Exec("INSERT INTO price_schedules ("+scheduleColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		schedule.ID, schedule.ProductID, schedule.Price, schedule.StartsAt, schedule.EndsAt, schedule.Status,
+		schedule.CreatedBy, schedule.CreatedAt)
+	return err
+}
+
+func (pr *PriceRepository) GetSchedule(id string) (models.PriceSchedule, error) {
+	row := pr.db.QueryRow("SELECT "+scheduleColumns+" FROM price_schedules WHERE id = ?", id)
+	return scanSchedule(row)
+}
+
+// ListSchedules returns the product's schedules in the order they start.
+func (pr *PriceRepository) ListSchedules(productID string) ([]models.PriceSchedule, error) {
+	return pr.querySchedules("SELECT "+scheduleColumns+" FROM price_schedules WHERE product_id = ? ORDER BY starts_at, created_at", productID)
+}
+
+// DueSchedules returns the schedules that should have started or ended by
+// now, in the order they fell due, so that a sale ends before the next
+// schedule of the same product starts.
+func (pr *PriceRepository) DueSchedules(now time.Time) ([]models.PriceSchedule, error) {
+	return pr.querySchedules(`SELECT `+scheduleColumns+` FROM price_schedules
+		WHERE (status = ? AND starts_at <= ?) OR (status = ? AND ends_at <= ?)
+		ORDER BY CASE status WHEN ? THEN ends_at ELSE starts_at END, created_at`,
+		models.SchedulePending, now, models.ScheduleActive, now, models.ScheduleActive)
+}
+
+func (pr *PriceRepository) querySchedules(query string, args ...any) ([]models.PriceSchedule, error) {
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []models.PriceSchedule
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+func (pr *PriceRepository) UpdateScheduleStatus(id string, status models.ScheduleStatus) error {
+	_, err := pr.db.Exec("UPDATE price_schedules SET status = ? WHERE id = ?", status, id)
+	return err
+}
+
+// ApplyScheduledPrice sets the product's prices from the change, records the
+// change and moves its schedule to status, all in one transaction so that a
+// restart can not apply a schedule twice.
+func (pr *PriceRepository) ApplyScheduledPrice(change models.PriceChange, status models.ScheduleStatus) error {
+	tx, err := pr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE products SET price = ?, compare_at_price = ? WHERE id = ?", change.Price, change.CompareAtPrice, change.ProductID)
+	if err != nil {
+		return err
+	}
+	err = addPriceChange(tx, change)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("UPDATE price_schedules SET status = ? WHERE id = ?", status, change.ScheduleID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSchedule(row rowScanner) (models.PriceSchedule, error) {
+	var schedule models.PriceSchedule
+	var endsAt sql.NullTime
+	err := row.Scan(&schedule.ID, &schedule.ProductID, &schedule.Price, &schedule.StartsAt, &endsAt, &schedule.Status,
+		&schedule.CreatedBy, &schedule.CreatedAt)
+	if err != nil {
+		return models.PriceSchedule{}, err
+	}
+	if endsAt.Valid {
+		schedule.EndsAt = &endsAt.Time
+	}
+	return schedule, nil
+}
@@ -0,0 +1,196 @@
+package priceRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+var (
+	changeRowColumns   = []string{"id", "product_id", "price", "compare_at_price", "reason", "schedule_id", "changed_at"}
+	scheduleRowColumns = []string{"id", "product_id", "price", "starts_at", "ends_at", "status", "created_by", "created_at"}
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, PriceManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &PriceRepository{db: db}
+}
+
+func TestAddPriceChange(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO price_history ("+changeColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("c1", "p1", 90.0, nil, models.PriceUpdated, nil, now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	change := models.PriceChange{ID: "c1", ProductID: "p1", Price: 90, Reason: models.PriceUpdated, ChangedAt: now}
+	if err := repo.AddPriceChange(change); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestListPriceChanges(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM price_history WHERE product_id = \\? ORDER BY changed_at").
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows(changeRowColumns).
+			AddRow("c1", "p1", 100.0, nil, "created", nil, now).
+			AddRow("c2", "p1", 80.0, 100.0, "sale_started", "s1", now))
+
+	changes, err := repo.ListPriceChanges("p1")
+	if err != nil || len(changes) != 2 {
+		t.Fatalf("unexpected result: %+v, err: %v", changes, err)
+	}
+	if changes[0].CompareAtPrice != nil || changes[0].ScheduleID != "" {
+		t.Errorf("unexpected first change: %+v", changes[0])
+	}
+	if changes[1].CompareAtPrice == nil || *changes[1].CompareAtPrice != 100 || changes[1].ScheduleID != "s1" {
+		t.Errorf("unexpected second change: %+v", changes[1])
+	}
+}
+
+func TestSaveSchedule(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	starts := time.Now()
+	ends := starts.Add(48 * time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO price_schedules ("+scheduleColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)")).
+		WithArgs("s1", "p1", 80.0, starts, &ends, models.SchedulePending, "u1", starts).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	schedule := models.PriceSchedule{ID: "s1", ProductID: "p1", Price: 80, StartsAt: starts, EndsAt: &ends,
+		Status: models.SchedulePending, CreatedBy: "u1", CreatedAt: starts}
+	if err := repo.SaveSchedule(schedule); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetSchedule(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	starts := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM price_schedules WHERE id = ?").
+		WithArgs("s1").
+		WillReturnRows(sqlmock.NewRows(scheduleRowColumns).
+			AddRow("s1", "p1", 80.0, starts, nil, "scheduled", "u1", starts))
+
+	schedule, err := repo.GetSchedule("s1")
+	if err != nil || schedule.ID != "s1" || schedule.EndsAt != nil || schedule.Status != models.SchedulePending {
+		t.Errorf("unexpected result: %+v, err: %v", schedule, err)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM price_schedules WHERE id = ?").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+	_, err = repo.GetSchedule("missing")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestListSchedules(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	starts := time.Now()
+	ends := starts.Add(time.Hour)
+	mock.ExpectQuery("SELECT (.+) FROM price_schedules WHERE product_id = \\? ORDER BY starts_at").
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows(scheduleRowColumns).
+			AddRow("s1", "p1", 80.0, starts, ends, "active", "u1", starts))
+
+	schedules, err := repo.ListSchedules("p1")
+	if err != nil || len(schedules) != 1 || schedules[0].EndsAt == nil || !schedules[0].EndsAt.Equal(ends) {
+		t.Errorf("unexpected result: %+v, err: %v", schedules, err)
+	}
+}
+
+func TestDueSchedules(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT (.+) FROM price_schedules\\s+WHERE \\(status = \\? AND starts_at <= \\?\\) OR \\(status = \\? AND ends_at <= \\?\\)").
+		WithArgs(models.SchedulePending, now, models.ScheduleActive, now, models.ScheduleActive).
+		WillReturnRows(sqlmock.NewRows(scheduleRowColumns).
+			AddRow("s1", "p1", 80.0, now, nil, "scheduled", "u1", now))
+
+	schedules, err := repo.DueSchedules(now)
+	if err != nil || len(schedules) != 1 {
+		t.Errorf("unexpected result: %+v, err: %v", schedules, err)
+	}
+}
+
+func TestUpdateScheduleStatus(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE price_schedules SET status = \\? WHERE id = \\?").
+		WithArgs(models.ScheduleCancelled, "s1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpdateScheduleStatus("s1", models.ScheduleCancelled); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestApplyScheduledPrice(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	compareAt := float32(100)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products SET price = \\?, compare_at_price = \\? WHERE id = \\?").
+		WithArgs(80.0, 100.0, "p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO price_history").
+		WithArgs("c1", "p1", 80.0, 100.0, models.PriceSaleStarted, "s1", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE price_schedules SET status = \\? WHERE id = \\?").
+		WithArgs(models.ScheduleActive, "s1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	change := models.PriceChange{ID: "c1", ProductID: "p1", Price: 80, CompareAtPrice: &compareAt,
+		Reason: models.PriceSaleStarted, ScheduleID: "s1", ChangedAt: now}
+	if err := repo.ApplyScheduledPrice(change, models.ScheduleActive); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestApplyScheduledPriceRollsBack(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE products SET price").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO price_history").WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	change := models.PriceChange{ID: "c1", ProductID: "p1", Price: 100, Reason: models.PriceSaleEnded, ScheduleID: "s1", ChangedAt: time.Now()}
+	if err := repo.ApplyScheduledPrice(change, models.ScheduleEnded); err == nil {
+		t.Error("expected an error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
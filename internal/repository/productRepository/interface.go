@@ -1,13 +1,21 @@
 //go:generate mockgen -source=interface.go -destination=../../mocks/mock_productRepository.go -package=mocks
 package productRepository
 
-import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
 
 type ProductManager interface {
-	AddProduct(models.Product) error
+	AddProduct(product models.Product, opening *models.StockMovement) error
 	RemoveProduct(id string) error
 	UpdateProduct(models.Product) error
-	GetAllProducts() ([]models.Product,error)
-	GetProductByName(name *string)	([]models.Product,error)
+	SetArchivedAt(id string, archivedAt *time.Time) error
+	ListProducts(query models.ProductQuery) ([]models.Product, int, error)
 	GetProductByID(id string)	(models.Product,error)
+	GetProductBySKU(sku string) (models.Product, error)
+	ExportProducts(afterID string, limit int) ([]models.Product, error)
+	SearchProducts(terms string, limit, offset int) ([]models.ProductSearchResult, int, error)
+	RebuildSearchIndex() error
 }
\ No newline at end of file
@@ -2,10 +2,23 @@ package productRepository
 
 import (
 	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 )
 
+const productColumns = "p.id, p.name, p.price, p.stock, p.created_at, p.description, p.tags, p.brand, p.weight_grams, p.length_mm, p.width_mm, p.height_mm, p.specs, p.sku, p.status, p.archived_at, p.compare_at_price"
+
+var productSortColumns = map[models.ProductSort]string{
+	models.SortByName:    "p.name",
+	models.SortByPrice:   "p.price",
+	models.SortByCreated: "p.created_at",
+}
+
 type ProductRepository struct {
 	Db *sql.DB
 }
@@ -14,10 +27,35 @@ func NewProductRepository(db *sql.DB) ProductManager {
 	return &ProductRepository{Db: db}
 }
 
-func (pr *ProductRepository) AddProduct(product models.Product) error {
-	_, err := pr.Db.Exec("INSERT INTO products (id, name, price, stock) VALUES (?, ?, ?, ?)",
-		product.ID, product.Name, product.Price, product.Stock)
-	return err
+// AddProduct saves a new product. When it starts with stock, opening is the
+// ledger entry for it and is recorded in the same transaction.
+func (pr *ProductRepository) AddProduct(product models.Product, opening *models.StockMovement) error {
+	specs, length, width, height, err := encodeDetails(product)
+	if err != nil {
+		return err
+	}
+	tx, err := pr.Db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO products (id, name, price, stock, created_at, description, tags, brand, weight_grams, length_mm, width_mm, height_mm, specs, sku, status, compare_at_price)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		product.ID, product.Name, product.Price, product.Stock, product.CreatedAt, product.Description, strings.Join(product.Tags, ","),
+		product.Brand, product.WeightGrams, length, width, height, specs, nullSKU(product.SKU), productStatus(product.Status), product.CompareAtPrice)
+	if err != nil {
+		return err
+	}
+	if opening != nil {
+		_, err = tx.Exec(`INSERT INTO stock_movements (id, product_id, variant_id, delta, balance, reason, actor_id, reference, note, created_at)
+			VALUES (?, ?, NULL, ?, ?, ?, ?, ?, ?, ?)`,
+			opening.ID, product.ID, opening.Delta, product.Stock, opening.Reason, nullString(opening.ActorID), nullString(opening.Reference), opening.Note, opening.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (pr *ProductRepository) RemoveProduct(id string) error {
@@ -25,14 +63,42 @@ func (pr *ProductRepository) RemoveProduct(id string) error {
 	return err
 }
 
+// UpdateProduct saves the product's details. Its stock is only changed
+// through the inventory ledger.
 func (pr *ProductRepository) UpdateProduct(product models.Product) error {
-	_, err := pr.Db.Exec("UPDATE products SET name = ?, price = ?, stock = ? WHERE id = ?",
-		product.Name, product.Price, product.Stock, product.ID)
+	specs, length, width, height, err := encodeDetails(product)
+	if err != nil {
+		return err
+	}
+	_, err = pr.Db.Exec(`UPDATE products SET name = ?, price = ?, description = ?, tags = ?,
+		brand = ?, weight_grams = ?, length_mm = ?, width_mm = ?, height_mm = ?, specs = ?, sku = ?, status = ?, compare_at_price = ? WHERE id = ?`,
+		product.Name, product.Price, product.Description, strings.Join(product.Tags, ","),
+		product.Brand, product.WeightGrams, length, width, height, specs, nullSKU(product.SKU), productStatus(product.Status), product.CompareAtPrice, product.ID)
+	return err
+}
+
+// SetArchivedAt archives the product at the given time, or restores it when
+// archivedAt is nil. UpdateProduct leaves the archive time alone.
+func (pr *ProductRepository) SetArchivedAt(id string, archivedAt *time.Time) error {
+	_, err := pr.Db.Exec("UPDATE products SET archived_at = ? WHERE id = ?", archivedAt, id)
 	return err
 }
 
-func (pr *ProductRepository) GetAllProducts() ([]models.Product, error) {
-	rows, err := pr.Db.Query("SELECT id,name,price,stock FROM products")
+func (pr *ProductRepository) GetProductByID(id string) (models.Product, error) {
+	row := pr.Db.QueryRow("SELECT "+productColumns+" FROM products p WHERE p.id = ?", id)
+	return scanProduct(row)
+}
+
+func (pr *ProductRepository) GetProductBySKU(sku string) (models.Product, error) {
+	row := pr.Db.QueryRow("SELECT "+productColumns+" FROM products p WHERE p.sku = ?", sku)
+	return scanProduct(row)
+}
+
+// ExportProducts returns up to limit products ordered by id, starting after
+// afterID. Paging by id keeps each read short, so a long export does not
+// block writers.
+func (pr *ProductRepository) ExportProducts(afterID string, limit int) ([]models.Product, error) {
+	rows, err := pr.Db.Query("SELECT "+productColumns+" FROM products p WHERE p.id > ? ORDER BY p.id LIMIT ?", afterID, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -40,40 +106,304 @@ func (pr *ProductRepository) GetAllProducts() ([]models.Product, error) {
 
 	var products []models.Product
 	for rows.Next() {
-		var product models.Product
-		err := rows.Scan(&product.ID, &product.Name, &product.Price, &product.Stock)
+		product, err := scanProduct(rows)
 		if err != nil {
 			return nil, err
 		}
 		products = append(products, product)
 	}
-	return products, nil
+	return products, rows.Err()
+}
+
+// productStatus stores products saved without a status as published.
+func productStatus(status models.ProductStatus) models.ProductStatus {
+	if status == "" {
+		return models.ProductPublished
+	}
+	return status
+}
+
+// nullSKU stores a missing SKU as NULL, which the unique index allows more
+// than once.
+func nullSKU(sku string) any {
+	return nullString(sku)
+}
+
+func nullString(value string) any {
+	if value == "" {
+		return nil
+	}
+	return value
 }
 
-func (pr *ProductRepository) GetProductByName(name *string) ([]models.Product, error) {
-	rows, err := pr.Db.Query("SELECT id,name,price,stock FROM products WHERE name LIKE ?", "%"+*name+"%")
+// encodeDetails returns the specs as JSON and the dimensions as nullable
+// columns, all NULL when the product has none.
+func encodeDetails(product models.Product) (string, any, any, any, error) {
+	specs := product.Specs
+	if specs == nil {
+		specs = []models.ProductSpec{}
+	}
+	encoded, err := json.Marshal(specs)
 	if err != nil {
-		return nil, err
+		return "", nil, nil, nil, err
+	}
+	if product.Dimensions == nil {
+		return string(encoded), nil, nil, nil, nil
+	}
+	d := product.Dimensions
+	return string(encoded), d.LengthMM, d.WidthMM, d.HeightMM, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProduct(row rowScanner, extra ...any) (models.Product, error) {
+	var product models.Product
+	var createdAt sql.NullTime
+	var tags, specs string
+	var length, width, height sql.NullInt64
+	var sku sql.NullString
+	var archivedAt sql.NullTime
+	var compareAt sql.NullFloat64
+	dest := append([]any{&product.ID, &product.Name, &product.Price, &product.Stock, &createdAt, &product.Description, &tags,
+		&product.Brand, &product.WeightGrams, &length, &width, &height, &specs, &sku, &product.Status, &archivedAt, &compareAt}, extra...)
+	err := row.Scan(dest...)
+	if err != nil {
+		return models.Product{}, err
+	}
+	product.CreatedAt = createdAt.Time
+	product.SKU = sku.String
+	if archivedAt.Valid {
+		archived := archivedAt.Time
+		product.ArchivedAt = &archived
+	}
+	if compareAt.Valid {
+		price := float32(compareAt.Float64)
+		product.CompareAtPrice = &price
+	}
+	product.Tags = []string{}
+	if tags != "" {
+		product.Tags = strings.Split(tags, ",")
+	}
+	if length.Valid && width.Valid && height.Valid {
+		product.Dimensions = &models.Dimensions{LengthMM: int(length.Int64), WidthMM: int(width.Int64), HeightMM: int(height.Int64)}
+	}
+	product.Specs = []models.ProductSpec{}
+	err = json.Unmarshal([]byte(specs), &product.Specs)
+	if err != nil {
+		return models.Product{}, err
+	}
+	return product, nil
+}
+
+// categoryTree selects the id of the category with the given slug and of all
+// of its descendants. UNION drops repeats, so a cycle can not loop forever.
+const categoryTree = `WITH RECURSIVE tree(id) AS (
+	SELECT id FROM categories WHERE slug = ?
+	UNION
+	SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id) `
+
+// visible matches the products the storefront shows.
+const visible = "p.status = 'published' AND p.archived_at IS NULL"
+
+// inStock matches products that can be bought: a product with variants is in
+// stock when any variant is, others by their own stock.
+const inStock = `CASE WHEN EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id)
+	THEN EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id AND v.stock > 0)
+	ELSE p.stock > 0 END`
+
+// specCondition matches products with a spec that passes the filter. A
+// filter value is compared with text specs, and also with number and bool
+// specs when it reads as one.
+func specCondition(filter models.SpecFilter) (string, []any) {
+	conditions := []string{"json_extract(s.value, '$.key') = ?"}
+	args := []any{filter.Key}
+	if filter.Value != "" {
+		matches := []string{"(json_extract(s.value, '$.type') = 'text' AND LOWER(json_extract(s.value, '$.value')) = ?)"}
+		args = append(args, strings.ToLower(filter.Value))
+		if number, err := strconv.ParseFloat(filter.Value, 64); err == nil {
+			matches = append(matches, "(json_extract(s.value, '$.type') = 'number' AND json_extract(s.value, '$.value') = ?)")
+			args = append(args, number)
+		}
+		if b, err := strconv.ParseBool(filter.Value); err == nil {
+			matches = append(matches, "(json_extract(s.value, '$.type') = 'bool' AND json_extract(s.value, '$.value') = ?)")
+			args = append(args, b)
+		}
+		conditions = append(conditions, "("+strings.Join(matches, " OR ")+")")
+	}
+	if filter.Min != nil || filter.Max != nil {
+		conditions = append(conditions, "json_extract(s.value, '$.type') = 'number'")
+	}
+	if filter.Min != nil {
+		conditions = append(conditions, "json_extract(s.value, '$.value') >= ?")
+		args = append(args, *filter.Min)
+	}
+	if filter.Max != nil {
+		conditions = append(conditions, "json_extract(s.value, '$.value') <= ?")
+		args = append(args, *filter.Max)
+	}
+	return "EXISTS (SELECT 1 FROM json_each(p.specs) s WHERE " + strings.Join(conditions, " AND ") + ")", args
+}
+
+// ListProducts returns one page of products matching the query along with
+// the total number of matches. The id breaks ties so pages never overlap.
+func (pr *ProductRepository) ListProducts(query models.ProductQuery) ([]models.Product, int, error) {
+	var with string
+	var conditions []string
+	var args []any
+	if query.Category != "" {
+		with = categoryTree
+		args = append(args, query.Category)
+		conditions = append(conditions, "p.id IN (SELECT product_id FROM product_categories WHERE category_id IN (SELECT id FROM tree))")
+	}
+	if query.Name != "" {
+		conditions = append(conditions, "LOWER(p.name) LIKE ? ESCAPE '\\'")
+		args = append(args, "%"+escapeLike(strings.ToLower(query.Name))+"%")
+	}
+	if query.Brand != "" {
+		conditions = append(conditions, "LOWER(p.brand) = ?")
+		args = append(args, strings.ToLower(query.Brand))
+	}
+	for _, filter := range query.Specs {
+		condition, specArgs := specCondition(filter)
+		conditions = append(conditions, condition)
+		args = append(args, specArgs...)
+	}
+	if query.MinPrice != nil {
+		conditions = append(conditions, "p.price >= ?")
+		args = append(args, *query.MinPrice)
+	}
+	if query.MaxPrice != nil {
+		conditions = append(conditions, "p.price <= ?")
+		args = append(args, *query.MaxPrice)
+	}
+	if query.InStock {
+		conditions = append(conditions, inStock)
+	}
+	statuses := query.Statuses
+	if len(statuses) == 0 {
+		statuses = []models.ProductStatus{models.ProductPublished}
+	}
+	conditions = append(conditions, "p.status IN (?"+strings.Repeat(", ?", len(statuses)-1)+")")
+	for _, status := range statuses {
+		args = append(args, status)
+	}
+	if query.Archived {
+		conditions = append(conditions, "p.archived_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "p.archived_at IS NULL")
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	err := pr.Db.QueryRow(with+"SELECT COUNT(*) FROM products p"+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := productSortColumns[query.Sort]
+	if !ok {
+		column = productSortColumns[models.SortByName]
+	}
+	order := column
+	if query.Desc {
+		order += " DESC"
+	}
+	rows, err := pr.Db.Query(with+"SELECT "+productColumns+" FROM products p"+where+" ORDER BY "+order+", p.id LIMIT ? OFFSET ?",
+		append(args, query.Limit, query.Offset)...)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer rows.Close()
+
 	var products []models.Product
 	for rows.Next() {
-		var product models.Product
-		err := rows.Scan(&product.ID, &product.Name, &product.Price, &product.Stock)
+		product, err := scanProduct(rows)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		products = append(products, product)
 	}
-	return products, nil
+	return products, total, rows.Err()
 }
 
-func (pr *ProductRepository) GetProductByID(id string) (models.Product, error) {
-	row := pr.Db.QueryRow("SELECT id,name,price,stock FROM products WHERE id = ?", id)
-	var product models.Product
-	err := row.Scan(&product.ID, &product.Name, &product.Price, &product.Stock)
+// SearchProducts ranks full-text matches for the search terms with BM25,
+// weighting the name above tags and tags above the description. Every term
+// matches as a prefix. Matched terms in Highlight and Snippet are wrapped in
+// models.SearchMarkStart and models.SearchMarkEnd.
+func (pr *ProductRepository) SearchProducts(terms string, limit, offset int) ([]models.ProductSearchResult, int, error) {
+	match := ftsQuery(terms)
+	if match == "" {
+		return nil, 0, nil
+	}
+
+	var total int
+	err := pr.Db.QueryRow(`SELECT COUNT(*) FROM products_fts JOIN products p ON p.id = products_fts.product_id
+		WHERE products_fts MATCH ? AND `+visible, match).Scan(&total)
 	if err != nil {
-		return models.Product{}, err
+		return nil, 0, err
 	}
-	return product, nil
+
+	rows, err := pr.Db.Query(`SELECT `+productColumns+`,
+		highlight(products_fts, 1, char(2), char(3)),
+		snippet(products_fts, -1, char(2), char(3), '…', 12),
+		bm25(products_fts, 0.0, 10.0, 2.0, 5.0) AS rank
+		FROM products_fts JOIN products p ON p.id = products_fts.product_id
+		WHERE products_fts MATCH ? AND `+visible+` ORDER BY rank, p.name, p.id LIMIT ? OFFSET ?`, match, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []models.ProductSearchResult
+	for rows.Next() {
+		var result models.ProductSearchResult
+		result.Product, err = scanProduct(rows, &result.Highlight, &result.Snippet, &result.Rank)
+		if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, result)
+	}
+	return results, total, rows.Err()
+}
+
+// RebuildSearchIndex refills the search index from the products table.
+func (pr *ProductRepository) RebuildSearchIndex() error {
+	tx, err := pr.Db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM products_fts")
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT INTO products_fts (product_id, name, description, tags) SELECT id, name, description, tags FROM products")
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT INTO products_fts (products_fts) VALUES ('optimize')")
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ftsQuery turns free text into an FTS5 query that requires every word as a
+// prefix. Words are quoted, so FTS5 operators in the input are plain text.
+func ftsQuery(terms string) string {
+	words := strings.FieldsFunc(terms, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = `"` + word + `"*`
+	}
+	return strings.Join(words, " ")
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+	return replacer.Replace(value)
 }
@@ -2,12 +2,18 @@ package productRepository
 
 import (
 	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 )
 
+var productRowColumns = []string{"id", "name", "price", "stock", "created_at", "description", "tags", "brand", "weight_grams", "length_mm", "width_mm", "height_mm", "specs", "sku", "status", "archived_at", "compare_at_price"}
+
 func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, ProductManager) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -20,14 +26,46 @@ func TestAddProduct(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
 
+	created := time.Now()
+	mock.ExpectBegin()
 	mock.ExpectExec("INSERT INTO products").
-		WithArgs("1", "Product1", 100.0, 10).
+		WithArgs("1", "Product1", 100.0, 10, created, "A product", "red,blue", "Acme", 1200, 300, 200, 100, `[{"key":"ram","type":"number","value":16,"unit":"GB"}]`, "LAP-1", models.ProductDraft, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs("m1", "1", 10, 10, models.StockOpening, "admin1", nil, "", created).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	product := models.Product{ID: "1", Name: "Product1", Price: 100.0, Stock: 10}
-	if err := repo.AddProduct(product); err != nil {
+	product := models.Product{ID: "1", SKU: "LAP-1", Name: "Product1", Description: "A product", Tags: []string{"red", "blue"}, Price: 100.0, Stock: 10, CreatedAt: created,
+		Status: models.ProductDraft, Brand: "Acme", WeightGrams: 1200, Dimensions: &models.Dimensions{LengthMM: 300, WidthMM: 200, HeightMM: 100},
+		Specs: []models.ProductSpec{{Key: "ram", Type: models.SpecNumber, Value: 16, Unit: "GB"}}}
+	opening := models.StockMovement{ID: "m1", ProductID: "1", Delta: 10, Balance: 10, Reason: models.StockOpening, ActorID: "admin1", CreatedAt: created}
+	if err := repo.AddProduct(product, &opening); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestAddProduct_LedgerFails(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO products").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").WillReturnError(errors.New("db error"))
+	// the product is not kept without its opening entry
+	mock.ExpectRollback()
+
+	product := models.Product{ID: "1", Name: "Product1", Price: 100.0, Stock: 10, CreatedAt: time.Now()}
+	opening := models.StockMovement{ID: "m1", ProductID: "1", Delta: 10, Balance: 10, Reason: models.StockOpening}
+	if err := repo.AddProduct(product, &opening); err == nil {
+		t.Error("expected error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
 }
 
 
@@ -48,83 +86,251 @@ func TestUpdateProduct(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
 
+	compareAt := float32(200)
 	mock.ExpectExec("UPDATE products").
-		WithArgs("UpdatedProduct", 150.0, 20, "1").
+		WithArgs("UpdatedProduct", 150.0, "", "", "", 0, nil, nil, nil, "[]", nil, models.ProductPublished, 200.0, "1").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	product := models.Product{ID: "1", Name: "UpdatedProduct", Price: 150.0, Stock: 20}
+	product := models.Product{ID: "1", Name: "UpdatedProduct", Price: 150.0, CompareAtPrice: &compareAt, Stock: 20}
 	if err := repo.UpdateProduct(product); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
 
-func TestGetAllProducts(t *testing.T) {
+func TestSetArchivedAt(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
 
-	mock.ExpectQuery("SELECT (.+) FROM products").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "stock"}).
-			AddRow("1", "Product1", 100.0, 10).
-			AddRow("2", "Product2", 200.0, 20))
+	archived := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET archived_at = ? WHERE id = ?")).
+		WithArgs(&archived, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET archived_at = ? WHERE id = ?")).
+		WithArgs(nil, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	products, err := repo.GetAllProducts()
-	if err != nil {
+	if err := repo.SetArchivedAt("1", &archived); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
-
-	expected := []models.Product{
-		{ID: "1", Name: "Product1", Price: 100.0, Stock: 10},
-		{ID: "2", Name: "Product2", Price: 200.0, Stock: 20},
+	if err := repo.SetArchivedAt("1", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
 	}
-
-	if len(products) != len(expected) {
-		t.Errorf("expected %d products, got %d", len(expected), len(products))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
 	}
 }
 
-func TestGetProductByName(t *testing.T) {
+func TestListProducts(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
 
-	mock.ExpectQuery("SELECT (.+) FROM products WHERE name LIKE ?").
-		WithArgs("%Product%").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "stock"}).
-			AddRow("1", "Product1", 100.0, 10).
-			AddRow("2", "Product2", 200.0, 20))
+	min, max := float32(10), float32(500)
+	query := models.ProductQuery{Name: "Pro_", Brand: "Acme", MinPrice: &min, MaxPrice: &max, InStock: true, Sort: models.SortByPrice, Desc: true, Limit: 20, Offset: 20}
+	where := " WHERE LOWER(p.name) LIKE ? ESCAPE '\\' AND LOWER(p.brand) = ? AND p.price >= ? AND p.price <= ? AND " + inStock +
+		" AND p.status IN (?) AND p.archived_at IS NULL"
 
-	name := "Product"
-	products, err := repo.GetProductByName(&name)
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products p"+where)).
+		WithArgs("%pro\\_%", "acme", min, max, models.ProductPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(22))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+productColumns+" FROM products p"+where+" ORDER BY p.price DESC, p.id LIMIT ? OFFSET ?")).
+		WithArgs("%pro\\_%", "acme", min, max, models.ProductPublished, 20, 20).
+		WillReturnRows(sqlmock.NewRows(productRowColumns).
+			AddRow("1", "Pro_1", 100.0, 10, time.Now(), "", "", "Acme", 0, nil, nil, nil, "[]", nil, "published", nil, nil).
+			AddRow("2", "Pro_2", 50.0, 5, nil, "", "", "ACME", 0, nil, nil, nil, "[]", nil, "published", nil, nil))
+
+	products, total, err := repo.ListProducts(query)
+	if err != nil || total != 22 || len(products) != 2 {
+		t.Errorf("unexpected result: %+v, total %d, err: %v", products, total, err)
 	}
+}
 
-	expected := []models.Product{
-		{ID: "1", Name: "Product1", Price: 100.0, Stock: 10},
-		{ID: "2", Name: "Product2", Price: 200.0, Stock: 20},
+func TestListProductsByCategory(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery("WITH RECURSIVE tree(.+)SELECT COUNT\\(\\*\\) FROM products p WHERE p.id IN").
+		WithArgs("computers", models.ProductPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("WITH RECURSIVE tree(.+)ORDER BY p.name, p.id LIMIT \\? OFFSET \\?").
+		WithArgs("computers", models.ProductPublished, 20, 0).
+		WillReturnRows(sqlmock.NewRows(productRowColumns).
+			AddRow("1", "Laptop", 1000.0, 5, nil, "", "", "", 0, nil, nil, nil, "[]", nil, "published", nil, nil))
+
+	products, total, err := repo.ListProducts(models.ProductQuery{Category: "computers", Limit: 20})
+	if err != nil || total != 1 || len(products) != 1 {
+		t.Errorf("unexpected result: %+v, total %d, err: %v", products, total, err)
 	}
+}
+
+func TestListArchivedProducts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := models.ProductQuery{Statuses: []models.ProductStatus{models.ProductDraft, models.ProductPublished}, Archived: true, Limit: 20}
+	where := " WHERE p.status IN (?, ?) AND p.archived_at IS NOT NULL"
 
-	if len(products) != len(expected) {
-		t.Errorf("expected %d products, got %d", len(expected), len(products))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products p" + where)).
+		WithArgs(models.ProductDraft, models.ProductPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT "+productColumns+" FROM products p"+where+" ORDER BY p.name, p.id LIMIT ? OFFSET ?")).
+		WithArgs(models.ProductDraft, models.ProductPublished, 20, 0).
+		WillReturnRows(sqlmock.NewRows(productRowColumns).
+			AddRow("1", "Laptop", 1000.0, 5, nil, "", "", "", 0, nil, nil, nil, "[]", nil, "draft", time.Now(), nil))
+
+	products, total, err := repo.ListProducts(query)
+	if err != nil || total != 1 || len(products) != 1 || products[0].ArchivedAt == nil || products[0].Status != models.ProductDraft {
+		t.Errorf("unexpected result: %+v, total %d, err: %v", products, total, err)
 	}
 }
 
+func TestListProductsBySpecs(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	min := 8.0
+	query := models.ProductQuery{Specs: []models.SpecFilter{{Key: "wireless", Value: "true"}, {Key: "ram", Min: &min}}, Limit: 20}
+	wireless := "EXISTS (SELECT 1 FROM json_each(p.specs) s WHERE json_extract(s.value, '$.key') = ? AND (" +
+		"(json_extract(s.value, '$.type') = 'text' AND LOWER(json_extract(s.value, '$.value')) = ?) OR " +
+		"(json_extract(s.value, '$.type') = 'bool' AND json_extract(s.value, '$.value') = ?)))"
+	ram := "EXISTS (SELECT 1 FROM json_each(p.specs) s WHERE json_extract(s.value, '$.key') = ? AND " +
+		"json_extract(s.value, '$.type') = 'number' AND json_extract(s.value, '$.value') >= ?)"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products p WHERE " + wireless + " AND " + ram + " AND p.status IN (?) AND p.archived_at IS NULL")).
+		WithArgs("wireless", "true", true, "ram", min, models.ProductPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("ORDER BY p.name, p.id LIMIT \\? OFFSET \\?").
+		WithArgs("wireless", "true", true, "ram", min, models.ProductPublished, 20, 0).
+		WillReturnRows(sqlmock.NewRows(productRowColumns))
+
+	_, total, err := repo.ListProducts(query)
+	if err != nil || total != 0 {
+		t.Errorf("unexpected result: total %d, err: %v", total, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
 
 func TestGetProductByID(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
 
-	mock.ExpectQuery("SELECT (.+) FROM products WHERE id = ?").
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	archived := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	compareAt := float32(120)
+	mock.ExpectQuery("SELECT (.+) FROM products p WHERE p.id = ?").
 		WithArgs("1").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price", "stock"}).
-			AddRow("1", "Product1", 100.0, 10))
+		WillReturnRows(sqlmock.NewRows(productRowColumns).
+			AddRow("1", "Product1", 100.0, 10, created, "A product", "red,blue", "Acme", 1200, 300, 200, 100,
+				`[{"key":"ram","type":"number","value":16,"unit":"GB"},{"key":"touchscreen","type":"bool","value":false}]`, "LAP-1", "published", archived, 120.0))
 
 	product, err := repo.GetProductByID("1")
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
-	expected := models.Product{ID: "1", Name: "Product1", Price: 100.0, Stock: 10}
-	if product != expected {
+	expected := models.Product{ID: "1", SKU: "LAP-1", Name: "Product1", Description: "A product", Tags: []string{"red", "blue"}, Price: 100.0, Stock: 10, CreatedAt: created,
+		Brand: "Acme", WeightGrams: 1200, Dimensions: &models.Dimensions{LengthMM: 300, WidthMM: 200, HeightMM: 100},
+		Status: models.ProductPublished, ArchivedAt: &archived, CompareAtPrice: &compareAt,
+		Specs: []models.ProductSpec{
+			{Key: "ram", Type: models.SpecNumber, Value: 16.0, Unit: "GB"},
+			{Key: "touchscreen", Type: models.SpecBool, Value: false},
+		}}
+	if !reflect.DeepEqual(product, expected) {
 		t.Errorf("expected %+v, got %+v", expected, product)
 	}
 }
+
+func TestGetProductBySKU(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + productColumns + " FROM products p WHERE p.sku = ?")).
+		WithArgs("LAP-1").
+		WillReturnRows(sqlmock.NewRows(productRowColumns).
+			AddRow("1", "Laptop", 1000.0, 5, nil, "", "", "", 0, nil, nil, nil, "[]", "LAP-1", "published", nil, nil))
+
+	product, err := repo.GetProductBySKU("LAP-1")
+	if err != nil || product.ID != "1" || product.SKU != "LAP-1" {
+		t.Errorf("unexpected result: %+v, err: %v", product, err)
+	}
+
+	mock.ExpectQuery("WHERE p.sku = ?").WithArgs("MISSING").WillReturnError(sql.ErrNoRows)
+	if _, err := repo.GetProductBySKU("MISSING"); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestExportProducts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + productColumns + " FROM products p WHERE p.id > ? ORDER BY p.id LIMIT ?")).
+		WithArgs("p1", 2).
+		WillReturnRows(sqlmock.NewRows(productRowColumns).
+			AddRow("p2", "Phone", 500.0, 3, nil, "", "", "", 0, nil, nil, nil, "[]", "PH-1", "draft", nil, nil).
+			AddRow("p3", "Headphones", 50.0, 0, nil, "", "audio", "", 0, nil, nil, nil, "[]", nil, "published", nil, nil))
+
+	products, err := repo.ExportProducts("p1", 2)
+	if err != nil || len(products) != 2 || products[0].SKU != "PH-1" || products[1].SKU != "" {
+		t.Errorf("unexpected result: %+v, err: %v", products, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestSearchProducts(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products_fts JOIN products p ON p.id = products_fts.product_id\n\t\tWHERE products_fts MATCH ? AND " + visible)).
+		WithArgs(`"wire"* "head"*`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM products_fts JOIN products p (.+) AND p.status = 'published' AND p.archived_at IS NULL ORDER BY rank, p.name, p.id LIMIT \\? OFFSET \\?").
+		WithArgs(`"wire"* "head"*`, 20, 0).
+		WillReturnRows(sqlmock.NewRows(append(productRowColumns, "highlight", "snippet", "rank")).
+			AddRow("p3", "Headphones", 2500.0, 50, nil, "Wireless headphones", "audio", "", 0, nil, nil, nil, "[]", nil, "published", nil, nil, "\x02Headphones\x03", "\x02Wireless\x03 headphones", -1.5))
+
+	results, total, err := repo.SearchProducts("wire* (head", 20, 0)
+	if err != nil || total != 1 || len(results) != 1 {
+		t.Fatalf("unexpected result: %+v, total %d, err: %v", results, total, err)
+	}
+	if results[0].ID != "p3" || results[0].Rank != -1.5 || results[0].Snippet != "\x02Wireless\x03 headphones" {
+		t.Errorf("unexpected result: %+v", results[0])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestSearchProductsWithoutWords(t *testing.T) {
+	db, _, repo := setupMockDB(t)
+	defer db.Close()
+
+	results, total, err := repo.SearchProducts(`"*" -`, 20, 0)
+	if err != nil || total != 0 || results != nil {
+		t.Errorf("unexpected result: %+v, total %d, err: %v", results, total, err)
+	}
+}
+
+func TestRebuildSearchIndex(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products_fts")).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO products_fts (product_id, name, description, tags) SELECT id, name, description, tags FROM products")).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO products_fts (products_fts) VALUES ('optimize')")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := repo.RebuildSearchIndex(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_resetTokenRepository.go -package=mocks
+package resetTokenRepository
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type ResetTokenManager interface {
+	SaveResetToken(models.PasswordResetToken) error
+	GetResetTokenByHash(tokenHash string) (models.PasswordResetToken, error)
+	GetLatestResetToken(userID string) (models.PasswordResetToken, error)
+	MarkResetTokenUsed(id string, usedAt time.Time) error
+	DeleteResetTokensByUserID(userID string) error
+}
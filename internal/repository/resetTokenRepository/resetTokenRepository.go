@@ -0,0 +1,69 @@
+package resetTokenRepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type ResetTokenRepository struct {
+	db *sql.DB
+}
+
+func NewResetTokenRepository(db *sql.DB) ResetTokenManager {
+	return &ResetTokenRepository{db: db}
+}
+
+func (rr *ResetTokenRepository) SaveResetToken(token models.PasswordResetToken) error {
+	_, err := rr.db.Exec("INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at) VALUES (?, ?, ?, ?)",
+		token.ID, token.UserID, token.TokenHash, token.ExpiresAt)
+	return err
+}
+
+func (rr *ResetTokenRepository) GetResetTokenByHash(tokenHash string) (models.PasswordResetToken, error) {
+	row := rr.db.QueryRow("SELECT id, user_id, token_hash, expires_at, used_at FROM password_reset_tokens WHERE token_hash = ?", tokenHash)
+	var token models.PasswordResetToken
+	var usedAt sql.NullTime
+	err := row.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt, &usedAt)
+	if err != nil {
+		return models.PasswordResetToken{}, err
+	}
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+	return token, nil
+}
+
+// GetLatestResetToken returns the user's unused token that expires last.
+func (rr *ResetTokenRepository) GetLatestResetToken(userID string) (models.PasswordResetToken, error) {
+	row := rr.db.QueryRow("SELECT id, user_id, token_hash, expires_at FROM password_reset_tokens WHERE user_id = ? AND used_at IS NULL ORDER BY expires_at DESC LIMIT 1", userID)
+	var token models.PasswordResetToken
+	err := row.Scan(&token.ID, &token.UserID, &token.TokenHash, &token.ExpiresAt)
+	if err != nil {
+		return models.PasswordResetToken{}, err
+	}
+	return token, nil
+}
+
+// MarkResetTokenUsed only succeeds once per token, so concurrent resets with
+// the same token can not both go through.
+func (rr *ResetTokenRepository) MarkResetTokenUsed(id string, usedAt time.Time) error {
+	result, err := rr.db.Exec("UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL", usedAt, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (rr *ResetTokenRepository) DeleteResetTokensByUserID(userID string) error {
+	_, err := rr.db.Exec("DELETE FROM password_reset_tokens WHERE user_id = ?", userID)
+	return err
+}
@@ -0,0 +1,110 @@
+package resetTokenRepository
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, ResetTokenManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &ResetTokenRepository{db: db}
+}
+
+func TestSaveResetToken(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	expiresAt := time.Now().Add(time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at) VALUES (?, ?, ?, ?)")).
+		WithArgs("t1", "u1", "hash", expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	token := models.PasswordResetToken{ID: "t1", UserID: "u1", TokenHash: "hash", ExpiresAt: expiresAt}
+	if err := repo.SaveResetToken(token); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetResetTokenByHash(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	expiresAt := time.Now().Add(time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, token_hash, expires_at, used_at FROM password_reset_tokens WHERE token_hash = ?")).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "used_at"}).
+			AddRow("t1", "u1", "hash", expiresAt, nil))
+
+	token, err := repo.GetResetTokenByHash("hash")
+	if err != nil || token.ID != "t1" || token.UserID != "u1" || token.UsedAt != nil {
+		t.Errorf("unexpected token: %+v, err: %v", token, err)
+	}
+}
+
+func TestGetLatestResetToken(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	query := regexp.QuoteMeta("SELECT id, user_id, token_hash, expires_at FROM password_reset_tokens WHERE user_id = ? AND used_at IS NULL ORDER BY expires_at DESC LIMIT 1")
+	expiresAt := time.Now().Add(time.Hour)
+	mock.ExpectQuery(query).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at"}).
+			AddRow("t1", "u1", "hash", expiresAt))
+
+	token, err := repo.GetLatestResetToken("u1")
+	if err != nil || token.ID != "t1" || !token.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("unexpected token: %+v, err: %v", token, err)
+	}
+
+	mock.ExpectQuery(query).
+		WithArgs("u2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at"}))
+
+	if _, err := repo.GetLatestResetToken("u2"); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestMarkResetTokenUsed(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL")).
+		WithArgs(now, "t1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.MarkResetTokenUsed("t1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE password_reset_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL")).
+		WithArgs(now, "t1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.MarkResetTokenUsed("t1", now); err == nil {
+		t.Error("expected error for already used token")
+	}
+}
+
+func TestDeleteResetTokensByUserID(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM password_reset_tokens WHERE user_id = ?")).
+		WithArgs("u1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.DeleteResetTokensByUserID("u1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
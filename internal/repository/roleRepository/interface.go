@@ -0,0 +1,15 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_roleRepository.go -package=mocks
+package roleRepository
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type RoleManager interface {
+	ListRoles() ([]models.Role, error)
+	GetRole(name string) (models.Role, error)
+	SaveRole(role models.Role) error
+	DeleteRole(name string) error
+	GetUserRoles(userID string) ([]models.Role, error)
+	AssignRole(userID, role string) error
+	UnassignRole(userID, role string) error
+	GetUserPermissions(userID string) ([]models.Permission, error)
+}
@@ -0,0 +1,157 @@
+package roleRepository
+
+import (
+	"database/sql"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const roleSelect = `SELECT r.name, r.description, r.built_in, rp.permission FROM roles r
+	LEFT JOIN role_permissions rp ON rp.role = r.name`
+
+type RoleRepository struct {
+	db *sql.DB
+}
+
+func NewRoleRepository(db *sql.DB) RoleManager {
+	return &RoleRepository{db: db}
+}
+
+func (rr *RoleRepository) ListRoles() ([]models.Role, error) {
+	rows, err := rr.db.Query(roleSelect + " ORDER BY r.name, rp.permission")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRoles(rows)
+}
+
+func (rr *RoleRepository) GetRole(name string) (models.Role, error) {
+	rows, err := rr.db.Query(roleSelect+" WHERE r.name = ? ORDER BY rp.permission", name)
+	if err != nil {
+		return models.Role{}, err
+	}
+	defer rows.Close()
+	roles, err := scanRoles(rows)
+	if err != nil {
+		return models.Role{}, err
+	}
+	if len(roles) == 0 {
+		return models.Role{}, sql.ErrNoRows
+	}
+	return roles[0], nil
+}
+
+// SaveRole creates or replaces a custom role together with its permissions.
+func (rr *RoleRepository) SaveRole(role models.Role) error {
+	tx, err := rr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT INTO roles (name, description, built_in) VALUES (?, ?, 0)
+		ON CONFLICT(name) DO UPDATE SET description = excluded.description`, role.Name, role.Description)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM role_permissions WHERE role = ?", role.Name)
+	if err != nil {
+		return err
+	}
+	for _, perm := range role.Permissions {
+		_, err = tx.Exec("INSERT INTO role_permissions (role, permission) VALUES (?, ?)", role.Name, perm)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteRole removes the role; its permissions and assignments go with it.
+func (rr *RoleRepository) DeleteRole(name string) error {
+	result, err := rr.db.Exec("DELETE FROM roles WHERE name = ?", name)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func (rr *RoleRepository) GetUserRoles(userID string) ([]models.Role, error) {
+	rows, err := rr.db.Query(roleSelect+" JOIN user_roles ur ON ur.role = r.name WHERE ur.user_id = ? ORDER BY r.name, rp.permission", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRoles(rows)
+}
+
+func (rr *RoleRepository) AssignRole(userID, role string) error {
+	_, err := rr.db.Exec("INSERT OR IGNORE INTO user_roles (user_id, role) VALUES (?, ?)", userID, role)
+	return err
+}
+
+func (rr *RoleRepository) UnassignRole(userID, role string) error {
+	result, err := rr.db.Exec("DELETE FROM user_roles WHERE user_id = ? AND role = ?", userID, role)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+// GetUserPermissions returns the union of the permissions of every role
+// assigned to the user.
+func (rr *RoleRepository) GetUserPermissions(userID string) ([]models.Permission, error) {
+	rows, err := rr.db.Query(`SELECT DISTINCT rp.permission FROM user_roles ur
+		JOIN role_permissions rp ON rp.role = ur.role
+		WHERE ur.user_id = ? ORDER BY rp.permission`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var perms []models.Permission
+	for rows.Next() {
+		var perm models.Permission
+		err := rows.Scan(&perm)
+		if err != nil {
+			return nil, err
+		}
+		perms = append(perms, perm)
+	}
+	return perms, rows.Err()
+}
+
+// scanRoles folds one row per role permission into roles. Rows must be
+// ordered by role name.
+func scanRoles(rows *sql.Rows) ([]models.Role, error) {
+	var roles []models.Role
+	for rows.Next() {
+		var role models.Role
+		var perm sql.NullString
+		err := rows.Scan(&role.Name, &role.Description, &role.BuiltIn, &perm)
+		if err != nil {
+			return nil, err
+		}
+		if len(roles) == 0 || roles[len(roles)-1].Name != role.Name {
+			role.Permissions = []models.Permission{}
+			roles = append(roles, role)
+		}
+		if perm.Valid {
+			last := &roles[len(roles)-1]
+			last.Permissions = append(last.Permissions, models.Permission(perm.String))
+		}
+	}
+	return roles, rows.Err()
+}
+
+func checkAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
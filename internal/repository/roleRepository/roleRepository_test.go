@@ -0,0 +1,142 @@
+package roleRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, RoleManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &RoleRepository{db: db}
+}
+
+var roleColumns = []string{"name", "description", "built_in", "permission"}
+
+func TestListRoles(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(roleSelect + " ORDER BY r.name, rp.permission")).
+		WillReturnRows(sqlmock.NewRows(roleColumns).
+			AddRow("catalog_manager", "Catalog", true, "coupons:write").
+			AddRow("catalog_manager", "Catalog", true, "products:write").
+			AddRow("empty", "No permissions yet", false, nil))
+
+	roles, err := repo.ListRoles()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(roles) != 2 || len(roles[0].Permissions) != 2 || !roles[0].BuiltIn {
+		t.Fatalf("unexpected roles: %+v", roles)
+	}
+	if roles[1].Name != "empty" || roles[1].Permissions == nil || len(roles[1].Permissions) != 0 {
+		t.Errorf("expected empty permission list for role without permissions, got %+v", roles[1])
+	}
+}
+
+func TestGetRole_NotFound(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(roleSelect + " WHERE r.name = ?")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows(roleColumns))
+
+	_, err := repo.GetRole("missing")
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestSaveRole(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO roles (name, description, built_in) VALUES (?, ?, 0)")).
+		WithArgs("support", "Support").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM role_permissions WHERE role = ?")).
+		WithArgs("support").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO role_permissions (role, permission) VALUES (?, ?)")).
+		WithArgs("support", models.PermUsersRead).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.SaveRole(models.Role{Name: "support", Description: "Support", Permissions: []models.Permission{models.PermUsersRead}})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestDeleteRole(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM roles WHERE name = ?")).
+		WithArgs("missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DeleteRole("missing"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestGetUserRoles(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("JOIN user_roles ur ON ur.role = r.name WHERE ur.user_id = ?")).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows(roleColumns).AddRow("finance", "Finance", true, "orders:read"))
+
+	roles, err := repo.GetUserRoles("u1")
+	if err != nil || len(roles) != 1 || roles[0].Name != "finance" {
+		t.Errorf("unexpected roles: %+v, err: %v", roles, err)
+	}
+}
+
+func TestAssignAndUnassignRole(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT OR IGNORE INTO user_roles (user_id, role) VALUES (?, ?)")).
+		WithArgs("u1", "finance").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	if err := repo.AssignRole("u1", "finance"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM user_roles WHERE user_id = ? AND role = ?")).
+		WithArgs("u1", "finance").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := repo.UnassignRole("u1", "finance"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows for missing assignment, got %v", err)
+	}
+}
+
+func TestGetUserPermissions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT rp.permission FROM user_roles ur")).
+		WithArgs("u1").
+		WillReturnRows(sqlmock.NewRows([]string{"permission"}).AddRow("orders:read").AddRow("users:read"))
+
+	perms, err := repo.GetUserPermissions("u1")
+	if err != nil || len(perms) != 2 || perms[0] != models.PermOrdersRead {
+		t.Errorf("unexpected permissions: %v, err: %v", perms, err)
+	}
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_sessionRepository.go -package=mocks
+package sessionRepository
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+type SessionManager interface {
+	CreateSession(session models.Session) error
+	GetSession(id string) (models.Session, error)
+	ListActiveSessions(userID string, now time.Time) ([]models.Session, error)
+	TouchSession(id string, seenAt time.Time) error
+	RevokeSession(userID, id string, revokedAt time.Time) error
+	DeleteExpiredSessions(before time.Time) error
+}
@@ -0,0 +1,99 @@
+package sessionRepository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const sessionColumns = "id, user_id, user_agent, ip, token_version, created_at, last_seen_at, expires_at, revoked_at"
+
+type SessionRepository struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) SessionManager {
+	return &SessionRepository{db: db}
+}
+
+func (sr *SessionRepository) CreateSession(session models.Session) error {
+	_, err := sr.db.Exec("INSERT INTO sessions ("+sessionColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		session.ID, session.UserID, session.UserAgent, session.IP, session.TokenVersion,
+		session.CreatedAt, session.LastSeenAt, session.ExpiresAt, session.RevokedAt)
+	return err
+}
+
+func (sr *SessionRepository) GetSession(id string) (models.Session, error) {
+	row := sr.db.QueryRow("SELECT "+sessionColumns+" FROM sessions WHERE id = ?", id)
+	return scanSession(row)
+}
+
+// ListActiveSessions leaves out sessions ended by a logout-all, which bumps
+// the user's token version instead of touching each session.
+func (sr *SessionRepository) ListActiveSessions(userID string, now time.Time) ([]models.Session, error) {
+	rows, err := sr.db.Query(`
+		SELECT s.id, s.user_id, s.user_agent, s.ip, s.token_version, s.created_at, s.last_seen_at, s.expires_at, s.revoked_at
+		FROM sessions s JOIN users u ON u.id = s.user_id
+		WHERE s.user_id = ? AND s.revoked_at IS NULL AND s.expires_at > ? AND s.token_version = u.token_version
+		ORDER BY s.last_seen_at DESC`, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.Session{}
+	for rows.Next() {
+		session, err := scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func (sr *SessionRepository) TouchSession(id string, seenAt time.Time) error {
+	_, err := sr.db.Exec("UPDATE sessions SET last_seen_at = ? WHERE id = ?", seenAt, id)
+	return err
+}
+
+// RevokeSession fails with sql.ErrNoRows when the user has no such session or
+// it is already revoked.
+func (sr *SessionRepository) RevokeSession(userID, id string, revokedAt time.Time) error {
+	result, err := sr.db.Exec("UPDATE sessions SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL", revokedAt, id, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (sr *SessionRepository) DeleteExpiredSessions(before time.Time) error {
+	_, err := sr.db.Exec("DELETE FROM sessions WHERE expires_at < ?", before)
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSession(row scanner) (models.Session, error) {
+	var session models.Session
+	var revokedAt sql.NullTime
+	err := row.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP, &session.TokenVersion,
+		&session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt, &revokedAt)
+	if err != nil {
+		return models.Session{}, err
+	}
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+	return session, nil
+}
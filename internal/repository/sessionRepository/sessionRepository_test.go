@@ -0,0 +1,136 @@
+package sessionRepository
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, SessionManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &SessionRepository{db: db}
+}
+
+var columns = []string{"id", "user_id", "user_agent", "ip", "token_version", "created_at", "last_seen_at", "expires_at", "revoked_at"}
+
+func TestCreateSession(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sessions (" + sessionColumns + ")")).
+		WithArgs("s1", "u1", "curl/8", "10.0.0.1", 2, now, now, now.Add(time.Hour), nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := repo.CreateSession(models.Session{
+		ID: "s1", UserID: "u1", UserAgent: "curl/8", IP: "10.0.0.1", TokenVersion: 2,
+		CreatedAt: now, LastSeenAt: now, ExpiresAt: now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetSession(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + sessionColumns + " FROM sessions WHERE id = ?")).
+		WithArgs("s1").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("s1", "u1", "curl/8", "10.0.0.1", 2, now, now, now, now))
+
+	session, err := repo.GetSession("s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.UserID != "u1" || session.TokenVersion != 2 || !session.IsRevoked() {
+		t.Errorf("unexpected session: %+v", session)
+	}
+}
+
+func TestListActiveSessions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE s.user_id = ? AND s.revoked_at IS NULL AND s.expires_at > ? AND s.token_version = u.token_version")).
+		WithArgs("u1", now).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("s1", "u1", "curl/8", "10.0.0.1", 2, now, now, now.Add(time.Hour), nil).
+			AddRow("s2", "u1", "Firefox", "10.0.0.2", 2, now, now, now.Add(time.Hour), nil))
+
+	sessions, err := repo.ListActiveSessions("u1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 2 || sessions[1].UserAgent != "Firefox" {
+		t.Errorf("unexpected sessions: %+v", sessions)
+	}
+}
+
+func TestTouchSession(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sessions SET last_seen_at = ? WHERE id = ?")).
+		WithArgs(now, "s1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.TouchSession("s1", now)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		wantErr  error
+	}{
+		{name: "Success", affected: 1},
+		{name: "Unknown or already revoked", affected: 0, wantErr: sql.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, repo := setupMockDB(t)
+			defer db.Close()
+
+			now := time.Now()
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE sessions SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL")).
+				WithArgs(now, "s1", "u1").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			err := repo.RevokeSession("u1", "s1", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDeleteExpiredSessions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM sessions WHERE expires_at < ?")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := repo.DeleteExpiredSessions(now)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
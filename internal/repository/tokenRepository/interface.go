@@ -0,0 +1,11 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_tokenRepository.go -package=mocks
+package tokenRepository
+
+import "time"
+
+type TokenManager interface {
+	RevokeToken(jti, userID string, expiresAt time.Time) error
+	IsTokenRevoked(jti string) (bool, error)
+	IncrementTokenVersion(userID string) error
+	DeleteExpiredTokens(now time.Time) error
+}
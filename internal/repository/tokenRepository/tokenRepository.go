@@ -0,0 +1,50 @@
+package tokenRepository
+
+import (
+	"database/sql"
+	"time"
+)
+
+type TokenRepository struct {
+	db *sql.DB
+}
+
+func NewTokenRepository(db *sql.DB) TokenManager {
+	return &TokenRepository{db: db}
+}
+
+func (tr *TokenRepository) RevokeToken(jti, userID string, expiresAt time.Time) error {
+	_, err := tr.db.Exec("INSERT OR IGNORE INTO revoked_tokens (jti, user_id, expires_at) VALUES (?, ?, ?)",
+		jti, userID, expiresAt)
+	return err
+}
+
+func (tr *TokenRepository) IsTokenRevoked(jti string) (bool, error) {
+	row := tr.db.QueryRow("SELECT COUNT(*) FROM revoked_tokens WHERE jti = ?", jti)
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (tr *TokenRepository) IncrementTokenVersion(userID string) error {
+	result, err := tr.db.Exec("UPDATE users SET token_version = token_version + 1 WHERE id = ?", userID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (tr *TokenRepository) DeleteExpiredTokens(now time.Time) error {
+	_, err := tr.db.Exec("DELETE FROM revoked_tokens WHERE expires_at < ?", now)
+	return err
+}
@@ -0,0 +1,81 @@
+package tokenRepository
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, TokenManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &TokenRepository{db: db}
+}
+
+func TestRevokeToken(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	expiresAt := time.Now().Add(time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT OR IGNORE INTO revoked_tokens (jti, user_id, expires_at) VALUES (?, ?, ?)")).
+		WithArgs("jti1", "user1", expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.RevokeToken("jti1", "user1", expiresAt); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestIsTokenRevoked(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM revoked_tokens WHERE jti = ?")).
+		WithArgs("jti1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	revoked, err := repo.IsTokenRevoked("jti1")
+	if err != nil || !revoked {
+		t.Errorf("expected token to be revoked, got %v, err: %v", revoked, err)
+	}
+}
+
+func TestIncrementTokenVersion(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET token_version = token_version + 1 WHERE id = ?")).
+		WithArgs("user1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.IncrementTokenVersion("user1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET token_version = token_version + 1 WHERE id = ?")).
+		WithArgs("missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.IncrementTokenVersion("missing"); err == nil {
+		t.Error("expected error for unknown user")
+	}
+}
+
+func TestDeleteExpiredTokens(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM revoked_tokens WHERE expires_at < ?")).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	if err := repo.DeleteExpiredTokens(now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -1,10 +1,23 @@
 //go:generate mockgen -source=interface.go -destination=../../mocks/mock_userRepository.go -package=mocks
 package userRepository
 
-import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
 
 type UserManager interface {
 	SaveUser(models.User) error
 	GetUserByID(id string) (models.User, error)
 	GetUserByEmail(email string) (models.User, error)
+	GetUsersByRole(role models.UserRole) ([]models.User, error)
+	UpdatePassword(id, password string) error
+	UpdateUserRole(id string, role models.UserRole) error
+	MarkEmailVerified(id string, verifiedAt time.Time) error
+	SetVerificationSentAt(id string, sentAt time.Time) error
+	UpdateUser(models.User) error
+	EraseUser(user models.User) error
+	ListUsers(filter models.UserFilter) ([]models.User, int, error)
+	UpdateUserStatus(id string, status models.UserStatus) error
 }
@@ -2,10 +2,14 @@ package userRepository
 
 import (
 	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 )
 
+const userColumns = "id, name, email, password, role, token_version, email_verified_at, verification_sent_at, status"
+
 type UserRepository struct {
 	Db *sql.DB
 }
@@ -14,6 +18,26 @@ func NewUserRepository(db *sql.DB) UserManager {
 	return &UserRepository{Db: db}
 }
 
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner) (models.User, error) {
+	var user models.User
+	var verifiedAt, sentAt sql.NullTime
+	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role, &user.TokenVersion, &verifiedAt, &sentAt, &user.Status)
+	if err != nil {
+		return models.User{}, err
+	}
+	if verifiedAt.Valid {
+		user.EmailVerifiedAt = &verifiedAt.Time
+	}
+	if sentAt.Valid {
+		user.VerificationSentAt = &sentAt.Time
+	}
+	return user, nil
+}
+
 func (ur *UserRepository) SaveUser(user models.User) error {
 	_, err := ur.Db.Exec("INSERT INTO users (id, name, email, password, role) VALUES (?, ?, ?, ?, ?)",
 		user.ID, user.Name, user.Email, user.Password, user.Role)
@@ -21,21 +45,181 @@ func (ur *UserRepository) SaveUser(user models.User) error {
 }
 
 func (ur *UserRepository) GetUserByID(id string) (models.User, error) {
-	row := ur.Db.QueryRow("SELECT id, name, email, password, role FROM users WHERE id = ?", id)
-	var user models.User
-	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role)
+	row := ur.Db.QueryRow("SELECT "+userColumns+" FROM users WHERE id = ?", id)
+	return scanUser(row)
+}
+
+func (ur *UserRepository) GetUserByEmail(email string) (models.User, error) {
+	row := ur.Db.QueryRow("SELECT "+userColumns+" FROM users WHERE email = ?", email)
+	return scanUser(row)
+}
+
+func (ur *UserRepository) GetUsersByRole(role models.UserRole) ([]models.User, error) {
+	rows, err := ur.Db.Query("SELECT "+userColumns+" FROM users WHERE role = ?", role)
 	if err != nil {
-		return models.User{}, err
+		return nil, err
 	}
-	return user, nil
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
 }
 
-func (ur *UserRepository) GetUserByEmail(email string) (models.User, error) {
-	row := ur.Db.QueryRow("SELECT id, name, email, password, role FROM users WHERE email = ?", email)
-	var user models.User
-	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role)
+func (ur *UserRepository) UpdatePassword(id, password string) error {
+	result, err := ur.Db.Exec("UPDATE users SET password = ? WHERE id = ?", password, id)
 	if err != nil {
-		return models.User{}, err
+		return err
 	}
-	return user, nil
+	return checkAffected(result)
+}
+
+// UpdateUserRole also bumps the token version, since issued tokens carry the
+// old role in their claims.
+func (ur *UserRepository) UpdateUserRole(id string, role models.UserRole) error {
+	result, err := ur.Db.Exec("UPDATE users SET role = ?, token_version = token_version + 1 WHERE id = ?", role, id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func (ur *UserRepository) MarkEmailVerified(id string, verifiedAt time.Time) error {
+	result, err := ur.Db.Exec("UPDATE users SET email_verified_at = ? WHERE id = ?", verifiedAt, id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func (ur *UserRepository) SetVerificationSentAt(id string, sentAt time.Time) error {
+	result, err := ur.Db.Exec("UPDATE users SET verification_sent_at = ? WHERE id = ?", sentAt, id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+// UpdateUser saves the profile fields. The verification timestamp is written
+// too so that an email change can clear it.
+func (ur *UserRepository) UpdateUser(user models.User) error {
+	result, err := ur.Db.Exec("UPDATE users SET name = ?, email = ?, email_verified_at = ? WHERE id = ?",
+		user.Name, user.Email, user.EmailVerifiedAt, user.ID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+// erasedUserTables hold per-user rows that are removed on erasure. Orders are
+// not among them, they are kept for accounting.
+var erasedUserTables = []string{
+	"cart", "sessions", "revoked_tokens", "password_reset_tokens", "user_mfa",
+	"mfa_recovery_codes", "user_roles", "api_keys", "user_identities",
+}
+
+// EraseUser overwrites the name, email and password with the anonymised values
+// in user, marks the account erased and removes its per-user rows, all in one
+// transaction. Bumping the token version ends any session still open.
+func (ur *UserRepository) EraseUser(user models.User) error {
+	tx, err := ur.Db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`UPDATE users SET name = ?, email = ?, password = ?, status = ?,
+		token_version = token_version + 1, email_verified_at = NULL, verification_sent_at = NULL WHERE id = ?`,
+		user.Name, user.Email, user.Password, models.UserErased, user.ID)
+	if err != nil {
+		return err
+	}
+	err = checkAffected(result)
+	if err != nil {
+		return err
+	}
+	for _, table := range erasedUserTables {
+		_, err = tx.Exec("DELETE FROM "+table+" WHERE user_id = ?", user.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListUsers returns one page of users matching the filter, ordered by email,
+// along with the total number of matches.
+func (ur *UserRepository) ListUsers(filter models.UserFilter) ([]models.User, int, error) {
+	var conditions []string
+	var args []any
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(strings.ToLower(filter.Query)) + "%"
+		conditions = append(conditions, "(LOWER(email) LIKE ? ESCAPE '\\' OR LOWER(name) LIKE ? ESCAPE '\\')")
+		args = append(args, pattern, pattern)
+	}
+	if filter.Role != nil {
+		conditions = append(conditions, "role = ?")
+		args = append(args, *filter.Role)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	err := ur.Db.QueryRow("SELECT COUNT(*) FROM users"+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := ur.Db.Query("SELECT "+userColumns+" FROM users"+where+" ORDER BY email LIMIT ? OFFSET ?",
+		append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, user)
+	}
+	return users, total, rows.Err()
+}
+
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+	return replacer.Replace(value)
+}
+
+func (ur *UserRepository) UpdateUserStatus(id string, status models.UserStatus) error {
+	result, err := ur.Db.Exec("UPDATE users SET status = ? WHERE id = ?", status, id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func checkAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
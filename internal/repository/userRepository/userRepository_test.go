@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
@@ -35,10 +36,10 @@ func TestGetUserByID(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
 
-	mock.ExpectQuery("SELECT id, name, email, password, role FROM users").
+	mock.ExpectQuery("SELECT id, name, email, password, role, token_version, email_verified_at, verification_sent_at, status FROM users").
 		WithArgs("1").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role"}).
-			AddRow("1", "John Doe", "john@example.com", "password123", models.Customer))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "token_version", "email_verified_at", "verification_sent_at", "status"}).
+			AddRow("1", "John Doe", "john@example.com", "password123", models.Customer, 0, nil, nil, "active"))
 
 	user, err := repo.GetUserByID("1")
 	if err != nil || user.ID != "1" || user.Name != "John Doe" || user.Email != "john@example.com" || user.Password != "password123" || user.Role != models.Customer {
@@ -50,13 +51,171 @@ func TestGetUserByEmail(t *testing.T) {
 	db, mock, repo := setupMockDB(t)
 	defer db.Close()
 
-	mock.ExpectQuery("SELECT id, name, email, password, role FROM users").
+	mock.ExpectQuery("SELECT id, name, email, password, role, token_version, email_verified_at, verification_sent_at, status FROM users").
 		WithArgs("john@example.com").
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role"}).
-			AddRow("1", "John Doe", "john@example.com", "password123", models.Customer))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "token_version", "email_verified_at", "verification_sent_at", "status"}).
+			AddRow("1", "John Doe", "john@example.com", "password123", models.Customer, 0, nil, nil, "active"))
 
 	user, err := repo.GetUserByEmail("john@example.com")
 	if err != nil || user.ID != "1" || user.Name != "John Doe" || user.Email != "john@example.com" || user.Password != "password123" || user.Role != models.Customer {
 		t.Errorf("unexpected user: %+v, err: %v", user, err)
 	}
 }
+
+func TestGetUsersByRole(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, role, token_version, email_verified_at, verification_sent_at, status FROM users WHERE role = ?")).
+		WithArgs(models.Admin).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "token_version", "email_verified_at", "verification_sent_at", "status"}).
+			AddRow("1", "Admin", "admin@example.com", "hash", models.Admin, 0, nil, nil, "active"))
+
+	users, err := repo.GetUsersByRole(models.Admin)
+	if err != nil || len(users) != 1 || users[0].Email != "admin@example.com" {
+		t.Errorf("unexpected users: %+v, err: %v", users, err)
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET password = ? WHERE id = ?")).
+		WithArgs("hash", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpdatePassword("1", "hash"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET password = ? WHERE id = ?")).
+		WithArgs("hash", "404").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.UpdatePassword("404", "hash"); err == nil {
+		t.Error("expected error for unknown user")
+	}
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET role = ?, token_version = token_version + 1 WHERE id = ?")).
+		WithArgs(models.Admin, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpdateUserRole("1", models.Admin); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMarkEmailVerified(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET email_verified_at = ? WHERE id = ?")).
+		WithArgs(now, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.MarkEmailVerified("1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSetVerificationSentAt(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET verification_sent_at = ? WHERE id = ?")).
+		WithArgs(now, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.SetVerificationSentAt("1", now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUpdateUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET name = ?, email = ?, email_verified_at = ? WHERE id = ?")).
+		WithArgs("Jane", "jane@example.com", nil, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpdateUser(models.User{ID: "1", Name: "Jane", Email: "jane@example.com"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEraseUser(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	user := models.User{ID: "1", Name: "Deleted user", Email: "erased-1@invalid", Password: "hash"}
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users SET name = \\?, email = \\?, password = \\?, status = \\?").
+		WithArgs("Deleted user", "erased-1@invalid", "hash", models.UserErased, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, table := range erasedUserTables {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE user_id = ?")).
+			WithArgs("1").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	if err := repo.EraseUser(user); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE users SET name").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	if err := repo.EraseUser(models.User{ID: "404"}); err == nil {
+		t.Error("expected error for unknown user")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	role := models.Customer
+	filter := models.UserFilter{Query: "Jo_n", Role: &role, Status: models.UserActive, Limit: 20, Offset: 40}
+	where := " WHERE (LOWER(email) LIKE ? ESCAPE '\\' OR LOWER(name) LIKE ? ESCAPE '\\') AND role = ? AND status = ?"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM users"+where)).
+		WithArgs("%jo\\_n%", "%jo\\_n%", models.Customer, models.UserActive).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(41))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, role, token_version, email_verified_at, verification_sent_at, status FROM users"+where+" ORDER BY email LIMIT ? OFFSET ?")).
+		WithArgs("%jo\\_n%", "%jo\\_n%", models.Customer, models.UserActive, 20, 40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "token_version", "email_verified_at", "verification_sent_at", "status"}).
+			AddRow("1", "Jo_n", "jo_n@example.com", "hash", models.Customer, 0, nil, nil, "active"))
+
+	users, total, err := repo.ListUsers(filter)
+	if err != nil || total != 41 || len(users) != 1 || users[0].Status != models.UserActive {
+		t.Errorf("unexpected result: %+v, total %d, err: %v", users, total, err)
+	}
+}
+
+func TestUpdateUserStatus(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET status = ? WHERE id = ?")).
+		WithArgs(models.UserSuspended, "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.UpdateUserStatus("1", models.UserSuspended); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
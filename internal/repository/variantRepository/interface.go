@@ -0,0 +1,15 @@
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_variantRepository.go -package=mocks
+package variantRepository
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+type VariantManager interface {
+	GetOptions(productID string) ([]models.ProductOption, error)
+	SetOptions(productID string, options []models.ProductOption) error
+	ListVariants(productID string) ([]models.Variant, error)
+	GetVariant(id string) (models.Variant, error)
+	GetVariantBySKU(sku string) (models.Variant, error)
+	SaveVariant(variant models.Variant, opening *models.StockMovement) error
+	UpdateVariant(variant models.Variant) error
+	DeleteVariant(id string) error
+}
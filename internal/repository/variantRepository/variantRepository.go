@@ -0,0 +1,194 @@
+package variantRepository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+const variantColumns = "id, product_id, sku, options, price, stock"
+
+type VariantRepository struct {
+	db *sql.DB
+}
+
+func NewVariantRepository(db *sql.DB) VariantManager {
+	return &VariantRepository{db: db}
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanVariant(row rowScanner) (models.Variant, error) {
+	var variant models.Variant
+	var options string
+	var price sql.NullFloat64
+	err := row.Scan(&variant.ID, &variant.ProductID, &variant.SKU, &options, &price, &variant.Stock)
+	if err != nil {
+		return models.Variant{}, err
+	}
+	err = json.Unmarshal([]byte(options), &variant.Options)
+	if err != nil {
+		return models.Variant{}, err
+	}
+	if price.Valid {
+		p := float32(price.Float64)
+		variant.Price = &p
+	}
+	return variant, nil
+}
+
+// encodeOptions stores options as JSON. Map keys are sorted when encoded, so
+// equal option sets give equal text, which the unique index relies on.
+func encodeOptions(options map[string]string) (string, error) {
+	encoded, err := json.Marshal(options)
+	return string(encoded), err
+}
+
+func (vr *VariantRepository) GetOptions(productID string) ([]models.ProductOption, error) {
+	rows, err := vr.db.Query("SELECT name, option_values FROM product_options WHERE product_id = ? ORDER BY position", productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var options []models.ProductOption
+	for rows.Next() {
+		var option models.ProductOption
+		var values string
+		err := rows.Scan(&option.Name, &values)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal([]byte(values), &option.Values)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+	return options, rows.Err()
+}
+
+// SetOptions replaces the product's option types, keeping their order.
+func (vr *VariantRepository) SetOptions(productID string, options []models.ProductOption) error {
+	tx, err := vr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("DELETE FROM product_options WHERE product_id = ?", productID)
+	if err != nil {
+		return err
+	}
+	for i, option := range options {
+		values, err := json.Marshal(option.Values)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec("INSERT INTO product_options (product_id, name, position, option_values) VALUES (?, ?, ?, ?)",
+			productID, option.Name, i, string(values))
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (vr *VariantRepository) ListVariants(productID string) ([]models.Variant, error) {
+	rows, err := vr.db.Query("SELECT "+variantColumns+" FROM product_variants WHERE product_id = ? ORDER BY sku", productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []models.Variant
+	for rows.Next() {
+		variant, err := scanVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	return variants, rows.Err()
+}
+
+func (vr *VariantRepository) GetVariant(id string) (models.Variant, error) {
+	row := vr.db.QueryRow("SELECT "+variantColumns+" FROM product_variants WHERE id = ?", id)
+	return scanVariant(row)
+}
+
+func (vr *VariantRepository) GetVariantBySKU(sku string) (models.Variant, error) {
+	row := vr.db.QueryRow("SELECT "+variantColumns+" FROM product_variants WHERE sku = ?", sku)
+	return scanVariant(row)
+}
+
+// SaveVariant saves a new variant. When it starts with stock, opening is the
+// ledger entry for it and is recorded in the same transaction.
+func (vr *VariantRepository) SaveVariant(variant models.Variant, opening *models.StockMovement) error {
+	options, err := encodeOptions(variant.Options)
+	if err != nil {
+		return err
+	}
+	tx, err := vr.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("INSERT INTO product_variants ("+variantColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		variant.ID, variant.ProductID, variant.SKU, options, variant.Price, variant.Stock)
+	if err != nil {
+		return err
+	}
+	if opening != nil {
+		var actorID any
+		if opening.ActorID != "" {
+			actorID = opening.ActorID
+		}
+		_, err = tx.Exec(`INSERT INTO stock_movements (id, product_id, variant_id, delta, balance, reason, actor_id, reference, note, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, NULL, ?, ?)`,
+			opening.ID, variant.ProductID, variant.ID, opening.Delta, variant.Stock, opening.Reason, actorID, opening.Note, opening.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// UpdateVariant saves the variant's details. Its stock is only changed
+// through the inventory ledger.
+func (vr *VariantRepository) UpdateVariant(variant models.Variant) error {
+	options, err := encodeOptions(variant.Options)
+	if err != nil {
+		return err
+	}
+	result, err := vr.db.Exec("UPDATE product_variants SET sku = ?, options = ?, price = ? WHERE id = ?",
+		variant.SKU, options, variant.Price, variant.ID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+// DeleteVariant removes the variant. Cart items holding it go with it.
+func (vr *VariantRepository) DeleteVariant(id string) error {
+	result, err := vr.db.Exec("DELETE FROM product_variants WHERE id = ?", id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result)
+}
+
+func checkAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package variantRepository
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+var variantRowColumns = []string{"id", "product_id", "sku", "options", "price", "stock"}
+
+func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, VariantManager) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock: %v", err)
+	}
+	return db, mock, &VariantRepository{db: db}
+}
+
+func TestGetOptions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT name, option_values FROM product_options WHERE product_id = ? ORDER BY position")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "option_values"}).
+			AddRow("Layout", `["US","UK"]`).
+			AddRow("Colour", `["Black"]`))
+
+	options, err := repo.GetOptions("p1")
+	expected := []models.ProductOption{{Name: "Layout", Values: []string{"US", "UK"}}, {Name: "Colour", Values: []string{"Black"}}}
+	if err != nil || !reflect.DeepEqual(options, expected) {
+		t.Errorf("unexpected options: %+v, err: %v", options, err)
+	}
+}
+
+func TestSetOptions(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_options WHERE product_id = ?")).
+		WithArgs("p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_options (product_id, name, position, option_values) VALUES (?, ?, ?, ?)")).
+		WithArgs("p1", "Layout", 0, `["US","UK"]`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	if err := repo.SetOptions("p1", []models.ProductOption{{Name: "Layout", Values: []string{"US", "UK"}}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestListVariants(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, product_id, sku, options, price, stock FROM product_variants WHERE product_id = ? ORDER BY sku")).
+		WithArgs("p1").
+		WillReturnRows(sqlmock.NewRows(variantRowColumns).
+			AddRow("v1", "p1", "KB-UK", `{"Layout":"UK"}`, 1300.0, 2).
+			AddRow("v2", "p1", "KB-US", `{"Layout":"US"}`, nil, 5))
+
+	variants, err := repo.ListVariants("p1")
+	if err != nil || len(variants) != 2 {
+		t.Fatalf("unexpected variants: %+v, err: %v", variants, err)
+	}
+	if *variants[0].Price != 1300 || variants[0].Options["Layout"] != "UK" || variants[1].Price != nil {
+		t.Errorf("unexpected variants: %+v", variants)
+	}
+}
+
+func TestGetVariant(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, product_id, sku, options, price, stock FROM product_variants WHERE id = ?")).
+		WithArgs("v1").
+		WillReturnRows(sqlmock.NewRows(variantRowColumns).AddRow("v1", "p1", "KB-UK", `{"Layout":"UK"}`, nil, 2))
+
+	variant, err := repo.GetVariant("v1")
+	if err != nil || variant.SKU != "KB-UK" {
+		t.Errorf("unexpected variant: %+v, err: %v", variant, err)
+	}
+
+	mock.ExpectQuery("SELECT (.+) FROM product_variants WHERE sku = ?").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	if _, err := repo.GetVariantBySKU("missing"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestSaveVariant(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	price := float32(1300)
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO product_variants (id, product_id, sku, options, price, stock) VALUES (?, ?, ?, ?, ?, ?)")).
+		WithArgs("v1", "p1", "KB-UK-BLK", `{"Colour":"Black","Layout":"UK"}`, &price, 2).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO stock_movements").
+		WithArgs("m1", "p1", "v1", 2, 2, models.StockOpening, "admin1", "", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	variant := models.Variant{ID: "v1", ProductID: "p1", SKU: "KB-UK-BLK", Options: map[string]string{"Layout": "UK", "Colour": "Black"}, Price: &price, Stock: 2}
+	opening := models.StockMovement{ID: "m1", ProductID: "p1", VariantID: "v1", Delta: 2, Balance: 2, Reason: models.StockOpening, ActorID: "admin1", CreatedAt: now}
+	if err := repo.SaveVariant(variant, &opening); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateVariant(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE product_variants SET sku = ?, options = ?, price = ? WHERE id = ?")).
+		WithArgs("KB-UK", `{"Layout":"UK"}`, nil, "v1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	variant := models.Variant{ID: "v1", SKU: "KB-UK", Options: map[string]string{"Layout": "UK"}, Stock: 1}
+	if err := repo.UpdateVariant(variant); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDeleteVariant(t *testing.T) {
+	db, mock, repo := setupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM product_variants WHERE id = ?")).
+		WithArgs("404").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.DeleteVariant("404"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+}
@@ -1,70 +1,262 @@
 package adminservice
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/cartRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/couponRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/importJobRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/inventoryRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/orderRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/priceRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authzService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/imageService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/suggestService"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
 )
 
+var (
+	ErrStockNotEditable = errors.New("stock can not be set directly, adjust it through the stock adjustments endpoint")
+	ErrOutranked        = errors.New("this account has permissions you do not have")
+	ErrNotGrantable     = errors.New("you can not grant permissions you do not have")
+)
+
 type AdminService struct {
 	productRepo productRepository.ProductManager
 	couponRepo  couponRepository.CouponManager
+	userRepo    userRepository.UserManager
+	cartRepo    cartRepository.CartManager
+	orderRepo   orderRepository.OrderManager
+	suggestServ suggestService.SuggestServiceManager
+	imageServ   imageService.ImageServiceManager
+
+	importJobRepo importJobRepository.ImportJobManager
+	priceRepo     priceRepository.PriceManager
+	inventoryRepo inventoryRepository.InventoryManager
+	authzServ     authzService.AuthzServiceManager
+	// importing is set while a catalogue import runs.
+	importing atomic.Bool
 }
 
-func NewAdminService(productRepo productRepository.ProductManager, couponRepo couponRepository.CouponManager) AdminServiceManager {
+func NewAdminService(productRepo productRepository.ProductManager, couponRepo couponRepository.CouponManager, userRepo userRepository.UserManager, cartRepo cartRepository.CartManager, orderRepo orderRepository.OrderManager, suggestServ suggestService.SuggestServiceManager, imageServ imageService.ImageServiceManager, importJobRepo importJobRepository.ImportJobManager, priceRepo priceRepository.PriceManager, inventoryRepo inventoryRepository.InventoryManager, authzServ authzService.AuthzServiceManager) AdminServiceManager {
 	return &AdminService{
-		productRepo: productRepo,
-		couponRepo:  couponRepo,
+		productRepo:   productRepo,
+		couponRepo:    couponRepo,
+		userRepo:      userRepo,
+		cartRepo:      cartRepo,
+		orderRepo:     orderRepo,
+		suggestServ:   suggestServ,
+		imageServ:     imageServ,
+		importJobRepo: importJobRepo,
+		priceRepo:     priceRepo,
+		inventoryRepo: inventoryRepo,
+		authzServ:     authzServ,
 	}
 }
 
-func (as *AdminService) AddProduct(name string, price float32, stock int) error {
-	if name == "" || price <= 0 || stock < 0 {
+func (as *AdminService) AddProduct(adminID string, req dto.ProductDTO) error {
+	stock := 0
+	if req.Stock != nil {
+		stock = *req.Stock
+	}
+	if req.Name == "" || req.Price <= 0 || stock < 0 {
 		return fmt.Errorf("invalid product details")
 	}
-	newProduct, err := as.CreateProduct(name, price, stock)
+	newProduct, err := as.CreateProduct(req)
+	if err != nil {
+		return err
+	}
+	err = as.checkSKU(newProduct)
 	if err != nil {
 		return err
 	}
-	return as.productRepo.AddProduct(newProduct)
+	// the stock comes in through the ledger, like every later change
+	var opening *models.StockMovement
+	if stock > 0 {
+		newProduct.Stock = stock
+		opening = &models.StockMovement{
+			ID:        utils.NewUUID(),
+			ProductID: newProduct.ID,
+			Delta:     stock,
+			Balance:   stock,
+			Reason:    models.StockOpening,
+			ActorID:   adminID,
+			CreatedAt: newProduct.CreatedAt,
+		}
+	}
+	err = as.productRepo.AddProduct(newProduct, opening)
+	if err != nil {
+		return err
+	}
+	as.recordPrice(newProduct, models.PriceCreated)
+	as.refreshSuggestions()
+	return nil
 }
 
-func (as *AdminService) CreateProduct(name string, price float32, stock int) (models.Product, error) {
+func (as *AdminService) CreateProduct(req dto.ProductDTO) (models.Product, error) {
 	newProduct := models.Product{
-		ID:    utils.NewUUID(),
-		Name:  name,
-		Price: price,
-		Stock: stock,
+		ID:        utils.NewUUID(),
+		Name:      req.Name,
+		Tags:      normalizeTags(req.Tags),
+		Price:     req.Price,
+		Status:    models.ProductPublished,
+		CreatedAt: time.Now().UTC(),
+	}
+	if req.Status != "" {
+		newProduct.Status = req.Status
+	}
+	if req.SKU != nil {
+		newProduct.SKU = strings.TrimSpace(*req.SKU)
 	}
+	if req.Description != nil {
+		newProduct.Description = strings.TrimSpace(*req.Description)
+	}
+	applyDetails(&newProduct, req)
 	return newProduct, nil
 }
 
-func (as *AdminService) UpdateProduct(id, name string, price float32, stock int) error {
+func (as *AdminService) UpdateProduct(id string, req dto.ProductDTO) error {
 	product,err := as.productRepo.GetProductByID(id)
 	if err != nil {
 		return fmt.Errorf("product not found")
 	}
-	if name != "" {
-		product.Name = name
+	if req.SKU != nil {
+		product.SKU = strings.TrimSpace(*req.SKU)
+		err = as.checkSKU(product)
+		if err != nil {
+			return err
+		}
 	}
-	if price > 0 {
-		product.Price = price
+	if req.Name != "" {
+		product.Name = req.Name
 	}
-	if stock > 0 {
-		product.Stock = stock
+	if req.Description != nil {
+		product.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Tags != nil {
+		product.Tags = normalizeTags(req.Tags)
+	}
+	applyDetails(&product, req)
+	oldPrice := product.RegularPrice()
+	if req.Price > 0 {
+		setPrice(&product, req.Price)
+	}
+	if req.Stock != nil && *req.Stock != product.Stock {
+		return ErrStockNotEditable
+	}
+	if req.Status != "" {
+		product.Status = req.Status
+	}
+	err = as.productRepo.UpdateProduct(product)
+	if err != nil {
+		return err
 	}
-	return as.productRepo.UpdateProduct(product)
+	if product.RegularPrice() != oldPrice {
+		as.recordPrice(product, models.PriceUpdated)
+	}
+	as.refreshSuggestions()
+	return nil
 }
 
-func (as *AdminService) RemoveProduct(id string) error {
-	product,err:=as.productRepo.GetProductByID(id)
+// setPrice changes the product's regular price. While a sale is on that is
+// its compare-at price, and the sale price stays until the sale ends.
+func setPrice(product *models.Product, price float32) {
+	if product.CompareAtPrice != nil {
+		product.CompareAtPrice = &price
+		return
+	}
+	product.Price = price
+}
+
+// recordPrice adds the product's prices to its price history. The product is
+// already saved by then, so a failure is logged rather than returned.
+func (as *AdminService) recordPrice(product models.Product, reason models.PriceReason) {
+	err := as.priceRepo.AddPriceChange(models.PriceChange{
+		ID:             utils.NewUUID(),
+		ProductID:      product.ID,
+		Price:          product.Price,
+		CompareAtPrice: product.CompareAtPrice,
+		Reason:         reason,
+		ChangedAt:      time.Now().UTC(),
+	})
 	if err != nil {
-		return fmt.Errorf("product not found")
+		log.Printf("can not record the price of product %s: %v", product.ID, err)
+	}
+}
+
+// checkSKU makes sure no other product has the product's SKU.
+func (as *AdminService) checkSKU(product models.Product) error {
+	if product.SKU == "" {
+		return nil
+	}
+	existing, err := as.productRepo.GetProductBySKU(product.SKU)
+	if err == nil && existing.ID != product.ID {
+		return ErrSKUExists
+	}
+	return nil
+}
+
+// applyDetails copies the brand, weight, dimensions and specs that are set
+// in req onto product. An empty specs list clears the specs.
+func applyDetails(product *models.Product, req dto.ProductDTO) {
+	if req.Brand != nil {
+		product.Brand = strings.TrimSpace(*req.Brand)
+	}
+	if req.WeightGrams != nil {
+		product.WeightGrams = *req.WeightGrams
+	}
+	if req.Dimensions != nil {
+		dimensions := *req.Dimensions
+		product.Dimensions = &dimensions
+	}
+	if req.Specs != nil {
+		product.Specs = normalizeSpecs(req.Specs)
+	}
+}
+
+// normalizeSpecs trims spec keys, text values and units and lower-cases keys.
+func normalizeSpecs(specs []models.ProductSpec) []models.ProductSpec {
+	normalized := make([]models.ProductSpec, 0, len(specs))
+	for _, spec := range specs {
+		spec.Key = strings.ToLower(strings.TrimSpace(spec.Key))
+		spec.Unit = strings.TrimSpace(spec.Unit)
+		if text, ok := spec.Value.(string); ok {
+			spec.Value = strings.TrimSpace(text)
+		}
+		normalized = append(normalized, spec)
+	}
+	return normalized
+}
+
+// normalizeTags lower-cases and trims tags and drops repeats.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// refreshSuggestions rebuilds the search suggestions after a catalogue change.
+// A failure leaves the old suggestions in place, so it does not fail the change.
+func (as *AdminService) refreshSuggestions() {
+	err := as.suggestServ.Refresh()
+	if err != nil {
+		log.Printf("can not refresh search suggestions: %v", err)
 	}
-	return as.productRepo.RemoveProduct(product.ID)
 }
 
 func (as *AdminService) AddCoupon(code string, discount float32) error {
@@ -89,3 +281,150 @@ func (as *AdminService) RemoveCoupon(code string) error {
 	}
 	return as.couponRepo.RemoveCoupon(coupon.Code)
 }
+
+// ChangeUserRole is refused when the user has permissions the admin lacks, or
+// when the new role grants some.
+func (as *AdminService) ChangeUserRole(adminID, userID string, role models.UserRole) error {
+	if role != models.Admin && role != models.Customer {
+		return fmt.Errorf("invalid role")
+	}
+	if adminID == userID {
+		return fmt.Errorf("admins can not change their own role")
+	}
+	user, err := as.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	allowed, err := as.authzServ.CanManage(adminID, user)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrOutranked
+	}
+	allowed, err = as.authzServ.CanGrant(adminID, role.Permissions())
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrNotGrantable
+	}
+	if user.Role == role {
+		return nil
+	}
+	return as.userRepo.UpdateUserRole(user.ID, role)
+}
+
+func (as *AdminService) ListUsers(filter models.UserFilter) (dto.UserListDTO, error) {
+	users, total, err := as.userRepo.ListUsers(filter)
+	if err != nil {
+		return dto.UserListDTO{}, fmt.Errorf("can not list users: %v", err)
+	}
+	list := dto.UserListDTO{
+		Users: make([]dto.AdminUserDTO, 0, len(users)),
+		Total: total,
+		Page:  1,
+		Limit: filter.Limit,
+	}
+	if filter.Limit > 0 {
+		list.Page = filter.Offset/filter.Limit + 1
+	}
+	for _, user := range users {
+		list.Users = append(list.Users, toAdminUser(user))
+	}
+	return list, nil
+}
+
+func (as *AdminService) GetUser(userID string) (dto.AdminUserDTO, error) {
+	user, err := as.userRepo.GetUserByID(userID)
+	if err != nil {
+		return dto.AdminUserDTO{}, fmt.Errorf("user not found")
+	}
+	return toAdminUser(user), nil
+}
+
+// SetUserStatus suspends or reactivates an account. A suspended user's
+// existing tokens stop working on their next request. Accounts with
+// permissions the admin lacks are refused.
+func (as *AdminService) SetUserStatus(adminID, userID string, status models.UserStatus) error {
+	if adminID == userID {
+		return fmt.Errorf("admins can not change their own status")
+	}
+	user, err := as.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	if user.Status == models.UserErased {
+		return fmt.Errorf("erased accounts can not be changed")
+	}
+	allowed, err := as.authzServ.CanManage(adminID, user)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrOutranked
+	}
+	if user.Status == status {
+		return nil
+	}
+	return as.userRepo.UpdateUserStatus(user.ID, status)
+}
+
+func (as *AdminService) GetUserCart(userID string) ([]dto.CartItemsDTO, error) {
+	_, err := as.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	cartID, err := as.cartRepo.GetCartIDByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("no cart associated with user: %v", err)
+	}
+	cartItems, err := as.cartRepo.GetCartItems(cartID)
+	if err != nil {
+		return nil, fmt.Errorf("can't fetch cart items: %v", err)
+	}
+	return cartItems, nil
+}
+
+func (as *AdminService) GetUserOrders(userID string) ([]models.Order, error) {
+	_, err := as.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	orders, err := as.orderRepo.GetOrdersByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("can't fetch orders: %v", err)
+	}
+	return orders, nil
+}
+
+func (as *AdminService) ListOrders(filter models.OrderFilter) (dto.OrderListDTO, error) {
+	orders, total, err := as.orderRepo.ListOrders(filter)
+	if err != nil {
+		return dto.OrderListDTO{}, fmt.Errorf("can't fetch orders: %v", err)
+	}
+	list := dto.OrderListDTO{
+		Orders: orders,
+		Total:  total,
+		Page:   1,
+		Limit:  filter.Limit,
+	}
+	if list.Orders == nil {
+		list.Orders = []models.Order{}
+	}
+	if filter.Limit > 0 {
+		list.Page = filter.Offset/filter.Limit + 1
+	}
+	return list, nil
+}
+
+func toAdminUser(user models.User) dto.AdminUserDTO {
+	return dto.AdminUserDTO{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role.String(),
+		Status:        string(user.Status),
+		EmailVerified: user.IsEmailVerified(),
+	}
+}
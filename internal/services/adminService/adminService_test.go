@@ -2,8 +2,10 @@ package adminservice_test
 
 import (
 	"errors"
+	"slices"
 	"testing"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	adminservice "github.com/meshyampratap01/OnlineShoppingCart/internal/services/adminService"
@@ -16,22 +18,56 @@ func TestAddProduct(t *testing.T) {
 
 	mockProductRepo := mocks.NewMockProductManager(ctrl)
 	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo)
+	mockSuggestServ := mocks.NewMockSuggestServiceManager(ctrl)
+	mockPriceRepo := mocks.NewMockPriceManager(ctrl)
+	mockInventoryRepo := mocks.NewMockInventoryManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo, nil, nil, nil, mockSuggestServ, nil, nil, mockPriceRepo, mockInventoryRepo, nil)
 
 	// Invalid input
-	err := service.AddProduct("", 0, -1)
+	negative := -1
+	stock10 := 10
+	err := service.AddProduct("admin1", dto.ProductDTO{Price: 0, Stock: &negative})
 	if err == nil {
 		t.Error("expected error for invalid product details")
 	}
 
 	// Valid input
-	mockProduct := models.Product{Name: "Test", Price: 100, Stock: 10}
-	mockProductRepo.EXPECT().AddProduct(gomock.Any()).Return(nil)
+	description := " Wireless headphones "
+	var added models.Product
+	// the stock is saved together with its opening entry in the ledger
+	mockProductRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any()).DoAndReturn(func(product models.Product, opening *models.StockMovement) error {
+		added = product
+		if opening == nil || opening.ProductID != product.ID || opening.Delta != 10 || opening.Balance != 10 ||
+			opening.Reason != models.StockOpening || opening.ActorID != "admin1" {
+			t.Errorf("unexpected opening entry: %+v", opening)
+		}
+		return nil
+	})
+	mockPriceRepo.EXPECT().AddPriceChange(gomock.Any()).DoAndReturn(func(change models.PriceChange) error {
+		if change.ProductID != added.ID || change.Price != 100 || change.Reason != models.PriceCreated {
+			t.Errorf("unexpected price change: %+v", change)
+		}
+		return nil
+	})
+	mockSuggestServ.EXPECT().Refresh().Return(nil)
 
-	err = service.AddProduct(mockProduct.Name, mockProduct.Price, mockProduct.Stock)
+	brand := " Sony "
+	dimensions := models.Dimensions{LengthMM: 200, WidthMM: 180, HeightMM: 90}
+	err = service.AddProduct("admin1", dto.ProductDTO{Name: "Test", Description: &description, Tags: []string{"Audio", " audio", "wireless"},
+		Brand: &brand, Dimensions: &dimensions, Price: 100, Stock: &stock10})
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
+	if added.Stock != 10 || added.Description != "Wireless headphones" || !slices.Equal(added.Tags, []string{"audio", "wireless"}) || added.CreatedAt.IsZero() {
+		t.Errorf("unexpected product: %+v", added)
+	}
+	if added.Brand != "Sony" || added.Dimensions == nil || *added.Dimensions != dimensions {
+		t.Errorf("unexpected product details: %+v", added)
+	}
+	// products are published unless added as drafts
+	if added.Status != models.ProductPublished {
+		t.Errorf("expected a published product, got %q", added.Status)
+	}
 }
 
 func TestUpdateProduct(t *testing.T) {
@@ -40,47 +76,132 @@ func TestUpdateProduct(t *testing.T) {
 
 	mockProductRepo := mocks.NewMockProductManager(ctrl)
 	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo)
+	mockSuggestServ := mocks.NewMockSuggestServiceManager(ctrl)
+	mockPriceRepo := mocks.NewMockPriceManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo, nil, nil, nil, mockSuggestServ, nil, nil, mockPriceRepo, nil, nil)
 
-	product := models.Product{ID: "123", Name: "Old", Price: 50, Stock: 5}
+	product := models.Product{ID: "123", Name: "Old", Brand: "Acme", WeightGrams: 500, Price: 50, Stock: 5,
+		Specs: []models.ProductSpec{{Key: "ram", Type: models.SpecNumber, Value: 8.0}}}
+	var updated models.Product
 	mockProductRepo.EXPECT().GetProductByID("123").Return(product, nil)
-	mockProductRepo.EXPECT().UpdateProduct(gomock.Any()).Return(nil)
+	mockProductRepo.EXPECT().UpdateProduct(gomock.Any()).DoAndReturn(func(product models.Product) error {
+		updated = product
+		return nil
+	})
+	mockPriceRepo.EXPECT().AddPriceChange(gomock.Any()).DoAndReturn(func(change models.PriceChange) error {
+		if change.ProductID != "123" || change.Price != 100 || change.CompareAtPrice != nil || change.Reason != models.PriceUpdated {
+			t.Errorf("unexpected price change: %+v", change)
+		}
+		return nil
+	})
+	mockSuggestServ.EXPECT().Refresh().Return(nil)
 
-	err := service.UpdateProduct("123", "New", 100, 10)
+	weight := 750
+	stock5 := 5
+	stock10 := 10
+	err := service.UpdateProduct("123", dto.ProductDTO{Name: "New", WeightGrams: &weight, Specs: []models.ProductSpec{
+		{Key: " OS ", Type: models.SpecText, Value: " Android "},
+	}, Price: 100, Stock: &stock5, Status: models.ProductDraft})
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
+	// the brand was not sent, so it is kept; the specs are replaced
+	if updated.Status != models.ProductDraft || updated.Brand != "Acme" || updated.WeightGrams != 750 || len(updated.Specs) != 1 || updated.Specs[0].Key != "os" || updated.Specs[0].Value != "Android" {
+		t.Errorf("unexpected product: %+v", updated)
+	}
+
+	// Stock is only changed through adjustments
+	mockProductRepo.EXPECT().GetProductByID("123").Return(product, nil)
+	err = service.UpdateProduct("123", dto.ProductDTO{Stock: &stock10})
+	if !errors.Is(err, adminservice.ErrStockNotEditable) {
+		t.Errorf("expected ErrStockNotEditable, got %v", err)
+	}
+	zero := 0
+	mockProductRepo.EXPECT().GetProductByID("123").Return(product, nil)
+	err = service.UpdateProduct("123", dto.ProductDTO{Stock: &zero})
+	if !errors.Is(err, adminservice.ErrStockNotEditable) {
+		t.Errorf("expected ErrStockNotEditable for zero stock, got %v", err)
+	}
 
 	// Product not found
 	mockProductRepo.EXPECT().GetProductByID("404").Return(models.Product{}, errors.New("not found"))
-	err = service.UpdateProduct("404", "New", 100, 10)
+	err = service.UpdateProduct("404", dto.ProductDTO{Name: "New", Price: 100, Stock: &stock10})
 	if err == nil {
 		t.Error("expected error for product not found")
 	}
 }
 
-func TestRemoveProduct(t *testing.T) {
+func TestUpdateProductPrice(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockProductRepo := mocks.NewMockProductManager(ctrl)
-	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo)
+	mockSuggestServ := mocks.NewMockSuggestServiceManager(ctrl)
+	mockPriceRepo := mocks.NewMockPriceManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, mockSuggestServ, nil, nil, mockPriceRepo, nil, nil)
 
-	product := models.Product{ID: "123"}
-	mockProductRepo.EXPECT().GetProductByID("123").Return(product, nil)
-	mockProductRepo.EXPECT().RemoveProduct("123").Return(nil)
+	// An unchanged price is not recorded
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", Price: 50}, nil)
+	mockProductRepo.EXPECT().UpdateProduct(gomock.Any()).Return(nil)
+	mockSuggestServ.EXPECT().Refresh().Return(nil)
+	if err := service.UpdateProduct("p1", dto.ProductDTO{Price: 50}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
 
-	err := service.RemoveProduct("123")
-	if err != nil {
+	// During a sale the regular price changes and the sale price stays
+	regular := float32(100)
+	var updated models.Product
+	mockProductRepo.EXPECT().GetProductByID("p2").Return(models.Product{ID: "p2", Price: 80, CompareAtPrice: &regular}, nil)
+	mockProductRepo.EXPECT().UpdateProduct(gomock.Any()).DoAndReturn(func(product models.Product) error {
+		updated = product
+		return nil
+	})
+	mockPriceRepo.EXPECT().AddPriceChange(gomock.Any()).DoAndReturn(func(change models.PriceChange) error {
+		if change.Price != 80 || change.CompareAtPrice == nil || *change.CompareAtPrice != 120 {
+			t.Errorf("unexpected price change: %+v", change)
+		}
+		return nil
+	})
+	mockSuggestServ.EXPECT().Refresh().Return(nil)
+	if err := service.UpdateProduct("p2", dto.ProductDTO{Price: 120}); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
+	if updated.Price != 80 || updated.CompareAtPrice == nil || *updated.CompareAtPrice != 120 {
+		t.Errorf("unexpected product: %+v", updated)
+	}
+}
 
-	// Product not found
-	mockProductRepo.EXPECT().GetProductByID("404").Return(models.Product{}, errors.New("not found"))
-	err = service.RemoveProduct("404")
-	if err == nil {
-		t.Error("expected error for product not found")
+func TestProductSKU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockSuggestServ := mocks.NewMockSuggestServiceManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, mockSuggestServ, nil, nil, nil, nil, nil)
+
+	// Adding with a SKU another product has
+	sku := " LAP-1 "
+	mockProductRepo.EXPECT().GetProductBySKU("LAP-1").Return(models.Product{ID: "p1", SKU: "LAP-1"}, nil)
+	err := service.AddProduct("admin1", dto.ProductDTO{SKU: &sku, Name: "Laptop", Price: 100})
+	if !errors.Is(err, adminservice.ErrSKUExists) {
+		t.Errorf("expected ErrSKUExists, got %v", err)
+	}
+
+	// Keeping its own SKU on update
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", SKU: "LAP-1"}, nil)
+	mockProductRepo.EXPECT().GetProductBySKU("LAP-1").Return(models.Product{ID: "p1", SKU: "LAP-1"}, nil)
+	mockProductRepo.EXPECT().UpdateProduct(gomock.Any()).Return(nil)
+	mockSuggestServ.EXPECT().Refresh().Return(nil)
+	if err := service.UpdateProduct("p1", dto.ProductDTO{SKU: &sku}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Taking another product's SKU on update
+	mockProductRepo.EXPECT().GetProductByID("p2").Return(models.Product{ID: "p2"}, nil)
+	mockProductRepo.EXPECT().GetProductBySKU("LAP-1").Return(models.Product{ID: "p1", SKU: "LAP-1"}, nil)
+	err = service.UpdateProduct("p2", dto.ProductDTO{SKU: &sku})
+	if !errors.Is(err, adminservice.ErrSKUExists) {
+		t.Errorf("expected ErrSKUExists, got %v", err)
 	}
 }
 
@@ -90,7 +211,7 @@ func TestAddCoupon(t *testing.T) {
 
 	mockProductRepo := mocks.NewMockProductManager(ctrl)
 	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo)
+	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	// Invalid coupon
 	err := service.AddCoupon("", -10)
@@ -120,7 +241,7 @@ func TestRemoveCoupon(t *testing.T) {
 
 	mockProductRepo := mocks.NewMockProductManager(ctrl)
 	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo)
+	service := adminservice.NewAdminService(mockProductRepo, mockCouponRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	// Coupon exists
 	mockCouponRepo.EXPECT().GetCouponByCode("SAVE10").Return(&models.Coupon{Code: "SAVE10"}, nil)
@@ -137,3 +258,178 @@ func TestRemoveCoupon(t *testing.T) {
 		t.Error("expected error for coupon not found")
 	}
 }
+
+func TestChangeUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockAuthzServ := mocks.NewMockAuthzServiceManager(ctrl)
+	service := adminservice.NewAdminService(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockAuthzServ)
+
+	// Admins can not demote themselves
+	err := service.ChangeUserRole("admin1", "admin1", models.Customer)
+	if err == nil {
+		t.Error("expected error when changing own role")
+	}
+
+	// User not found
+	mockUserRepo.EXPECT().GetUserByID("404").Return(models.User{}, errors.New("not found"))
+	err = service.ChangeUserRole("admin1", "404", models.Admin)
+	if err == nil {
+		t.Error("expected error for user not found")
+	}
+
+	// Role managers can not demote an account that outranks them
+	mockUserRepo.EXPECT().GetUserByID("admin2").Return(models.User{ID: "admin2", Role: models.Admin}, nil)
+	mockAuthzServ.EXPECT().CanManage("agent1", gomock.Any()).Return(false, nil)
+	err = service.ChangeUserRole("agent1", "admin2", models.Customer)
+	if !errors.Is(err, adminservice.ErrOutranked) {
+		t.Errorf("expected ErrOutranked, got %v", err)
+	}
+
+	// nor promote anyone past their own permissions
+	mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Role: models.Customer}, nil)
+	mockAuthzServ.EXPECT().CanManage("agent1", gomock.Any()).Return(true, nil)
+	mockAuthzServ.EXPECT().CanGrant("agent1", models.Admin.Permissions()).Return(false, nil)
+	err = service.ChangeUserRole("agent1", "u1", models.Admin)
+	if !errors.Is(err, adminservice.ErrNotGrantable) {
+		t.Errorf("expected ErrNotGrantable, got %v", err)
+	}
+
+	// Promote a customer
+	mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Role: models.Customer}, nil)
+	mockAuthzServ.EXPECT().CanManage("admin1", gomock.Any()).Return(true, nil)
+	mockAuthzServ.EXPECT().CanGrant("admin1", models.Admin.Permissions()).Return(true, nil)
+	mockUserRepo.EXPECT().UpdateUserRole("u1", models.Admin).Return(nil)
+	err = service.ChangeUserRole("admin1", "u1", models.Admin)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	service := adminservice.NewAdminService(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	filter := models.UserFilter{Query: "bob", Limit: 10, Offset: 20}
+	mockUserRepo.EXPECT().ListUsers(filter).Return([]models.User{
+		{ID: "u1", Name: "Bob", Email: "bob@example.com", Role: models.Customer, Status: models.UserSuspended},
+	}, 21, nil)
+
+	list, err := service.ListUsers(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.Total != 21 || list.Page != 3 || list.Limit != 10 || len(list.Users) != 1 {
+		t.Errorf("unexpected list: %+v", list)
+	}
+	if list.Users[0].Role != "Customer" || list.Users[0].Status != "suspended" {
+		t.Errorf("unexpected user: %+v", list.Users[0])
+	}
+}
+
+func TestListOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mocks.NewMockOrderManager(ctrl)
+	service := adminservice.NewAdminService(nil, nil, nil, nil, mockOrderRepo, nil, nil, nil, nil, nil, nil)
+
+	filter := models.OrderFilter{Limit: 10, Offset: 10}
+	mockOrderRepo.EXPECT().ListOrders(filter).Return(nil, 10, nil)
+
+	list, err := service.ListOrders(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list.Total != 10 || list.Page != 2 || list.Limit != 10 || list.Orders == nil || len(list.Orders) != 0 {
+		t.Errorf("unexpected list: %+v", list)
+	}
+}
+
+func TestSetUserStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockAuthzServ := mocks.NewMockAuthzServiceManager(ctrl)
+	service := adminservice.NewAdminService(nil, nil, mockUserRepo, nil, nil, nil, nil, nil, nil, nil, mockAuthzServ)
+
+	// Admins can not suspend themselves
+	err := service.SetUserStatus("admin1", "admin1", models.UserSuspended)
+	if err == nil {
+		t.Error("expected error when suspending own account")
+	}
+
+	// User not found
+	mockUserRepo.EXPECT().GetUserByID("404").Return(models.User{}, errors.New("not found"))
+	err = service.SetUserStatus("admin1", "404", models.UserSuspended)
+	if err == nil {
+		t.Error("expected error for user not found")
+	}
+
+	// Support agents can not suspend an admin
+	mockUserRepo.EXPECT().GetUserByID("admin2").Return(models.User{ID: "admin2", Role: models.Admin, Status: models.UserActive}, nil)
+	mockAuthzServ.EXPECT().CanManage("agent1", gomock.Any()).Return(false, nil)
+	err = service.SetUserStatus("agent1", "admin2", models.UserSuspended)
+	if !errors.Is(err, adminservice.ErrOutranked) {
+		t.Errorf("expected ErrOutranked, got %v", err)
+	}
+
+	// Already suspended is a no-op
+	mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Status: models.UserSuspended}, nil)
+	mockAuthzServ.EXPECT().CanManage("admin1", gomock.Any()).Return(true, nil).Times(2)
+	err = service.SetUserStatus("admin1", "u1", models.UserSuspended)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Erased accounts stay erased
+	mockUserRepo.EXPECT().GetUserByID("u3").Return(models.User{ID: "u3", Status: models.UserErased}, nil)
+	err = service.SetUserStatus("admin1", "u3", models.UserActive)
+	if err == nil {
+		t.Error("expected error when reactivating an erased account")
+	}
+
+	// Suspend an active user
+	mockUserRepo.EXPECT().GetUserByID("u2").Return(models.User{ID: "u2", Status: models.UserActive}, nil)
+	mockUserRepo.EXPECT().UpdateUserStatus("u2", models.UserSuspended).Return(nil)
+	err = service.SetUserStatus("admin1", "u2", models.UserSuspended)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetUserCartAndOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockCartRepo := mocks.NewMockCartManager(ctrl)
+	mockOrderRepo := mocks.NewMockOrderManager(ctrl)
+	service := adminservice.NewAdminService(nil, nil, mockUserRepo, mockCartRepo, mockOrderRepo, nil, nil, nil, nil, nil, nil)
+
+	mockUserRepo.EXPECT().GetUserByID("404").Return(models.User{}, errors.New("not found"))
+	_, err := service.GetUserCart("404")
+	if err == nil {
+		t.Error("expected error for user not found")
+	}
+
+	mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1"}, nil).Times(2)
+	mockCartRepo.EXPECT().GetCartIDByUserID("u1").Return("cart1", nil)
+	mockCartRepo.EXPECT().GetCartItems("cart1").Return([]dto.CartItemsDTO{{ProductID: "p1", Quantity: 2}}, nil)
+	items, err := service.GetUserCart("u1")
+	if err != nil || len(items) != 1 {
+		t.Errorf("expected one cart item, got %v, err: %v", items, err)
+	}
+
+	mockOrderRepo.EXPECT().GetOrdersByUserID("u1").Return([]models.Order{{ID: "o1", UserID: "u1"}}, nil)
+	orders, err := service.GetUserOrders("u1")
+	if err != nil || len(orders) != 1 {
+		t.Errorf("expected one order, got %v, err: %v", orders, err)
+	}
+}
@@ -0,0 +1,93 @@
+package adminservice
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+var (
+	ErrProductNotFound    = errors.New("product not found")
+	ErrProductNotArchived = errors.New("archive the product before purging it")
+	ErrProductOrdered     = errors.New("the product is in past orders and can not be purged")
+)
+
+// ArchiveProduct hides the product from the storefront and stops it being
+// bought. Carts keep it, marked unavailable. Archiving an archived product
+// does nothing.
+func (as *AdminService) ArchiveProduct(id string) error {
+	product, err := as.getProduct(id)
+	if err != nil {
+		return err
+	}
+	if product.ArchivedAt != nil {
+		return nil
+	}
+	archivedAt := time.Now().UTC()
+	err = as.productRepo.SetArchivedAt(id, &archivedAt)
+	if err != nil {
+		return fmt.Errorf("can not archive product: %v", err)
+	}
+	as.refreshSuggestions()
+	return nil
+}
+
+// RestoreProduct brings an archived product back in the status it had.
+func (as *AdminService) RestoreProduct(id string) error {
+	product, err := as.getProduct(id)
+	if err != nil {
+		return err
+	}
+	if product.ArchivedAt == nil {
+		return nil
+	}
+	err = as.productRepo.SetArchivedAt(id, nil)
+	if err != nil {
+		return fmt.Errorf("can not restore product: %v", err)
+	}
+	as.refreshSuggestions()
+	return nil
+}
+
+// PurgeProduct deletes an archived product and then the stored files of its
+// images. Products that were ever ordered are kept for the order history.
+func (as *AdminService) PurgeProduct(id string) error {
+	product, err := as.getProduct(id)
+	if err != nil {
+		return err
+	}
+	if product.ArchivedAt == nil {
+		return ErrProductNotArchived
+	}
+	orders, err := as.orderRepo.CountProductOrders(id)
+	if err != nil {
+		return fmt.Errorf("can not check orders of product: %v", err)
+	}
+	if orders > 0 {
+		return ErrProductOrdered
+	}
+	images, err := as.imageServ.ListImages(id)
+	if err != nil {
+		return err
+	}
+	err = as.productRepo.RemoveProduct(id)
+	if err != nil {
+		return err
+	}
+	as.imageServ.DeleteImageBlobs(images)
+	return nil
+}
+
+func (as *AdminService) getProduct(id string) (models.Product, error) {
+	product, err := as.productRepo.GetProductByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Product{}, ErrProductNotFound
+	}
+	if err != nil {
+		return models.Product{}, fmt.Errorf("can not fetch product: %v", err)
+	}
+	return product, nil
+}
@@ -0,0 +1,106 @@
+package adminservice_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	adminservice "github.com/meshyampratap01/OnlineShoppingCart/internal/services/adminService"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArchiveProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockSuggestServ := mocks.NewMockSuggestServiceManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, mockSuggestServ, nil, nil, nil, nil, nil)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", Status: models.ProductPublished}, nil)
+	mockProductRepo.EXPECT().SetArchivedAt("p1", gomock.Not(gomock.Nil())).Return(nil)
+	mockSuggestServ.EXPECT().Refresh().Return(nil)
+	if err := service.ArchiveProduct("p1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// archiving again changes nothing
+	archivedAt := time.Now()
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", ArchivedAt: &archivedAt}, nil)
+	if err := service.ArchiveProduct("p1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("404").Return(models.Product{}, sql.ErrNoRows)
+	if err := service.ArchiveProduct("404"); !errors.Is(err, adminservice.ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestRestoreProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockSuggestServ := mocks.NewMockSuggestServiceManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, mockSuggestServ, nil, nil, nil, nil, nil)
+
+	archivedAt := time.Now()
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", Status: models.ProductDraft, ArchivedAt: &archivedAt}, nil)
+	mockProductRepo.EXPECT().SetArchivedAt("p1", nil).Return(nil)
+	mockSuggestServ.EXPECT().Refresh().Return(errors.New("db error"))
+	if err := service.RestoreProduct("p1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("p2").Return(models.Product{ID: "p2"}, nil)
+	if err := service.RestoreProduct("p2"); err != nil {
+		t.Errorf("unexpected error restoring a live product: %v", err)
+	}
+}
+
+func TestPurgeProduct(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockOrderRepo := mocks.NewMockOrderManager(ctrl)
+	mockImageServ := mocks.NewMockImageServiceManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, mockOrderRepo, nil, mockImageServ, nil, nil, nil, nil)
+
+	archivedAt := time.Now()
+	archived := models.Product{ID: "p1", ArchivedAt: &archivedAt}
+	images := []models.ProductImage{{ID: "i1", ProductID: "p1", ContentType: "image/png"}}
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(archived, nil)
+	mockOrderRepo.EXPECT().CountProductOrders("p1").Return(0, nil)
+	mockImageServ.EXPECT().ListImages("p1").Return(images, nil)
+	mockProductRepo.EXPECT().RemoveProduct("p1").Return(nil)
+	mockImageServ.EXPECT().DeleteImageBlobs(images)
+	if err := service.PurgeProduct("p1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Blobs are kept when the product can not be removed
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(archived, nil)
+	mockOrderRepo.EXPECT().CountProductOrders("p1").Return(0, nil)
+	mockImageServ.EXPECT().ListImages("p1").Return(images, nil)
+	mockProductRepo.EXPECT().RemoveProduct("p1").Return(errors.New("db error"))
+	if err := service.PurgeProduct("p1"); err == nil {
+		t.Error("expected error for failed removal")
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(archived, nil)
+	mockOrderRepo.EXPECT().CountProductOrders("p1").Return(3, nil)
+	if err := service.PurgeProduct("p1"); !errors.Is(err, adminservice.ErrProductOrdered) {
+		t.Errorf("expected ErrProductOrdered, got %v", err)
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("p2").Return(models.Product{ID: "p2"}, nil)
+	if err := service.PurgeProduct("p2"); !errors.Is(err, adminservice.ErrProductNotArchived) {
+		t.Errorf("expected ErrProductNotArchived, got %v", err)
+	}
+}
@@ -0,0 +1,355 @@
+package adminservice
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
+)
+
+var (
+	ErrSKUExists         = errors.New("a product with this sku already exists")
+	ErrImportRunning     = errors.New("another import is still running")
+	ErrImportJobNotFound = errors.New("import job not found")
+	ErrUnsupportedFormat = errors.New("catalogue files must be CSV or JSON lines")
+	ErrMalformedImport   = errors.New("the import file can not be read")
+	ErrEmptyImport       = errors.New("the import file has no rows")
+	ErrTooManyRows       = fmt.Errorf("an import can have at most %d rows", config.MaxImportRows)
+)
+
+const (
+	// maxReportedErrors caps the rows listed in a job's errors.
+	maxReportedErrors = 100
+	// importProgressInterval is how many rows are written between progress
+	// updates of a job.
+	importProgressInterval = 100
+	exportBatchSize        = 500
+)
+
+// ImportProducts creates or updates products from a CSV or JSON lines file,
+// matching rows to products by SKU. Every row is checked before anything is
+// written, and an import with an invalid row writes nothing. A dry run only
+// checks the rows and counts what would be created and updated.
+//
+// Imports of up to config.ImportSyncRows rows finish before ImportProducts
+// returns; larger ones return a running job that can be followed with
+// GetImportJob. Only one import runs at a time.
+func (as *AdminService) ImportProducts(adminID, format string, body io.Reader, dryRun bool) (models.ImportJob, error) {
+	if !as.importing.CompareAndSwap(false, true) {
+		return models.ImportJob{}, ErrImportRunning
+	}
+	rows, err := parseImport(format, body)
+	if err != nil {
+		as.importing.Store(false)
+		return models.ImportJob{}, err
+	}
+	job := models.ImportJob{
+		ID:        utils.NewUUID(),
+		CreatedBy: adminID,
+		Format:    format,
+		DryRun:    dryRun,
+		Status:    models.ImportRunning,
+		TotalRows: len(rows),
+		Errors:    []models.ImportRowError{},
+		CreatedAt: time.Now().UTC(),
+	}
+	err = as.importJobRepo.SaveJob(job)
+	if err != nil {
+		as.importing.Store(false)
+		return models.ImportJob{}, fmt.Errorf("can not save import job: %v", err)
+	}
+	if len(rows) <= config.ImportSyncRows {
+		return as.runImport(job, rows), nil
+	}
+	go as.runImport(job, rows)
+	return job, nil
+}
+
+func (as *AdminService) runImport(job models.ImportJob, rows []importRow) models.ImportJob {
+	defer as.importing.Store(false)
+
+	creates, updates := as.checkRows(rows)
+	for _, row := range rows {
+		if len(row.errors) > 0 {
+			addRowError(&job, row, row.errors)
+		}
+	}
+	if job.DryRun {
+		job.ProcessedRows = len(rows)
+		job.Created = creates
+		job.Updated = updates
+		return as.finishImport(job, models.ImportSucceeded)
+	}
+	if job.Failed > 0 {
+		return as.finishImport(job, models.ImportFailed)
+	}
+
+	for _, row := range rows {
+		created, err := as.upsertRow(job, row.product)
+		switch {
+		case err != nil:
+			addRowError(&job, row, []string{err.Error()})
+		case created:
+			job.Created++
+		default:
+			job.Updated++
+		}
+		job.ProcessedRows++
+		if job.ProcessedRows%importProgressInterval == 0 {
+			err = as.importJobRepo.UpdateJob(job)
+			if err != nil {
+				log.Printf("can not record progress of import %s: %v", job.ID, err)
+			}
+		}
+	}
+	if job.Created+job.Updated > 0 {
+		as.refreshSuggestions()
+	}
+	return as.finishImport(job, models.ImportSucceeded)
+}
+
+// checkRows validates every row, recording the problems on the row, and
+// counts the valid rows that would create and update products.
+func (as *AdminService) checkRows(rows []importRow) (int, int) {
+	var creates, updates int
+	lines := make(map[string]int, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		if len(row.errors) > 0 {
+			continue
+		}
+		row.errors = validateImportRow(row.product)
+		sku := row.product.SKU
+		if first, ok := lines[sku]; ok && sku != "" {
+			row.errors = append(row.errors, fmt.Sprintf("sku is also on line %d", first))
+		} else {
+			lines[sku] = row.line
+		}
+		if validators.ValidateSKU(sku) != nil {
+			continue
+		}
+
+		_, err := as.productRepo.GetProductBySKU(sku)
+		isNew := errors.Is(err, sql.ErrNoRows)
+		if err != nil && !isNew {
+			row.errors = append(row.errors, fmt.Sprintf("can not look up sku: %v", err))
+		}
+		if isNew && row.product.Name == nil {
+			row.errors = append(row.errors, "name is required for a new product")
+		}
+		if isNew && row.product.Price == nil {
+			row.errors = append(row.errors, "price is required for a new product")
+		}
+		if len(row.errors) > 0 {
+			continue
+		}
+		if isNew {
+			creates++
+		} else {
+			updates++
+		}
+	}
+	return creates, updates
+}
+
+func validateImportRow(product dto.ProductImportDTO) []string {
+	var errs []string
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	check(validators.ValidateSKU(product.SKU))
+	if product.Name != nil {
+		check(validators.ValidateName(strings.TrimSpace(*product.Name)))
+	}
+	if product.Description != nil {
+		check(validators.ValidateDescription(*product.Description))
+	}
+	if product.Brand != nil {
+		check(validators.ValidateBrand(*product.Brand))
+	}
+	if product.Price != nil && *product.Price <= 0 {
+		errs = append(errs, "price must be greater than zero")
+	}
+	if product.Stock != nil && *product.Stock < 0 {
+		errs = append(errs, "stock can not be negative")
+	}
+	if product.WeightGrams != nil {
+		check(validators.ValidateWeight(*product.WeightGrams))
+	}
+	if product.Dimensions != nil {
+		check(validators.ValidateDimensions(*product.Dimensions))
+	}
+	check(validators.ValidateSpecs(product.Specs))
+	check(validators.ValidateTags(product.Tags))
+	if product.Status != nil {
+		_, err := models.ParseProductStatus(*product.Status)
+		check(err)
+	}
+	return errs
+}
+
+// upsertRow updates the product with the row's SKU, or creates it, and
+// reports whether it was created. A stock in the row is recorded in the
+// ledger against the job.
+func (as *AdminService) upsertRow(job models.ImportJob, row dto.ProductImportDTO) (bool, error) {
+	product, err := as.productRepo.GetProductBySKU(row.SKU)
+	if errors.Is(err, sql.ErrNoRows) {
+		if row.Name == nil || row.Price == nil {
+			return false, fmt.Errorf("the product was removed during the import")
+		}
+		product = models.Product{ID: utils.NewUUID(), SKU: row.SKU, Tags: []string{}, Status: models.ProductPublished, CreatedAt: time.Now().UTC()}
+		applyImportRow(&product, row)
+		// a new product's stock is recorded with it rather than set after
+		var opening *models.StockMovement
+		if row.Stock != nil && *row.Stock > 0 {
+			movement := importMovement(job, product)
+			movement.Delta, movement.Balance = *row.Stock, *row.Stock
+			product.Stock = *row.Stock
+			opening = &movement
+		}
+		err = as.productRepo.AddProduct(product, opening)
+		if err != nil {
+			return false, err
+		}
+		as.recordPrice(product, models.PriceImported)
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can not look up sku: %v", err)
+	}
+	oldPrice := product.RegularPrice()
+	applyImportRow(&product, row)
+	err = as.productRepo.UpdateProduct(product)
+	if err != nil {
+		return false, err
+	}
+	if product.RegularPrice() != oldPrice {
+		as.recordPrice(product, models.PriceImported)
+	}
+	return false, as.importStock(job, product, row)
+}
+
+func (as *AdminService) importStock(job models.ImportJob, product models.Product, row dto.ProductImportDTO) error {
+	if row.Stock == nil {
+		return nil
+	}
+	_, err := as.inventoryRepo.SetStock(importMovement(job, product), *row.Stock)
+	if err != nil {
+		return fmt.Errorf("can not set stock: %v", err)
+	}
+	return nil
+}
+
+// importMovement is the ledger entry for stock set by the job's row of the
+// product; the delta and balance are filled in once they are known.
+func importMovement(job models.ImportJob, product models.Product) models.StockMovement {
+	return models.StockMovement{
+		ID:        utils.NewUUID(),
+		ProductID: product.ID,
+		Reason:    models.StockImport,
+		ActorID:   job.CreatedBy,
+		Reference: job.ID,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func applyImportRow(product *models.Product, row dto.ProductImportDTO) {
+	if row.Name != nil {
+		product.Name = strings.TrimSpace(*row.Name)
+	}
+	if row.Description != nil {
+		product.Description = strings.TrimSpace(*row.Description)
+	}
+	if row.Tags != nil {
+		product.Tags = normalizeTags(row.Tags)
+	}
+	if row.Price != nil {
+		setPrice(product, *row.Price)
+	}
+	if row.Status != nil {
+		product.Status = models.ProductStatus(*row.Status)
+	}
+	applyDetails(product, dto.ProductDTO{Brand: row.Brand, WeightGrams: row.WeightGrams, Dimensions: row.Dimensions, Specs: row.Specs})
+}
+
+func addRowError(job *models.ImportJob, row importRow, errs []string) {
+	job.Failed++
+	if len(job.Errors) < maxReportedErrors {
+		job.Errors = append(job.Errors, models.ImportRowError{Line: row.line, SKU: row.product.SKU, Errors: errs})
+	}
+}
+
+func (as *AdminService) finishImport(job models.ImportJob, status models.ImportStatus) models.ImportJob {
+	finishedAt := time.Now().UTC()
+	job.Status = status
+	job.FinishedAt = &finishedAt
+	err := as.importJobRepo.UpdateJob(job)
+	if err != nil {
+		log.Printf("can not record the result of import %s: %v", job.ID, err)
+	}
+	return job
+}
+
+func (as *AdminService) GetImportJob(id string) (models.ImportJob, error) {
+	job, err := as.importJobRepo.GetJob(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.ImportJob{}, ErrImportJobNotFound
+	}
+	if err != nil {
+		return models.ImportJob{}, fmt.Errorf("can not fetch import job: %v", err)
+	}
+	return job, nil
+}
+
+// FailInterruptedImports marks imports that a restart cut short as failed.
+func (as *AdminService) FailInterruptedImports() {
+	count, err := as.importJobRepo.FailRunningJobs()
+	if err != nil {
+		log.Printf("can not fail interrupted imports: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Printf("marked %d interrupted imports as failed", count)
+	}
+}
+
+// ExportProducts writes the whole catalogue to w in batches, in the format an
+// import reads.
+func (as *AdminService) ExportProducts(w io.Writer, format string) error {
+	writer, err := newCatalogueWriter(format, w)
+	if err != nil {
+		return err
+	}
+	after := ""
+	for {
+		products, err := as.productRepo.ExportProducts(after, exportBatchSize)
+		if err != nil {
+			return fmt.Errorf("can not export products: %v", err)
+		}
+		for _, product := range products {
+			err = writer.Write(exportRow(product))
+			if err != nil {
+				return err
+			}
+		}
+		err = writer.Flush()
+		if err != nil {
+			return err
+		}
+		if len(products) < exportBatchSize {
+			return nil
+		}
+		after = products[len(products)-1].ID
+	}
+}
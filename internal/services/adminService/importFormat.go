@@ -0,0 +1,306 @@
+package adminservice
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+// csvColumns are the columns of a CSV export, in order. Imports may leave
+// any of them out except sku. Tags are comma separated within their cell and
+// specs are a JSON array.
+var csvColumns = []string{"sku", "name", "description", "brand", "price", "stock", "tags",
+	"weight_grams", "length_mm", "width_mm", "height_mm", "specs", "status"}
+
+const maxJSONLineBytes = 1 << 20
+
+// importRow is one product of an import file and what is wrong with it.
+type importRow struct {
+	line    int
+	product dto.ProductImportDTO
+	errors  []string
+}
+
+func parseImport(format string, body io.Reader) ([]importRow, error) {
+	var rows []importRow
+	var err error
+	switch format {
+	case models.FormatCSV:
+		rows, err = parseCSV(body)
+	case models.FormatJSONL:
+		rows, err = parseJSONL(body)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmptyImport
+	}
+	return rows, nil
+}
+
+// parseCSV reads a CSV file with a header row. A row with a bad cell is
+// reported on its own, but a file that is not valid CSV is rejected as a
+// whole, since the rows after the fault can not be told apart.
+func parseCSV(body io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(body)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, ErrEmptyImport
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(csvColumns, name) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrMalformedImport, name)
+		}
+		if slices.Contains(columns, name) {
+			return nil, fmt.Errorf("%w: column %q is listed twice", ErrMalformedImport, name)
+		}
+		columns[i] = name
+	}
+	if !slices.Contains(columns, "sku") {
+		return nil, fmt.Errorf("%w: the header must include a sku column", ErrMalformedImport)
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, csvError(err)
+		}
+		if len(rows) == config.MaxImportRows {
+			return nil, ErrTooManyRows
+		}
+		line, _ := reader.FieldPos(0)
+		row := importRow{line: line}
+		if err != nil {
+			row.errors = []string{fmt.Sprintf("expected %d cells, found %d", len(columns), len(record))}
+			rows = append(rows, row)
+			continue
+		}
+		cells := make(map[string]string, len(columns))
+		for i, cell := range record {
+			cells[columns[i]] = strings.TrimSpace(cell)
+		}
+		row.product, row.errors = decodeCSVRow(cells)
+		rows = append(rows, row)
+	}
+}
+
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return fmt.Errorf("%w: %v", ErrMalformedImport, err)
+	}
+	return fmt.Errorf("can not read import: %w", err)
+}
+
+// decodeCSVRow reads the cells of a row; empty cells leave the field unset.
+func decodeCSVRow(cells map[string]string) (dto.ProductImportDTO, []string) {
+	var errs []string
+	text := func(column string) *string {
+		if value := cells[column]; value != "" {
+			return &value
+		}
+		return nil
+	}
+	integer := func(column string) *int {
+		value := cells[column]
+		if value == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, column+" must be a whole number")
+			return nil
+		}
+		return &n
+	}
+
+	product := dto.ProductImportDTO{
+		SKU:         cells["sku"],
+		Name:        text("name"),
+		Description: text("description"),
+		Brand:       text("brand"),
+		Stock:       integer("stock"),
+		WeightGrams: integer("weight_grams"),
+		Status:      text("status"),
+	}
+	if value := cells["price"]; value != "" {
+		price, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			errs = append(errs, "price must be a number")
+		} else {
+			p := float32(price)
+			product.Price = &p
+		}
+	}
+	if value := cells["tags"]; value != "" {
+		product.Tags = strings.Split(value, ",")
+	}
+	if value := cells["specs"]; value != "" {
+		err := json.Unmarshal([]byte(value), &product.Specs)
+		if err != nil {
+			errs = append(errs, "specs must be a JSON array of specs")
+		}
+	}
+
+	given := 0
+	for _, column := range []string{"length_mm", "width_mm", "height_mm"} {
+		if cells[column] != "" {
+			given++
+		}
+	}
+	length, width, height := integer("length_mm"), integer("width_mm"), integer("height_mm")
+	switch {
+	case length != nil && width != nil && height != nil:
+		product.Dimensions = &models.Dimensions{LengthMM: *length, WidthMM: *width, HeightMM: *height}
+	case given > 0 && given < 3:
+		errs = append(errs, "length_mm, width_mm and height_mm must be given together")
+	}
+	return product, errs
+}
+
+// parseJSONL reads one JSON object per line. Blank lines are skipped.
+func parseJSONL(body io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxJSONLineBytes)
+	var rows []importRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == config.MaxImportRows {
+			return nil, ErrTooManyRows
+		}
+		row := importRow{line: line}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&row.product)
+		if err == nil && decoder.More() {
+			err = errors.New("a line must hold a single object")
+		}
+		if err != nil {
+			row.errors = []string{"invalid JSON: " + err.Error()}
+		}
+		row.product.SKU = strings.TrimSpace(row.product.SKU)
+		rows = append(rows, row)
+	}
+	err := scanner.Err()
+	if errors.Is(err, bufio.ErrTooLong) {
+		return nil, fmt.Errorf("%w: line %d is longer than %d KiB", ErrMalformedImport, line+1, maxJSONLineBytes>>10)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can not read import: %w", err)
+	}
+	return rows, nil
+}
+
+// exportRow turns a product into the row an import of it would read back.
+// The price is the regular price, since that is what an import sets.
+func exportRow(product models.Product) dto.ProductImportDTO {
+	tags := product.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	specs := product.Specs
+	if specs == nil {
+		specs = []models.ProductSpec{}
+	}
+	status := string(product.Status)
+	price := product.RegularPrice()
+	return dto.ProductImportDTO{
+		SKU:         product.SKU,
+		Name:        &product.Name,
+		Description: &product.Description,
+		Brand:       &product.Brand,
+		Price:       &price,
+		Stock:       &product.Stock,
+		Tags:        tags,
+		WeightGrams: &product.WeightGrams,
+		Dimensions:  product.Dimensions,
+		Specs:       specs,
+		Status:      &status,
+	}
+}
+
+// catalogueWriter writes export rows in one of the file formats.
+type catalogueWriter interface {
+	Write(row dto.ProductImportDTO) error
+	Flush() error
+}
+
+func newCatalogueWriter(format string, w io.Writer) (catalogueWriter, error) {
+	switch format {
+	case models.FormatCSV:
+		writer := &csvCatalogueWriter{writer: csv.NewWriter(w)}
+		return writer, writer.writer.Write(csvColumns)
+	case models.FormatJSONL:
+		buffered := bufio.NewWriter(w)
+		return &jsonlCatalogueWriter{buffered: buffered, encoder: json.NewEncoder(buffered)}, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+type csvCatalogueWriter struct {
+	writer *csv.Writer
+}
+
+func (cw *csvCatalogueWriter) Write(row dto.ProductImportDTO) error {
+	specs, err := json.Marshal(row.Specs)
+	if err != nil {
+		return err
+	}
+	var length, width, height string
+	if row.Dimensions != nil {
+		length = strconv.Itoa(row.Dimensions.LengthMM)
+		width = strconv.Itoa(row.Dimensions.WidthMM)
+		height = strconv.Itoa(row.Dimensions.HeightMM)
+	}
+	return cw.writer.Write([]string{
+		row.SKU, *row.Name, *row.Description, *row.Brand,
+		strconv.FormatFloat(float64(*row.Price), 'f', -1, 32), strconv.Itoa(*row.Stock),
+		strings.Join(row.Tags, ","), strconv.Itoa(*row.WeightGrams), length, width, height, string(specs), *row.Status,
+	})
+}
+
+func (cw *csvCatalogueWriter) Flush() error {
+	cw.writer.Flush()
+	return cw.writer.Error()
+}
+
+type jsonlCatalogueWriter struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+}
+
+func (jw *jsonlCatalogueWriter) Write(row dto.ProductImportDTO) error {
+	return jw.encoder.Encode(row)
+}
+
+func (jw *jsonlCatalogueWriter) Flush() error {
+	return jw.buffered.Flush()
+}
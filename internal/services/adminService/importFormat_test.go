@@ -0,0 +1,90 @@
+package adminservice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+func TestParseCSV(t *testing.T) {
+	body := "\ufeffSKU, Name ,description,length_mm,width_mm,height_mm\n" +
+		"A-1,Lamp,\"Two\nlines\",100,100,300\n" +
+		"A-2,Desk\n" +
+		"A-3,Chair,,400,x,\n"
+	rows, err := parseCSV(strings.NewReader(body))
+	if err != nil || len(rows) != 3 {
+		t.Fatalf("unexpected rows: %+v, err: %v", rows, err)
+	}
+
+	lamp := rows[0]
+	if lamp.line != 2 || lamp.errors != nil || lamp.product.SKU != "A-1" || *lamp.product.Description != "Two\nlines" ||
+		*lamp.product.Dimensions != (models.Dimensions{LengthMM: 100, WidthMM: 100, HeightMM: 300}) {
+		t.Errorf("unexpected first row: %+v", lamp)
+	}
+	// the quoted description spans two lines, so the next row starts on line 4
+	if rows[1].line != 4 || len(rows[1].errors) != 1 || rows[1].errors[0] != "expected 6 cells, found 2" {
+		t.Errorf("unexpected second row: %+v", rows[1])
+	}
+	expected := []string{"width_mm must be a whole number", "length_mm, width_mm and height_mm must be given together"}
+	if rows[2].line != 5 || !reflect.DeepEqual(rows[2].errors, expected) || rows[2].product.Description != nil {
+		t.Errorf("unexpected third row: %+v", rows[2])
+	}
+}
+
+func TestParseJSONL(t *testing.T) {
+	body := `{"sku":" A-1 ","stock":0,"tags":[],"specs":[{"key":"ram","type":"number","value":8}]}
+
+{"sku":"A-2"} {"sku":"A-3"}
+not json
+`
+	rows, err := parseJSONL(strings.NewReader(body))
+	if err != nil || len(rows) != 3 {
+		t.Fatalf("unexpected rows: %+v, err: %v", rows, err)
+	}
+	first := rows[0].product
+	if first.SKU != "A-1" || first.Stock == nil || *first.Stock != 0 || first.Tags == nil || first.Specs[0].Value != 8.0 {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	if rows[1].line != 3 || rows[1].errors[0] != "invalid JSON: a line must hold a single object" {
+		t.Errorf("unexpected second row: %+v", rows[1])
+	}
+	if rows[2].line != 4 || !strings.HasPrefix(rows[2].errors[0], "invalid JSON: ") {
+		t.Errorf("unexpected third row: %+v", rows[2])
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	product := models.Product{ID: "p1", SKU: "LAP-001", Name: "Laptop", Description: "Light, \"thin\"", Brand: "Lenovo",
+		Price: 74999.99, Stock: 3, Tags: []string{"computer", "notebook"}, WeightGrams: 1400,
+		Dimensions: &models.Dimensions{LengthMM: 320, WidthMM: 220, HeightMM: 18},
+		Specs:      []models.ProductSpec{{Key: "touchscreen", Type: models.SpecBool, Value: false}}, Status: models.ProductDraft}
+
+	for _, format := range []string{models.FormatCSV, models.FormatJSONL} {
+		var out strings.Builder
+		writer, err := newCatalogueWriter(format, &out)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if err := writer.Write(exportRow(product)); err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", format, err)
+		}
+
+		rows, err := parseImport(format, strings.NewReader(out.String()))
+		if err != nil || len(rows) != 1 || rows[0].errors != nil {
+			t.Fatalf("%s: unexpected rows: %+v, err: %v", format, rows, err)
+		}
+		imported := models.Product{ID: "p1", CreatedAt: product.CreatedAt}
+		imported.SKU = rows[0].product.SKU
+		applyImportRow(&imported, rows[0].product)
+		// stock is imported through the ledger rather than copied
+		imported.Stock = *rows[0].product.Stock
+		if !reflect.DeepEqual(imported, product) {
+			t.Errorf("%s: round trip changed the product:\n%+v\n%+v", format, product, imported)
+		}
+	}
+}
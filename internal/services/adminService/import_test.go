@@ -0,0 +1,285 @@
+package adminservice_test
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	adminservice "github.com/meshyampratap01/OnlineShoppingCart/internal/services/adminService"
+	"go.uber.org/mock/gomock"
+)
+
+const importCSV = `sku,name,price,stock,tags
+LAP-9,Gaming Laptop,120000,4,"computer, Gaming"
+PHN-001,,,0,
+BAD-1,,-5,,
+`
+
+func TestImportProductsDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockJobRepo := mocks.NewMockImportJobManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, nil, nil, mockJobRepo, nil, nil, nil)
+
+	mockJobRepo.EXPECT().SaveJob(gomock.Any()).Return(nil)
+	mockProductRepo.EXPECT().GetProductBySKU("LAP-9").Return(models.Product{}, sql.ErrNoRows)
+	mockProductRepo.EXPECT().GetProductBySKU("PHN-001").Return(models.Product{ID: "p2", SKU: "PHN-001"}, nil)
+	mockProductRepo.EXPECT().GetProductBySKU("BAD-1").Return(models.Product{}, sql.ErrNoRows)
+	mockJobRepo.EXPECT().UpdateJob(gomock.Any()).Return(nil)
+
+	job, err := service.ImportProducts("admin1", models.FormatCSV, strings.NewReader(importCSV), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != models.ImportSucceeded || !job.DryRun || job.TotalRows != 3 || job.ProcessedRows != 3 || job.FinishedAt == nil {
+		t.Errorf("unexpected job: %+v", job)
+	}
+	if job.Created != 1 || job.Updated != 1 || job.Failed != 1 || len(job.Errors) != 1 {
+		t.Fatalf("unexpected counts: %+v", job)
+	}
+	rowErr := job.Errors[0]
+	expected := []string{"price must be greater than zero", "name is required for a new product"}
+	if rowErr.Line != 4 || rowErr.SKU != "BAD-1" || strings.Join(rowErr.Errors, "|") != strings.Join(expected, "|") {
+		t.Errorf("unexpected row error: %+v", rowErr)
+	}
+}
+
+func TestImportProductsWithInvalidRowsWritesNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockJobRepo := mocks.NewMockImportJobManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, nil, nil, mockJobRepo, nil, nil, nil)
+
+	body := `{"sku":"A-1","name":"Lamp","price":20}
+{"sku":"A-1","name":"Lamp","price":20}
+{"sku":"A-2","colour":"red"}
+`
+	mockJobRepo.EXPECT().SaveJob(gomock.Any()).Return(nil)
+	mockProductRepo.EXPECT().GetProductBySKU("A-1").Return(models.Product{}, sql.ErrNoRows).Times(2)
+	mockJobRepo.EXPECT().UpdateJob(gomock.Any()).Return(nil)
+
+	job, err := service.ImportProducts("admin1", models.FormatJSONL, strings.NewReader(body), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Status != models.ImportFailed || job.Failed != 2 || job.Created != 0 || job.ProcessedRows != 0 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+	if job.Errors[0].Line != 2 || job.Errors[0].Errors[0] != "sku is also on line 1" {
+		t.Errorf("unexpected duplicate error: %+v", job.Errors[0])
+	}
+	if job.Errors[1].Line != 3 || !strings.Contains(job.Errors[1].Errors[0], `unknown field "colour"`) {
+		t.Errorf("unexpected JSON error: %+v", job.Errors[1])
+	}
+}
+
+func TestImportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockJobRepo := mocks.NewMockImportJobManager(ctrl)
+	mockSuggestServ := mocks.NewMockSuggestServiceManager(ctrl)
+	mockPriceRepo := mocks.NewMockPriceManager(ctrl)
+	mockInventoryRepo := mocks.NewMockInventoryManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, mockSuggestServ, nil, mockJobRepo, mockPriceRepo, mockInventoryRepo, nil)
+
+	existing := models.Product{ID: "p2", SKU: "PHN-001", Name: "Smartphone", Brand: "Samsung", Price: 35000, Stock: 25, Tags: []string{"phone"}}
+	mockJobRepo.EXPECT().SaveJob(gomock.Any()).Return(nil)
+	mockProductRepo.EXPECT().GetProductBySKU("LAP-9").Return(models.Product{}, sql.ErrNoRows).Times(2)
+	mockProductRepo.EXPECT().GetProductBySKU("PHN-001").Return(existing, nil).Times(2)
+
+	var added, updated models.Product
+	// the new product is saved with its stock recorded against the job
+	mockProductRepo.EXPECT().AddProduct(gomock.Any(), gomock.Any()).DoAndReturn(func(product models.Product, opening *models.StockMovement) error {
+		added = product
+		if opening == nil || opening.ProductID != product.ID || opening.Delta != 4 || opening.Balance != 4 ||
+			opening.Reason != models.StockImport || opening.ActorID != "admin1" || opening.Reference == "" {
+			t.Errorf("unexpected opening entry: %+v", opening)
+		}
+		return nil
+	})
+	mockProductRepo.EXPECT().UpdateProduct(gomock.Any()).DoAndReturn(func(product models.Product) error {
+		updated = product
+		return nil
+	})
+	// only the new product's price is recorded, the other row keeps its price
+	mockPriceRepo.EXPECT().AddPriceChange(gomock.Any()).DoAndReturn(func(change models.PriceChange) error {
+		if change.ProductID != added.ID || change.Price != 120000 || change.Reason != models.PriceImported {
+			t.Errorf("unexpected price change: %+v", change)
+		}
+		return nil
+	})
+	// the existing product's stock is set through the ledger, recorded against the job
+	stocks := map[string]int{}
+	mockInventoryRepo.EXPECT().SetStock(gomock.Any(), gomock.Any()).DoAndReturn(func(movement models.StockMovement, count int) (models.StockMovement, error) {
+		if movement.Reason != models.StockImport || movement.ActorID != "admin1" || movement.Reference == "" {
+			t.Errorf("unexpected movement: %+v", movement)
+		}
+		stocks[movement.ProductID] = count
+		return movement, nil
+	})
+	mockSuggestServ.EXPECT().Refresh().Return(nil)
+	mockJobRepo.EXPECT().UpdateJob(gomock.Any()).Return(nil)
+
+	body := strings.Replace(importCSV, "BAD-1,,-5,,\n", "", 1)
+	job, err := service.ImportProducts("admin1", models.FormatCSV, strings.NewReader(body), false)
+	if err != nil || job.Status != models.ImportSucceeded || job.Created != 1 || job.Updated != 1 || job.ProcessedRows != 2 {
+		t.Fatalf("unexpected job: %+v, err: %v", job, err)
+	}
+	if added.ID == "" || added.SKU != "LAP-9" || added.Name != "Gaming Laptop" || added.Price != 120000 || added.Stock != 4 ||
+		strings.Join(added.Tags, ",") != "computer,gaming" || added.CreatedAt.IsZero() {
+		t.Errorf("unexpected new product: %+v", added)
+	}
+	// only the stock was given, and zero stock is applied
+	if updated.ID != "p2" || updated.Name != "Smartphone" || updated.Price != 35000 || stocks["p2"] != 0 || updated.Brand != "Samsung" {
+		t.Errorf("unexpected updated product: %+v", updated)
+	}
+}
+
+func TestImportProductsInBackground(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	syncRows := config.ImportSyncRows
+	config.ImportSyncRows = 1
+	defer func() { config.ImportSyncRows = syncRows }()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockJobRepo := mocks.NewMockImportJobManager(ctrl)
+	mockSuggestServ := mocks.NewMockSuggestServiceManager(ctrl)
+	mockPriceRepo := mocks.NewMockPriceManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, mockSuggestServ, nil, mockJobRepo, mockPriceRepo, nil, nil)
+
+	release := make(chan struct{})
+	done := make(chan models.ImportJob, 1)
+	mockJobRepo.EXPECT().SaveJob(gomock.Any()).Return(nil)
+	mockProductRepo.EXPECT().GetProductBySKU(gomock.Any()).DoAndReturn(func(sku string) (models.Product, error) {
+		<-release
+		return models.Product{}, sql.ErrNoRows
+	}).Times(4)
+	mockProductRepo.EXPECT().AddProduct(gomock.Any(), nil).Return(nil).Times(2)
+	mockPriceRepo.EXPECT().AddPriceChange(gomock.Any()).Return(nil).Times(2)
+	mockSuggestServ.EXPECT().Refresh().Return(nil)
+	mockJobRepo.EXPECT().UpdateJob(gomock.Any()).DoAndReturn(func(job models.ImportJob) error {
+		done <- job
+		return nil
+	})
+
+	body := "sku,name,price\nA-1,Lamp,20\nA-2,Desk,150\n"
+	job, err := service.ImportProducts("admin1", models.FormatCSV, strings.NewReader(body), false)
+	if err != nil || job.Status != models.ImportRunning || job.TotalRows != 2 {
+		t.Fatalf("unexpected job: %+v, err: %v", job, err)
+	}
+
+	_, err = service.ImportProducts("admin1", models.FormatCSV, strings.NewReader(body), true)
+	if !errors.Is(err, adminservice.ErrImportRunning) {
+		t.Errorf("expected ErrImportRunning, got %v", err)
+	}
+
+	close(release)
+	finished := <-done
+	if finished.ID != job.ID || finished.Status != models.ImportSucceeded || finished.Created != 2 || finished.ProcessedRows != 2 {
+		t.Errorf("unexpected finished job: %+v", finished)
+	}
+}
+
+func TestImportProductsRejectsFile(t *testing.T) {
+	service := adminservice.NewAdminService(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		format string
+		body   string
+		err    error
+	}{
+		{"xml", "<products/>", adminservice.ErrUnsupportedFormat},
+		{models.FormatCSV, "", adminservice.ErrEmptyImport},
+		{models.FormatCSV, "sku,name\n", adminservice.ErrEmptyImport},
+		{models.FormatCSV, "sku,colour\nA-1,red\n", adminservice.ErrMalformedImport},
+		{models.FormatCSV, "name,price\nLamp,20\n", adminservice.ErrMalformedImport},
+		{models.FormatCSV, "sku,name\nA-1,\"Lamp\n", adminservice.ErrMalformedImport},
+		{models.FormatJSONL, "\n\n", adminservice.ErrEmptyImport},
+	}
+	for _, test := range tests {
+		_, err := service.ImportProducts("admin1", test.format, strings.NewReader(test.body), false)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s %q: expected %v, got %v", test.format, test.body, test.err, err)
+		}
+	}
+}
+
+func TestGetImportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockImportJobManager(ctrl)
+	service := adminservice.NewAdminService(nil, nil, nil, nil, nil, nil, nil, mockJobRepo, nil, nil, nil)
+
+	mockJobRepo.EXPECT().GetJob("j1").Return(models.ImportJob{ID: "j1", Status: models.ImportRunning}, nil)
+	job, err := service.GetImportJob("j1")
+	if err != nil || job.ID != "j1" {
+		t.Errorf("unexpected job: %+v, err: %v", job, err)
+	}
+
+	mockJobRepo.EXPECT().GetJob("missing").Return(models.ImportJob{}, sql.ErrNoRows)
+	if _, err := service.GetImportJob("missing"); !errors.Is(err, adminservice.ErrImportJobNotFound) {
+		t.Errorf("expected ErrImportJobNotFound, got %v", err)
+	}
+}
+
+func TestFailInterruptedImports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobRepo := mocks.NewMockImportJobManager(ctrl)
+	service := adminservice.NewAdminService(nil, nil, nil, nil, nil, nil, nil, mockJobRepo, nil, nil, nil)
+
+	mockJobRepo.EXPECT().FailRunningJobs().Return(1, nil)
+	service.FailInterruptedImports()
+}
+
+func TestExportProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	service := adminservice.NewAdminService(mockProductRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	products := []models.Product{
+		{ID: "p1", SKU: "LAP-001", Name: "Laptop", Description: "14 inch, light", Price: 75000.5, Stock: 10, Tags: []string{"computer", "notebook"},
+			Brand: "Lenovo", WeightGrams: 1400, Dimensions: &models.Dimensions{LengthMM: 320, WidthMM: 220, HeightMM: 18},
+			Specs: []models.ProductSpec{{Key: "ram", Type: models.SpecNumber, Value: 16.0, Unit: "GB"}}, Status: models.ProductPublished},
+		{ID: "p2", SKU: "PHN-001", Name: "Smartphone", Price: 35000, Tags: []string{}, Specs: []models.ProductSpec{}, Status: models.ProductDraft},
+	}
+	mockProductRepo.EXPECT().ExportProducts("", 500).Return(products, nil).Times(2)
+
+	var csvOut strings.Builder
+	err := service.ExportProducts(&csvOut, models.FormatCSV)
+	expected := `sku,name,description,brand,price,stock,tags,weight_grams,length_mm,width_mm,height_mm,specs,status
+LAP-001,Laptop,"14 inch, light",Lenovo,75000.5,10,"computer,notebook",1400,320,220,18,"[{""key"":""ram"",""type"":""number"",""value"":16,""unit"":""GB""}]",published
+PHN-001,Smartphone,,,35000,0,,0,,,,[],draft
+`
+	if err != nil || csvOut.String() != expected {
+		t.Errorf("unexpected CSV export, err: %v\n%s", err, csvOut.String())
+	}
+
+	var jsonlOut strings.Builder
+	err = service.ExportProducts(&jsonlOut, models.FormatJSONL)
+	lines := strings.Split(strings.TrimSpace(jsonlOut.String()), "\n")
+	if err != nil || len(lines) != 2 ||
+		lines[1] != `{"sku":"PHN-001","name":"Smartphone","description":"","brand":"","price":35000,"stock":0,"weight_grams":0,"status":"draft"}` {
+		t.Errorf("unexpected JSON lines export, err: %v\n%s", err, jsonlOut.String())
+	}
+
+	if err := service.ExportProducts(&jsonlOut, "xml"); !errors.Is(err, adminservice.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+}
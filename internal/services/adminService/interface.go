@@ -1,12 +1,31 @@
 package adminservice
 
-//go:generate mockgen -source=interface.go -destination=../../mocks/mock_adminServcie.go -package mocks
+import (
+	"io"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
 
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_adminServcie.go -package mocks
 
 type AdminServiceManager interface {
-	AddProduct(name string, price float32, stock int) error
-	UpdateProduct(id, name string, price float32, stock int) error
-	RemoveProduct(code string) error
+	AddProduct(adminID string, req dto.ProductDTO) error
+	UpdateProduct(id string, req dto.ProductDTO) error
+	ArchiveProduct(id string) error
+	RestoreProduct(id string) error
+	PurgeProduct(id string) error
+	ImportProducts(adminID, format string, body io.Reader, dryRun bool) (models.ImportJob, error)
+	GetImportJob(id string) (models.ImportJob, error)
+	ExportProducts(w io.Writer, format string) error
+	FailInterruptedImports()
 	AddCoupon(code string, discount float32) error
 	RemoveCoupon(code string) error
+	ChangeUserRole(adminID, userID string, role models.UserRole) error
+	ListUsers(filter models.UserFilter) (dto.UserListDTO, error)
+	GetUser(userID string) (dto.AdminUserDTO, error)
+	SetUserStatus(adminID, userID string, status models.UserStatus) error
+	GetUserCart(userID string) ([]dto.CartItemsDTO, error)
+	GetUserOrders(userID string) ([]models.Order, error)
+	ListOrders(filter models.OrderFilter) (dto.OrderListDTO, error)
 }
\ No newline at end of file
@@ -0,0 +1,117 @@
+package apiKeyService
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/apiKeyRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authzService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+// KeyPrefix starts every API key so leaked keys are easy to recognise.
+const KeyPrefix = "osk_"
+
+// displayPrefixLen is how much of the key is kept in clear for listings.
+const displayPrefixLen = 12
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+type APIKeyService struct {
+	apiKeyRepo apiKeyRepository.APIKeyManager
+	authzServ  authzService.AuthzServiceManager
+	now        func() time.Time
+}
+
+func NewAPIKeyService(apiKeyRepo apiKeyRepository.APIKeyManager, authzServ authzService.AuthzServiceManager) APIKeyServiceManager {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		authzServ:  authzServ,
+		now:        time.Now,
+	}
+}
+
+// CreateAPIKey issues a key acting for the owner. Each scope must be a
+// permission the owner holds, so a key can never do more than its creator.
+func (as *APIKeyService) CreateAPIKey(ownerID string, ownerRole models.UserRole, name string, scopes []string) (dto.APIKeyCreatedDTO, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return dto.APIKeyCreatedDTO{}, fmt.Errorf("name must be 1-100 characters")
+	}
+	if len(scopes) == 0 {
+		return dto.APIKeyCreatedDTO{}, fmt.Errorf("at least one scope is required")
+	}
+	var perms []models.Permission
+	for _, scope := range scopes {
+		perm, err := models.ParsePermission(strings.TrimSpace(scope))
+		if err != nil {
+			return dto.APIKeyCreatedDTO{}, err
+		}
+		if perm == models.PermAPIKeysManage {
+			// a key that can mint keys could mint one broader than itself
+			return dto.APIKeyCreatedDTO{}, fmt.Errorf("api keys can not be given %s", perm)
+		}
+		allowed, err := as.authzServ.HasPermission(ownerID, ownerRole, perm)
+		if err != nil {
+			return dto.APIKeyCreatedDTO{}, err
+		}
+		if !allowed {
+			return dto.APIKeyCreatedDTO{}, fmt.Errorf("you do not have permission %s", perm)
+		}
+		if !slices.Contains(perms, perm) {
+			perms = append(perms, perm)
+		}
+	}
+
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		return dto.APIKeyCreatedDTO{}, fmt.Errorf("can not create api key: %v", err)
+	}
+	secret := KeyPrefix + token
+	key := models.APIKey{
+		ID:        utils.NewUUID(),
+		UserID:    ownerID,
+		Name:      name,
+		Prefix:    secret[:displayPrefixLen],
+		KeyHash:   utils.HashToken(secret),
+		Scopes:    perms,
+		CreatedAt: as.now(),
+	}
+	err = as.apiKeyRepo.SaveAPIKey(key)
+	if err != nil {
+		return dto.APIKeyCreatedDTO{}, fmt.Errorf("can not create api key: %v", err)
+	}
+
+	created := dto.APIKeyCreatedDTO{
+		ID:        key.ID,
+		Name:      key.Name,
+		Key:       secret,
+		Prefix:    key.Prefix,
+		CreatedAt: key.CreatedAt,
+	}
+	for _, perm := range perms {
+		created.Scopes = append(created.Scopes, string(perm))
+	}
+	return created, nil
+}
+
+func (as *APIKeyService) ListAPIKeys() ([]models.APIKey, error) {
+	keys, err := as.apiKeyRepo.ListAPIKeys()
+	if err != nil {
+		return nil, fmt.Errorf("can not list api keys: %v", err)
+	}
+	return keys, nil
+}
+
+func (as *APIKeyService) RevokeAPIKey(id string) error {
+	err := as.apiKeyRepo.RevokeAPIKey(id, as.now())
+	if err != nil {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
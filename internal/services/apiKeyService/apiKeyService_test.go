@@ -0,0 +1,98 @@
+package apiKeyService
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func setupService(t *testing.T) (*APIKeyService, *mocks.MockAPIKeyManager, *mocks.MockAuthzServiceManager) {
+	ctrl := gomock.NewController(t)
+	mockRepo := mocks.NewMockAPIKeyManager(ctrl)
+	mockAuthz := mocks.NewMockAuthzServiceManager(ctrl)
+	fixedNow := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &APIKeyService{
+		apiKeyRepo: mockRepo,
+		authzServ:  mockAuthz,
+		now:        func() time.Time { return fixedNow },
+	}
+	return service, mockRepo, mockAuthz
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	service, mockRepo, mockAuthz := setupService(t)
+
+	t.Run("Missing name", func(t *testing.T) {
+		_, err := service.CreateAPIKey("admin1", models.Admin, " ", []string{"orders:read"})
+		if err == nil {
+			t.Error("expected error for missing name")
+		}
+	})
+
+	t.Run("No scopes", func(t *testing.T) {
+		_, err := service.CreateAPIKey("admin1", models.Admin, "ERP", nil)
+		if err == nil {
+			t.Error("expected error for missing scopes")
+		}
+	})
+
+	t.Run("Key management scope", func(t *testing.T) {
+		_, err := service.CreateAPIKey("admin1", models.Admin, "ERP", []string{"api_keys:manage"})
+		if err == nil {
+			t.Error("expected error for api_keys:manage scope")
+		}
+	})
+
+	t.Run("Scope the owner lacks", func(t *testing.T) {
+		mockAuthz.EXPECT().HasPermission("u1", models.Customer, models.PermProductsWrite).Return(false, nil)
+
+		_, err := service.CreateAPIKey("u1", models.Customer, "ERP", []string{"products:write"})
+		if err == nil {
+			t.Error("expected error for scope beyond the owner's permissions")
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockAuthz.EXPECT().HasPermission("admin1", models.Admin, gomock.Any()).Return(true, nil).Times(3)
+		var saved models.APIKey
+		mockRepo.EXPECT().SaveAPIKey(gomock.Any()).DoAndReturn(func(key models.APIKey) error {
+			saved = key
+			return nil
+		})
+
+		created, err := service.CreateAPIKey("admin1", models.Admin, "ERP", []string{"products:write", "orders:read", "orders:read"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(created.Key, KeyPrefix) || !strings.HasPrefix(created.Key, saved.Prefix) {
+			t.Errorf("unexpected key %q with prefix %q", created.Key, saved.Prefix)
+		}
+		if saved.KeyHash != utils.HashToken(created.Key) || strings.Contains(saved.KeyHash, created.Key) {
+			t.Error("expected only the hash of the key to be stored")
+		}
+		if len(saved.Scopes) != 2 || len(created.Scopes) != 2 {
+			t.Errorf("expected duplicate scopes to be dropped, got %v", saved.Scopes)
+		}
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	service, mockRepo, _ := setupService(t)
+	now := service.now()
+
+	mockRepo.EXPECT().RevokeAPIKey("missing", now).Return(errors.New("no rows"))
+	if err := service.RevokeAPIKey("missing"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected ErrAPIKeyNotFound, got %v", err)
+	}
+
+	mockRepo.EXPECT().RevokeAPIKey("k1", now).Return(nil)
+	if err := service.RevokeAPIKey("k1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package apiKeyService
+
+import (
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_apiKeyService.go -package mocks
+
+type APIKeyServiceManager interface {
+	CreateAPIKey(ownerID string, ownerRole models.UserRole, name string, scopes []string) (dto.APIKeyCreatedDTO, error)
+	ListAPIKeys() ([]models.APIKey, error)
+	RevokeAPIKey(id string) error
+}
@@ -0,0 +1,199 @@
+package authService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/jwtKeys"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/apiKeyRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/sessionRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/tokenRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var (
+	ErrUserSuspended   = errors.New("account is suspended")
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+type AuthService struct {
+	tokenRepo   tokenRepository.TokenManager
+	userRepo    userRepository.UserManager
+	apiKeyRepo  apiKeyRepository.APIKeyManager
+	sessionRepo sessionRepository.SessionManager
+}
+
+func NewAuthService(tokenRepo tokenRepository.TokenManager, userRepo userRepository.UserManager, apiKeyRepo apiKeyRepository.APIKeyManager, sessionRepo sessionRepository.SessionManager) AuthServiceManager {
+	return &AuthService{
+		tokenRepo:   tokenRepo,
+		userRepo:    userRepo,
+		apiKeyRepo:  apiKeyRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+// ValidateToken rejects tokens that were logged out individually, issued
+// before the user's last logout-all, whose session was terminated, or that
+// belong to a suspended user. The session's last-seen time is written at most
+// once per config.SessionLastSeenInterval.
+func (as *AuthService) ValidateToken(claims models.UserJWT) error {
+	if claims.ID == "" {
+		return fmt.Errorf("token has no id")
+	}
+	revoked, err := as.tokenRepo.IsTokenRevoked(claims.ID)
+	if err != nil {
+		return fmt.Errorf("can not verify token: %v", err)
+	}
+	if revoked {
+		return fmt.Errorf("token has been revoked")
+	}
+	user, err := as.userRepo.GetUserByID(claims.UserID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	if user.TokenVersion != claims.TokenVersion {
+		return fmt.Errorf("token has been revoked")
+	}
+	if user.Status == models.UserSuspended {
+		return ErrUserSuspended
+	}
+
+	session, err := as.sessionRepo.GetSession(claims.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// issued before sessions were recorded, it simply runs out
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can not verify token: %v", err)
+	}
+	if session.IsRevoked() {
+		return fmt.Errorf("session has been terminated")
+	}
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) >= config.SessionLastSeenInterval {
+		err = as.sessionRepo.TouchSession(session.ID, now)
+		if err != nil {
+			log.Printf("can not record activity of session %s: %v", session.ID, err)
+		}
+	}
+	return nil
+}
+
+// AuthenticateAPIKey returns claims for the key's owner, limited to the key's
+// scopes. The last-used time is written at most once per
+// config.APIKeyLastUsedInterval.
+func (as *AuthService) AuthenticateAPIKey(key string) (models.UserJWT, error) {
+	apiKey, err := as.apiKeyRepo.GetAPIKeyByHash(utils.HashToken(key))
+	if err != nil {
+		return models.UserJWT{}, fmt.Errorf("invalid api key")
+	}
+	if apiKey.IsRevoked() {
+		return models.UserJWT{}, fmt.Errorf("api key has been revoked")
+	}
+	user, err := as.userRepo.GetUserByID(apiKey.UserID)
+	if err != nil {
+		return models.UserJWT{}, fmt.Errorf("user not found")
+	}
+	if user.Status == models.UserSuspended {
+		return models.UserJWT{}, ErrUserSuspended
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= config.APIKeyLastUsedInterval {
+		err = as.apiKeyRepo.UpdateAPIKeyLastUsed(apiKey.ID, now)
+		if err != nil {
+			log.Printf("can not record use of api key %s: %v", apiKey.ID, err)
+		}
+	}
+
+	return models.UserJWT{
+		UserID:       user.ID,
+		Email:        user.Email,
+		Role:         user.Role,
+		TokenVersion: user.TokenVersion,
+		APIKeyID:     apiKey.ID,
+		Scopes:       apiKey.Scopes,
+	}, nil
+}
+
+func (as *AuthService) Logout(claims models.UserJWT) error {
+	expiresAt := time.Now().Add(config.JWT_TTL)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	err := as.tokenRepo.RevokeToken(claims.ID, claims.UserID, expiresAt)
+	if err != nil {
+		return fmt.Errorf("can not revoke token: %v", err)
+	}
+	err = as.sessionRepo.RevokeSession(claims.UserID, claims.ID, time.Now())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("can not end session: %v", err)
+	}
+	err = as.tokenRepo.DeleteExpiredTokens(time.Now())
+	if err != nil {
+		return fmt.Errorf("can not clean up revoked tokens: %v", err)
+	}
+	return nil
+}
+
+func (as *AuthService) LogoutAll(userID string) error {
+	err := as.tokenRepo.IncrementTokenVersion(userID)
+	if err != nil {
+		return fmt.Errorf("can not revoke sessions: %v", err)
+	}
+	return nil
+}
+
+func (as *AuthService) RevokeUserSessions(userID string) error {
+	_, err := as.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	return as.LogoutAll(userID)
+}
+
+// ListSessions returns the user's live sessions, marking the one currentID
+// belongs to.
+func (as *AuthService) ListSessions(userID, currentID string) ([]dto.SessionDTO, error) {
+	sessions, err := as.sessionRepo.ListActiveSessions(userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("can not list sessions: %v", err)
+	}
+	list := make([]dto.SessionDTO, len(sessions))
+	for i, session := range sessions {
+		list[i] = dto.SessionDTO{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IP:         session.IP,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentID,
+		}
+	}
+	return list, nil
+}
+
+// TerminateSession ends one of the user's sessions; its token is rejected from
+// the next request on.
+func (as *AuthService) TerminateSession(userID, sessionID string) error {
+	err := as.sessionRepo.RevokeSession(userID, sessionID, time.Now())
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("can not end session: %v", err)
+	}
+	return nil
+}
+
+func (as *AuthService) GetJWKS() jwtKeys.JWKS {
+	return config.JWTKeys.JWKS()
+}
@@ -0,0 +1,277 @@
+package authService
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokenRepo := mocks.NewMockTokenManager(ctrl)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockSessionRepo := mocks.NewMockSessionManager(ctrl)
+	service := NewAuthService(mockTokenRepo, mockUserRepo, nil, mockSessionRepo)
+
+	claims := models.UserJWT{UserID: "user1", TokenVersion: 2, RegisteredClaims: jwt.RegisteredClaims{ID: "jti1"}}
+	activeUser := models.User{ID: "user1", TokenVersion: 2, Status: models.UserActive}
+
+	t.Run("Missing token id", func(t *testing.T) {
+		err := service.ValidateToken(models.UserJWT{UserID: "user1"})
+		if err == nil {
+			t.Error("expected error for token without id")
+		}
+	})
+
+	t.Run("Revoked token", func(t *testing.T) {
+		mockTokenRepo.EXPECT().IsTokenRevoked("jti1").Return(true, nil)
+
+		err := service.ValidateToken(claims)
+		if err == nil {
+			t.Error("expected error for revoked token")
+		}
+	})
+
+	t.Run("Stale token version", func(t *testing.T) {
+		mockTokenRepo.EXPECT().IsTokenRevoked("jti1").Return(false, nil)
+		mockUserRepo.EXPECT().GetUserByID("user1").Return(models.User{ID: "user1", TokenVersion: 3, Status: models.UserActive}, nil)
+
+		err := service.ValidateToken(claims)
+		if err == nil {
+			t.Error("expected error for token issued before logout-all")
+		}
+	})
+
+	t.Run("Suspended user", func(t *testing.T) {
+		mockTokenRepo.EXPECT().IsTokenRevoked("jti1").Return(false, nil)
+		mockUserRepo.EXPECT().GetUserByID("user1").Return(models.User{ID: "user1", TokenVersion: 2, Status: models.UserSuspended}, nil)
+
+		err := service.ValidateToken(claims)
+		if !errors.Is(err, ErrUserSuspended) {
+			t.Errorf("expected ErrUserSuspended, got %v", err)
+		}
+	})
+
+	t.Run("Terminated session", func(t *testing.T) {
+		revokedAt := time.Now()
+		mockTokenRepo.EXPECT().IsTokenRevoked("jti1").Return(false, nil)
+		mockUserRepo.EXPECT().GetUserByID("user1").Return(activeUser, nil)
+		mockSessionRepo.EXPECT().GetSession("jti1").Return(models.Session{ID: "jti1", RevokedAt: &revokedAt}, nil)
+
+		err := service.ValidateToken(claims)
+		if err == nil {
+			t.Error("expected error for terminated session")
+		}
+	})
+
+	t.Run("Token without session", func(t *testing.T) {
+		mockTokenRepo.EXPECT().IsTokenRevoked("jti1").Return(false, nil)
+		mockUserRepo.EXPECT().GetUserByID("user1").Return(activeUser, nil)
+		mockSessionRepo.EXPECT().GetSession("jti1").Return(models.Session{}, sql.ErrNoRows)
+
+		err := service.ValidateToken(claims)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Valid token records activity", func(t *testing.T) {
+		mockTokenRepo.EXPECT().IsTokenRevoked("jti1").Return(false, nil)
+		mockUserRepo.EXPECT().GetUserByID("user1").Return(activeUser, nil)
+		mockSessionRepo.EXPECT().GetSession("jti1").Return(models.Session{ID: "jti1", LastSeenAt: time.Now().Add(-time.Hour)}, nil)
+		mockSessionRepo.EXPECT().TouchSession("jti1", gomock.Any()).Return(nil)
+
+		err := service.ValidateToken(claims)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Recently seen session is not written", func(t *testing.T) {
+		mockTokenRepo.EXPECT().IsTokenRevoked("jti1").Return(false, nil)
+		mockUserRepo.EXPECT().GetUserByID("user1").Return(activeUser, nil)
+		mockSessionRepo.EXPECT().GetSession("jti1").Return(models.Session{ID: "jti1", LastSeenAt: time.Now()}, nil)
+
+		err := service.ValidateToken(claims)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLogout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokenRepo := mocks.NewMockTokenManager(ctrl)
+	mockSessionRepo := mocks.NewMockSessionManager(ctrl)
+	service := NewAuthService(mockTokenRepo, nil, nil, mockSessionRepo)
+
+	claims := models.UserJWT{UserID: "user1", RegisteredClaims: jwt.RegisteredClaims{ID: "jti1"}}
+
+	mockTokenRepo.EXPECT().RevokeToken("jti1", "user1", gomock.Any()).Return(nil)
+	mockSessionRepo.EXPECT().RevokeSession("user1", "jti1", gomock.Any()).Return(nil)
+	mockTokenRepo.EXPECT().DeleteExpiredTokens(gomock.Any()).Return(nil)
+	if err := service.Logout(claims); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mockTokenRepo.EXPECT().RevokeToken("jti1", "user1", gomock.Any()).Return(errors.New("db error"))
+	if err := service.Logout(claims); err == nil {
+		t.Error("expected error when revocation fails")
+	}
+}
+
+func TestLogoutAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokenRepo := mocks.NewMockTokenManager(ctrl)
+	service := NewAuthService(mockTokenRepo, nil, nil, nil)
+
+	mockTokenRepo.EXPECT().IncrementTokenVersion("user1").Return(nil)
+	if err := service.LogoutAll("user1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTokenRepo := mocks.NewMockTokenManager(ctrl)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	service := NewAuthService(mockTokenRepo, mockUserRepo, nil, nil)
+
+	mockUserRepo.EXPECT().GetUserByID("404").Return(models.User{}, errors.New("not found"))
+	if err := service.RevokeUserSessions("404"); err == nil {
+		t.Error("expected error for unknown user")
+	}
+
+	mockUserRepo.EXPECT().GetUserByID("user1").Return(models.User{ID: "user1"}, nil)
+	mockTokenRepo.EXPECT().IncrementTokenVersion("user1").Return(nil)
+	if err := service.RevokeUserSessions("user1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockAPIKeyRepo := mocks.NewMockAPIKeyManager(ctrl)
+	service := NewAuthService(nil, mockUserRepo, mockAPIKeyRepo, nil)
+	hash := utils.HashToken("osk_secret")
+
+	t.Run("Unknown key", func(t *testing.T) {
+		mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(hash).Return(models.APIKey{}, errors.New("not found"))
+
+		_, err := service.AuthenticateAPIKey("osk_secret")
+		if err == nil {
+			t.Error("expected error for unknown key")
+		}
+	})
+
+	t.Run("Revoked key", func(t *testing.T) {
+		revokedAt := time.Now()
+		mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(hash).Return(models.APIKey{ID: "k1", RevokedAt: &revokedAt}, nil)
+
+		_, err := service.AuthenticateAPIKey("osk_secret")
+		if err == nil {
+			t.Error("expected error for revoked key")
+		}
+	})
+
+	t.Run("Suspended owner", func(t *testing.T) {
+		mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(hash).Return(models.APIKey{ID: "k1", UserID: "admin1"}, nil)
+		mockUserRepo.EXPECT().GetUserByID("admin1").Return(models.User{ID: "admin1", Status: models.UserSuspended}, nil)
+
+		_, err := service.AuthenticateAPIKey("osk_secret")
+		if !errors.Is(err, ErrUserSuspended) {
+			t.Errorf("expected ErrUserSuspended, got %v", err)
+		}
+	})
+
+	t.Run("Valid key records first use", func(t *testing.T) {
+		scopes := []models.Permission{models.PermOrdersRead}
+		mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(hash).Return(models.APIKey{ID: "k1", UserID: "admin1", Scopes: scopes}, nil)
+		mockUserRepo.EXPECT().GetUserByID("admin1").Return(models.User{ID: "admin1", Role: models.Admin, Status: models.UserActive}, nil)
+		mockAPIKeyRepo.EXPECT().UpdateAPIKeyLastUsed("k1", gomock.Any()).Return(nil)
+
+		claims, err := service.AuthenticateAPIKey("osk_secret")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !claims.IsAPIKey() || claims.UserID != "admin1" || claims.Role != models.Admin || len(claims.Scopes) != 1 {
+			t.Errorf("unexpected claims: %+v", claims)
+		}
+	})
+
+	t.Run("Recent use is not rewritten", func(t *testing.T) {
+		lastUsed := time.Now()
+		mockAPIKeyRepo.EXPECT().GetAPIKeyByHash(hash).Return(models.APIKey{ID: "k1", UserID: "admin1", LastUsedAt: &lastUsed}, nil)
+		mockUserRepo.EXPECT().GetUserByID("admin1").Return(models.User{ID: "admin1", Role: models.Admin, Status: models.UserActive}, nil)
+
+		_, err := service.AuthenticateAPIKey("osk_secret")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestListSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionRepo := mocks.NewMockSessionManager(ctrl)
+	service := NewAuthService(nil, nil, nil, mockSessionRepo)
+
+	mockSessionRepo.EXPECT().ListActiveSessions("user1", gomock.Any()).Return([]models.Session{
+		{ID: "s1", UserID: "user1", UserAgent: "curl/8", IP: "10.0.0.1"},
+		{ID: "s2", UserID: "user1", UserAgent: "Firefox", IP: "10.0.0.2"},
+	}, nil)
+
+	sessions, err := service.ListSessions("user1", "s2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].Current || !sessions[1].Current || sessions[1].UserAgent != "Firefox" {
+		t.Errorf("unexpected sessions: %+v", sessions)
+	}
+}
+
+func TestTerminateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSessionRepo := mocks.NewMockSessionManager(ctrl)
+	service := NewAuthService(nil, nil, nil, mockSessionRepo)
+
+	t.Run("Unknown session", func(t *testing.T) {
+		mockSessionRepo.EXPECT().RevokeSession("user1", "s9", gomock.Any()).Return(sql.ErrNoRows)
+
+		err := service.TerminateSession("user1", "s9")
+		if !errors.Is(err, ErrSessionNotFound) {
+			t.Errorf("expected ErrSessionNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockSessionRepo.EXPECT().RevokeSession("user1", "s1", gomock.Any()).Return(nil)
+
+		err := service.TerminateSession("user1", "s1")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
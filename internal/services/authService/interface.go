@@ -0,0 +1,20 @@
+package authService
+
+import (
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/jwtKeys"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_authService.go -package mocks
+
+type AuthServiceManager interface {
+	ValidateToken(claims models.UserJWT) error
+	AuthenticateAPIKey(key string) (models.UserJWT, error)
+	Logout(claims models.UserJWT) error
+	LogoutAll(userID string) error
+	RevokeUserSessions(userID string) error
+	ListSessions(userID, currentID string) ([]dto.SessionDTO, error)
+	TerminateSession(userID, sessionID string) error
+	GetJWKS() jwtKeys.JWKS
+}
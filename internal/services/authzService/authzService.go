@@ -0,0 +1,273 @@
+package authzService
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/roleRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+)
+
+var (
+	ErrRoleNotFound = errors.New("role not found")
+	ErrRoleExists   = errors.New("role already exists")
+	ErrBuiltInRole  = errors.New("built-in roles can not be changed")
+	ErrOutranked    = errors.New("this account has permissions you do not have")
+	ErrNotGrantable = errors.New("you can not grant or change permissions you do not have")
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+type AuthzService struct {
+	roleRepo roleRepository.RoleManager
+	userRepo userRepository.UserManager
+}
+
+func NewAuthzService(roleRepo roleRepository.RoleManager, userRepo userRepository.UserManager) AuthzServiceManager {
+	return &AuthzService{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+	}
+}
+
+// HasPermission checks the account role first and only then the assigned
+// roles, so admins and customers never touch the role tables.
+func (as *AuthzService) HasPermission(userID string, role models.UserRole, perm models.Permission) (bool, error) {
+	if slices.Contains(role.Permissions(), perm) {
+		return true, nil
+	}
+	perms, err := as.roleRepo.GetUserPermissions(userID)
+	if err != nil {
+		return false, fmt.Errorf("can not load permissions: %v", err)
+	}
+	return slices.Contains(perms, perm), nil
+}
+
+// UserPermissions returns what the account role and the assigned roles grant
+// together.
+func (as *AuthzService) UserPermissions(userID string, role models.UserRole) ([]models.Permission, error) {
+	perms := slices.Clone(role.Permissions())
+	assigned, err := as.roleRepo.GetUserPermissions(userID)
+	if err != nil {
+		return nil, fmt.Errorf("can not load permissions: %v", err)
+	}
+	for _, perm := range assigned {
+		if !slices.Contains(perms, perm) {
+			perms = append(perms, perm)
+		}
+	}
+	return perms, nil
+}
+
+// IsStaff reports whether the user holds any permission beyond shopping.
+func (as *AuthzService) IsStaff(userID string, role models.UserRole) (bool, error) {
+	perms, err := as.UserPermissions(userID, role)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(perms, func(perm models.Permission) bool { return perm != models.PermCartUse }), nil
+}
+
+// CanManage reports whether the actor holds every staff permission target has,
+// so that nobody can suspend or take over an account that outranks them.
+func (as *AuthzService) CanManage(actorID string, target models.User) (bool, error) {
+	targetPerms, err := as.UserPermissions(target.ID, target.Role)
+	if err != nil {
+		return false, err
+	}
+	return as.CanGrant(actorID, targetPerms)
+}
+
+// CanGrant reports whether the actor holds every staff permission in perms,
+// so that nobody can hand out more than they have themselves.
+func (as *AuthzService) CanGrant(actorID string, perms []models.Permission) (bool, error) {
+	actor, err := as.userRepo.GetUserByID(actorID)
+	if err != nil {
+		return false, fmt.Errorf("user not found")
+	}
+	actorPerms, err := as.UserPermissions(actor.ID, actor.Role)
+	if err != nil {
+		return false, err
+	}
+	for _, perm := range perms {
+		if perm != models.PermCartUse && !slices.Contains(actorPerms, perm) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (as *AuthzService) ListPermissions() []models.Permission {
+	return models.AllPermissions
+}
+
+func (as *AuthzService) ListRoles() ([]models.Role, error) {
+	roles, err := as.roleRepo.ListRoles()
+	if err != nil {
+		return nil, fmt.Errorf("can not list roles: %v", err)
+	}
+	return roles, nil
+}
+
+func (as *AuthzService) GetRole(name string) (models.Role, error) {
+	role, err := as.roleRepo.GetRole(name)
+	if err != nil {
+		return models.Role{}, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (as *AuthzService) CreateRole(actorID, name, description string, permissions []string) (models.Role, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !roleNamePattern.MatchString(name) {
+		return models.Role{}, fmt.Errorf("role name must be 2-50 lowercase letters, digits or underscores")
+	}
+	if _, err := models.ParseUserRole(name); err == nil {
+		return models.Role{}, fmt.Errorf("role name %q is reserved", name)
+	}
+	_, err := as.roleRepo.GetRole(name)
+	if err == nil {
+		return models.Role{}, ErrRoleExists
+	}
+	return as.saveRole(actorID, name, description, permissions)
+}
+
+// UpdateRole, like DeleteRole, is refused when the role grants something the
+// actor lacks, as the change would take it away from the role's holders.
+func (as *AuthzService) UpdateRole(actorID, name, description string, permissions []string) (models.Role, error) {
+	role, err := as.modifiableRole(actorID, name)
+	if err != nil {
+		return models.Role{}, err
+	}
+	return as.saveRole(actorID, role.Name, description, permissions)
+}
+
+func (as *AuthzService) saveRole(actorID, name, description string, permissions []string) (models.Role, error) {
+	role := models.Role{
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		Permissions: []models.Permission{},
+	}
+	for _, p := range permissions {
+		perm, err := models.ParsePermission(strings.TrimSpace(p))
+		if err != nil {
+			return models.Role{}, err
+		}
+		if !slices.Contains(role.Permissions, perm) {
+			role.Permissions = append(role.Permissions, perm)
+		}
+	}
+	err := as.requireGrantable(actorID, role.Permissions)
+	if err != nil {
+		return models.Role{}, err
+	}
+	err = as.roleRepo.SaveRole(role)
+	if err != nil {
+		return models.Role{}, fmt.Errorf("can not save role: %v", err)
+	}
+	return role, nil
+}
+
+func (as *AuthzService) DeleteRole(actorID, name string) error {
+	role, err := as.modifiableRole(actorID, name)
+	if err != nil {
+		return err
+	}
+	err = as.roleRepo.DeleteRole(role.Name)
+	if err != nil {
+		return fmt.Errorf("can not delete role: %v", err)
+	}
+	return nil
+}
+
+func (as *AuthzService) GetUserRoles(userID string) ([]models.Role, error) {
+	_, err := as.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found")
+	}
+	roles, err := as.roleRepo.GetUserRoles(userID)
+	if err != nil {
+		return nil, fmt.Errorf("can not load roles: %v", err)
+	}
+	return roles, nil
+}
+
+// AssignRole is refused when the user outranks the actor or the role grants
+// something the actor lacks.
+func (as *AuthzService) AssignRole(actorID, userID, role string) error {
+	err := as.requireManageable(actorID, userID)
+	if err != nil {
+		return err
+	}
+	assigned, err := as.roleRepo.GetRole(role)
+	if err != nil {
+		return ErrRoleNotFound
+	}
+	err = as.requireGrantable(actorID, assigned.Permissions)
+	if err != nil {
+		return err
+	}
+	err = as.roleRepo.AssignRole(userID, role)
+	if err != nil {
+		return fmt.Errorf("can not assign role: %v", err)
+	}
+	return nil
+}
+
+func (as *AuthzService) UnassignRole(actorID, userID, role string) error {
+	err := as.requireManageable(actorID, userID)
+	if err != nil {
+		return err
+	}
+	err = as.roleRepo.UnassignRole(userID, role)
+	if err != nil {
+		return fmt.Errorf("user does not have role %q", role)
+	}
+	return nil
+}
+
+// modifiableRole loads a custom role whose permissions the actor all holds.
+func (as *AuthzService) modifiableRole(actorID, name string) (models.Role, error) {
+	role, err := as.roleRepo.GetRole(name)
+	if err != nil {
+		return models.Role{}, ErrRoleNotFound
+	}
+	if role.BuiltIn {
+		return models.Role{}, ErrBuiltInRole
+	}
+	err = as.requireGrantable(actorID, role.Permissions)
+	if err != nil {
+		return models.Role{}, err
+	}
+	return role, nil
+}
+
+func (as *AuthzService) requireManageable(actorID, userID string) error {
+	user, err := as.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	allowed, err := as.CanManage(actorID, user)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrOutranked
+	}
+	return nil
+}
+
+func (as *AuthzService) requireGrantable(actorID string, perms []models.Permission) error {
+	allowed, err := as.CanGrant(actorID, perms)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrNotGrantable
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package authzService
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+func setupService(t *testing.T) (AuthzServiceManager, *mocks.MockRoleManager, *mocks.MockUserManager) {
+	ctrl := gomock.NewController(t)
+	mockRoleRepo := mocks.NewMockRoleManager(ctrl)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	return NewAuthzService(mockRoleRepo, mockUserRepo), mockRoleRepo, mockUserRepo
+}
+
+func TestHasPermission(t *testing.T) {
+	service, mockRoleRepo, _ := setupService(t)
+
+	t.Run("Admin has staff permissions", func(t *testing.T) {
+		ok, err := service.HasPermission("admin1", models.Admin, models.PermProductsWrite)
+		if err != nil || !ok {
+			t.Errorf("expected admin to have products:write, got %v, err: %v", ok, err)
+		}
+	})
+
+	t.Run("Customer can use the cart", func(t *testing.T) {
+		ok, err := service.HasPermission("u1", models.Customer, models.PermCartUse)
+		if err != nil || !ok {
+			t.Errorf("expected customer to have cart:use, got %v, err: %v", ok, err)
+		}
+	})
+
+	t.Run("Assigned role grants permission", func(t *testing.T) {
+		mockRoleRepo.EXPECT().GetUserPermissions("u1").Return([]models.Permission{models.PermProductsWrite}, nil)
+
+		ok, err := service.HasPermission("u1", models.Customer, models.PermProductsWrite)
+		if err != nil || !ok {
+			t.Errorf("expected permission from assigned role, got %v, err: %v", ok, err)
+		}
+	})
+
+	t.Run("Missing permission", func(t *testing.T) {
+		mockRoleRepo.EXPECT().GetUserPermissions("u1").Return([]models.Permission{models.PermOrdersRead}, nil)
+
+		ok, err := service.HasPermission("u1", models.Customer, models.PermUsersManage)
+		if err != nil || ok {
+			t.Errorf("expected no permission, got %v, err: %v", ok, err)
+		}
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRoleRepo.EXPECT().GetUserPermissions("u1").Return(nil, errors.New("db down"))
+
+		_, err := service.HasPermission("u1", models.Customer, models.PermUsersManage)
+		if err == nil {
+			t.Error("expected error when permissions can not be loaded")
+		}
+	})
+}
+
+func TestIsStaff(t *testing.T) {
+	service, mockRoleRepo, _ := setupService(t)
+
+	tests := []struct {
+		name     string
+		role     models.UserRole
+		assigned []models.Permission
+		want     bool
+	}{
+		{"Admin", models.Admin, nil, true},
+		{"Customer", models.Customer, nil, false},
+		{"Customer with a staff role", models.Customer, []models.Permission{models.PermOrdersRead}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo.EXPECT().GetUserPermissions("u1").Return(tt.assigned, nil)
+
+			staff, err := service.IsStaff("u1", tt.role)
+			if err != nil || staff != tt.want {
+				t.Errorf("expected %v, got %v, err: %v", tt.want, staff, err)
+			}
+		})
+	}
+}
+
+func TestCanManage(t *testing.T) {
+	service, mockRoleRepo, mockUserRepo := setupService(t)
+
+	admin := models.User{ID: "admin1", Role: models.Admin}
+	agent := models.User{ID: "agent1", Role: models.Customer}
+	customer := models.User{ID: "u1", Role: models.Customer}
+	support := []models.Permission{models.PermUsersRead, models.PermUsersManage, models.PermOrdersRead}
+
+	tests := []struct {
+		name   string
+		actor  models.User
+		target models.User
+		want   bool
+	}{
+		{"Agent on customer", agent, customer, true},
+		{"Agent on admin", agent, admin, false},
+		{"Admin on agent", admin, agent, true},
+		{"Admin on customer", admin, customer, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRoleRepo.EXPECT().GetUserPermissions(gomock.Any()).DoAndReturn(func(userID string) ([]models.Permission, error) {
+				if userID == agent.ID {
+					return support, nil
+				}
+				return nil, nil
+			}).Times(2)
+			mockUserRepo.EXPECT().GetUserByID(tt.actor.ID).Return(tt.actor, nil)
+
+			ok, err := service.CanManage(tt.actor.ID, tt.target)
+			if err != nil || ok != tt.want {
+				t.Errorf("expected %v, got %v, err: %v", tt.want, ok, err)
+			}
+		})
+	}
+}
+
+// expectUsers serves the given users and their assigned permissions to any
+// number of lookups.
+func expectUsers(mockUserRepo *mocks.MockUserManager, mockRoleRepo *mocks.MockRoleManager, users map[string]models.User, assigned map[string][]models.Permission) {
+	mockUserRepo.EXPECT().GetUserByID(gomock.Any()).DoAndReturn(func(id string) (models.User, error) {
+		user, ok := users[id]
+		if !ok {
+			return models.User{}, errors.New("not found")
+		}
+		return user, nil
+	}).AnyTimes()
+	mockRoleRepo.EXPECT().GetUserPermissions(gomock.Any()).DoAndReturn(func(id string) ([]models.Permission, error) {
+		return assigned[id], nil
+	}).AnyTimes()
+}
+
+var (
+	testUsers = map[string]models.User{
+		"admin1": {ID: "admin1", Role: models.Admin},
+		"agent1": {ID: "agent1", Role: models.Customer},
+		"u1":     {ID: "u1", Role: models.Customer},
+	}
+	testAssigned = map[string][]models.Permission{
+		"agent1": {models.PermUsersRead, models.PermRolesManage},
+	}
+)
+
+func TestCreateRole(t *testing.T) {
+	service, mockRoleRepo, mockUserRepo := setupService(t)
+	expectUsers(mockUserRepo, mockRoleRepo, testUsers, testAssigned)
+
+	t.Run("Invalid name", func(t *testing.T) {
+		_, err := service.CreateRole("admin1", "Bad Name!", "", nil)
+		if err == nil {
+			t.Error("expected error for invalid name")
+		}
+	})
+
+	t.Run("Reserved name", func(t *testing.T) {
+		_, err := service.CreateRole("admin1", "admin", "", nil)
+		if err == nil {
+			t.Error("expected error for reserved name")
+		}
+	})
+
+	t.Run("Existing role", func(t *testing.T) {
+		mockRoleRepo.EXPECT().GetRole("finance").Return(models.Role{Name: "finance"}, nil)
+
+		_, err := service.CreateRole("admin1", "finance", "", nil)
+		if !errors.Is(err, ErrRoleExists) {
+			t.Errorf("expected ErrRoleExists, got %v", err)
+		}
+	})
+
+	t.Run("Unknown permission", func(t *testing.T) {
+		mockRoleRepo.EXPECT().GetRole("auditor").Return(models.Role{}, errors.New("not found"))
+
+		_, err := service.CreateRole("admin1", "auditor", "", []string{"everything:all"})
+		if err == nil {
+			t.Error("expected error for unknown permission")
+		}
+	})
+
+	t.Run("Permission the actor lacks", func(t *testing.T) {
+		mockRoleRepo.EXPECT().GetRole("auditor").Return(models.Role{}, errors.New("not found"))
+
+		_, err := service.CreateRole("agent1", "auditor", "", []string{"users:read", "orders:read"})
+		if !errors.Is(err, ErrNotGrantable) {
+			t.Errorf("expected ErrNotGrantable, got %v", err)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRoleRepo.EXPECT().GetRole("auditor").Return(models.Role{}, errors.New("not found"))
+		mockRoleRepo.EXPECT().SaveRole(models.Role{
+			Name:        "auditor",
+			Description: "Reads orders",
+			Permissions: []models.Permission{models.PermOrdersRead, models.PermUsersRead},
+		}).Return(nil)
+
+		role, err := service.CreateRole("admin1", " Auditor ", "Reads orders", []string{"orders:read", "users:read", "orders:read"})
+		if err != nil || role.Name != "auditor" || len(role.Permissions) != 2 {
+			t.Errorf("unexpected role: %+v, err: %v", role, err)
+		}
+	})
+}
+
+func TestUpdateAndDeleteRole(t *testing.T) {
+	service, mockRoleRepo, mockUserRepo := setupService(t)
+	expectUsers(mockUserRepo, mockRoleRepo, testUsers, testAssigned)
+
+	mockRoleRepo.EXPECT().GetRole("finance").Return(models.Role{Name: "finance", BuiltIn: true}, nil).Times(2)
+	_, err := service.UpdateRole("admin1", "finance", "", nil)
+	if !errors.Is(err, ErrBuiltInRole) {
+		t.Errorf("expected ErrBuiltInRole on update, got %v", err)
+	}
+	err = service.DeleteRole("admin1", "finance")
+	if !errors.Is(err, ErrBuiltInRole) {
+		t.Errorf("expected ErrBuiltInRole on delete, got %v", err)
+	}
+
+	mockRoleRepo.EXPECT().GetRole("missing").Return(models.Role{}, errors.New("not found"))
+	err = service.DeleteRole("admin1", "missing")
+	if !errors.Is(err, ErrRoleNotFound) {
+		t.Errorf("expected ErrRoleNotFound, got %v", err)
+	}
+
+	billing := models.Role{Name: "billing", Permissions: []models.Permission{models.PermOrdersRead}}
+	mockRoleRepo.EXPECT().GetRole("billing").Return(billing, nil).Times(2)
+	_, err = service.UpdateRole("agent1", "billing", "", []string{"users:read"})
+	if !errors.Is(err, ErrNotGrantable) {
+		t.Errorf("expected ErrNotGrantable on update, got %v", err)
+	}
+	err = service.DeleteRole("agent1", "billing")
+	if !errors.Is(err, ErrNotGrantable) {
+		t.Errorf("expected ErrNotGrantable on delete, got %v", err)
+	}
+
+	mockRoleRepo.EXPECT().GetRole("auditor").Return(models.Role{Name: "auditor"}, nil)
+	mockRoleRepo.EXPECT().DeleteRole("auditor").Return(nil)
+	err = service.DeleteRole("agent1", "auditor")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAssignRole(t *testing.T) {
+	service, mockRoleRepo, mockUserRepo := setupService(t)
+	expectUsers(mockUserRepo, mockRoleRepo, testUsers, testAssigned)
+
+	if err := service.AssignRole("admin1", "404", "finance"); err == nil {
+		t.Error("expected error for unknown user")
+	}
+
+	if err := service.AssignRole("agent1", "admin1", "finance"); !errors.Is(err, ErrOutranked) {
+		t.Errorf("expected ErrOutranked, got %v", err)
+	}
+
+	mockRoleRepo.EXPECT().GetRole("missing").Return(models.Role{}, errors.New("not found"))
+	if err := service.AssignRole("admin1", "u1", "missing"); !errors.Is(err, ErrRoleNotFound) {
+		t.Errorf("expected ErrRoleNotFound, got %v", err)
+	}
+
+	mockRoleRepo.EXPECT().GetRole("finance").Return(models.Role{Name: "finance", Permissions: []models.Permission{models.PermOrdersRead}}, nil).Times(2)
+	if err := service.AssignRole("agent1", "u1", "finance"); !errors.Is(err, ErrNotGrantable) {
+		t.Errorf("expected ErrNotGrantable, got %v", err)
+	}
+
+	mockRoleRepo.EXPECT().AssignRole("u1", "finance").Return(nil)
+	if err := service.AssignRole("admin1", "u1", "finance"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUnassignRole(t *testing.T) {
+	service, mockRoleRepo, mockUserRepo := setupService(t)
+	expectUsers(mockUserRepo, mockRoleRepo, testUsers, testAssigned)
+
+	if err := service.UnassignRole("u1", "agent1", "support_agent"); !errors.Is(err, ErrOutranked) {
+		t.Errorf("expected ErrOutranked, got %v", err)
+	}
+
+	mockRoleRepo.EXPECT().UnassignRole("agent1", "support_agent").Return(nil)
+	if err := service.UnassignRole("admin1", "agent1", "support_agent"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
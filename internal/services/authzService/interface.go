@@ -0,0 +1,22 @@
+package authzService
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_authzService.go -package mocks
+
+type AuthzServiceManager interface {
+	HasPermission(userID string, role models.UserRole, perm models.Permission) (bool, error)
+	UserPermissions(userID string, role models.UserRole) ([]models.Permission, error)
+	IsStaff(userID string, role models.UserRole) (bool, error)
+	CanManage(actorID string, target models.User) (bool, error)
+	CanGrant(actorID string, perms []models.Permission) (bool, error)
+	ListPermissions() []models.Permission
+	ListRoles() ([]models.Role, error)
+	GetRole(name string) (models.Role, error)
+	CreateRole(actorID, name, description string, permissions []string) (models.Role, error)
+	UpdateRole(actorID, name, description string, permissions []string) (models.Role, error)
+	DeleteRole(actorID, name string) error
+	GetUserRoles(userID string) ([]models.Role, error)
+	AssignRole(actorID, userID, role string) error
+	UnassignRole(actorID, userID, role string) error
+}
@@ -1,22 +1,45 @@
 package cartservice
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	cartRepository "github.com/meshyampratap01/OnlineShoppingCart/internal/repository/cartRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/couponRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/inventoryRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/orderRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/variantRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var (
+	ErrEmailNotVerified = errors.New("please verify your email address before checking out")
+	ErrVariantRequired  = errors.New("choose a variant of this product")
+	ErrVariantNotFound  = errors.New("variant not found for this product")
+	ErrUnavailable      = errors.New("this product is no longer available")
+	ErrEmptyCart        = errors.New("your cart is empty")
 )
 
 type CartService struct {
-	cartRepo   cartRepository.CartManager
-	prodRepo   productRepository.ProductManager
-	couponRepo couponRepository.CouponManager
+	cartRepo    cartRepository.CartManager
+	prodRepo    productRepository.ProductManager
+	couponRepo  couponRepository.CouponManager
+	userRepo    userRepository.UserManager
+	orderRepo   orderRepository.OrderManager
+	variantRepo variantRepository.VariantManager
+
+	inventoryRepo inventoryRepository.InventoryManager
 }
 
-func NewCartService(cartRepo cartRepository.CartManager, prodRepo productRepository.ProductManager, couponRepo couponRepository.CouponManager) *CartService {
-	return &CartService{cartRepo: cartRepo, prodRepo: prodRepo, couponRepo: couponRepo}
+func NewCartService(cartRepo cartRepository.CartManager, prodRepo productRepository.ProductManager, couponRepo couponRepository.CouponManager, userRepo userRepository.UserManager, orderRepo orderRepository.OrderManager, variantRepo variantRepository.VariantManager, inventoryRepo inventoryRepository.InventoryManager) *CartService {
+	return &CartService{cartRepo: cartRepo, prodRepo: prodRepo, couponRepo: couponRepo, userRepo: userRepo, orderRepo: orderRepo, variantRepo: variantRepo, inventoryRepo: inventoryRepo}
 }
 
 func (cs *CartService) GetCartItems(userID string) ([]dto.CartItemsDTO, error) {
@@ -31,29 +54,49 @@ func (cs *CartService) GetCartItems(userID string) ([]dto.CartItemsDTO, error) {
 	return cartItems, nil
 }
 
-func (cs *CartService) AddToCart(userID, prodID string) error {
+// AddToCart adds one of the product to the cart. Products with variants need
+// the variant to add, and stock is checked on that variant.
+func (cs *CartService) AddToCart(userID, prodID, variantID string) error {
 	prod, err := cs.prodRepo.GetProductByID(prodID)
 	if err != nil {
 		return err
 	}
-	if prod.Stock <= 0 {
-		return fmt.Errorf("product %s is out of stock", prod.Name)
+	if !prod.Buyable() {
+		return ErrUnavailable
+	}
+	name, stock := prod.Name, prod.Stock
+	variants, err := cs.variantRepo.ListVariants(prodID)
+	if err != nil {
+		return fmt.Errorf("can not fetch variants: %v", err)
+	}
+	switch {
+	case len(variants) > 0 && variantID == "":
+		return ErrVariantRequired
+	case variantID != "":
+		variant, err := cs.variantRepo.GetVariant(variantID)
+		if err != nil || variant.ProductID != prodID {
+			return ErrVariantNotFound
+		}
+		name, stock = prod.Name+" ("+variant.SKU+")", variant.Stock
+	}
+	if stock <= 0 {
+		return fmt.Errorf("product %s is out of stock", name)
 	}
 	cartID, err := cs.cartRepo.GetCartIDByUserID(userID)
 	if err != nil {
 		return fmt.Errorf("no cart associated with the user: %v", err)
 	}
-	quantity, err := cs.cartRepo.GetCartItemQuantity(cartID, prodID)
+	quantity, err := cs.cartRepo.GetCartItemQuantity(cartID, prodID, variantID)
 	if err!=nil{
 		return fmt.Errorf("product can not be added in cart: %v",err)
 	}
-	if prod.Stock < quantity+1 {
+	if stock < quantity+1 {
 		return fmt.Errorf("not enough stock")
 	}
-	return cs.cartRepo.AddToCart(userID, prod)
+	return cs.cartRepo.AddToCart(userID, prod, variantID)
 }
 
-func (cs *CartService) RemoveFromCart(userID, prodID string) error {
+func (cs *CartService) RemoveFromCart(userID, prodID, variantID string) error {
 	cartID, err := cs.cartRepo.GetCartIDByUserID(userID)
 	if err != nil {
 		return fmt.Errorf("no cart associated with the user: %v", err)
@@ -65,8 +108,8 @@ func (cs *CartService) RemoveFromCart(userID, prodID string) error {
 	}
 
 	for _, cartItem := range cartItems {
-		if prodID == cartItem.ProductID {
-			err := cs.cartRepo.RemoveFromCart(cartID, prodID)
+		if prodID == cartItem.ProductID && variantID == cartItem.VariantID {
+			err := cs.cartRepo.RemoveFromCart(cartID, prodID, variantID)
 			return err
 		}
 	}
@@ -74,6 +117,15 @@ func (cs *CartService) RemoveFromCart(userID, prodID string) error {
 }
 
 func (cs *CartService) Checkout(userID string, couponCode string) (float32, error) {
+	if config.RequireVerifiedEmailForCheckout {
+		user, err := cs.userRepo.GetUserByID(userID)
+		if err != nil {
+			return 0, fmt.Errorf("user not found")
+		}
+		if !user.IsEmailVerified() {
+			return 0, ErrEmailNotVerified
+		}
+	}
 	cartID, err := cs.cartRepo.GetCartIDByUserID(userID)
 	if err != nil {
 		return 0, err
@@ -82,27 +134,21 @@ func (cs *CartService) Checkout(userID string, couponCode string) (float32, erro
 	if err != nil {
 		return 0, err
 	}
+	if len(cartItems) == 0 {
+		return 0, ErrEmptyCart
+	}
+	for _, item := range cartItems {
+		if !item.Available {
+			return 0, fmt.Errorf("%w: remove %s from the cart", ErrUnavailable, item.ProductName)
+		}
+	}
 
 	var total float32
 	for _, item := range cartItems {
 		total += item.Price * float32(item.Quantity)
-		prod, err := cs.prodRepo.GetProductByID(item.ProductID)
-		if err != nil {
-			return 0, fmt.Errorf("product %s not found", item.ProductName)
-		}
-		if prod.Stock < item.Quantity {
-			return 0, fmt.Errorf("insufficient stock for product %s", prod.Name)
-		}
-		prod.Stock -= item.Quantity
-		err = cs.prodRepo.UpdateProduct(prod)
-		if err != nil {
-			return 0, fmt.Errorf("failed to update stock for product %s", prod.Name)
-		}
-	}
-	err = cs.cartRepo.EmptyCart(userID)
-	if err != nil {
-		return 0, fmt.Errorf("can't update cart: %v", err)
 	}
+	// the coupon is checked before any stock is taken, as sales stay in the
+	// ledger once recorded
 	if couponCode != "" {
 		coupon, err := cs.couponRepo.GetCouponByCode(couponCode)
 		if err != nil || coupon == nil {
@@ -110,5 +156,99 @@ func (cs *CartService) Checkout(userID string, couponCode string) (float32, erro
 		}
 		total = total - (total * coupon.Discount / 100)
 	}
+
+	order := models.Order{
+		ID:         utils.NewUUID(),
+		UserID:     userID,
+		Total:      total,
+		CouponCode: couponCode,
+		CreatedAt:  time.Now().UTC(),
+	}
+	sales, err := cs.takeStock(order, cartItems)
+	if err != nil {
+		return 0, err
+	}
+	for _, item := range cartItems {
+		order.Items = append(order.Items, models.OrderItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			VariantID:   item.VariantID,
+			SKU:         item.SKU,
+			Options:     item.Options,
+			Price:       item.Price,
+			Quantity:    item.Quantity,
+		})
+	}
+	err = cs.orderRepo.SaveOrder(order)
+	if err != nil {
+		cs.returnStock(order, sales)
+		return 0, fmt.Errorf("can not record order: %v", err)
+	}
+	// the order is placed by now, so a cart that can not be emptied is
+	// logged rather than failing the checkout
+	err = cs.cartRepo.EmptyCart(userID)
+	if err != nil {
+		log.Printf("can not empty the cart of user %s after order %s: %v", userID, order.ID, err)
+	}
 	return total, nil
 }
+
+// takeStock records the sale of every item in the inventory ledger, taking
+// the quantities from the stock of their variants, or of the products when
+// an item has no variant. Either all items are taken or none. The sales carry
+// the order's time, so the ledger and the order agree on when it happened.
+func (cs *CartService) takeStock(order models.Order, cartItems []dto.CartItemsDTO) ([]models.StockMovement, error) {
+	movements := make([]models.StockMovement, 0, len(cartItems))
+	for _, item := range cartItems {
+		movements = append(movements, models.StockMovement{
+			ID:        utils.NewUUID(),
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Delta:     -item.Quantity,
+			Reason:    models.StockSale,
+			ActorID:   order.UserID,
+			Reference: order.ID,
+			CreatedAt: order.CreatedAt,
+		})
+	}
+	sales, err := cs.inventoryRepo.MoveStock(movements)
+	var insufficient *inventoryRepository.InsufficientStockError
+	if errors.As(err, &insufficient) {
+		for _, item := range cartItems {
+			if item.ProductID != insufficient.Movement.ProductID || item.VariantID != insufficient.Movement.VariantID {
+				continue
+			}
+			if item.VariantID != "" {
+				return nil, fmt.Errorf("insufficient stock for product %s (%s)", item.ProductName, item.SKU)
+			}
+			return nil, fmt.Errorf("insufficient stock for product %s", item.ProductName)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update stock: %v", err)
+	}
+	return sales, nil
+}
+
+// returnStock puts back the stock of an order that could not be saved. The
+// sales stay in the ledger, so they are reversed with cancellation entries.
+func (cs *CartService) returnStock(order models.Order, sales []models.StockMovement) {
+	movements := make([]models.StockMovement, 0, len(sales))
+	for _, sale := range sales {
+		movements = append(movements, models.StockMovement{
+			ID:        utils.NewUUID(),
+			ProductID: sale.ProductID,
+			VariantID: sale.VariantID,
+			Delta:     -sale.Delta,
+			Reason:    models.StockCancellation,
+			ActorID:   order.UserID,
+			Reference: order.ID,
+			Note:      "checkout failed",
+			CreatedAt: time.Now().UTC(),
+		})
+	}
+	_, err := cs.inventoryRepo.MoveStock(movements)
+	if err != nil {
+		log.Printf("can not return the stock of failed order %s: %v", order.ID, err)
+	}
+}
@@ -2,11 +2,14 @@ package cartservice
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/inventoryRepository"
 	"go.uber.org/mock/gomock"
 )
 
@@ -17,11 +20,11 @@ func TestGetCartItems(t *testing.T) {
 	mockCartRepo := mocks.NewMockCartManager(ctrl)
 	mockProdRepo := mocks.NewMockProductManager(ctrl)
 	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := NewCartService(mockCartRepo, mockProdRepo, mockCouponRepo)
+	service := NewCartService(mockCartRepo, mockProdRepo, mockCouponRepo, nil, nil, nil, nil)
 
 	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil)
 	mockCartRepo.EXPECT().GetCartItems("cart123").Return([]dto.CartItemsDTO{
-		{ProductID: "p1", ProductName: "Item1", Price: 100, Quantity: 2},
+		{ProductID: "p1", ProductName: "Item1", Price: 100, Quantity: 2, Available: true},
 	}, nil)
 
 	items, err := service.GetCartItems("user1")
@@ -43,27 +46,96 @@ func TestAddToCart(t *testing.T) {
 	mockCartRepo := mocks.NewMockCartManager(ctrl)
 	mockProdRepo := mocks.NewMockProductManager(ctrl)
 	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := NewCartService(mockCartRepo, mockProdRepo, mockCouponRepo)
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	service := NewCartService(mockCartRepo, mockProdRepo, mockCouponRepo, nil, nil, mockVariantRepo, nil)
 
-	product := models.Product{ID: "p1", Name: "Item1", Stock: 5}
+	product := models.Product{ID: "p1", Name: "Item1", Stock: 5, Status: models.ProductPublished}
 	mockProdRepo.EXPECT().GetProductByID("p1").Return(product, nil)
+	mockVariantRepo.EXPECT().ListVariants("p1").Return(nil, nil)
 	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil)
-	mockCartRepo.EXPECT().GetCartItemQuantity("cart123", "p1").Return(2, nil)
-	mockCartRepo.EXPECT().AddToCart("user1", product).Return(nil)
+	mockCartRepo.EXPECT().GetCartItemQuantity("cart123", "p1", "").Return(2, nil)
+	mockCartRepo.EXPECT().AddToCart("user1", product, "").Return(nil)
 
-	err := service.AddToCart("user1", "p1")
+	err := service.AddToCart("user1", "p1", "")
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
 	product.Stock = 0
 	mockProdRepo.EXPECT().GetProductByID("p2").Return(product, nil)
-	err = service.AddToCart("user1", "p2")
+	mockVariantRepo.EXPECT().ListVariants("p2").Return(nil, nil)
+	err = service.AddToCart("user1", "p2", "")
 	if err == nil {
 		t.Error("expected error for out of stock")
 	}
 }
 
+func TestAddUnavailableToCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProdRepo := mocks.NewMockProductManager(ctrl)
+	service := NewCartService(nil, mockProdRepo, nil, nil, nil, nil, nil)
+
+	archivedAt := time.Now()
+	for _, product := range []models.Product{
+		{ID: "p1", Stock: 5, Status: models.ProductDraft},
+		{ID: "p1", Stock: 5, Status: models.ProductPublished, ArchivedAt: &archivedAt},
+	} {
+		mockProdRepo.EXPECT().GetProductByID("p1").Return(product, nil)
+		if err := service.AddToCart("user1", "p1", ""); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("%+v: expected ErrUnavailable, got %v", product, err)
+		}
+	}
+}
+
+func TestAddVariantToCart(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartManager(ctrl)
+	mockProdRepo := mocks.NewMockProductManager(ctrl)
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	service := NewCartService(mockCartRepo, mockProdRepo, nil, nil, nil, mockVariantRepo, nil)
+
+	// the product's own stock is ignored once it has variants
+	product := models.Product{ID: "p1", Name: "Shirt", Stock: 0, Status: models.ProductPublished}
+	variants := []models.Variant{
+		{ID: "v1", ProductID: "p1", SKU: "SHIRT-S", Stock: 3},
+		{ID: "v2", ProductID: "p1", SKU: "SHIRT-M", Stock: 1},
+	}
+	mockProdRepo.EXPECT().GetProductByID("p1").Return(product, nil).Times(4)
+	mockVariantRepo.EXPECT().ListVariants("p1").Return(variants, nil).Times(4)
+
+	err := service.AddToCart("user1", "p1", "")
+	if !errors.Is(err, ErrVariantRequired) {
+		t.Errorf("expected ErrVariantRequired, got %v", err)
+	}
+
+	mockVariantRepo.EXPECT().GetVariant("other").Return(models.Variant{ID: "other", ProductID: "p2"}, nil)
+	err = service.AddToCart("user1", "p1", "other")
+	if !errors.Is(err, ErrVariantNotFound) {
+		t.Errorf("expected ErrVariantNotFound, got %v", err)
+	}
+
+	mockVariantRepo.EXPECT().GetVariant("v1").Return(variants[0], nil)
+	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil)
+	mockCartRepo.EXPECT().GetCartItemQuantity("cart123", "p1", "v1").Return(2, nil)
+	mockCartRepo.EXPECT().AddToCart("user1", product, "v1").Return(nil)
+	err = service.AddToCart("user1", "p1", "v1")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mockVariantRepo.EXPECT().GetVariant("v2").Return(variants[1], nil)
+	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil)
+	mockCartRepo.EXPECT().GetCartItemQuantity("cart123", "p1", "v2").Return(1, nil)
+	err = service.AddToCart("user1", "p1", "v2")
+	if err == nil {
+		t.Error("expected error for not enough variant stock")
+	}
+}
+
 func TestRemoveFromCart(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -71,22 +143,23 @@ func TestRemoveFromCart(t *testing.T) {
 	mockCartRepo := mocks.NewMockCartManager(ctrl)
 	mockProdRepo := mocks.NewMockProductManager(ctrl)
 	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := NewCartService(mockCartRepo, mockProdRepo, mockCouponRepo)
+	service := NewCartService(mockCartRepo, mockProdRepo, mockCouponRepo, nil, nil, nil, nil)
 
 	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil)
 	mockCartRepo.EXPECT().GetCartItems("cart123").Return([]dto.CartItemsDTO{
 		{ProductID: "p1"},
+		{ProductID: "p1", VariantID: "v1"},
 	}, nil)
-	mockCartRepo.EXPECT().RemoveFromCart("cart123", "p1").Return(nil)
+	mockCartRepo.EXPECT().RemoveFromCart("cart123", "p1", "v1").Return(nil)
 
-	err := service.RemoveFromCart("user1", "p1")
+	err := service.RemoveFromCart("user1", "p1", "v1")
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
 	mockCartRepo.EXPECT().GetCartIDByUserID("user2").Return("cart456", nil)
 	mockCartRepo.EXPECT().GetCartItems("cart456").Return([]dto.CartItemsDTO{}, nil)
-	err = service.RemoveFromCart("user2", "p2")
+	err = service.RemoveFromCart("user2", "p2", "")
 	if err == nil {
 		t.Error("expected error for product not in cart")
 	}
@@ -99,34 +172,174 @@ func TestCheckout(t *testing.T) {
 	mockCartRepo := mocks.NewMockCartManager(ctrl)
 	mockProdRepo := mocks.NewMockProductManager(ctrl)
 	mockCouponRepo := mocks.NewMockCouponManager(ctrl)
-	service := NewCartService(mockCartRepo, mockProdRepo, mockCouponRepo)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockOrderRepo := mocks.NewMockOrderManager(ctrl)
+	mockInventoryRepo := mocks.NewMockInventoryManager(ctrl)
+	service := NewCartService(mockCartRepo, mockProdRepo, mockCouponRepo, mockUserRepo, mockOrderRepo, nil, mockInventoryRepo)
 
 	cartItems := []dto.CartItemsDTO{
-		{ProductID: "p1", ProductName: "Item1", Price: 100, Quantity: 2},
+		{ProductID: "p1", ProductName: "Item1", Price: 100, Quantity: 2, Available: true},
+	}
+	verifiedAt := time.Now()
+
+	mockUserRepo.EXPECT().GetUserByID("unverified").Return(models.User{ID: "unverified"}, nil)
+	_, err := service.Checkout("unverified", "")
+	if err == nil {
+		t.Error("expected error for unverified email")
 	}
-	product := models.Product{ID: "p1", Name: "Item1", Stock: 5}
 
+	mockUserRepo.EXPECT().GetUserByID("user1").Return(models.User{ID: "user1", EmailVerifiedAt: &verifiedAt}, nil)
 	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil)
 	mockCartRepo.EXPECT().GetCartItems("cart123").Return(cartItems, nil)
-	mockProdRepo.EXPECT().GetProductByID("p1").Return(product, nil)
-	mockProdRepo.EXPECT().UpdateProduct(gomock.Any()).Return(nil)
-	mockCartRepo.EXPECT().EmptyCart("user1").Return(nil)
 	mockCouponRepo.EXPECT().GetCouponByCode("SAVE10").Return(&models.Coupon{Code: "SAVE10", Discount: 10}, nil)
+	var sale models.StockMovement
+	mockInventoryRepo.EXPECT().MoveStock(gomock.Any()).DoAndReturn(func(movements []models.StockMovement) ([]models.StockMovement, error) {
+		sale = movements[0]
+		return movements, nil
+	})
+	mockCartRepo.EXPECT().EmptyCart("user1").Return(nil)
+	mockOrderRepo.EXPECT().SaveOrder(gomock.Any()).DoAndReturn(func(order models.Order) error {
+		if order.UserID != "user1" || order.Total != 180 || order.CouponCode != "SAVE10" || len(order.Items) != 1 || order.Items[0].Quantity != 2 {
+			t.Errorf("unexpected order: %+v", order)
+		}
+		if sale.ProductID != "p1" || sale.Delta != -2 || sale.Reason != models.StockSale || sale.ActorID != "user1" || sale.Reference != order.ID {
+			t.Errorf("unexpected sale: %+v", sale)
+		}
+		if order.CreatedAt.Location() != time.UTC || !sale.CreatedAt.Equal(order.CreatedAt) {
+			t.Errorf("expected the sale at the order's UTC time, got %v and %v", sale.CreatedAt, order.CreatedAt)
+		}
+		return nil
+	})
 
 	total, err := service.Checkout("user1", "SAVE10")
 	if err != nil || total != 180 {
 		t.Errorf("unexpected error or wrong total: %v, total: %v", err, total)
 	}
 
+	mockUserRepo.EXPECT().GetUserByID("user2").Return(models.User{ID: "user2", EmailVerifiedAt: &verifiedAt}, nil)
 	mockCartRepo.EXPECT().GetCartIDByUserID("user2").Return("cart456", nil)
 	mockCartRepo.EXPECT().GetCartItems("cart456").Return(cartItems, nil)
-	mockProdRepo.EXPECT().GetProductByID("p1").Return(product, nil)
-	mockProdRepo.EXPECT().UpdateProduct(gomock.Any()).Return(nil)
-	mockCartRepo.EXPECT().EmptyCart("user2").Return(nil)
 	mockCouponRepo.EXPECT().GetCouponByCode("INVALID").Return(nil, errors.New("not found"))
 
+	// no stock is taken for an invalid coupon
 	_, err = service.Checkout("user2", "INVALID")
 	if err == nil {
 		t.Error("expected error for invalid coupon")
 	}
+
+	mockUserRepo.EXPECT().GetUserByID("user3").Return(models.User{ID: "user3", EmailVerifiedAt: &verifiedAt}, nil)
+	mockCartRepo.EXPECT().GetCartIDByUserID("user3").Return("cart789", nil)
+	mockCartRepo.EXPECT().GetCartItems("cart789").Return(cartItems, nil)
+	mockInventoryRepo.EXPECT().MoveStock(gomock.Any()).DoAndReturn(func(movements []models.StockMovement) ([]models.StockMovement, error) {
+		return nil, &inventoryRepository.InsufficientStockError{Movement: movements[0]}
+	})
+
+	_, err = service.Checkout("user3", "")
+	if err == nil || err.Error() != "insufficient stock for product Item1" {
+		t.Errorf("expected insufficient stock for Item1, got %v", err)
+	}
+}
+
+func TestCheckoutFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartManager(ctrl)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockOrderRepo := mocks.NewMockOrderManager(ctrl)
+	mockInventoryRepo := mocks.NewMockInventoryManager(ctrl)
+	service := NewCartService(mockCartRepo, nil, nil, mockUserRepo, mockOrderRepo, nil, mockInventoryRepo)
+
+	verifiedAt := time.Now()
+	mockUserRepo.EXPECT().GetUserByID("user1").Return(models.User{ID: "user1", EmailVerifiedAt: &verifiedAt}, nil).Times(2)
+	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil).Times(2)
+
+	// an empty cart places no order
+	mockCartRepo.EXPECT().GetCartItems("cart123").Return(nil, nil)
+	_, err := service.Checkout("user1", "")
+	if !errors.Is(err, ErrEmptyCart) {
+		t.Errorf("expected ErrEmptyCart, got %v", err)
+	}
+
+	// when the order can not be saved the stock is put back and the cart kept
+	mockCartRepo.EXPECT().GetCartItems("cart123").Return([]dto.CartItemsDTO{
+		{ProductID: "p1", ProductName: "Item1", Price: 100, Quantity: 2, Available: true},
+	}, nil)
+	var sale models.StockMovement
+	mockInventoryRepo.EXPECT().MoveStock(gomock.Any()).DoAndReturn(func(movements []models.StockMovement) ([]models.StockMovement, error) {
+		sale = movements[0]
+		return movements, nil
+	})
+	mockOrderRepo.EXPECT().SaveOrder(gomock.Any()).Return(errors.New("db error"))
+	mockInventoryRepo.EXPECT().MoveStock(gomock.Any()).DoAndReturn(func(movements []models.StockMovement) ([]models.StockMovement, error) {
+		if len(movements) != 1 || movements[0].Delta != 2 || movements[0].Reason != models.StockCancellation || movements[0].Reference != sale.Reference {
+			t.Errorf("unexpected reversal: %+v", movements)
+		}
+		return movements, nil
+	})
+	_, err = service.Checkout("user1", "")
+	if err == nil {
+		t.Error("expected an error when the order can not be saved")
+	}
+}
+
+func TestCheckoutUnavailable(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartManager(ctrl)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	service := NewCartService(mockCartRepo, nil, nil, mockUserRepo, nil, nil, nil)
+
+	verifiedAt := time.Now()
+	mockUserRepo.EXPECT().GetUserByID("user1").Return(models.User{ID: "user1", EmailVerifiedAt: &verifiedAt}, nil)
+	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil)
+	mockCartRepo.EXPECT().GetCartItems("cart123").Return([]dto.CartItemsDTO{
+		{ProductID: "p1", ProductName: "Item1", Price: 100, Quantity: 1, Available: true},
+		{ProductID: "p2", ProductName: "Item2", Price: 50, Quantity: 1},
+	}, nil)
+
+	// no stock is taken and the cart is kept
+	_, err := service.Checkout("user1", "")
+	if !errors.Is(err, ErrUnavailable) || !strings.Contains(err.Error(), "Item2") {
+		t.Errorf("expected ErrUnavailable naming Item2, got %v", err)
+	}
+}
+
+func TestCheckoutVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCartRepo := mocks.NewMockCartManager(ctrl)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockOrderRepo := mocks.NewMockOrderManager(ctrl)
+	mockInventoryRepo := mocks.NewMockInventoryManager(ctrl)
+	service := NewCartService(mockCartRepo, nil, nil, mockUserRepo, mockOrderRepo, nil, mockInventoryRepo)
+
+	verifiedAt := time.Now()
+	options := map[string]string{"size": "M"}
+	cartItems := []dto.CartItemsDTO{
+		{ProductID: "p1", ProductName: "Shirt", Price: 25, Quantity: 2, VariantID: "v1", SKU: "SHIRT-M", Options: options, Available: true},
+	}
+	mockUserRepo.EXPECT().GetUserByID("user1").Return(models.User{ID: "user1", EmailVerifiedAt: &verifiedAt}, nil)
+	mockCartRepo.EXPECT().GetCartIDByUserID("user1").Return("cart123", nil)
+	mockCartRepo.EXPECT().GetCartItems("cart123").Return(cartItems, nil)
+	mockInventoryRepo.EXPECT().MoveStock(gomock.Any()).DoAndReturn(func(movements []models.StockMovement) ([]models.StockMovement, error) {
+		if len(movements) != 1 || movements[0].VariantID != "v1" || movements[0].Delta != -2 {
+			t.Errorf("unexpected movements: %+v", movements)
+		}
+		return movements, nil
+	})
+	mockCartRepo.EXPECT().EmptyCart("user1").Return(nil)
+	mockOrderRepo.EXPECT().SaveOrder(gomock.Any()).DoAndReturn(func(order models.Order) error {
+		if len(order.Items) != 1 || order.Items[0].VariantID != "v1" || order.Items[0].SKU != "SHIRT-M" || order.Items[0].Options["size"] != "M" {
+			t.Errorf("unexpected order: %+v", order)
+		}
+		return nil
+	})
+
+	total, err := service.Checkout("user1", "")
+	if err != nil || total != 50 {
+		t.Errorf("unexpected error or wrong total: %v, total: %v", err, total)
+	}
 }
@@ -8,7 +8,7 @@ import (
 
 type CartServiceManager interface {
 	GetCartItems(userID string) ([]dto.CartItemsDTO, error)
-	AddToCart(userID, prodID string) error
-	RemoveFromCart(userID, prodID string) error
+	AddToCart(userID, prodID, variantID string) error
+	RemoveFromCart(userID, prodID, variantID string) error
 	Checkout(userID string, couponCode string) (float32, error)
-}
\ No newline at end of file
+}
\ No newline at end of file
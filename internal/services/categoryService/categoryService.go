@@ -0,0 +1,241 @@
+package categoryService
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/categoryRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var (
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrCategoryExists      = errors.New("a category with this slug already exists")
+	ErrCategoryHasChildren = errors.New("category has subcategories, move or delete them first")
+	ErrProductNotFound     = errors.New("product not found")
+)
+
+var (
+	slugPattern  = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+const maxCategoryNameLen = 100
+
+type CategoryService struct {
+	categoryRepo categoryRepository.CategoryManager
+	productRepo  productRepository.ProductManager
+}
+
+func NewCategoryService(categoryRepo categoryRepository.CategoryManager, productRepo productRepository.ProductManager) CategoryServiceManager {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+		productRepo:  productRepo,
+	}
+}
+
+// GetCategoryTree returns the top-level categories with their descendants
+// nested under Children, each level in display order.
+func (cs *CategoryService) GetCategoryTree() ([]models.Category, error) {
+	categories, err := cs.categoryRepo.ListCategories()
+	if err != nil {
+		return nil, fmt.Errorf("can not list categories: %v", err)
+	}
+	children := make(map[string][]models.Category)
+	for _, category := range categories {
+		parent := ""
+		if category.ParentID != nil {
+			parent = *category.ParentID
+		}
+		children[parent] = append(children[parent], category)
+	}
+	return buildTree(children, "", make(map[string]bool)), nil
+}
+
+func buildTree(children map[string][]models.Category, parent string, seen map[string]bool) []models.Category {
+	nodes := []models.Category{}
+	for _, category := range children[parent] {
+		if seen[category.ID] {
+			continue
+		}
+		seen[category.ID] = true
+		category.Children = buildTree(children, category.ID, seen)
+		nodes = append(nodes, category)
+	}
+	return nodes
+}
+
+func (cs *CategoryService) ListCategories() ([]models.Category, error) {
+	categories, err := cs.categoryRepo.ListCategories()
+	if err != nil {
+		return nil, fmt.Errorf("can not list categories: %v", err)
+	}
+	if categories == nil {
+		categories = []models.Category{}
+	}
+	return categories, nil
+}
+
+func (cs *CategoryService) GetCategory(id string) (models.Category, error) {
+	category, err := cs.categoryRepo.GetCategoryByID(id)
+	if err != nil {
+		return models.Category{}, ErrCategoryNotFound
+	}
+	return category, nil
+}
+
+func (cs *CategoryService) CreateCategory(req dto.CategoryDTO) (models.Category, error) {
+	category := models.Category{ID: utils.NewUUID()}
+	err := cs.apply(&category, req)
+	if err != nil {
+		return models.Category{}, err
+	}
+	err = cs.categoryRepo.SaveCategory(category)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("can not save category: %v", err)
+	}
+	return category, nil
+}
+
+func (cs *CategoryService) UpdateCategory(id string, req dto.CategoryDTO) (models.Category, error) {
+	category, err := cs.categoryRepo.GetCategoryByID(id)
+	if err != nil {
+		return models.Category{}, ErrCategoryNotFound
+	}
+	err = cs.apply(&category, req)
+	if err != nil {
+		return models.Category{}, err
+	}
+	err = cs.categoryRepo.UpdateCategory(category)
+	if err != nil {
+		return models.Category{}, fmt.Errorf("can not update category: %v", err)
+	}
+	return category, nil
+}
+
+// apply validates req and copies it onto category.
+func (cs *CategoryService) apply(category *models.Category, req dto.CategoryDTO) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxCategoryNameLen {
+		return fmt.Errorf("category name must be 1-%d characters", maxCategoryNameLen)
+	}
+	slug := strings.TrimSpace(req.Slug)
+	if slug == "" {
+		slug = slugify(name)
+	}
+	if !slugPattern.MatchString(slug) || len(slug) > maxCategoryNameLen {
+		return fmt.Errorf("slug must be lowercase letters and digits separated by single hyphens")
+	}
+	existing, err := cs.categoryRepo.GetCategoryBySlug(slug)
+	if err == nil && existing.ID != category.ID {
+		return ErrCategoryExists
+	}
+
+	var parentID *string
+	if req.ParentID != nil && *req.ParentID != "" {
+		parentID = req.ParentID
+		err = cs.checkParent(category.ID, *parentID)
+		if err != nil {
+			return err
+		}
+	}
+
+	category.ParentID = parentID
+	category.Name = name
+	category.Slug = slug
+	category.SortOrder = req.SortOrder
+	return nil
+}
+
+// checkParent makes sure parentID exists and is neither the category itself
+// nor one of its descendants, which would cut the subtree off the tree.
+func (cs *CategoryService) checkParent(id, parentID string) error {
+	categories, err := cs.categoryRepo.ListCategories()
+	if err != nil {
+		return fmt.Errorf("can not list categories: %v", err)
+	}
+	parents := make(map[string]*string, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+	if _, ok := parents[parentID]; !ok {
+		return fmt.Errorf("parent category not found")
+	}
+	for current := &parentID; current != nil; current = parents[*current] {
+		if *current == id {
+			return fmt.Errorf("a category can not be moved under itself or its subcategories")
+		}
+	}
+	return nil
+}
+
+func (cs *CategoryService) DeleteCategory(id string) error {
+	_, err := cs.categoryRepo.GetCategoryByID(id)
+	if err != nil {
+		return ErrCategoryNotFound
+	}
+	children, err := cs.categoryRepo.CountChildren(id)
+	if err != nil {
+		return fmt.Errorf("can not delete category: %v", err)
+	}
+	if children > 0 {
+		return ErrCategoryHasChildren
+	}
+	err = cs.categoryRepo.DeleteCategory(id)
+	if err != nil {
+		return fmt.Errorf("can not delete category: %v", err)
+	}
+	return nil
+}
+
+func (cs *CategoryService) GetProductCategories(productID string) ([]models.Category, error) {
+	_, err := cs.productRepo.GetProductByID(productID)
+	if err != nil {
+		return nil, ErrProductNotFound
+	}
+	categories, err := cs.categoryRepo.GetProductCategories(productID)
+	if err != nil {
+		return nil, fmt.Errorf("can not fetch categories: %v", err)
+	}
+	if categories == nil {
+		categories = []models.Category{}
+	}
+	return categories, nil
+}
+
+// SetProductCategories replaces the product's categories with the ones named
+// by slugs. Unknown slugs fail the whole update.
+func (cs *CategoryService) SetProductCategories(productID string, slugs []string) ([]models.Category, error) {
+	_, err := cs.productRepo.GetProductByID(productID)
+	if err != nil {
+		return nil, ErrProductNotFound
+	}
+	categories := []models.Category{}
+	var ids []string
+	for _, slug := range slugs {
+		category, err := cs.categoryRepo.GetCategoryBySlug(strings.TrimSpace(slug))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, slug)
+		}
+		if slices.Contains(ids, category.ID) {
+			continue
+		}
+		categories = append(categories, category)
+		ids = append(ids, category.ID)
+	}
+	err = cs.categoryRepo.SetProductCategories(productID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("can not assign categories: %v", err)
+	}
+	return categories, nil
+}
+
+func slugify(name string) string {
+	return strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
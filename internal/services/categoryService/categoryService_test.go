@@ -0,0 +1,183 @@
+package categoryService
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+// computers > laptops > gaming-laptops, plus a separate books category
+var catalogue = []models.Category{
+	{ID: "c1", Name: "Computers", Slug: "computers"},
+	{ID: "c4", Name: "Books", Slug: "books", SortOrder: 1},
+	{ID: "c2", ParentID: ptr("c1"), Name: "Laptops", Slug: "laptops"},
+	{ID: "c3", ParentID: ptr("c2"), Name: "Gaming laptops", Slug: "gaming-laptops"},
+}
+
+func setupService(t *testing.T) (*CategoryService, *mocks.MockCategoryManager, *mocks.MockProductManager) {
+	ctrl := gomock.NewController(t)
+	categoryRepo := mocks.NewMockCategoryManager(ctrl)
+	productRepo := mocks.NewMockProductManager(ctrl)
+	return &CategoryService{categoryRepo: categoryRepo, productRepo: productRepo}, categoryRepo, productRepo
+}
+
+func TestGetCategoryTree(t *testing.T) {
+	service, categoryRepo, _ := setupService(t)
+	categoryRepo.EXPECT().ListCategories().Return(catalogue, nil)
+
+	tree, err := service.GetCategoryTree()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tree) != 2 || tree[0].Slug != "computers" || tree[1].Slug != "books" {
+		t.Fatalf("unexpected top level: %+v", tree)
+	}
+	laptops := tree[0].Children
+	if len(laptops) != 1 || laptops[0].Slug != "laptops" || len(laptops[0].Children) != 1 || laptops[0].Children[0].Slug != "gaming-laptops" {
+		t.Errorf("unexpected subtree: %+v", laptops)
+	}
+}
+
+func TestCreateCategory(t *testing.T) {
+	t.Run("Slug is derived from the name", func(t *testing.T) {
+		service, categoryRepo, _ := setupService(t)
+		categoryRepo.EXPECT().GetCategoryBySlug("home-garden").Return(models.Category{}, sql.ErrNoRows)
+		categoryRepo.EXPECT().ListCategories().Return(catalogue, nil)
+		categoryRepo.EXPECT().SaveCategory(gomock.Any()).Return(nil)
+
+		category, err := service.CreateCategory(dto.CategoryDTO{Name: " Home & Garden ", ParentID: ptr("c1"), SortOrder: 3})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if category.ID == "" || category.Slug != "home-garden" || category.Name != "Home & Garden" || *category.ParentID != "c1" || category.SortOrder != 3 {
+			t.Errorf("unexpected category: %+v", category)
+		}
+	})
+
+	t.Run("Invalid slug", func(t *testing.T) {
+		service, _, _ := setupService(t)
+		_, err := service.CreateCategory(dto.CategoryDTO{Name: "Books", Slug: "Books!"})
+		if err == nil {
+			t.Error("expected error for invalid slug")
+		}
+	})
+
+	t.Run("Slug taken", func(t *testing.T) {
+		service, categoryRepo, _ := setupService(t)
+		categoryRepo.EXPECT().GetCategoryBySlug("books").Return(catalogue[1], nil)
+
+		_, err := service.CreateCategory(dto.CategoryDTO{Name: "Books"})
+		if !errors.Is(err, ErrCategoryExists) {
+			t.Errorf("expected ErrCategoryExists, got %v", err)
+		}
+	})
+
+	t.Run("Unknown parent", func(t *testing.T) {
+		service, categoryRepo, _ := setupService(t)
+		categoryRepo.EXPECT().GetCategoryBySlug("tablets").Return(models.Category{}, sql.ErrNoRows)
+		categoryRepo.EXPECT().ListCategories().Return(catalogue, nil)
+
+		_, err := service.CreateCategory(dto.CategoryDTO{Name: "Tablets", ParentID: ptr("404")})
+		if err == nil {
+			t.Error("expected error for unknown parent")
+		}
+	})
+}
+
+func TestUpdateCategory(t *testing.T) {
+	t.Run("Can not move under a descendant", func(t *testing.T) {
+		service, categoryRepo, _ := setupService(t)
+		categoryRepo.EXPECT().GetCategoryByID("c1").Return(catalogue[0], nil)
+		categoryRepo.EXPECT().GetCategoryBySlug("computers").Return(catalogue[0], nil)
+		categoryRepo.EXPECT().ListCategories().Return(catalogue, nil)
+
+		_, err := service.UpdateCategory("c1", dto.CategoryDTO{Name: "Computers", ParentID: ptr("c3")})
+		if err == nil {
+			t.Error("expected error for a cycle")
+		}
+	})
+
+	t.Run("Move to top level", func(t *testing.T) {
+		service, categoryRepo, _ := setupService(t)
+		categoryRepo.EXPECT().GetCategoryByID("c2").Return(catalogue[2], nil)
+		categoryRepo.EXPECT().GetCategoryBySlug("laptops").Return(catalogue[2], nil)
+		categoryRepo.EXPECT().UpdateCategory(models.Category{ID: "c2", Name: "Laptops", Slug: "laptops"}).Return(nil)
+
+		category, err := service.UpdateCategory("c2", dto.CategoryDTO{Name: "Laptops"})
+		if err != nil || category.ParentID != nil {
+			t.Errorf("unexpected category: %+v, err: %v", category, err)
+		}
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		service, categoryRepo, _ := setupService(t)
+		categoryRepo.EXPECT().GetCategoryByID("404").Return(models.Category{}, sql.ErrNoRows)
+
+		_, err := service.UpdateCategory("404", dto.CategoryDTO{Name: "X"})
+		if !errors.Is(err, ErrCategoryNotFound) {
+			t.Errorf("expected ErrCategoryNotFound, got %v", err)
+		}
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	service, categoryRepo, _ := setupService(t)
+
+	categoryRepo.EXPECT().GetCategoryByID("c1").Return(catalogue[0], nil)
+	categoryRepo.EXPECT().CountChildren("c1").Return(1, nil)
+	if err := service.DeleteCategory("c1"); !errors.Is(err, ErrCategoryHasChildren) {
+		t.Errorf("expected ErrCategoryHasChildren, got %v", err)
+	}
+
+	categoryRepo.EXPECT().GetCategoryByID("c3").Return(catalogue[3], nil)
+	categoryRepo.EXPECT().CountChildren("c3").Return(0, nil)
+	categoryRepo.EXPECT().DeleteCategory("c3").Return(nil)
+	if err := service.DeleteCategory("c3"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSetProductCategories(t *testing.T) {
+	t.Run("Unknown product", func(t *testing.T) {
+		service, _, productRepo := setupService(t)
+		productRepo.EXPECT().GetProductByID("404").Return(models.Product{}, sql.ErrNoRows)
+
+		_, err := service.SetProductCategories("404", []string{"books"})
+		if !errors.Is(err, ErrProductNotFound) {
+			t.Errorf("expected ErrProductNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Unknown category", func(t *testing.T) {
+		service, categoryRepo, productRepo := setupService(t)
+		productRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil)
+		categoryRepo.EXPECT().GetCategoryBySlug("nope").Return(models.Category{}, sql.ErrNoRows)
+
+		_, err := service.SetProductCategories("p1", []string{"nope"})
+		if !errors.Is(err, ErrCategoryNotFound) {
+			t.Errorf("expected ErrCategoryNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Duplicates are dropped", func(t *testing.T) {
+		service, categoryRepo, productRepo := setupService(t)
+		productRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil)
+		categoryRepo.EXPECT().GetCategoryBySlug("laptops").Return(catalogue[2], nil).Times(2)
+		categoryRepo.EXPECT().GetCategoryBySlug("books").Return(catalogue[1], nil)
+		categoryRepo.EXPECT().SetProductCategories("p1", []string{"c2", "c4"}).Return(nil)
+
+		categories, err := service.SetProductCategories("p1", []string{"laptops", "books", "laptops"})
+		if err != nil || len(categories) != 2 {
+			t.Errorf("unexpected categories: %+v, err: %v", categories, err)
+		}
+	})
+}
@@ -0,0 +1,19 @@
+package categoryService
+
+import (
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_categoryService.go -package mocks
+
+type CategoryServiceManager interface {
+	GetCategoryTree() ([]models.Category, error)
+	ListCategories() ([]models.Category, error)
+	GetCategory(id string) (models.Category, error)
+	CreateCategory(req dto.CategoryDTO) (models.Category, error)
+	UpdateCategory(id string, req dto.CategoryDTO) (models.Category, error)
+	DeleteCategory(id string) error
+	GetProductCategories(productID string) ([]models.Category, error)
+	SetProductCategories(productID string, slugs []string) ([]models.Category, error)
+}
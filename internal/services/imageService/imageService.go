@@ -0,0 +1,275 @@
+package imageService
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/blobstore"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/imageRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var (
+	ErrProductNotFound   = errors.New("product not found")
+	ErrImageNotFound     = errors.New("image not found")
+	ErrImageTooLarge     = fmt.Errorf("images can be at most %d MiB", config.MaxImageUploadBytes>>20)
+	ErrUnsupportedType   = errors.New("only JPEG, PNG and GIF images are accepted")
+	ErrInvalidImage      = errors.New("the image can not be decoded")
+	ErrImageDimensions   = fmt.Errorf("images can be at most %d pixels wide or high and %d megapixels", maxImageSide, maxImagePixels/1_000_000)
+	ErrTooManyImages     = fmt.Errorf("a product can have at most %d images", config.MaxImagesPerProduct)
+	ErrInvalidImageOrder = errors.New("the order must list each of the product's images once")
+)
+
+const (
+	maxImageSide   = 8000
+	maxImagePixels = 24_000_000
+)
+
+// extensions maps the accepted content types, as sniffed from the upload, to
+// the extension of the stored original.
+var extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type ImageService struct {
+	imageRepo   imageRepository.ImageManager
+	productRepo productRepository.ProductManager
+	store       blobstore.BlobStore
+}
+
+func NewImageService(imageRepo imageRepository.ImageManager, productRepo productRepository.ProductManager, store blobstore.BlobStore) ImageServiceManager {
+	return &ImageService{imageRepo: imageRepo, productRepo: productRepo, store: store}
+}
+
+// UploadImage stores the original and its thumbnails and appends the image
+// to the product's gallery. The first image becomes the primary one.
+func (is *ImageService) UploadImage(productID string, data []byte) (models.ProductImage, error) {
+	_, err := is.productRepo.GetProductByID(productID)
+	if err != nil {
+		return models.ProductImage{}, ErrProductNotFound
+	}
+	if int64(len(data)) > config.MaxImageUploadBytes {
+		return models.ProductImage{}, ErrImageTooLarge
+	}
+	contentType := http.DetectContentType(data)
+	if _, ok := extensions[contentType]; !ok {
+		return models.ProductImage{}, ErrUnsupportedType
+	}
+	// the header is checked before decoding, so a small file can not make
+	// us allocate a huge bitmap
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return models.ProductImage{}, ErrInvalidImage
+	}
+	if cfg.Width > maxImageSide || cfg.Height > maxImageSide || cfg.Width*cfg.Height > maxImagePixels {
+		return models.ProductImage{}, ErrImageDimensions
+	}
+
+	existing, err := is.imageRepo.ListImages(productID)
+	if err != nil {
+		return models.ProductImage{}, fmt.Errorf("can not fetch images: %v", err)
+	}
+	if len(existing) >= config.MaxImagesPerProduct {
+		return models.ProductImage{}, ErrTooManyImages
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return models.ProductImage{}, ErrInvalidImage
+	}
+
+	img := models.ProductImage{
+		ID:          utils.NewUUID(),
+		ProductID:   productID,
+		Primary:     len(existing) == 0,
+		ContentType: contentType,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		SizeBytes:   len(data),
+		CreatedAt:   time.Now(),
+	}
+	if len(existing) > 0 {
+		img.Position = existing[len(existing)-1].Position + 1
+	}
+
+	blobs := map[string][]byte{originalKey(img): data}
+	thumbnails, err := makeThumbnails(src, contentType)
+	if err != nil {
+		return models.ProductImage{}, fmt.Errorf("can not make thumbnails: %v", err)
+	}
+	for size, thumbnail := range thumbnails {
+		blobs[thumbnailKey(img, size)] = thumbnail
+	}
+	var written []string
+	for key, blob := range blobs {
+		err = is.store.Put(key, contentTypeOf(key), blob)
+		if err != nil {
+			is.deleteBlobs(written)
+			return models.ProductImage{}, fmt.Errorf("can not store image: %v", err)
+		}
+		written = append(written, key)
+	}
+
+	err = is.imageRepo.SaveImage(img)
+	if err != nil {
+		is.deleteBlobs(written)
+		return models.ProductImage{}, fmt.Errorf("can not save image: %v", err)
+	}
+	return is.withURLs(img), nil
+}
+
+func (is *ImageService) ListImages(productID string) ([]models.ProductImage, error) {
+	_, err := is.productRepo.GetProductByID(productID)
+	if err != nil {
+		return nil, ErrProductNotFound
+	}
+	images, err := is.imageRepo.ListImages(productID)
+	if err != nil {
+		return nil, fmt.Errorf("can not fetch images: %v", err)
+	}
+	list := []models.ProductImage{}
+	for _, img := range images {
+		list = append(list, is.withURLs(img))
+	}
+	return list, nil
+}
+
+// ReorderImages sets the gallery order; imageIDs must hold every image of the
+// product exactly once.
+func (is *ImageService) ReorderImages(productID string, imageIDs []string) ([]models.ProductImage, error) {
+	images, err := is.ListImages(productID)
+	if err != nil {
+		return nil, err
+	}
+	current := make([]string, len(images))
+	for i, img := range images {
+		current[i] = img.ID
+	}
+	requested := slices.Clone(imageIDs)
+	slices.Sort(current)
+	slices.Sort(requested)
+	if !slices.Equal(current, requested) {
+		return nil, ErrInvalidImageOrder
+	}
+	err = is.imageRepo.UpdatePositions(productID, imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("can not reorder images: %v", err)
+	}
+	return is.ListImages(productID)
+}
+
+func (is *ImageService) SetPrimaryImage(productID, imageID string) ([]models.ProductImage, error) {
+	_, err := is.getImage(productID, imageID)
+	if err != nil {
+		return nil, err
+	}
+	err = is.imageRepo.SetPrimary(productID, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("can not set primary image: %v", err)
+	}
+	return is.ListImages(productID)
+}
+
+// DeleteImage removes the image and its blobs. When it was the primary image
+// the next one in the gallery takes over.
+func (is *ImageService) DeleteImage(productID, imageID string) error {
+	img, err := is.getImage(productID, imageID)
+	if err != nil {
+		return err
+	}
+	err = is.imageRepo.DeleteImage(imageID)
+	if err != nil {
+		return fmt.Errorf("can not delete image: %v", err)
+	}
+	is.DeleteImageBlobs([]models.ProductImage{img})
+	if !img.Primary {
+		return nil
+	}
+
+	remaining, err := is.imageRepo.ListImages(productID)
+	if err != nil {
+		return fmt.Errorf("can not fetch images: %v", err)
+	}
+	if len(remaining) > 0 {
+		err = is.imageRepo.SetPrimary(productID, remaining[0].ID)
+		if err != nil {
+			return fmt.Errorf("can not set primary image: %v", err)
+		}
+	}
+	return nil
+}
+
+// ImagesFor returns the galleries of several products, for listings.
+func (is *ImageService) ImagesFor(productIDs []string) (map[string][]models.ProductImage, error) {
+	images, err := is.imageRepo.ListImagesForProducts(productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("can not fetch images: %v", err)
+	}
+	for productID, list := range images {
+		for i, img := range list {
+			list[i] = is.withURLs(img)
+		}
+		images[productID] = list
+	}
+	return images, nil
+}
+
+// DeleteImageBlobs removes the stored files of images whose rows are already
+// gone. Failures are only logged, leaving at worst an orphaned file.
+func (is *ImageService) DeleteImageBlobs(images []models.ProductImage) {
+	for _, img := range images {
+		keys := []string{originalKey(img)}
+		for _, size := range thumbnailSizes {
+			keys = append(keys, thumbnailKey(img, size.name))
+		}
+		is.deleteBlobs(keys)
+	}
+}
+
+func (is *ImageService) deleteBlobs(keys []string) {
+	for _, key := range keys {
+		if err := is.store.Delete(key); err != nil {
+			log.Printf("can not delete blob %s: %v", key, err)
+		}
+	}
+}
+
+func (is *ImageService) getImage(productID, imageID string) (models.ProductImage, error) {
+	img, err := is.imageRepo.GetImage(imageID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && img.ProductID != productID) {
+		return models.ProductImage{}, ErrImageNotFound
+	}
+	if err != nil {
+		return models.ProductImage{}, fmt.Errorf("can not fetch image: %v", err)
+	}
+	return img, nil
+}
+
+func (is *ImageService) withURLs(img models.ProductImage) models.ProductImage {
+	img.URL = is.store.URL(originalKey(img))
+	img.Thumbnails = make(map[string]string, len(thumbnailSizes))
+	for _, size := range thumbnailSizes {
+		img.Thumbnails[size.name] = is.store.URL(thumbnailKey(img, size.name))
+	}
+	return img
+}
+
+func originalKey(img models.ProductImage) string {
+	return fmt.Sprintf("products/%s/%s/original.%s", img.ProductID, img.ID, extensions[img.ContentType])
+}
+
+func thumbnailKey(img models.ProductImage, size string) string {
+	return fmt.Sprintf("products/%s/%s/%s.%s", img.ProductID, img.ID, size, thumbnailExtension(img.ContentType))
+}
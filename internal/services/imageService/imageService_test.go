@@ -0,0 +1,274 @@
+package imageService
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/blobstore"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+func setupService(t *testing.T) (ImageServiceManager, *mocks.MockImageManager, *mocks.MockProductManager, string) {
+	ctrl := gomock.NewController(t)
+	root := t.TempDir()
+	store, err := blobstore.NewLocalStore(root, "http://shop.test/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockImageRepo := mocks.NewMockImageManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	return NewImageService(mockImageRepo, mockProductRepo, store), mockImageRepo, mockProductRepo, root
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decodeSize(t *testing.T, path string) (int, int) {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	cfg, _, err := image.DecodeConfig(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg.Width, cfg.Height
+}
+
+func TestUploadImage(t *testing.T) {
+	service, mockImageRepo, mockProductRepo, root := setupService(t)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil)
+	mockImageRepo.EXPECT().ListImages("p1").Return(nil, nil)
+	mockImageRepo.EXPECT().SaveImage(gomock.Any()).Return(nil)
+
+	img, err := service.UploadImage("p1", encodePNG(t, 2000, 500))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !img.Primary || img.Position != 0 || img.ContentType != "image/png" || img.Width != 2000 || img.Height != 500 {
+		t.Errorf("unexpected image: %+v", img)
+	}
+	dir := filepath.Join(root, "products", "p1", img.ID)
+	if img.URL != "http://shop.test/media/products/p1/"+img.ID+"/original.png" || len(img.Thumbnails) != 3 {
+		t.Errorf("unexpected urls: %s %v", img.URL, img.Thumbnails)
+	}
+	for name, want := range map[string][2]int{"large": {1024, 256}, "medium": {480, 120}, "small": {160, 40}} {
+		w, h := decodeSize(t, filepath.Join(dir, name+".png"))
+		if w != want[0] || h != want[1] {
+			t.Errorf("%s thumbnail is %dx%d, want %dx%d", name, w, h, want[0], want[1])
+		}
+	}
+}
+
+func TestUploadImageThumbnailFormats(t *testing.T) {
+	service, mockImageRepo, mockProductRepo, root := setupService(t)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil).AnyTimes()
+	mockImageRepo.EXPECT().ListImages("p1").Return([]models.ProductImage{{ID: "i0", Position: 4}}, nil).AnyTimes()
+	mockImageRepo.EXPECT().SaveImage(gomock.Any()).Return(nil).AnyTimes()
+
+	var jpg bytes.Buffer
+	jpeg.Encode(&jpg, image.NewRGBA(image.Rect(0, 0, 100, 300)), nil)
+	img, err := service.UploadImage("p1", jpg.Bytes())
+	if err != nil || img.Primary || img.Position != 5 || img.ContentType != "image/jpeg" {
+		t.Fatalf("unexpected image: %+v, err: %v", img, err)
+	}
+	// never enlarged
+	if w, h := decodeSize(t, filepath.Join(root, "products", "p1", img.ID, "large.jpg")); w != 100 || h != 300 {
+		t.Errorf("large thumbnail is %dx%d, want 100x300", w, h)
+	}
+	if w, h := decodeSize(t, filepath.Join(root, "products", "p1", img.ID, "small.jpg")); w != 53 || h != 160 {
+		t.Errorf("small thumbnail is %dx%d, want 53x160", w, h)
+	}
+
+	var animation bytes.Buffer
+	gif.Encode(&animation, image.NewPaletted(image.Rect(0, 0, 20, 20), color.Palette{color.Black, color.White}), nil)
+	img, err = service.UploadImage("p1", animation.Bytes())
+	if err != nil || img.ContentType != "image/gif" {
+		t.Fatalf("unexpected image: %+v, err: %v", img, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "products", "p1", img.ID, "medium.png")); err != nil {
+		t.Errorf("expected a png thumbnail of a gif: %v", err)
+	}
+}
+
+func TestUploadImageRejects(t *testing.T) {
+	service, mockImageRepo, mockProductRepo, root := setupService(t)
+
+	mockProductRepo.EXPECT().GetProductByID("404").Return(models.Product{}, sql.ErrNoRows)
+	if _, err := service.UploadImage("404", encodePNG(t, 10, 10)); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil).AnyTimes()
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"text", []byte("<html>not an image</html>"), ErrUnsupportedType},
+		{"truncated png", encodePNG(t, 10, 10)[:20], ErrInvalidImage},
+		{"too wide", encodePNG(t, 8001, 1), ErrImageDimensions},
+		{"too large", make([]byte, config.MaxImageUploadBytes+1), ErrImageTooLarge},
+	}
+	for _, tt := range tests {
+		if _, err := service.UploadImage("p1", tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+
+	full := make([]models.ProductImage, config.MaxImagesPerProduct)
+	mockImageRepo.EXPECT().ListImages("p1").Return(full, nil)
+	if _, err := service.UploadImage("p1", encodePNG(t, 10, 10)); !errors.Is(err, ErrTooManyImages) {
+		t.Errorf("expected ErrTooManyImages, got %v", err)
+	}
+
+	// blobs written before a failed save are removed again
+	mockImageRepo.EXPECT().ListImages("p1").Return(nil, nil)
+	mockImageRepo.EXPECT().SaveImage(gomock.Any()).Return(errors.New("db down"))
+	if _, err := service.UploadImage("p1", encodePNG(t, 10, 10)); err == nil {
+		t.Error("expected an error when the image can not be saved")
+	}
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Errorf("blobs left behind: %v", entries)
+	}
+}
+
+func TestReorderImages(t *testing.T) {
+	service, mockImageRepo, mockProductRepo, _ := setupService(t)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil).AnyTimes()
+	images := []models.ProductImage{{ID: "i1", ProductID: "p1"}, {ID: "i2", ProductID: "p1"}}
+	mockImageRepo.EXPECT().ListImages("p1").Return(images, nil).AnyTimes()
+
+	for _, order := range [][]string{{"i1"}, {"i1", "i1"}, {"i1", "i2", "i3"}, {"i1", "x"}} {
+		if _, err := service.ReorderImages("p1", order); !errors.Is(err, ErrInvalidImageOrder) {
+			t.Errorf("order %v: expected ErrInvalidImageOrder, got %v", order, err)
+		}
+	}
+
+	mockImageRepo.EXPECT().UpdatePositions("p1", []string{"i2", "i1"}).Return(nil)
+	if _, err := service.ReorderImages("p1", []string{"i2", "i1"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSetPrimaryImage(t *testing.T) {
+	service, mockImageRepo, mockProductRepo, _ := setupService(t)
+
+	mockImageRepo.EXPECT().GetImage("other").Return(models.ProductImage{ID: "other", ProductID: "p2"}, nil)
+	if _, err := service.SetPrimaryImage("p1", "other"); !errors.Is(err, ErrImageNotFound) {
+		t.Errorf("expected ErrImageNotFound, got %v", err)
+	}
+
+	mockImageRepo.EXPECT().GetImage("i2").Return(models.ProductImage{ID: "i2", ProductID: "p1"}, nil)
+	mockImageRepo.EXPECT().SetPrimary("p1", "i2").Return(nil)
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil)
+	mockImageRepo.EXPECT().ListImages("p1").Return([]models.ProductImage{{ID: "i2", ProductID: "p1", Primary: true}}, nil)
+	images, err := service.SetPrimaryImage("p1", "i2")
+	if err != nil || len(images) != 1 || !images[0].Primary {
+		t.Errorf("unexpected images: %+v, err: %v", images, err)
+	}
+}
+
+func TestDeleteImage(t *testing.T) {
+	service, mockImageRepo, mockProductRepo, root := setupService(t)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil)
+	mockImageRepo.EXPECT().ListImages("p1").Return(nil, nil)
+	mockImageRepo.EXPECT().SaveImage(gomock.Any()).Return(nil)
+	img, err := service.UploadImage("p1", encodePNG(t, 10, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockImageRepo.EXPECT().GetImage(img.ID).Return(img, nil)
+	mockImageRepo.EXPECT().DeleteImage(img.ID).Return(nil)
+	mockImageRepo.EXPECT().ListImages("p1").Return([]models.ProductImage{{ID: "i2", ProductID: "p1"}}, nil)
+	mockImageRepo.EXPECT().SetPrimary("p1", "i2").Return(nil)
+	if err := service.DeleteImage("p1", img.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Errorf("blobs left behind: %v", entries)
+	}
+
+	mockImageRepo.EXPECT().GetImage("404").Return(models.ProductImage{}, sql.ErrNoRows)
+	if err := service.DeleteImage("p1", "404"); !errors.Is(err, ErrImageNotFound) {
+		t.Errorf("expected ErrImageNotFound, got %v", err)
+	}
+}
+
+func TestImagesFor(t *testing.T) {
+	service, mockImageRepo, _, _ := setupService(t)
+
+	mockImageRepo.EXPECT().ListImagesForProducts([]string{"p1", "p2"}).
+		Return(map[string][]models.ProductImage{"p1": {{ID: "i1", ProductID: "p1", ContentType: "image/jpeg"}}}, nil)
+	images, err := service.ImagesFor([]string{"p1", "p2"})
+	if err != nil || images["p1"][0].Thumbnails["small"] != "http://shop.test/media/products/p1/i1/small.jpg" {
+		t.Errorf("unexpected images: %+v, err: %v", images, err)
+	}
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct{ width, height, maxSide, wantWidth, wantHeight int }{
+		{1200, 800, 480, 480, 320},
+		{800, 1200, 160, 107, 160},
+		{100, 300, 1024, 100, 300},
+		{5000, 1, 160, 160, 1},
+	}
+	for _, tt := range tests {
+		w, h := fit(tt.width, tt.height, tt.maxSide)
+		if w != tt.wantWidth || h != tt.wantHeight {
+			t.Errorf("fit(%d, %d, %d) = %dx%d, want %dx%d", tt.width, tt.height, tt.maxSide, w, h, tt.wantWidth, tt.wantHeight)
+		}
+	}
+}
+
+func TestShrink(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	// left half opaque white, right half transparent
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			src.Set(x, y, color.White)
+		}
+	}
+	dst := shrink(src, 2, 1)
+	if dst.Rect.Dx() != 2 || dst.Rect.Dy() != 1 {
+		t.Fatalf("unexpected size %v", dst.Rect)
+	}
+	if got := dst.RGBAAt(0, 0); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("left pixel = %v", got)
+	}
+	if got := dst.RGBAAt(1, 0); got != (color.RGBA{}) {
+		t.Errorf("right pixel = %v", got)
+	}
+	if shrink(src, 4, 2) != src {
+		t.Error("expected a small image to be kept as is")
+	}
+}
@@ -0,0 +1,15 @@
+package imageService
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_imageService.go -package mocks
+
+type ImageServiceManager interface {
+	UploadImage(productID string, data []byte) (models.ProductImage, error)
+	ListImages(productID string) ([]models.ProductImage, error)
+	ReorderImages(productID string, imageIDs []string) ([]models.ProductImage, error)
+	SetPrimaryImage(productID, imageID string) ([]models.ProductImage, error)
+	DeleteImage(productID, imageID string) error
+	ImagesFor(productIDs []string) (map[string][]models.ProductImage, error)
+	DeleteImageBlobs(images []models.ProductImage)
+}
@@ -0,0 +1,114 @@
+package imageService
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"mime"
+	"path"
+)
+
+// thumbnailSizes bound the longer side of each thumbnail, largest first so
+// every size can be shrunk from the one before it.
+var thumbnailSizes = []struct {
+	name    string
+	maxSide int
+}{
+	{"large", 1024},
+	{"medium", 480},
+	{"small", 160},
+}
+
+const thumbnailQuality = 85
+
+// thumbnailExtension keeps photos as JPEG; PNG and GIF sources may have
+// transparency, so their thumbnails are PNG.
+func thumbnailExtension(contentType string) string {
+	if contentType == "image/jpeg" {
+		return "jpg"
+	}
+	return "png"
+}
+
+func contentTypeOf(key string) string {
+	return mime.TypeByExtension(path.Ext(key))
+}
+
+// makeThumbnails encodes every thumbnail size of src, keyed by size name.
+// Images are never enlarged, so a small source gives thumbnails of its own
+// size.
+func makeThumbnails(src image.Image, contentType string) (map[string][]byte, error) {
+	bounds := src.Bounds()
+	current := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(current, current.Bounds(), src, bounds.Min, draw.Src)
+
+	thumbnails := make(map[string][]byte, len(thumbnailSizes))
+	for _, size := range thumbnailSizes {
+		width, height := fit(bounds.Dx(), bounds.Dy(), size.maxSide)
+		current = shrink(current, width, height)
+		var buf bytes.Buffer
+		var err error
+		if thumbnailExtension(contentType) == "jpg" {
+			err = jpeg.Encode(&buf, current, &jpeg.Options{Quality: thumbnailQuality})
+		} else {
+			err = png.Encode(&buf, current)
+		}
+		if err != nil {
+			return nil, err
+		}
+		thumbnails[size.name] = buf.Bytes()
+	}
+	return thumbnails, nil
+}
+
+// fit scales a width and height down, keeping the aspect ratio, until the
+// longer side is at most maxSide.
+func fit(width, height, maxSide int) (int, int) {
+	if width <= maxSide && height <= maxSide {
+		return width, height
+	}
+	if width >= height {
+		return maxSide, max(1, (height*maxSide+width/2)/width)
+	}
+	return max(1, (width*maxSide+height/2)/height), maxSide
+}
+
+// shrink scales src down to dw by dh with a box filter. Each output pixel is
+// the average of the source pixels it covers; averaging premultiplied colours
+// keeps transparent edges clean.
+func shrink(src *image.RGBA, dw, dh int) *image.RGBA {
+	sw, sh := src.Rect.Dx(), src.Rect.Dy()
+	if dw >= sw && dh >= sh {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0 := y * sh / dh
+		y1 := max(y0+1, (y+1)*sh/dh)
+		for x := 0; x < dw; x++ {
+			x0 := x * sw / dw
+			x1 := max(x0+1, (x+1)*sw/dw)
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(src.Rect.Min.X+x0, src.Rect.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(src.Pix[i])
+					g += int(src.Pix[i+1])
+					b += int(src.Pix[i+2])
+					a += int(src.Pix[i+3])
+					i += 4
+					n++
+				}
+			}
+			j := dst.PixOffset(x, y)
+			dst.Pix[j] = uint8((r + n/2) / n)
+			dst.Pix[j+1] = uint8((g + n/2) / n)
+			dst.Pix[j+2] = uint8((b + n/2) / n)
+			dst.Pix[j+3] = uint8((a + n/2) / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,14 @@
+package inventoryService
+
+import (
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_inventoryService.go -package mocks
+
+type InventoryServiceManager interface {
+	ListStockMovements(productID string, filter models.StockMovementFilter) (dto.StockMovementListDTO, error)
+	AdjustStock(adminID, productID string, req dto.StockAdjustmentDTO) (models.StockMovement, error)
+	ReconcileStock() error
+}
@@ -0,0 +1,168 @@
+package inventoryService
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/inventoryRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/variantRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var (
+	ErrProductNotFound   = errors.New("product not found")
+	ErrVariantNotFound   = errors.New("variant not found for this product")
+	ErrVariantRequired   = errors.New("this product has variants, choose the variant to adjust")
+	ErrInsufficientStock = errors.New("the adjustment would take stock below zero")
+)
+
+const maxNoteLen = 500
+
+type InventoryService struct {
+	inventoryRepo inventoryRepository.InventoryManager
+	productRepo   productRepository.ProductManager
+	variantRepo   variantRepository.VariantManager
+}
+
+func NewInventoryService(inventoryRepo inventoryRepository.InventoryManager, productRepo productRepository.ProductManager, variantRepo variantRepository.VariantManager) InventoryServiceManager {
+	return &InventoryService{inventoryRepo: inventoryRepo, productRepo: productRepo, variantRepo: variantRepo}
+}
+
+func (is *InventoryService) ListStockMovements(productID string, filter models.StockMovementFilter) (dto.StockMovementListDTO, error) {
+	_, err := is.productRepo.GetProductByID(productID)
+	if err != nil {
+		return dto.StockMovementListDTO{}, ErrProductNotFound
+	}
+	if filter.VariantID != "" {
+		err = is.checkVariant(productID, filter.VariantID)
+		if err != nil {
+			return dto.StockMovementListDTO{}, err
+		}
+	}
+	movements, total, err := is.inventoryRepo.ListMovements(productID, filter)
+	if err != nil {
+		return dto.StockMovementListDTO{}, fmt.Errorf("can not list stock movements: %v", err)
+	}
+	list := dto.StockMovementListDTO{
+		Movements: movements,
+		Total:     total,
+		Page:      1,
+		Limit:     filter.Limit,
+	}
+	if list.Movements == nil {
+		list.Movements = []models.StockMovement{}
+	}
+	if filter.Limit > 0 {
+		list.Page = filter.Offset/filter.Limit + 1
+	}
+	return list, nil
+}
+
+// AdjustStock adds req.Delta to the stock of the product, or of the variant
+// when the product has variants, and returns the ledger entry.
+func (is *InventoryService) AdjustStock(adminID, productID string, req dto.StockAdjustmentDTO) (models.StockMovement, error) {
+	if req.Delta == 0 {
+		return models.StockMovement{}, fmt.Errorf("delta must not be zero")
+	}
+	reason, err := models.ParseAdjustmentReason(req.Reason)
+	if err != nil {
+		return models.StockMovement{}, err
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxNoteLen {
+		return models.StockMovement{}, fmt.Errorf("note can be at most %d characters", maxNoteLen)
+	}
+	_, err = is.productRepo.GetProductByID(productID)
+	if err != nil {
+		return models.StockMovement{}, ErrProductNotFound
+	}
+	if req.VariantID != "" {
+		err = is.checkVariant(productID, req.VariantID)
+		if err != nil {
+			return models.StockMovement{}, err
+		}
+	} else {
+		variants, err := is.variantRepo.ListVariants(productID)
+		if err != nil {
+			return models.StockMovement{}, fmt.Errorf("can not fetch variants: %v", err)
+		}
+		// variants hold their own stock and the product's is not sold
+		if len(variants) > 0 {
+			return models.StockMovement{}, ErrVariantRequired
+		}
+	}
+
+	recorded, err := is.inventoryRepo.MoveStock([]models.StockMovement{{
+		ID:        utils.NewUUID(),
+		ProductID: productID,
+		VariantID: req.VariantID,
+		Delta:     req.Delta,
+		Reason:    reason,
+		ActorID:   adminID,
+		Note:      note,
+		CreatedAt: time.Now().UTC(),
+	}})
+	var insufficient *inventoryRepository.InsufficientStockError
+	if errors.As(err, &insufficient) {
+		return models.StockMovement{}, ErrInsufficientStock
+	}
+	if err != nil {
+		return models.StockMovement{}, fmt.Errorf("can not adjust stock: %v", err)
+	}
+	return recorded[0], nil
+}
+
+// ReconcileStock brings the ledger in line with the stock columns, which are
+// never changed. Stock from before the ledger existed is recorded as an
+// opening entry; any other difference is recorded as a reconciliation entry
+// and logged, so it stays visible in the product's history.
+func (is *InventoryService) ReconcileStock() error {
+	drifts, err := is.inventoryRepo.StockDrift()
+	if err != nil {
+		return fmt.Errorf("can not compare stock with the ledger: %v", err)
+	}
+	var errs []error
+	for _, drift := range drifts {
+		item := "product " + drift.ProductID
+		if drift.VariantID != "" {
+			item += " variant " + drift.VariantID
+		}
+		movement := models.StockMovement{
+			ID:        utils.NewUUID(),
+			ProductID: drift.ProductID,
+			VariantID: drift.VariantID,
+			Delta:     drift.Stock - drift.LedgerStock,
+			Balance:   drift.Stock,
+			Reason:    models.StockOpening,
+			CreatedAt: time.Now().UTC(),
+		}
+		if drift.Entries > 0 {
+			movement.Reason = models.StockReconciliation
+			movement.Note = fmt.Sprintf("stock was %d, the ledger had %d", drift.Stock, drift.LedgerStock)
+		}
+		err = is.inventoryRepo.RecordBalance(movement)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("can not record the stock of %s: %v", item, err))
+			continue
+		}
+		if drift.Entries > 0 {
+			log.Printf("stock of %s was %d but the ledger had %d, recorded the difference", item, drift.Stock, drift.LedgerStock)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (is *InventoryService) checkVariant(productID, variantID string) error {
+	variant, err := is.variantRepo.GetVariant(variantID)
+	if err != nil || variant.ProductID != productID {
+		return ErrVariantNotFound
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package inventoryService
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/inventoryRepository"
+	"go.uber.org/mock/gomock"
+)
+
+func setup(t *testing.T) (*mocks.MockInventoryManager, *mocks.MockProductManager, *mocks.MockVariantManager, InventoryServiceManager) {
+	ctrl := gomock.NewController(t)
+	mockInventoryRepo := mocks.NewMockInventoryManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	return mockInventoryRepo, mockProductRepo, mockVariantRepo, NewInventoryService(mockInventoryRepo, mockProductRepo, mockVariantRepo)
+}
+
+func TestListStockMovements(t *testing.T) {
+	mockInventoryRepo, mockProductRepo, mockVariantRepo, service := setup(t)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil).Times(2)
+	filter := models.StockMovementFilter{Limit: 20, Offset: 40}
+	mockInventoryRepo.EXPECT().ListMovements("p1", filter).Return(nil, 41, nil)
+
+	list, err := service.ListStockMovements("p1", filter)
+	if err != nil || list.Movements == nil || list.Total != 41 || list.Page != 3 || list.Limit != 20 {
+		t.Errorf("unexpected list: %+v, err: %v", list, err)
+	}
+
+	mockVariantRepo.EXPECT().GetVariant("v9").Return(models.Variant{ID: "v9", ProductID: "p2"}, nil)
+	_, err = service.ListStockMovements("p1", models.StockMovementFilter{VariantID: "v9", Limit: 20})
+	if !errors.Is(err, ErrVariantNotFound) {
+		t.Errorf("expected ErrVariantNotFound for another product's variant, got %v", err)
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("404").Return(models.Product{}, sql.ErrNoRows)
+	_, err = service.ListStockMovements("404", filter)
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestAdjustStock(t *testing.T) {
+	mockInventoryRepo, mockProductRepo, mockVariantRepo, service := setup(t)
+
+	for _, req := range []dto.StockAdjustmentDTO{
+		{Delta: 0},
+		{Delta: 5, Reason: "sale"},
+		{Delta: 5, Note: strings.Repeat("a", maxNoteLen+1)},
+	} {
+		if _, err := service.AdjustStock("admin1", "p1", req); err == nil {
+			t.Errorf("expected an error for %+v", req)
+		}
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil).AnyTimes()
+	mockVariantRepo.EXPECT().ListVariants("p1").Return(nil, nil).Times(2)
+	mockInventoryRepo.EXPECT().MoveStock(gomock.Any()).DoAndReturn(func(movements []models.StockMovement) ([]models.StockMovement, error) {
+		movement := movements[0]
+		if movement.ProductID != "p1" || movement.Delta != -2 || movement.Reason != models.StockReturn ||
+			movement.ActorID != "admin1" || movement.Note != "damaged in transit" {
+			t.Errorf("unexpected movement: %+v", movement)
+		}
+		movement.Balance = 8
+		return []models.StockMovement{movement}, nil
+	})
+	movement, err := service.AdjustStock("admin1", "p1", dto.StockAdjustmentDTO{Delta: -2, Reason: "return", Note: " damaged in transit "})
+	if err != nil || movement.Balance != 8 {
+		t.Errorf("unexpected movement: %+v, err: %v", movement, err)
+	}
+
+	mockInventoryRepo.EXPECT().MoveStock(gomock.Any()).DoAndReturn(func(movements []models.StockMovement) ([]models.StockMovement, error) {
+		return nil, &inventoryRepository.InsufficientStockError{Movement: movements[0]}
+	})
+	_, err = service.AdjustStock("admin1", "p1", dto.StockAdjustmentDTO{Delta: -100})
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("expected ErrInsufficientStock, got %v", err)
+	}
+}
+
+func TestAdjustVariantStock(t *testing.T) {
+	mockInventoryRepo, mockProductRepo, mockVariantRepo, service := setup(t)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil).AnyTimes()
+	mockVariantRepo.EXPECT().ListVariants("p1").Return([]models.Variant{{ID: "v1", ProductID: "p1"}}, nil)
+	_, err := service.AdjustStock("admin1", "p1", dto.StockAdjustmentDTO{Delta: 3})
+	if !errors.Is(err, ErrVariantRequired) {
+		t.Errorf("expected ErrVariantRequired, got %v", err)
+	}
+
+	mockVariantRepo.EXPECT().GetVariant("v1").Return(models.Variant{ID: "v1", ProductID: "p1"}, nil)
+	mockInventoryRepo.EXPECT().MoveStock(gomock.Any()).DoAndReturn(func(movements []models.StockMovement) ([]models.StockMovement, error) {
+		if movements[0].VariantID != "v1" || movements[0].Reason != models.StockAdjustment {
+			t.Errorf("unexpected movement: %+v", movements[0])
+		}
+		return movements, nil
+	})
+	_, err = service.AdjustStock("admin1", "p1", dto.StockAdjustmentDTO{VariantID: "v1", Delta: 3})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestReconcileStock(t *testing.T) {
+	mockInventoryRepo, _, _, service := setup(t)
+
+	mockInventoryRepo.EXPECT().StockDrift().Return([]models.StockDrift{
+		{ProductID: "p1", Stock: 10},
+		{ProductID: "p2", VariantID: "v1", Stock: 7, LedgerStock: 4, Entries: 3},
+		{ProductID: "p3", Stock: 0, LedgerStock: -2, Entries: 2},
+		{ProductID: "p4", Stock: 1, LedgerStock: 3, Entries: 1},
+	}, nil)
+	var recorded []models.StockMovement
+	mockInventoryRepo.EXPECT().RecordBalance(gomock.Any()).DoAndReturn(func(movement models.StockMovement) error {
+		recorded = append(recorded, movement)
+		if movement.ProductID == "p4" {
+			return errors.New("db error")
+		}
+		return nil
+	}).Times(4)
+
+	// a failing entry does not keep the others from being recorded
+	err := service.ReconcileStock()
+	if err == nil {
+		t.Error("expected the failed entry to be reported")
+	}
+	if len(recorded) != 4 {
+		t.Fatalf("expected 4 entries, got %+v", recorded)
+	}
+	if m := recorded[0]; m.ProductID != "p1" || m.Delta != 10 || m.Balance != 10 || m.Reason != models.StockOpening {
+		t.Errorf("unexpected opening balance: %+v", m)
+	}
+	// drifted stock is kept and the ledger catches up with it
+	if m := recorded[1]; m.VariantID != "v1" || m.Delta != 3 || m.Balance != 7 || m.Reason != models.StockReconciliation || m.Note == "" {
+		t.Errorf("unexpected reconciliation: %+v", m)
+	}
+	if m := recorded[2]; m.Delta != 2 || m.Balance != 0 || m.Reason != models.StockReconciliation {
+		t.Errorf("unexpected reconciliation of a negative ledger: %+v", m)
+	}
+}
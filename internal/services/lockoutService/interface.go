@@ -0,0 +1,10 @@
+package lockoutService
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_lockoutService.go -package mocks
+
+type LockoutServiceManager interface {
+	Check(email, ip string) error
+	RecordFailure(email, ip string) error
+	RecordSuccess(email string) error
+	Unlock(adminID, userID string) error
+}
@@ -0,0 +1,202 @@
+package lockoutService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/auditRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/loginAttemptRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+// LockedError is returned while an account or address is backing off or
+// locked out.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, try again in %v", e.RetryAfter)
+}
+
+type policy struct {
+	prefix           string
+	freeAttempts     int
+	lockoutThreshold int
+	auditEvent       string
+}
+
+type LockoutService struct {
+	attemptRepo loginAttemptRepository.LoginAttemptManager
+	auditRepo   auditRepository.AuditManager
+	userRepo    userRepository.UserManager
+	now         func() time.Time
+}
+
+func NewLockoutService(attemptRepo loginAttemptRepository.LoginAttemptManager, auditRepo auditRepository.AuditManager, userRepo userRepository.UserManager) LockoutServiceManager {
+	return &LockoutService{
+		attemptRepo: attemptRepo,
+		auditRepo:   auditRepo,
+		userRepo:    userRepo,
+		now:         time.Now,
+	}
+}
+
+func accountPolicy() policy {
+	return policy{"account:", config.AccountFreeAttempts, config.AccountLockoutThreshold, models.AuditAccountLocked}
+}
+
+func ipPolicy() policy {
+	return policy{"ip:", config.IPFreeAttempts, config.IPLockoutThreshold, models.AuditIPLocked}
+}
+
+func accountKey(email string) string {
+	return accountPolicy().prefix + strings.ToLower(strings.TrimSpace(email))
+}
+
+func (ls *LockoutService) Check(email, ip string) error {
+	now := ls.now()
+	keys := []string{accountKey(email)}
+	if ip != "" {
+		keys = append(keys, ipPolicy().prefix+ip)
+	}
+	for _, key := range keys {
+		attempt, err := ls.attemptRepo.GetLoginAttempt(key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("can not check login attempts: %v", err)
+		}
+		if attempt.IsLocked(now) {
+			retry := attempt.LockedUntil.Sub(now).Round(time.Second)
+			if retry < time.Second {
+				retry = time.Second
+			}
+			return &LockedError{RetryAfter: retry}
+		}
+	}
+	return nil
+}
+
+func (ls *LockoutService) RecordFailure(email, ip string) error {
+	err := ls.recordFailure(accountPolicy(), accountKey(email), email, ip)
+	if err != nil {
+		return err
+	}
+	if ip == "" {
+		return nil
+	}
+	return ls.recordFailure(ipPolicy(), ipPolicy().prefix+ip, email, ip)
+}
+
+func (ls *LockoutService) recordFailure(p policy, key, email, ip string) error {
+	now := ls.now()
+	attempt, err := ls.attemptRepo.GetLoginAttempt(key)
+	if errors.Is(err, sql.ErrNoRows) {
+		attempt = models.LoginAttempt{Key: key}
+	} else if err != nil {
+		return fmt.Errorf("can not load login attempts: %v", err)
+	}
+	if now.Sub(attempt.LastFailureAt) > config.LoginAttemptWindow {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	attempt.LockedUntil = nil
+
+	if attempt.Failures >= p.lockoutThreshold {
+		lockedUntil := now.Add(config.LoginLockoutDuration)
+		attempt.LockedUntil = &lockedUntil
+		detail := fmt.Sprintf("%d failed attempts, locked until %s", attempt.Failures, lockedUntil.Format(time.RFC3339))
+		userID := ""
+		if p.prefix == accountPolicy().prefix {
+			userID = ls.userIDForEmail(email)
+			if userID == "" {
+				detail = key + ": " + detail
+			}
+		}
+		ls.audit(p.auditEvent, userID, "", ip, detail)
+	} else if attempt.Failures > p.freeAttempts {
+		lockedUntil := now.Add(backoff(attempt.Failures - p.freeAttempts))
+		attempt.LockedUntil = &lockedUntil
+	}
+
+	err = ls.attemptRepo.SaveLoginAttempt(attempt)
+	if err != nil {
+		return fmt.Errorf("can not save login attempts: %v", err)
+	}
+	return nil
+}
+
+// backoff doubles from LoginBackoffBase for every failure past the free ones,
+// capped at the lockout duration.
+func backoff(extraFailures int) time.Duration {
+	factor := math.Pow(2, float64(extraFailures-1))
+	delay := time.Duration(float64(config.LoginBackoffBase) * factor)
+	if delay <= 0 || delay > config.LoginLockoutDuration {
+		return config.LoginLockoutDuration
+	}
+	return delay
+}
+
+// RecordSuccess clears the account counter. The address counter is left alone
+// so an attacker can not reset it by logging into their own account.
+func (ls *LockoutService) RecordSuccess(email string) error {
+	err := ls.attemptRepo.DeleteLoginAttempt(accountKey(email))
+	if err != nil {
+		return fmt.Errorf("can not reset login attempts: %v", err)
+	}
+	err = ls.attemptRepo.DeleteStaleLoginAttempts(ls.now().Add(-config.LoginAttemptWindow))
+	if err != nil {
+		return fmt.Errorf("can not clean up login attempts: %v", err)
+	}
+	return nil
+}
+
+func (ls *LockoutService) Unlock(adminID, userID string) error {
+	user, err := ls.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	err = ls.attemptRepo.DeleteLoginAttempt(accountKey(user.Email))
+	if err != nil {
+		return fmt.Errorf("can not unlock account: %v", err)
+	}
+	ls.audit(models.AuditAccountUnlocked, user.ID, adminID, "", "unlocked by admin")
+	return nil
+}
+
+func (ls *LockoutService) userIDForEmail(email string) string {
+	user, err := ls.userRepo.GetUserByEmail(strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return ""
+	}
+	return user.ID
+}
+
+// audit records an entry on a best-effort basis; a failed write must not turn
+// into a login error.
+func (ls *LockoutService) audit(event, userID, actorID, ip, detail string) {
+	entry := models.AuditEntry{
+		ID:        utils.NewUUID(),
+		Event:     event,
+		UserID:    userID,
+		ActorID:   actorID,
+		IP:        ip,
+		Detail:    detail,
+		CreatedAt: ls.now(),
+	}
+	err := ls.auditRepo.SaveAuditEntry(entry)
+	if err != nil {
+		log.Printf("can not write audit entry %s: %v", event, err)
+	}
+}
@@ -0,0 +1,170 @@
+package lockoutService
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+func setupService(t *testing.T) (*LockoutService, *mocks.MockLoginAttemptManager, *mocks.MockAuditManager, *mocks.MockUserManager) {
+	ctrl := gomock.NewController(t)
+	mockAttemptRepo := mocks.NewMockLoginAttemptManager(ctrl)
+	mockAuditRepo := mocks.NewMockAuditManager(ctrl)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	fixedNow := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &LockoutService{
+		attemptRepo: mockAttemptRepo,
+		auditRepo:   mockAuditRepo,
+		userRepo:    mockUserRepo,
+		now:         func() time.Time { return fixedNow },
+	}
+	return service, mockAttemptRepo, mockAuditRepo, mockUserRepo
+}
+
+func TestCheck(t *testing.T) {
+	service, mockAttemptRepo, _, _ := setupService(t)
+	now := service.now()
+	lockedUntil := now.Add(90 * time.Second)
+
+	t.Run("No attempts", func(t *testing.T) {
+		mockAttemptRepo.EXPECT().GetLoginAttempt("account:user@example.com").Return(models.LoginAttempt{}, sql.ErrNoRows)
+		mockAttemptRepo.EXPECT().GetLoginAttempt("ip:1.2.3.4").Return(models.LoginAttempt{}, sql.ErrNoRows)
+
+		if err := service.Check("User@Example.com", "1.2.3.4"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Address locked", func(t *testing.T) {
+		mockAttemptRepo.EXPECT().GetLoginAttempt("account:user@example.com").Return(models.LoginAttempt{}, sql.ErrNoRows)
+		mockAttemptRepo.EXPECT().GetLoginAttempt("ip:1.2.3.4").Return(models.LoginAttempt{Key: "ip:1.2.3.4", LockedUntil: &lockedUntil}, nil)
+
+		err := service.Check("user@example.com", "1.2.3.4")
+		var locked *LockedError
+		if !errors.As(err, &locked) || locked.RetryAfter != 90*time.Second {
+			t.Errorf("expected LockedError with 90s, got %v", err)
+		}
+	})
+
+	t.Run("Lock expired", func(t *testing.T) {
+		expired := now.Add(-time.Second)
+		mockAttemptRepo.EXPECT().GetLoginAttempt("account:user@example.com").Return(models.LoginAttempt{LockedUntil: &expired}, nil)
+
+		if err := service.Check("user@example.com", ""); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestRecordFailure(t *testing.T) {
+	service, mockAttemptRepo, mockAuditRepo, mockUserRepo := setupService(t)
+	now := service.now()
+
+	t.Run("Free attempt", func(t *testing.T) {
+		mockAttemptRepo.EXPECT().GetLoginAttempt("account:user@example.com").Return(models.LoginAttempt{}, sql.ErrNoRows)
+		mockAttemptRepo.EXPECT().SaveLoginAttempt(models.LoginAttempt{Key: "account:user@example.com", Failures: 1, LastFailureAt: now}).Return(nil)
+
+		if err := service.RecordFailure("user@example.com", ""); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Backoff doubles", func(t *testing.T) {
+		previous := models.LoginAttempt{Key: "account:user@example.com", Failures: config.AccountFreeAttempts + 1, LastFailureAt: now.Add(-time.Minute)}
+		mockAttemptRepo.EXPECT().GetLoginAttempt("account:user@example.com").Return(previous, nil)
+		mockAttemptRepo.EXPECT().SaveLoginAttempt(gomock.Any()).DoAndReturn(func(attempt models.LoginAttempt) error {
+			if attempt.LockedUntil == nil || attempt.LockedUntil.Sub(now) != 2*config.LoginBackoffBase {
+				t.Errorf("expected backoff of %v, got %+v", 2*config.LoginBackoffBase, attempt)
+			}
+			return nil
+		})
+
+		if err := service.RecordFailure("user@example.com", ""); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Old failures are forgotten", func(t *testing.T) {
+		previous := models.LoginAttempt{Key: "ip:1.2.3.4", Failures: 40, LastFailureAt: now.Add(-2 * config.LoginAttemptWindow)}
+		mockAttemptRepo.EXPECT().GetLoginAttempt("account:user@example.com").Return(models.LoginAttempt{}, sql.ErrNoRows)
+		mockAttemptRepo.EXPECT().SaveLoginAttempt(gomock.Any()).Return(nil)
+		mockAttemptRepo.EXPECT().GetLoginAttempt("ip:1.2.3.4").Return(previous, nil)
+		mockAttemptRepo.EXPECT().SaveLoginAttempt(models.LoginAttempt{Key: "ip:1.2.3.4", Failures: 1, LastFailureAt: now}).Return(nil)
+
+		if err := service.RecordFailure("user@example.com", "1.2.3.4"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Threshold locks and audits", func(t *testing.T) {
+		previous := models.LoginAttempt{Key: "account:user@example.com", Failures: config.AccountLockoutThreshold - 1, LastFailureAt: now.Add(-time.Minute)}
+		mockAttemptRepo.EXPECT().GetLoginAttempt("account:user@example.com").Return(previous, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(models.User{ID: "u1"}, nil)
+		mockAuditRepo.EXPECT().SaveAuditEntry(gomock.Any()).DoAndReturn(func(entry models.AuditEntry) error {
+			if entry.Event != models.AuditAccountLocked || entry.UserID != "u1" || entry.IP != "1.2.3.4" {
+				t.Errorf("unexpected audit entry: %+v", entry)
+			}
+			return nil
+		})
+		mockAttemptRepo.EXPECT().SaveLoginAttempt(gomock.Any()).DoAndReturn(func(attempt models.LoginAttempt) error {
+			if !attempt.IsLocked(now.Add(config.LoginLockoutDuration - time.Second)) {
+				t.Errorf("expected lockout, got %+v", attempt)
+			}
+			return nil
+		})
+		mockAttemptRepo.EXPECT().GetLoginAttempt("ip:1.2.3.4").Return(models.LoginAttempt{}, sql.ErrNoRows)
+		mockAttemptRepo.EXPECT().SaveLoginAttempt(gomock.Any()).Return(nil)
+
+		if err := service.RecordFailure("user@example.com", "1.2.3.4"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	if backoff(1) != config.LoginBackoffBase || backoff(3) != 4*config.LoginBackoffBase {
+		t.Errorf("unexpected backoff: %v, %v", backoff(1), backoff(3))
+	}
+	if backoff(100) != config.LoginLockoutDuration {
+		t.Errorf("expected backoff to be capped, got %v", backoff(100))
+	}
+}
+
+func TestRecordSuccess(t *testing.T) {
+	service, mockAttemptRepo, _, _ := setupService(t)
+
+	mockAttemptRepo.EXPECT().DeleteLoginAttempt("account:user@example.com").Return(nil)
+	mockAttemptRepo.EXPECT().DeleteStaleLoginAttempts(service.now().Add(-config.LoginAttemptWindow)).Return(nil)
+
+	if err := service.RecordSuccess("user@example.com"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	service, mockAttemptRepo, mockAuditRepo, mockUserRepo := setupService(t)
+
+	mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Email: "user@example.com"}, nil)
+	mockAttemptRepo.EXPECT().DeleteLoginAttempt("account:user@example.com").Return(nil)
+	mockAuditRepo.EXPECT().SaveAuditEntry(gomock.Any()).DoAndReturn(func(entry models.AuditEntry) error {
+		if entry.Event != models.AuditAccountUnlocked || entry.ActorID != "admin" || entry.UserID != "u1" {
+			t.Errorf("unexpected audit entry: %+v", entry)
+		}
+		return nil
+	})
+
+	if err := service.Unlock("admin", "u1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mockUserRepo.EXPECT().GetUserByID("missing").Return(models.User{}, sql.ErrNoRows)
+	if err := service.Unlock("admin", "missing"); err == nil {
+		t.Error("expected error for unknown user")
+	}
+}
@@ -0,0 +1,18 @@
+package mfaService
+
+import (
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_mfaService.go -package mocks
+
+type MFAServiceManager interface {
+	IsEnabled(userID string) (bool, error)
+	IsRequired(user models.User) (bool, error)
+	BeginEnrollment(userID string) (dto.MFAEnrollmentDTO, error)
+	ConfirmEnrollment(userID, code string) ([]string, error)
+	VerifyCode(userID, code string) error
+	RegenerateRecoveryCodes(userID, code string) ([]string, error)
+	Disable(userID, code string) error
+}
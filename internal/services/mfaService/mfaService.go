@@ -0,0 +1,216 @@
+package mfaService
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/mfaRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authzService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/totp"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var (
+	ErrInvalidMFACode = errors.New("invalid authentication code")
+	ErrMFARequired    = errors.New("two-factor authentication is mandatory for this account")
+)
+
+const recoveryCodeCount = 10
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type MFAService struct {
+	mfaRepo   mfaRepository.MFAManager
+	userRepo  userRepository.UserManager
+	authzServ authzService.AuthzServiceManager
+	now       func() time.Time
+}
+
+func NewMFAService(mfaRepo mfaRepository.MFAManager, userRepo userRepository.UserManager, authzServ authzService.AuthzServiceManager) MFAServiceManager {
+	return &MFAService{
+		mfaRepo:   mfaRepo,
+		userRepo:  userRepo,
+		authzServ: authzServ,
+		now:       time.Now,
+	}
+}
+
+func (ms *MFAService) IsEnabled(userID string) (bool, error) {
+	mfa, err := ms.mfaRepo.GetMFAByUserID(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can not load two-factor settings: %v", err)
+	}
+	return mfa.IsEnabled(), nil
+}
+
+// IsRequired reports whether the user has to use two-factor authentication.
+// It is required of every account with a staff permission, whether it comes
+// from the admin role or from an assigned role.
+func (ms *MFAService) IsRequired(user models.User) (bool, error) {
+	if !config.RequireAdminMFA {
+		return false, nil
+	}
+	return ms.authzServ.IsStaff(user.ID, user.Role)
+}
+
+// BeginEnrollment stores a fresh secret that only becomes active once a code
+// generated from it is confirmed.
+func (ms *MFAService) BeginEnrollment(userID string) (dto.MFAEnrollmentDTO, error) {
+	user, err := ms.userRepo.GetUserByID(userID)
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, fmt.Errorf("user not found")
+	}
+	enabled, err := ms.IsEnabled(userID)
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, err
+	}
+	if enabled {
+		return dto.MFAEnrollmentDTO{}, fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, fmt.Errorf("can not generate secret: %v", err)
+	}
+	err = ms.mfaRepo.SaveMFASecret(userID, secret)
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, fmt.Errorf("can not save secret: %v", err)
+	}
+	uri := totp.URI(config.MFAIssuer, user.Email, secret)
+	return dto.MFAEnrollmentDTO{Secret: secret, OTPAuthURI: uri, QRPayload: uri}, nil
+}
+
+func (ms *MFAService) ConfirmEnrollment(userID, code string) ([]string, error) {
+	mfa, err := ms.mfaRepo.GetMFAByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("no pending two-factor enrolment")
+	}
+	if mfa.IsEnabled() {
+		return nil, fmt.Errorf("two-factor authentication is already enabled")
+	}
+	err = ms.useTOTP(mfa, normalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := ms.newRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	err = ms.mfaRepo.EnableMFA(userID, ms.now())
+	if err != nil {
+		return nil, fmt.Errorf("can not enable two-factor authentication: %v", err)
+	}
+	return codes, nil
+}
+
+// VerifyCode accepts either a current TOTP code or an unused recovery code.
+func (ms *MFAService) VerifyCode(userID, code string) error {
+	mfa, err := ms.mfaRepo.GetMFAByUserID(userID)
+	if err != nil || !mfa.IsEnabled() {
+		return fmt.Errorf("two-factor authentication is not enabled")
+	}
+	code = normalizeCode(code)
+	if isTOTPCode(code) {
+		return ms.useTOTP(mfa, code)
+	}
+	err = ms.mfaRepo.UseRecoveryCode(userID, utils.HashToken(code), ms.now())
+	if err != nil {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (ms *MFAService) RegenerateRecoveryCodes(userID, code string) ([]string, error) {
+	err := ms.VerifyCode(userID, code)
+	if err != nil {
+		return nil, err
+	}
+	return ms.newRecoveryCodes(userID)
+}
+
+func (ms *MFAService) Disable(userID, code string) error {
+	user, err := ms.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	required, err := ms.IsRequired(user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrMFARequired
+	}
+	err = ms.VerifyCode(userID, code)
+	if err != nil {
+		return err
+	}
+	err = ms.mfaRepo.DeleteMFA(userID)
+	if err != nil {
+		return fmt.Errorf("can not disable two-factor authentication: %v", err)
+	}
+	return nil
+}
+
+// useTOTP checks the code and records its time step so it can not be replayed.
+func (ms *MFAService) useTOTP(mfa models.UserMFA, code string) error {
+	step, ok := totp.Validate(mfa.Secret, code, ms.now())
+	if !ok || step <= mfa.LastUsedStep {
+		return ErrInvalidMFACode
+	}
+	err := ms.mfaRepo.UpdateLastUsedStep(mfa.UserID, step)
+	if err != nil {
+		return ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (ms *MFAService) newRecoveryCodes(userID string) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		raw := make([]byte, 6)
+		_, err := rand.Read(raw)
+		if err != nil {
+			return nil, fmt.Errorf("can not generate recovery codes: %v", err)
+		}
+		code := strings.ToLower(recoveryEncoding.EncodeToString(raw))
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = utils.HashToken(code)
+	}
+	err := ms.mfaRepo.ReplaceRecoveryCodes(userID, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("can not save recovery codes: %v", err)
+	}
+	return codes, nil
+}
+
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
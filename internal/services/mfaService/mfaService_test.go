@@ -0,0 +1,194 @@
+package mfaService
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/totp"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+const testSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func setupService(t *testing.T) (*MFAService, *mocks.MockMFAManager, *mocks.MockUserManager) {
+	ctrl := gomock.NewController(t)
+	mockMFARepo := mocks.NewMockMFAManager(ctrl)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	fixedNow := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &MFAService{
+		mfaRepo:  mockMFARepo,
+		userRepo: mockUserRepo,
+		now:      func() time.Time { return fixedNow },
+	}
+	return service, mockMFARepo, mockUserRepo
+}
+
+func TestIsEnabled(t *testing.T) {
+	service, mockMFARepo, _ := setupService(t)
+	now := service.now()
+
+	mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(models.UserMFA{}, sql.ErrNoRows)
+	enabled, err := service.IsEnabled("u1")
+	if err != nil || enabled {
+		t.Errorf("expected disabled without error, got %v, %v", enabled, err)
+	}
+
+	mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(models.UserMFA{UserID: "u1", EnabledAt: &now}, nil)
+	enabled, err = service.IsEnabled("u1")
+	if err != nil || !enabled {
+		t.Errorf("expected enabled, got %v, %v", enabled, err)
+	}
+}
+
+func TestBeginEnrollment(t *testing.T) {
+	service, mockMFARepo, mockUserRepo := setupService(t)
+
+	mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Email: "user@example.com"}, nil)
+	mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(models.UserMFA{}, sql.ErrNoRows)
+	mockMFARepo.EXPECT().SaveMFASecret("u1", gomock.Any()).Return(nil)
+
+	enrollment, err := service.BeginEnrollment("u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enrollment.Secret == "" || enrollment.QRPayload != enrollment.OTPAuthURI ||
+		!strings.Contains(enrollment.OTPAuthURI, "secret="+enrollment.Secret) {
+		t.Errorf("unexpected enrollment: %+v", enrollment)
+	}
+}
+
+func TestConfirmEnrollment(t *testing.T) {
+	service, mockMFARepo, _ := setupService(t)
+	code, _ := totp.Code(testSecret, service.now())
+
+	t.Run("Wrong code", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(models.UserMFA{UserID: "u1", Secret: testSecret}, nil)
+
+		_, err := service.ConfirmEnrollment("u1", "000000")
+		if !errors.Is(err, ErrInvalidMFACode) {
+			t.Errorf("expected ErrInvalidMFACode, got %v", err)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(models.UserMFA{UserID: "u1", Secret: testSecret}, nil)
+		mockMFARepo.EXPECT().UpdateLastUsedStep("u1", totp.Step(service.now())).Return(nil)
+		mockMFARepo.EXPECT().ReplaceRecoveryCodes("u1", gomock.Len(recoveryCodeCount)).Return(nil)
+		mockMFARepo.EXPECT().EnableMFA("u1", service.now()).Return(nil)
+
+		codes, err := service.ConfirmEnrollment("u1", code)
+		if err != nil || len(codes) != recoveryCodeCount {
+			t.Errorf("unexpected result: %v, err: %v", codes, err)
+		}
+	})
+}
+
+func TestVerifyCode(t *testing.T) {
+	service, mockMFARepo, _ := setupService(t)
+	now := service.now()
+	code, _ := totp.Code(testSecret, now)
+	enabled := models.UserMFA{UserID: "u1", Secret: testSecret, EnabledAt: &now}
+
+	t.Run("Valid TOTP", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(enabled, nil)
+		mockMFARepo.EXPECT().UpdateLastUsedStep("u1", totp.Step(now)).Return(nil)
+
+		if err := service.VerifyCode("u1", code[:3]+" "+code[3:]); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Replayed TOTP", func(t *testing.T) {
+		used := enabled
+		used.LastUsedStep = totp.Step(now)
+		mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(used, nil)
+
+		if err := service.VerifyCode("u1", code); !errors.Is(err, ErrInvalidMFACode) {
+			t.Errorf("expected ErrInvalidMFACode, got %v", err)
+		}
+	})
+
+	t.Run("Recovery code", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(enabled, nil)
+		mockMFARepo.EXPECT().UseRecoveryCode("u1", utils.HashToken("abcdefghij"), now).Return(nil)
+
+		if err := service.VerifyCode("u1", "ABCDE-FGHIJ"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Used recovery code", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(enabled, nil)
+		mockMFARepo.EXPECT().UseRecoveryCode("u1", gomock.Any(), now).Return(sql.ErrNoRows)
+
+		if err := service.VerifyCode("u1", "abcde-fghij"); !errors.Is(err, ErrInvalidMFACode) {
+			t.Errorf("expected ErrInvalidMFACode, got %v", err)
+		}
+	})
+
+	t.Run("Not enrolled", func(t *testing.T) {
+		mockMFARepo.EXPECT().GetMFAByUserID("u2").Return(models.UserMFA{}, sql.ErrNoRows)
+
+		if err := service.VerifyCode("u2", code); err == nil {
+			t.Error("expected error when mfa is not enabled")
+		}
+	})
+}
+
+func TestIsRequired(t *testing.T) {
+	service, _, _ := setupService(t)
+	mockAuthzServ := mocks.NewMockAuthzServiceManager(gomock.NewController(t))
+	service.authzServ = mockAuthzServ
+	agent := models.User{ID: "agent1", Role: models.Customer}
+
+	config.RequireAdminMFA = true
+	mockAuthzServ.EXPECT().IsStaff("agent1", models.Customer).Return(true, nil)
+	required, err := service.IsRequired(agent)
+	if err != nil || !required {
+		t.Errorf("expected mfa to be required of staff, got %v, err: %v", required, err)
+	}
+
+	config.RequireAdminMFA = false
+	defer func() { config.RequireAdminMFA = true }()
+	required, err = service.IsRequired(agent)
+	if err != nil || required {
+		t.Errorf("expected mfa to be optional when not enforced, got %v, err: %v", required, err)
+	}
+}
+
+func TestDisable(t *testing.T) {
+	service, mockMFARepo, mockUserRepo := setupService(t)
+	mockAuthzServ := mocks.NewMockAuthzServiceManager(gomock.NewController(t))
+	service.authzServ = mockAuthzServ
+	now := service.now()
+	code, _ := totp.Code(testSecret, now)
+	config.RequireAdminMFA = true
+
+	t.Run("Staff can not disable", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("admin").Return(models.User{ID: "admin", Role: models.Admin}, nil)
+		mockAuthzServ.EXPECT().IsStaff("admin", models.Admin).Return(true, nil)
+
+		if err := service.Disable("admin", code); !errors.Is(err, ErrMFARequired) {
+			t.Errorf("expected ErrMFARequired, got %v", err)
+		}
+	})
+
+	t.Run("Customer disables", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Role: models.Customer}, nil)
+		mockAuthzServ.EXPECT().IsStaff("u1", models.Customer).Return(false, nil)
+		mockMFARepo.EXPECT().GetMFAByUserID("u1").Return(models.UserMFA{UserID: "u1", Secret: testSecret, EnabledAt: &now}, nil)
+		mockMFARepo.EXPECT().UpdateLastUsedStep("u1", totp.Step(now)).Return(nil)
+		mockMFARepo.EXPECT().DeleteMFA("u1").Return(nil)
+
+		if err := service.Disable("u1", code); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package oidcService
+
+import (
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_oidcService.go -package mocks
+
+type OIDCServiceManager interface {
+	BeginLogin() (string, error)
+	CompleteLogin(state, code string, client models.ClientInfo) (dto.LoginResultDTO, error)
+}
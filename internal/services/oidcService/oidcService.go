@@ -0,0 +1,315 @@
+package oidcService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/oidc"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/cartRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/oidcRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/tokenRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/userService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var (
+	ErrOIDCDisabled      = errors.New("single sign-on is not configured")
+	ErrInvalidLoginState = errors.New("invalid or expired login state")
+	ErrUnverifiedEmail   = errors.New("an account with this email already exists and the identity provider has not verified the email")
+	ErrUnverifiedAccount = errors.New("an account with this email already exists and its email has not been verified")
+)
+
+type OIDCService struct {
+	cfg         oidc.Config
+	adminGroups []string
+
+	oidcRepo  oidcRepository.OIDCManager
+	userRepo  userRepository.UserManager
+	cartRepo  cartRepository.CartManager
+	tokenRepo tokenRepository.TokenManager
+	userServ  userService.UserServiceManager
+	now       func() time.Time
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// NewOIDCService builds the single sign-on service. Members of adminGroups
+// become admins; when it is empty roles are never changed by a login.
+func NewOIDCService(cfg oidc.Config, adminGroups []string, oidcRepo oidcRepository.OIDCManager, userRepo userRepository.UserManager, cartRepo cartRepository.CartManager, tokenRepo tokenRepository.TokenManager, userServ userService.UserServiceManager) OIDCServiceManager {
+	return &OIDCService{
+		cfg:         cfg,
+		adminGroups: adminGroups,
+		oidcRepo:    oidcRepo,
+		userRepo:    userRepo,
+		cartRepo:    cartRepo,
+		tokenRepo:   tokenRepo,
+		userServ:    userServ,
+		now:         time.Now,
+	}
+}
+
+// BeginLogin stores a fresh state, nonce and PKCE verifier and returns the
+// provider URL to send the browser to.
+func (oi *OIDCService) BeginLogin() (string, error) {
+	provider, err := oi.getProvider()
+	if err != nil {
+		return "", err
+	}
+
+	err = oi.oidcRepo.DeleteExpiredLoginStates(oi.now())
+	if err != nil {
+		log.Printf("can not delete expired oidc login states: %v", err)
+	}
+
+	state, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", fmt.Errorf("can not generate state")
+	}
+	nonce, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", fmt.Errorf("can not generate nonce")
+	}
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return "", fmt.Errorf("can not generate code verifier")
+	}
+
+	err = oi.oidcRepo.SaveLoginState(models.OIDCLoginState{
+		StateHash:    utils.HashToken(state),
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    oi.now().Add(config.OIDCLoginTTL),
+	})
+	if err != nil {
+		return "", fmt.Errorf("can not save login state: %v", err)
+	}
+	return provider.AuthCodeURL(state, nonce, verifier), nil
+}
+
+// CompleteLogin handles the provider's callback and logs the linked user in
+// exactly like a password login, second factor included.
+func (oi *OIDCService) CompleteLogin(state, code string, client models.ClientInfo) (dto.LoginResultDTO, error) {
+	provider, err := oi.getProvider()
+	if err != nil {
+		return dto.LoginResultDTO{}, err
+	}
+	if state == "" || code == "" {
+		return dto.LoginResultDTO{}, ErrInvalidLoginState
+	}
+
+	loginState, err := oi.oidcRepo.ConsumeLoginState(utils.HashToken(state))
+	if errors.Is(err, sql.ErrNoRows) {
+		return dto.LoginResultDTO{}, ErrInvalidLoginState
+	}
+	if err != nil {
+		return dto.LoginResultDTO{}, fmt.Errorf("can not load login state: %v", err)
+	}
+	if !oi.now().Before(loginState.ExpiresAt) {
+		return dto.LoginResultDTO{}, ErrInvalidLoginState
+	}
+
+	idToken, err := provider.Exchange(code, loginState.CodeVerifier)
+	if err != nil {
+		return dto.LoginResultDTO{}, err
+	}
+	claims, err := provider.VerifyIDToken(idToken, loginState.Nonce)
+	if err != nil {
+		return dto.LoginResultDTO{}, err
+	}
+
+	user, err := oi.resolveUser(claims)
+	if err != nil {
+		return dto.LoginResultDTO{}, err
+	}
+	user, err = oi.syncRole(user, claims)
+	if err != nil {
+		return dto.LoginResultDTO{}, err
+	}
+	return oi.userServ.StartSession(user, client)
+}
+
+// resolveUser finds the user linked to the identity. Unknown identities are
+// linked to the account with the same email when both the provider and the
+// shop have verified it, otherwise a new account is created.
+func (oi *OIDCService) resolveUser(claims oidc.Claims) (models.User, error) {
+	identity, err := oi.oidcRepo.GetIdentity(oi.cfg.Issuer, claims.Subject)
+	if err == nil {
+		user, err := oi.userRepo.GetUserByID(identity.UserID)
+		if err != nil {
+			return models.User{}, fmt.Errorf("linked user not found")
+		}
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return models.User{}, fmt.Errorf("can not load identity: %v", err)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	if email == "" {
+		return models.User{}, fmt.Errorf("identity provider did not return an email")
+	}
+
+	user, err := oi.userRepo.GetUserByEmail(email)
+	switch {
+	case err == nil:
+		if !claims.EmailVerified {
+			return models.User{}, ErrUnverifiedEmail
+		}
+		if !user.IsEmailVerified() {
+			return models.User{}, ErrUnverifiedAccount
+		}
+		user, err = oi.revokePassword(user)
+		if err != nil {
+			return models.User{}, err
+		}
+	case errors.Is(err, sql.ErrNoRows):
+		user, err = oi.createUser(claims, email)
+		if err != nil {
+			return models.User{}, err
+		}
+	default:
+		return models.User{}, fmt.Errorf("can not load user: %v", err)
+	}
+
+	err = oi.oidcRepo.SaveIdentity(models.UserIdentity{
+		Issuer:    oi.cfg.Issuer,
+		Subject:   claims.Subject,
+		UserID:    user.ID,
+		Email:     email,
+		CreatedAt: oi.now(),
+	})
+	if err != nil {
+		return models.User{}, fmt.Errorf("can not link identity: %v", err)
+	}
+	return user, nil
+}
+
+// revokePassword replaces the password of an account about to be linked with
+// an unusable random one and logs out its sessions, so nobody who knew the
+// old password keeps access. The user can set a new one through the
+// forgot-password flow.
+func (oi *OIDCService) revokePassword(user models.User) (models.User, error) {
+	hashedPass, err := randomPassword()
+	if err != nil {
+		return models.User{}, fmt.Errorf("can not link identity: %v", err)
+	}
+	err = oi.userRepo.UpdatePassword(user.ID, hashedPass)
+	if err != nil {
+		return models.User{}, fmt.Errorf("can not link identity: %v", err)
+	}
+	err = oi.tokenRepo.IncrementTokenVersion(user.ID)
+	if err != nil {
+		return models.User{}, fmt.Errorf("can not revoke sessions: %v", err)
+	}
+	return oi.userRepo.GetUserByID(user.ID)
+}
+
+// createUser gives the account an unusable random password; the user can set
+// one later through the forgot-password flow.
+func (oi *OIDCService) createUser(claims oidc.Claims, email string) (models.User, error) {
+	hashedPass, err := randomPassword()
+	if err != nil {
+		return models.User{}, fmt.Errorf("can not create new user")
+	}
+	name := strings.TrimSpace(claims.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	role := models.Customer
+	if oi.inAdminGroup(claims.Groups) {
+		role = models.Admin
+	}
+
+	user := models.User{
+		ID:       utils.NewUUID(),
+		Name:     name,
+		Email:    email,
+		Password: hashedPass,
+		Role:     role,
+		Status:   models.UserActive,
+	}
+	err = oi.userRepo.SaveUser(user)
+	if err != nil {
+		return models.User{}, fmt.Errorf("can not save new user")
+	}
+	if claims.EmailVerified {
+		verifiedAt := oi.now()
+		err = oi.userRepo.MarkEmailVerified(user.ID, verifiedAt)
+		if err != nil {
+			return models.User{}, fmt.Errorf("can not mark email verified: %v", err)
+		}
+		user.EmailVerifiedAt = &verifiedAt
+	}
+	err = oi.cartRepo.CreateCart(utils.NewUUID(), user.ID)
+	if err != nil {
+		return models.User{}, fmt.Errorf("can not associate cart for the user: %v", err)
+	}
+	return user, nil
+}
+
+func randomPassword() (string, error) {
+	password, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", err
+	}
+	return utils.HashPassword(password)
+}
+
+// syncRole makes the role follow admin group membership. The user is read
+// back after a change because it bumps the token version.
+func (oi *OIDCService) syncRole(user models.User, claims oidc.Claims) (models.User, error) {
+	if len(oi.adminGroups) == 0 {
+		return user, nil
+	}
+	role := models.Customer
+	if oi.inAdminGroup(claims.Groups) {
+		role = models.Admin
+	}
+	if user.Role == role {
+		return user, nil
+	}
+	err := oi.userRepo.UpdateUserRole(user.ID, role)
+	if err != nil {
+		return models.User{}, fmt.Errorf("can not update role: %v", err)
+	}
+	return oi.userRepo.GetUserByID(user.ID)
+}
+
+func (oi *OIDCService) inAdminGroup(groups []string) bool {
+	for _, group := range groups {
+		if slices.Contains(oi.adminGroups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// getProvider discovers the provider on first use and keeps retrying until
+// that succeeds, so the shop can start while the provider is unreachable.
+func (oi *OIDCService) getProvider() (*oidc.Provider, error) {
+	if oi.cfg.Issuer == "" || oi.cfg.ClientID == "" {
+		return nil, ErrOIDCDisabled
+	}
+	oi.mu.Lock()
+	defer oi.mu.Unlock()
+	if oi.provider != nil {
+		return oi.provider, nil
+	}
+	provider, err := oidc.Discover(oi.cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	oi.provider = provider
+	return provider, nil
+}
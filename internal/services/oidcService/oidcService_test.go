@@ -0,0 +1,308 @@
+package oidcService
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/oidc"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/oidc/oidctest"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+var fixedNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type fixture struct {
+	service   *OIDCService
+	idp       *oidctest.Provider
+	oidcRepo  *mocks.MockOIDCManager
+	userRepo  *mocks.MockUserManager
+	cartRepo  *mocks.MockCartManager
+	tokenRepo *mocks.MockTokenManager
+	userServ  *mocks.MockUserServiceManager
+}
+
+func setupService(t *testing.T, adminGroups ...string) fixture {
+	ctrl := gomock.NewController(t)
+	idp, srv := oidctest.NewServer("shop", "s3cret")
+	t.Cleanup(srv.Close)
+
+	f := fixture{
+		idp:       idp,
+		oidcRepo:  mocks.NewMockOIDCManager(ctrl),
+		userRepo:  mocks.NewMockUserManager(ctrl),
+		cartRepo:  mocks.NewMockCartManager(ctrl),
+		tokenRepo: mocks.NewMockTokenManager(ctrl),
+		userServ:  mocks.NewMockUserServiceManager(ctrl),
+	}
+	f.service = &OIDCService{
+		cfg: oidc.Config{
+			Issuer:       idp.Issuer,
+			ClientID:     "shop",
+			ClientSecret: "s3cret",
+			RedirectURL:  "http://localhost:8080/api/v1/login/oidc/callback",
+			Scopes:       []string{"openid", "email", "profile"},
+			GroupsClaim:  "groups",
+		},
+		adminGroups: adminGroups,
+		oidcRepo:    f.oidcRepo,
+		userRepo:    f.userRepo,
+		cartRepo:    f.cartRepo,
+		tokenRepo:   f.tokenRepo,
+		userServ:    f.userServ,
+		now:         func() time.Time { return fixedNow },
+	}
+	return f
+}
+
+// authorize runs BeginLogin and the provider's consent step, leaving the
+// stored state to be consumed by CompleteLogin.
+func (f fixture) authorize(t *testing.T, user oidctest.User) (state, code string) {
+	f.idp.SetUser(user)
+
+	var saved models.OIDCLoginState
+	f.oidcRepo.EXPECT().DeleteExpiredLoginStates(fixedNow).Return(nil)
+	f.oidcRepo.EXPECT().SaveLoginState(gomock.Any()).DoAndReturn(func(s models.OIDCLoginState) error {
+		saved = s
+		return nil
+	})
+
+	authURL, err := f.service.BeginLogin()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code, state, err = f.idp.Authorize(authURL)
+	if err != nil {
+		t.Fatalf("authorize failed: %v", err)
+	}
+	if saved.StateHash != utils.HashToken(state) || !saved.ExpiresAt.After(fixedNow) {
+		t.Fatalf("unexpected saved state: %+v", saved)
+	}
+	f.oidcRepo.EXPECT().ConsumeLoginState(saved.StateHash).Return(saved, nil)
+	return state, code
+}
+
+var alice = oidctest.User{
+	Subject:       "sub-1",
+	Email:         "Alice@Corp.example",
+	EmailVerified: true,
+	Name:          "Alice",
+	Groups:        []string{"staff"},
+}
+
+func TestBeginLogin_Disabled(t *testing.T) {
+	service := &OIDCService{}
+	_, err := service.BeginLogin()
+	if !errors.Is(err, ErrOIDCDisabled) {
+		t.Errorf("expected ErrOIDCDisabled, got %v", err)
+	}
+}
+
+func TestCompleteLogin_InvalidState(t *testing.T) {
+	f := setupService(t)
+
+	t.Run("Missing parameters", func(t *testing.T) {
+		_, err := f.service.CompleteLogin("", "code", models.ClientInfo{})
+		if !errors.Is(err, ErrInvalidLoginState) {
+			t.Errorf("expected ErrInvalidLoginState, got %v", err)
+		}
+	})
+
+	t.Run("Unknown state", func(t *testing.T) {
+		f.oidcRepo.EXPECT().ConsumeLoginState(utils.HashToken("state")).Return(models.OIDCLoginState{}, sql.ErrNoRows)
+
+		_, err := f.service.CompleteLogin("state", "code", models.ClientInfo{})
+		if !errors.Is(err, ErrInvalidLoginState) {
+			t.Errorf("expected ErrInvalidLoginState, got %v", err)
+		}
+	})
+
+	t.Run("Expired state", func(t *testing.T) {
+		f.oidcRepo.EXPECT().ConsumeLoginState(utils.HashToken("state")).
+			Return(models.OIDCLoginState{ExpiresAt: fixedNow.Add(-time.Second)}, nil)
+
+		_, err := f.service.CompleteLogin("state", "code", models.ClientInfo{})
+		if !errors.Is(err, ErrInvalidLoginState) {
+			t.Errorf("expected ErrInvalidLoginState, got %v", err)
+		}
+	})
+}
+
+func TestCompleteLogin_NewUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		groups   []string
+		wantRole models.UserRole
+	}{
+		{name: "Customer", groups: []string{"staff"}, wantRole: models.Customer},
+		{name: "Admin group", groups: []string{"staff", "shop-admins"}, wantRole: models.Admin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := setupService(t, "shop-admins")
+			user := alice
+			user.Groups = tt.groups
+			state, code := f.authorize(t, user)
+
+			var created models.User
+			f.oidcRepo.EXPECT().GetIdentity(f.idp.Issuer, "sub-1").Return(models.UserIdentity{}, sql.ErrNoRows)
+			f.userRepo.EXPECT().GetUserByEmail("alice@corp.example").Return(models.User{}, sql.ErrNoRows)
+			f.userRepo.EXPECT().SaveUser(gomock.Any()).DoAndReturn(func(u models.User) error {
+				created = u
+				return nil
+			})
+			f.userRepo.EXPECT().MarkEmailVerified(gomock.Any(), fixedNow).Return(nil)
+			f.cartRepo.EXPECT().CreateCart(gomock.Any(), gomock.Any()).Return(nil)
+			f.oidcRepo.EXPECT().SaveIdentity(gomock.Any()).DoAndReturn(func(identity models.UserIdentity) error {
+				if identity.UserID != created.ID || identity.Subject != "sub-1" || identity.Issuer != f.idp.Issuer {
+					t.Errorf("unexpected identity: %+v", identity)
+				}
+				return nil
+			})
+			f.userServ.EXPECT().StartSession(gomock.Any(), gomock.Any()).DoAndReturn(func(u models.User, _ models.ClientInfo) (dto.LoginResultDTO, error) {
+				if u.ID != created.ID || !u.IsEmailVerified() {
+					t.Errorf("unexpected session user: %+v", u)
+				}
+				return dto.LoginResultDTO{Token: "jwt"}, nil
+			})
+
+			result, err := f.service.CompleteLogin(state, code, models.ClientInfo{})
+			if err != nil || result.Token != "jwt" {
+				t.Fatalf("expected token, got %+v, err: %v", result, err)
+			}
+			if created.Role != tt.wantRole || created.Email != "alice@corp.example" || created.Name != "Alice" {
+				t.Errorf("unexpected user: %+v", created)
+			}
+			if created.Password == "" || !utils.IsHashedPassword(created.Password) {
+				t.Error("expected a random hashed password")
+			}
+		})
+	}
+}
+
+func TestCompleteLogin_ExistingEmail(t *testing.T) {
+	verifiedAt := fixedNow.Add(-time.Hour)
+	existing := models.User{ID: "u1", Email: "alice@corp.example", Password: "hash", Role: models.Customer, Status: models.UserActive, EmailVerifiedAt: &verifiedAt}
+
+	t.Run("Verified email is linked", func(t *testing.T) {
+		f := setupService(t)
+		state, code := f.authorize(t, alice)
+
+		revoked := existing
+		revoked.Password = "scrambled"
+		revoked.TokenVersion = 1
+		f.oidcRepo.EXPECT().GetIdentity(f.idp.Issuer, "sub-1").Return(models.UserIdentity{}, sql.ErrNoRows)
+		f.userRepo.EXPECT().GetUserByEmail("alice@corp.example").Return(existing, nil)
+		f.userRepo.EXPECT().UpdatePassword("u1", gomock.Any()).DoAndReturn(func(_, hash string) error {
+			if hash == "" || hash == existing.Password {
+				t.Errorf("expected a new password hash, got %q", hash)
+			}
+			return nil
+		})
+		f.tokenRepo.EXPECT().IncrementTokenVersion("u1").Return(nil)
+		f.userRepo.EXPECT().GetUserByID("u1").Return(revoked, nil)
+		f.oidcRepo.EXPECT().SaveIdentity(models.UserIdentity{
+			Issuer: f.idp.Issuer, Subject: "sub-1", UserID: "u1", Email: "alice@corp.example", CreatedAt: fixedNow,
+		}).Return(nil)
+		f.userServ.EXPECT().StartSession(revoked, gomock.Any()).Return(dto.LoginResultDTO{Token: "jwt"}, nil)
+
+		_, err := f.service.CompleteLogin(state, code, models.ClientInfo{})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Unverified email is refused", func(t *testing.T) {
+		f := setupService(t)
+		user := alice
+		user.EmailVerified = false
+		state, code := f.authorize(t, user)
+
+		f.oidcRepo.EXPECT().GetIdentity(f.idp.Issuer, "sub-1").Return(models.UserIdentity{}, sql.ErrNoRows)
+		f.userRepo.EXPECT().GetUserByEmail("alice@corp.example").Return(existing, nil)
+
+		_, err := f.service.CompleteLogin(state, code, models.ClientInfo{})
+		if !errors.Is(err, ErrUnverifiedEmail) {
+			t.Errorf("expected ErrUnverifiedEmail, got %v", err)
+		}
+	})
+
+	t.Run("Unverified account is refused", func(t *testing.T) {
+		f := setupService(t)
+		state, code := f.authorize(t, alice)
+
+		unverified := existing
+		unverified.EmailVerifiedAt = nil
+		f.oidcRepo.EXPECT().GetIdentity(f.idp.Issuer, "sub-1").Return(models.UserIdentity{}, sql.ErrNoRows)
+		f.userRepo.EXPECT().GetUserByEmail("alice@corp.example").Return(unverified, nil)
+
+		_, err := f.service.CompleteLogin(state, code, models.ClientInfo{})
+		if !errors.Is(err, ErrUnverifiedAccount) {
+			t.Errorf("expected ErrUnverifiedAccount, got %v", err)
+		}
+	})
+}
+
+func TestCompleteLogin_LinkedIdentity(t *testing.T) {
+	admin := models.User{ID: "u1", Email: "alice@corp.example", Role: models.Admin, Status: models.UserActive, TokenVersion: 1}
+	identity := models.UserIdentity{Subject: "sub-1", UserID: "u1"}
+
+	t.Run("Role follows groups", func(t *testing.T) {
+		f := setupService(t, "shop-admins")
+		state, code := f.authorize(t, alice)
+
+		demoted := admin
+		demoted.Role = models.Customer
+		demoted.TokenVersion = 2
+		f.oidcRepo.EXPECT().GetIdentity(f.idp.Issuer, "sub-1").Return(identity, nil)
+		f.userRepo.EXPECT().GetUserByID("u1").Return(admin, nil)
+		f.userRepo.EXPECT().UpdateUserRole("u1", models.Customer).Return(nil)
+		f.userRepo.EXPECT().GetUserByID("u1").Return(demoted, nil)
+		f.userServ.EXPECT().StartSession(demoted, gomock.Any()).Return(dto.LoginResultDTO{Token: "jwt"}, nil)
+
+		_, err := f.service.CompleteLogin(state, code, models.ClientInfo{})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Role kept without admin groups", func(t *testing.T) {
+		f := setupService(t)
+		state, code := f.authorize(t, alice)
+
+		f.oidcRepo.EXPECT().GetIdentity(f.idp.Issuer, "sub-1").Return(identity, nil)
+		f.userRepo.EXPECT().GetUserByID("u1").Return(admin, nil)
+		f.userServ.EXPECT().StartSession(admin, gomock.Any()).Return(dto.LoginResultDTO{MFARequired: true, MFAToken: "mfa"}, nil)
+
+		result, err := f.service.CompleteLogin(state, code, models.ClientInfo{})
+		if err != nil || !result.MFARequired {
+			t.Errorf("expected mfa challenge, got %+v, err: %v", result, err)
+		}
+	})
+}
+
+func TestCompleteLogin_CodeReplay(t *testing.T) {
+	f := setupService(t)
+	state, code := f.authorize(t, alice)
+
+	f.oidcRepo.EXPECT().GetIdentity(f.idp.Issuer, "sub-1").Return(models.UserIdentity{UserID: "u1"}, nil)
+	f.userRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1"}, nil)
+	f.userServ.EXPECT().StartSession(gomock.Any(), gomock.Any()).Return(dto.LoginResultDTO{Token: "jwt"}, nil)
+	_, err := f.service.CompleteLogin(state, code, models.ClientInfo{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the state is gone after the first callback
+	f.oidcRepo.EXPECT().ConsumeLoginState(utils.HashToken(state)).Return(models.OIDCLoginState{}, sql.ErrNoRows)
+	_, err = f.service.CompleteLogin(state, code, models.ClientInfo{})
+	if !errors.Is(err, ErrInvalidLoginState) {
+		t.Errorf("expected ErrInvalidLoginState, got %v", err)
+	}
+}
@@ -0,0 +1,12 @@
+package passwordService
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_passwordService.go -package mocks
+
+type PasswordServiceManager interface {
+	ForgotPassword(email string) error
+	ResetPassword(token, newPassword string) error
+	ChangePassword(userID, oldPassword, newPassword string, client models.ClientInfo) error
+	ForceReset(adminID, userID string) error
+}
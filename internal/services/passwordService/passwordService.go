@@ -0,0 +1,219 @@
+package passwordService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mailer"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/resetTokenRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/tokenRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/authzService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var ErrOutranked = errors.New("this account has permissions you do not have")
+
+type PasswordService struct {
+	userRepo       userRepository.UserManager
+	resetTokenRepo resetTokenRepository.ResetTokenManager
+	tokenRepo      tokenRepository.TokenManager
+	mailer         mailer.Mailer
+	authzServ      authzService.AuthzServiceManager
+	lockoutServ    lockoutService.LockoutServiceManager
+	now            func() time.Time
+}
+
+// NewPasswordService builds the service; lockoutServ may be nil to skip
+// throttling of password changes.
+func NewPasswordService(userRepo userRepository.UserManager, resetTokenRepo resetTokenRepository.ResetTokenManager, tokenRepo tokenRepository.TokenManager, mailer mailer.Mailer, authzServ authzService.AuthzServiceManager, lockoutServ lockoutService.LockoutServiceManager) PasswordServiceManager {
+	return &PasswordService{
+		userRepo:       userRepo,
+		resetTokenRepo: resetTokenRepo,
+		tokenRepo:      tokenRepo,
+		mailer:         mailer,
+		authzServ:      authzServ,
+		lockoutServ:    lockoutServ,
+		now:            time.Now,
+	}
+}
+
+// ForgotPassword mails a reset link. Unknown emails are ignored silently and a
+// failure to send is only logged, so the response is the same either way and
+// the endpoint can not be used to discover registered accounts. While the
+// last link is younger than PasswordResetCooldown no new one is sent, so the
+// endpoint can not be used to flood an inbox either.
+func (ps *PasswordService) ForgotPassword(email string) error {
+	user, err := ps.userRepo.GetUserByEmail(email)
+	if err != nil {
+		return nil
+	}
+	cooling, err := ps.resetCoolingDown(user.ID)
+	if err != nil {
+		log.Printf("password reset for user %s: %v", user.ID, err)
+		return nil
+	}
+	if cooling {
+		return nil
+	}
+	err = ps.sendResetLink(user)
+	if err != nil {
+		log.Printf("password reset for user %s: %v", user.ID, err)
+	}
+	return nil
+}
+
+// resetCoolingDown reports whether the user's latest unexpired reset token
+// was issued within PasswordResetCooldown. Tokens only store their expiry, so
+// the issue time is worked back from PasswordResetTTL.
+func (ps *PasswordService) resetCoolingDown(userID string) (bool, error) {
+	latest, err := ps.resetTokenRepo.GetLatestResetToken(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can not check reset tokens: %v", err)
+	}
+	now := ps.now()
+	issuedAt := latest.ExpiresAt.Add(-config.PasswordResetTTL)
+	return now.Before(latest.ExpiresAt) && now.Sub(issuedAt) < config.PasswordResetCooldown, nil
+}
+
+// ForceReset is the admin action for a compromised account: the current
+// password stops working, every session is revoked and the owner is mailed a
+// reset link. Accounts with permissions the admin lacks are refused.
+func (ps *PasswordService) ForceReset(adminID, userID string) error {
+	user, err := ps.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	allowed, err := ps.authzServ.CanManage(adminID, user)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrOutranked
+	}
+	scrambled, err := utils.GenerateSecureToken()
+	if err != nil {
+		return fmt.Errorf("can not reset password: %v", err)
+	}
+	err = ps.setPassword(user.ID, scrambled)
+	if err != nil {
+		return err
+	}
+	return ps.sendResetLink(user)
+}
+
+func (ps *PasswordService) sendResetLink(user models.User) error {
+	err := ps.resetTokenRepo.DeleteResetTokensByUserID(user.ID)
+	if err != nil {
+		return fmt.Errorf("can not create reset token: %v", err)
+	}
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		return fmt.Errorf("can not create reset token: %v", err)
+	}
+	resetToken := models.PasswordResetToken{
+		ID:        utils.NewUUID(),
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: ps.now().Add(config.PasswordResetTTL),
+	}
+	err = ps.resetTokenRepo.SaveResetToken(resetToken)
+	if err != nil {
+		return fmt.Errorf("can not create reset token: %v", err)
+	}
+
+	link := config.AppBaseURL + "/reset-password?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("Hi %s,\n\nUse the link below to reset your password. It expires in %v.\n\n%s\n\nIf you did not ask for a reset, you can ignore this email.",
+		user.Name, config.PasswordResetTTL, link)
+	err = ps.mailer.Send(user.Email, "Reset your password", body)
+	if err != nil {
+		return fmt.Errorf("can not send reset email: %v", err)
+	}
+	return nil
+}
+
+func (ps *PasswordService) ResetPassword(token, newPassword string) error {
+	resetToken, err := ps.resetTokenRepo.GetResetTokenByHash(utils.HashToken(token))
+	if err != nil {
+		return fmt.Errorf("invalid or expired reset token")
+	}
+	now := ps.now()
+	if resetToken.UsedAt != nil || now.After(resetToken.ExpiresAt) {
+		return fmt.Errorf("invalid or expired reset token")
+	}
+	err = ps.resetTokenRepo.MarkResetTokenUsed(resetToken.ID, now)
+	if err != nil {
+		return fmt.Errorf("invalid or expired reset token")
+	}
+
+	err = ps.setPassword(resetToken.UserID, newPassword)
+	if err != nil {
+		return err
+	}
+	return ps.resetTokenRepo.DeleteResetTokensByUserID(resetToken.UserID)
+}
+
+// ChangePassword counts a wrong old password as a failed login, so a stolen
+// session can not be used to guess the password past the lockout.
+func (ps *PasswordService) ChangePassword(userID, oldPassword, newPassword string, client models.ClientInfo) error {
+	user, err := ps.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	if ps.lockoutServ != nil {
+		err = ps.lockoutServ.Check(user.Email, client.IP)
+		if err != nil {
+			return err
+		}
+	}
+	if !utils.CheckPassword(user.Password, oldPassword) {
+		if ps.lockoutServ != nil {
+			err = ps.lockoutServ.RecordFailure(user.Email, client.IP)
+			if err != nil {
+				log.Printf("can not record failed password change for %s: %v", user.Email, err)
+			}
+		}
+		return fmt.Errorf("old password is incorrect")
+	}
+	if oldPassword == newPassword {
+		return fmt.Errorf("new password must be different from the old password")
+	}
+	err = ps.setPassword(user.ID, newPassword)
+	if err != nil {
+		return err
+	}
+	if ps.lockoutServ != nil {
+		err = ps.lockoutServ.RecordSuccess(user.Email)
+		if err != nil {
+			log.Printf("can not reset failed logins for %s: %v", user.Email, err)
+		}
+	}
+	return nil
+}
+
+// setPassword stores the new hash and logs the user out of every session.
+func (ps *PasswordService) setPassword(userID, newPassword string) error {
+	hashedPass, err := utils.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("unable to hash the password")
+	}
+	err = ps.userRepo.UpdatePassword(userID, hashedPass)
+	if err != nil {
+		return fmt.Errorf("can not update password: %v", err)
+	}
+	err = ps.tokenRepo.IncrementTokenVersion(userID)
+	if err != nil {
+		return fmt.Errorf("can not revoke sessions: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package passwordService
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeMailer struct {
+	to   string
+	body string
+	err  error
+}
+
+func (fm *fakeMailer) Send(to, subject, body string) error {
+	fm.to = to
+	fm.body = body
+	return fm.err
+}
+
+func setupService(t *testing.T) (*PasswordService, *mocks.MockUserManager, *mocks.MockResetTokenManager, *mocks.MockTokenManager, *fakeMailer) {
+	ctrl := gomock.NewController(t)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mockResetRepo := mocks.NewMockResetTokenManager(ctrl)
+	mockTokenRepo := mocks.NewMockTokenManager(ctrl)
+	mailer := &fakeMailer{}
+	fixedNow := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &PasswordService{
+		userRepo:       mockUserRepo,
+		resetTokenRepo: mockResetRepo,
+		tokenRepo:      mockTokenRepo,
+		mailer:         mailer,
+		now:            func() time.Time { return fixedNow },
+	}
+	return service, mockUserRepo, mockResetRepo, mockTokenRepo, mailer
+}
+
+func TestForgotPassword(t *testing.T) {
+	service, mockUserRepo, mockResetRepo, _, mailer := setupService(t)
+
+	t.Run("Unknown email", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByEmail("nobody@example.com").Return(models.User{}, errors.New("not found"))
+
+		err := service.ForgotPassword("nobody@example.com")
+		if err != nil {
+			t.Errorf("expected unknown email to be ignored, got error: %v", err)
+		}
+	})
+
+	t.Run("Sends hashed single-use token", func(t *testing.T) {
+		var saved models.PasswordResetToken
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(models.User{ID: "u1", Email: "user@example.com"}, nil)
+		mockResetRepo.EXPECT().GetLatestResetToken("u1").Return(models.PasswordResetToken{}, sql.ErrNoRows)
+		mockResetRepo.EXPECT().DeleteResetTokensByUserID("u1").Return(nil)
+		mockResetRepo.EXPECT().SaveResetToken(gomock.Any()).DoAndReturn(func(token models.PasswordResetToken) error {
+			saved = token
+			return nil
+		})
+
+		err := service.ForgotPassword("user@example.com")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mailer.to != "user@example.com" {
+			t.Errorf("expected mail to user@example.com, got %s", mailer.to)
+		}
+		token := mailer.body[strings.Index(mailer.body, "token=")+len("token="):]
+		token = strings.Fields(token)[0]
+		if saved.TokenHash != utils.HashToken(token) {
+			t.Error("expected only the hash of the mailed token to be stored")
+		}
+		if !saved.ExpiresAt.Equal(service.now().Add(30 * time.Minute)) {
+			t.Errorf("unexpected expiry: %v", saved.ExpiresAt)
+		}
+	})
+
+	t.Run("Mail failure looks like success", func(t *testing.T) {
+		mailer.err = errors.New("smtp down")
+		defer func() { mailer.err = nil }()
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(models.User{ID: "u1", Email: "user@example.com"}, nil)
+		mockResetRepo.EXPECT().GetLatestResetToken("u1").Return(models.PasswordResetToken{}, sql.ErrNoRows)
+		mockResetRepo.EXPECT().DeleteResetTokensByUserID("u1").Return(nil)
+		mockResetRepo.EXPECT().SaveResetToken(gomock.Any()).Return(nil)
+
+		err := service.ForgotPassword("user@example.com")
+		if err != nil {
+			t.Errorf("expected the same response as for an unknown email, got error: %v", err)
+		}
+	})
+
+	t.Run("Recent link is not resent", func(t *testing.T) {
+		mailer.to = ""
+		issuedAt := service.now().Add(-time.Minute)
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(models.User{ID: "u1", Email: "user@example.com"}, nil)
+		mockResetRepo.EXPECT().GetLatestResetToken("u1").Return(models.PasswordResetToken{ID: "t1", UserID: "u1", ExpiresAt: issuedAt.Add(30 * time.Minute)}, nil)
+
+		err := service.ForgotPassword("user@example.com")
+		if err != nil {
+			t.Errorf("expected the same response as for an unknown email, got error: %v", err)
+		}
+		if mailer.to != "" {
+			t.Error("expected no mail within the cooldown")
+		}
+	})
+
+	t.Run("Link older than the cooldown is replaced", func(t *testing.T) {
+		issuedAt := service.now().Add(-10 * time.Minute)
+		mockUserRepo.EXPECT().GetUserByEmail("user@example.com").Return(models.User{ID: "u1", Email: "user@example.com"}, nil)
+		mockResetRepo.EXPECT().GetLatestResetToken("u1").Return(models.PasswordResetToken{ID: "t1", UserID: "u1", ExpiresAt: issuedAt.Add(30 * time.Minute)}, nil)
+		mockResetRepo.EXPECT().DeleteResetTokensByUserID("u1").Return(nil)
+		mockResetRepo.EXPECT().SaveResetToken(gomock.Any()).Return(nil)
+
+		if err := service.ForgotPassword("user@example.com"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	service, mockUserRepo, mockResetRepo, mockTokenRepo, _ := setupService(t)
+	now := service.now()
+	hash := utils.HashToken("token")
+
+	t.Run("Unknown token", func(t *testing.T) {
+		mockResetRepo.EXPECT().GetResetTokenByHash(hash).Return(models.PasswordResetToken{}, errors.New("not found"))
+
+		if err := service.ResetPassword("token", "NewPass@123"); err == nil {
+			t.Error("expected error for unknown token")
+		}
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		mockResetRepo.EXPECT().GetResetTokenByHash(hash).Return(models.PasswordResetToken{ID: "t1", UserID: "u1", ExpiresAt: now.Add(-time.Second)}, nil)
+
+		if err := service.ResetPassword("token", "NewPass@123"); err == nil {
+			t.Error("expected error for expired token")
+		}
+	})
+
+	t.Run("Used token", func(t *testing.T) {
+		usedAt := now.Add(-time.Minute)
+		mockResetRepo.EXPECT().GetResetTokenByHash(hash).Return(models.PasswordResetToken{ID: "t1", UserID: "u1", ExpiresAt: now.Add(time.Minute), UsedAt: &usedAt}, nil)
+
+		if err := service.ResetPassword("token", "NewPass@123"); err == nil {
+			t.Error("expected error for used token")
+		}
+	})
+
+	t.Run("Successful reset", func(t *testing.T) {
+		mockResetRepo.EXPECT().GetResetTokenByHash(hash).Return(models.PasswordResetToken{ID: "t1", UserID: "u1", ExpiresAt: now.Add(time.Minute)}, nil)
+		mockResetRepo.EXPECT().MarkResetTokenUsed("t1", now).Return(nil)
+		mockUserRepo.EXPECT().UpdatePassword("u1", gomock.Any()).Return(nil)
+		mockTokenRepo.EXPECT().IncrementTokenVersion("u1").Return(nil)
+		mockResetRepo.EXPECT().DeleteResetTokensByUserID("u1").Return(nil)
+
+		if err := service.ResetPassword("token", "NewPass@123"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	service, mockUserRepo, _, mockTokenRepo, _ := setupService(t)
+	hashed, _ := utils.HashPassword("OldPass@123")
+
+	t.Run("Wrong old password", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Password: hashed}, nil)
+
+		if err := service.ChangePassword("u1", "Wrong@123", "NewPass@123", models.ClientInfo{}); err == nil {
+			t.Error("expected error for wrong old password")
+		}
+	})
+
+	t.Run("Successful change", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Password: hashed}, nil)
+		mockUserRepo.EXPECT().UpdatePassword("u1", gomock.Any()).Return(nil)
+		mockTokenRepo.EXPECT().IncrementTokenVersion("u1").Return(nil)
+
+		if err := service.ChangePassword("u1", "OldPass@123", "NewPass@123", models.ClientInfo{}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestChangePasswordLockout(t *testing.T) {
+	service, mockUserRepo, _, mockTokenRepo, _ := setupService(t)
+	mockLockoutServ := mocks.NewMockLockoutServiceManager(gomock.NewController(t))
+	service.lockoutServ = mockLockoutServ
+	hashed, _ := utils.HashPassword("OldPass@123")
+	user := models.User{ID: "u1", Email: "user@example.com", Password: hashed}
+	client := models.ClientInfo{IP: "10.0.0.1"}
+
+	t.Run("Locked account", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(user, nil)
+		mockLockoutServ.EXPECT().Check("user@example.com", "10.0.0.1").Return(&lockoutService.LockedError{RetryAfter: time.Minute})
+
+		err := service.ChangePassword("u1", "OldPass@123", "NewPass@123", client)
+		var locked *lockoutService.LockedError
+		if !errors.As(err, &locked) {
+			t.Errorf("expected a lockout error, got %v", err)
+		}
+	})
+
+	t.Run("Wrong old password counts as a failure", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(user, nil)
+		mockLockoutServ.EXPECT().Check("user@example.com", "10.0.0.1").Return(nil)
+		mockLockoutServ.EXPECT().RecordFailure("user@example.com", "10.0.0.1").Return(nil)
+
+		if err := service.ChangePassword("u1", "Wrong@123", "NewPass@123", client); err == nil {
+			t.Error("expected error for wrong old password")
+		}
+	})
+
+	t.Run("Successful change clears failures", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(user, nil)
+		mockLockoutServ.EXPECT().Check("user@example.com", "10.0.0.1").Return(nil)
+		mockUserRepo.EXPECT().UpdatePassword("u1", gomock.Any()).Return(nil)
+		mockTokenRepo.EXPECT().IncrementTokenVersion("u1").Return(nil)
+		mockLockoutServ.EXPECT().RecordSuccess("user@example.com").Return(nil)
+
+		if err := service.ChangePassword("u1", "OldPass@123", "NewPass@123", client); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestForceReset(t *testing.T) {
+	service, mockUserRepo, mockResetRepo, mockTokenRepo, mailer := setupService(t)
+	mockAuthzServ := mocks.NewMockAuthzServiceManager(gomock.NewController(t))
+	service.authzServ = mockAuthzServ
+
+	t.Run("Unknown user", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("missing").Return(models.User{}, errors.New("not found"))
+
+		if err := service.ForceReset("admin1", "missing"); err == nil {
+			t.Error("expected error for unknown user")
+		}
+	})
+
+	t.Run("Account with more permissions", func(t *testing.T) {
+		admin := models.User{ID: "admin2", Role: models.Admin}
+		mockUserRepo.EXPECT().GetUserByID("admin2").Return(admin, nil)
+		mockAuthzServ.EXPECT().CanManage("agent1", admin).Return(false, nil)
+
+		if err := service.ForceReset("agent1", "admin2"); !errors.Is(err, ErrOutranked) {
+			t.Errorf("expected ErrOutranked, got %v", err)
+		}
+	})
+
+	t.Run("Scrambles password, revokes sessions and mails link", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Email: "user@example.com"}, nil)
+		mockAuthzServ.EXPECT().CanManage("admin1", gomock.Any()).Return(true, nil)
+		mockUserRepo.EXPECT().UpdatePassword("u1", gomock.Any()).Return(nil)
+		mockTokenRepo.EXPECT().IncrementTokenVersion("u1").Return(nil)
+		mockResetRepo.EXPECT().DeleteResetTokensByUserID("u1").Return(nil)
+		mockResetRepo.EXPECT().SaveResetToken(gomock.Any()).Return(nil)
+
+		if err := service.ForceReset("admin1", "u1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mailer.to != "user@example.com" || !strings.Contains(mailer.body, "/reset-password?token=") {
+			t.Errorf("expected reset link mailed to user, got to=%q body=%q", mailer.to, mailer.body)
+		}
+	})
+}
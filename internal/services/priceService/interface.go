@@ -0,0 +1,18 @@
+package priceService
+
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_priceService.go -package mocks
+
+type PriceServiceManager interface {
+	GetPriceTimeline(productID string) (dto.PriceTimelineDTO, error)
+	SchedulePrice(adminID, productID string, req dto.PriceScheduleDTO) (models.PriceSchedule, error)
+	CancelSchedule(productID, scheduleID string) (models.PriceSchedule, error)
+	ApplyDueSchedules(now time.Time) error
+	RunScheduler(interval time.Duration)
+}
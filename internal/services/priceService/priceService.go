@@ -0,0 +1,238 @@
+package priceService
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/priceRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+)
+
+var (
+	ErrProductNotFound  = errors.New("product not found")
+	ErrScheduleNotFound = errors.New("price schedule not found")
+	ErrScheduleOverlap  = errors.New("the product already has a price scheduled for this time")
+	ErrScheduleClosed   = errors.New("this price schedule has already ended or been cancelled")
+	ErrNotASale         = errors.New("a sale price must be below the product's regular price")
+)
+
+type PriceService struct {
+	priceRepo   priceRepository.PriceManager
+	productRepo productRepository.ProductManager
+	// mu keeps the scheduler and admins from changing a product's schedules
+	// at the same time.
+	mu sync.Mutex
+}
+
+func NewPriceService(priceRepo priceRepository.PriceManager, productRepo productRepository.ProductManager) PriceServiceManager {
+	return &PriceService{priceRepo: priceRepo, productRepo: productRepo}
+}
+
+func (ps *PriceService) GetPriceTimeline(productID string) (dto.PriceTimelineDTO, error) {
+	product, err := ps.productRepo.GetProductByID(productID)
+	if err != nil {
+		return dto.PriceTimelineDTO{}, ErrProductNotFound
+	}
+	history, err := ps.priceRepo.ListPriceChanges(productID)
+	if err != nil {
+		return dto.PriceTimelineDTO{}, fmt.Errorf("can not fetch price history: %v", err)
+	}
+	schedules, err := ps.priceRepo.ListSchedules(productID)
+	if err != nil {
+		return dto.PriceTimelineDTO{}, fmt.Errorf("can not fetch price schedules: %v", err)
+	}
+	timeline := dto.PriceTimelineDTO{
+		ProductID:      product.ID,
+		Price:          product.Price,
+		CompareAtPrice: product.CompareAtPrice,
+		History:        history,
+		Schedules:      schedules,
+	}
+	if timeline.History == nil {
+		timeline.History = []models.PriceChange{}
+	}
+	if timeline.Schedules == nil {
+		timeline.Schedules = []models.PriceSchedule{}
+	}
+	return timeline, nil
+}
+
+// SchedulePrice adds a price for the product from req.StartsAt. A product
+// has one price at a time, so schedules may not overlap.
+func (ps *PriceService) SchedulePrice(adminID, productID string, req dto.PriceScheduleDTO) (models.PriceSchedule, error) {
+	now := time.Now().UTC()
+	switch {
+	case req.Price <= 0:
+		return models.PriceSchedule{}, fmt.Errorf("price must be greater than zero")
+	case req.StartsAt == nil:
+		return models.PriceSchedule{}, fmt.Errorf("starts_at is required")
+	case req.StartsAt.Before(now):
+		return models.PriceSchedule{}, fmt.Errorf("starts_at must be in the future")
+	case req.EndsAt != nil && !req.EndsAt.After(*req.StartsAt):
+		return models.PriceSchedule{}, fmt.Errorf("ends_at must be after starts_at")
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	product, err := ps.productRepo.GetProductByID(productID)
+	if err != nil {
+		return models.PriceSchedule{}, ErrProductNotFound
+	}
+	if req.EndsAt != nil && req.Price >= product.RegularPrice() {
+		return models.PriceSchedule{}, ErrNotASale
+	}
+	schedule := models.PriceSchedule{
+		ID:        utils.NewUUID(),
+		ProductID: productID,
+		Price:     req.Price,
+		StartsAt:  req.StartsAt.UTC(),
+		Status:    models.SchedulePending,
+		CreatedBy: adminID,
+		CreatedAt: now,
+	}
+	if req.EndsAt != nil {
+		endsAt := req.EndsAt.UTC()
+		schedule.EndsAt = &endsAt
+	}
+	schedules, err := ps.priceRepo.ListSchedules(productID)
+	if err != nil {
+		return models.PriceSchedule{}, fmt.Errorf("can not fetch price schedules: %v", err)
+	}
+	for _, other := range schedules {
+		if other.Open() && overlaps(schedule, other) {
+			return models.PriceSchedule{}, ErrScheduleOverlap
+		}
+	}
+	err = ps.priceRepo.SaveSchedule(schedule)
+	if err != nil {
+		return models.PriceSchedule{}, fmt.Errorf("can not save price schedule: %v", err)
+	}
+	return schedule, nil
+}
+
+// overlaps reports whether two schedules would be in force at the same time.
+// A schedule without an end takes effect at a single instant.
+func overlaps(a, b models.PriceSchedule) bool {
+	if a.EndsAt == nil && b.EndsAt == nil {
+		return a.StartsAt.Equal(b.StartsAt)
+	}
+	if a.EndsAt == nil {
+		a, b = b, a
+	}
+	if b.EndsAt == nil {
+		return !b.StartsAt.Before(a.StartsAt) && b.StartsAt.Before(*a.EndsAt)
+	}
+	return a.StartsAt.Before(*b.EndsAt) && b.StartsAt.Before(*a.EndsAt)
+}
+
+// CancelSchedule drops a schedule that has not started, or ends a running
+// sale at once.
+func (ps *PriceService) CancelSchedule(productID, scheduleID string) (models.PriceSchedule, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	schedule, err := ps.priceRepo.GetSchedule(scheduleID)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && schedule.ProductID != productID {
+		return models.PriceSchedule{}, ErrScheduleNotFound
+	}
+	if err != nil {
+		return models.PriceSchedule{}, fmt.Errorf("can not fetch price schedule: %v", err)
+	}
+	switch schedule.Status {
+	case models.SchedulePending:
+		err = ps.priceRepo.UpdateScheduleStatus(schedule.ID, models.ScheduleCancelled)
+	case models.ScheduleActive:
+		err = ps.endSale(schedule, models.PriceSaleCancelled, models.ScheduleCancelled, time.Now().UTC())
+	default:
+		return models.PriceSchedule{}, ErrScheduleClosed
+	}
+	if err != nil {
+		return models.PriceSchedule{}, fmt.Errorf("can not cancel price schedule: %v", err)
+	}
+	schedule.Status = models.ScheduleCancelled
+	return schedule, nil
+}
+
+// ApplyDueSchedules starts and ends the schedules that are due by now. A
+// schedule that fails is logged and tried again on the next run.
+func (ps *PriceService) ApplyDueSchedules(now time.Time) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	due, err := ps.priceRepo.DueSchedules(now)
+	if err != nil {
+		return fmt.Errorf("can not fetch due price schedules: %v", err)
+	}
+	for _, schedule := range due {
+		err := ps.applySchedule(schedule, now)
+		if err != nil {
+			log.Printf("can not apply price schedule %s: %v", schedule.ID, err)
+		}
+	}
+	return nil
+}
+
+func (ps *PriceService) applySchedule(schedule models.PriceSchedule, now time.Time) error {
+	if schedule.Status == models.ScheduleActive {
+		return ps.endSale(schedule, models.PriceSaleEnded, models.ScheduleEnded, now)
+	}
+	product, err := ps.productRepo.GetProductByID(schedule.ProductID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case schedule.EndsAt == nil:
+		// a lasting change sets the regular price, like an admin update
+		price, compareAt := schedule.Price, product.CompareAtPrice
+		if compareAt != nil {
+			price, compareAt = product.Price, &schedule.Price
+		}
+		return ps.applyPrice(schedule, price, compareAt, models.PriceScheduled, models.ScheduleEnded, now)
+	case !schedule.EndsAt.After(now):
+		// the whole sale passed while the server was down
+		return ps.priceRepo.UpdateScheduleStatus(schedule.ID, models.ScheduleEnded)
+	default:
+		regular := product.RegularPrice()
+		return ps.applyPrice(schedule, schedule.Price, &regular, models.PriceSaleStarted, models.ScheduleActive, now)
+	}
+}
+
+// endSale brings back the product's regular price.
+func (ps *PriceService) endSale(schedule models.PriceSchedule, reason models.PriceReason, status models.ScheduleStatus, now time.Time) error {
+	product, err := ps.productRepo.GetProductByID(schedule.ProductID)
+	if err != nil {
+		return err
+	}
+	return ps.applyPrice(schedule, product.RegularPrice(), nil, reason, status, now)
+}
+
+func (ps *PriceService) applyPrice(schedule models.PriceSchedule, price float32, compareAt *float32, reason models.PriceReason, status models.ScheduleStatus, now time.Time) error {
+	return ps.priceRepo.ApplyScheduledPrice(models.PriceChange{
+		ID:             utils.NewUUID(),
+		ProductID:      schedule.ProductID,
+		Price:          price,
+		CompareAtPrice: compareAt,
+		Reason:         reason,
+		ScheduleID:     schedule.ID,
+		ChangedAt:      now,
+	}, status)
+}
+
+// RunScheduler applies due schedules at once and then every interval. It is
+// run in its own goroutine for the life of the server.
+func (ps *PriceService) RunScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := ps.ApplyDueSchedules(time.Now().UTC())
+		if err != nil {
+			log.Printf("can not apply price schedules: %v", err)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,216 @@
+package priceService
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+func setup(t *testing.T) (*mocks.MockPriceManager, *mocks.MockProductManager, PriceServiceManager) {
+	ctrl := gomock.NewController(t)
+	mockPriceRepo := mocks.NewMockPriceManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	return mockPriceRepo, mockProductRepo, NewPriceService(mockPriceRepo, mockProductRepo)
+}
+
+func TestGetPriceTimeline(t *testing.T) {
+	mockPriceRepo, mockProductRepo, service := setup(t)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", Price: 100}, nil)
+	mockPriceRepo.EXPECT().ListPriceChanges("p1").Return(nil, nil)
+	mockPriceRepo.EXPECT().ListSchedules("p1").Return(nil, nil)
+
+	timeline, err := service.GetPriceTimeline("p1")
+	if err != nil || timeline.Price != 100 || timeline.History == nil || timeline.Schedules == nil {
+		t.Errorf("unexpected timeline: %+v, err: %v", timeline, err)
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("404").Return(models.Product{}, sql.ErrNoRows)
+	_, err = service.GetPriceTimeline("404")
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestSchedulePrice(t *testing.T) {
+	mockPriceRepo, mockProductRepo, service := setup(t)
+
+	starts := time.Now().Add(24 * time.Hour)
+	ends := starts.Add(48 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+	for _, req := range []dto.PriceScheduleDTO{
+		{Price: 0, StartsAt: &starts},
+		{Price: 80},
+		{Price: 80, StartsAt: &past},
+		{Price: 80, StartsAt: &starts, EndsAt: &starts},
+	} {
+		if _, err := service.SchedulePrice("admin1", "p1", req); err == nil {
+			t.Errorf("expected an error for %+v", req)
+		}
+	}
+
+	// A sale must be cheaper than the regular price
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", Price: 100}, nil).AnyTimes()
+	_, err := service.SchedulePrice("admin1", "p1", dto.PriceScheduleDTO{Price: 120, StartsAt: &starts, EndsAt: &ends})
+	if !errors.Is(err, ErrNotASale) {
+		t.Errorf("expected ErrNotASale, got %v", err)
+	}
+
+	// Overlapping an open schedule
+	other := models.PriceSchedule{ID: "s0", ProductID: "p1", StartsAt: ends.Add(-time.Hour), Status: models.SchedulePending}
+	mockPriceRepo.EXPECT().ListSchedules("p1").Return([]models.PriceSchedule{other}, nil)
+	_, err = service.SchedulePrice("admin1", "p1", dto.PriceScheduleDTO{Price: 80, StartsAt: &starts, EndsAt: &ends})
+	if !errors.Is(err, ErrScheduleOverlap) {
+		t.Errorf("expected ErrScheduleOverlap, got %v", err)
+	}
+
+	// A cancelled schedule does not count
+	other.Status = models.ScheduleCancelled
+	mockPriceRepo.EXPECT().ListSchedules("p1").Return([]models.PriceSchedule{other}, nil)
+	mockPriceRepo.EXPECT().SaveSchedule(gomock.Any()).Return(nil)
+	schedule, err := service.SchedulePrice("admin1", "p1", dto.PriceScheduleDTO{Price: 80, StartsAt: &starts, EndsAt: &ends})
+	if err != nil || schedule.ID == "" || schedule.Status != models.SchedulePending || schedule.CreatedBy != "admin1" || !schedule.EndsAt.Equal(ends) {
+		t.Errorf("unexpected schedule: %+v, err: %v", schedule, err)
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	at := func(hours int) *time.Time {
+		t := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(hours) * time.Hour)
+		return &t
+	}
+	schedule := func(start int, end *time.Time) models.PriceSchedule {
+		return models.PriceSchedule{StartsAt: *at(start), EndsAt: end}
+	}
+	tests := []struct {
+		a, b     models.PriceSchedule
+		expected bool
+	}{
+		{schedule(0, at(10)), schedule(5, at(15)), true},
+		{schedule(0, at(10)), schedule(10, at(20)), false},
+		{schedule(0, at(10)), schedule(5, nil), true},
+		{schedule(10, nil), schedule(0, at(10)), false},
+		{schedule(5, nil), schedule(5, nil), true},
+		{schedule(5, nil), schedule(6, nil), false},
+	}
+	for i, test := range tests {
+		if got := overlaps(test.a, test.b); got != test.expected {
+			t.Errorf("case %d: expected %v, got %v", i, test.expected, got)
+		}
+	}
+}
+
+func TestApplyDueSchedules(t *testing.T) {
+	mockPriceRepo, mockProductRepo, service := setup(t)
+
+	now := time.Now().UTC()
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Minute)
+	regular := float32(100)
+	due := []models.PriceSchedule{
+		// a sale ending, a sale starting, a lasting change during a sale
+		// and a sale that passed while the server was down
+		{ID: "s1", ProductID: "p1", Price: 70, EndsAt: &earlier, Status: models.ScheduleActive},
+		{ID: "s2", ProductID: "p2", Price: 80, EndsAt: &later, Status: models.SchedulePending},
+		{ID: "s3", ProductID: "p3", Price: 120, Status: models.SchedulePending},
+		{ID: "s4", ProductID: "p4", Price: 60, EndsAt: &earlier, Status: models.SchedulePending},
+	}
+	mockPriceRepo.EXPECT().DueSchedules(now).Return(due, nil)
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", Price: 70, CompareAtPrice: &regular}, nil)
+	mockProductRepo.EXPECT().GetProductByID("p2").Return(models.Product{ID: "p2", Price: 100}, nil)
+	mockProductRepo.EXPECT().GetProductByID("p3").Return(models.Product{ID: "p3", Price: 90, CompareAtPrice: &regular}, nil)
+	mockProductRepo.EXPECT().GetProductByID("p4").Return(models.Product{ID: "p4", Price: 100}, nil)
+
+	type applied struct {
+		change models.PriceChange
+		status models.ScheduleStatus
+	}
+	var changes []applied
+	mockPriceRepo.EXPECT().ApplyScheduledPrice(gomock.Any(), gomock.Any()).DoAndReturn(func(change models.PriceChange, status models.ScheduleStatus) error {
+		changes = append(changes, applied{change, status})
+		return nil
+	}).Times(3)
+	mockPriceRepo.EXPECT().UpdateScheduleStatus("s4", models.ScheduleEnded).Return(nil)
+
+	if err := service.ApplyDueSchedules(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes)
+	}
+	if c := changes[0]; c.change.Price != 100 || c.change.CompareAtPrice != nil || c.change.Reason != models.PriceSaleEnded || c.status != models.ScheduleEnded {
+		t.Errorf("unexpected sale end: %+v", c)
+	}
+	if c := changes[1]; c.change.Price != 80 || *c.change.CompareAtPrice != 100 || c.change.Reason != models.PriceSaleStarted || c.status != models.ScheduleActive {
+		t.Errorf("unexpected sale start: %+v", c)
+	}
+	if c := changes[2]; c.change.Price != 90 || *c.change.CompareAtPrice != 120 || c.change.Reason != models.PriceScheduled || c.status != models.ScheduleEnded {
+		t.Errorf("unexpected lasting change: %+v", c)
+	}
+}
+
+func TestApplyDueSchedulesKeepsGoing(t *testing.T) {
+	mockPriceRepo, mockProductRepo, service := setup(t)
+
+	now := time.Now().UTC()
+	due := []models.PriceSchedule{
+		{ID: "s1", ProductID: "p1", Price: 70, Status: models.SchedulePending},
+		{ID: "s2", ProductID: "p2", Price: 80, Status: models.SchedulePending},
+	}
+	mockPriceRepo.EXPECT().DueSchedules(now).Return(due, nil)
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{}, errors.New("database is locked"))
+	mockProductRepo.EXPECT().GetProductByID("p2").Return(models.Product{ID: "p2", Price: 100}, nil)
+	mockPriceRepo.EXPECT().ApplyScheduledPrice(gomock.Any(), models.ScheduleEnded).Return(nil)
+
+	if err := service.ApplyDueSchedules(now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCancelSchedule(t *testing.T) {
+	mockPriceRepo, mockProductRepo, service := setup(t)
+
+	// Not started
+	mockPriceRepo.EXPECT().GetSchedule("s1").Return(models.PriceSchedule{ID: "s1", ProductID: "p1", Status: models.SchedulePending}, nil)
+	mockPriceRepo.EXPECT().UpdateScheduleStatus("s1", models.ScheduleCancelled).Return(nil)
+	schedule, err := service.CancelSchedule("p1", "s1")
+	if err != nil || schedule.Status != models.ScheduleCancelled {
+		t.Errorf("unexpected result: %+v, err: %v", schedule, err)
+	}
+
+	// A running sale ends at once
+	regular := float32(100)
+	mockPriceRepo.EXPECT().GetSchedule("s2").Return(models.PriceSchedule{ID: "s2", ProductID: "p1", Status: models.ScheduleActive}, nil)
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1", Price: 80, CompareAtPrice: &regular}, nil)
+	mockPriceRepo.EXPECT().ApplyScheduledPrice(gomock.Any(), models.ScheduleCancelled).DoAndReturn(func(change models.PriceChange, status models.ScheduleStatus) error {
+		if change.Price != 100 || change.CompareAtPrice != nil || change.Reason != models.PriceSaleCancelled {
+			t.Errorf("unexpected price change: %+v", change)
+		}
+		return nil
+	})
+	if _, err := service.CancelSchedule("p1", "s2"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// Already ended
+	mockPriceRepo.EXPECT().GetSchedule("s3").Return(models.PriceSchedule{ID: "s3", ProductID: "p1", Status: models.ScheduleEnded}, nil)
+	if _, err := service.CancelSchedule("p1", "s3"); !errors.Is(err, ErrScheduleClosed) {
+		t.Errorf("expected ErrScheduleClosed, got %v", err)
+	}
+
+	// Another product's schedule, or none
+	mockPriceRepo.EXPECT().GetSchedule("s4").Return(models.PriceSchedule{ID: "s4", ProductID: "p2", Status: models.SchedulePending}, nil)
+	if _, err := service.CancelSchedule("p1", "s4"); !errors.Is(err, ErrScheduleNotFound) {
+		t.Errorf("expected ErrScheduleNotFound, got %v", err)
+	}
+	mockPriceRepo.EXPECT().GetSchedule("s5").Return(models.PriceSchedule{}, sql.ErrNoRows)
+	if _, err := service.CancelSchedule("p1", "s5"); !errors.Is(err, ErrScheduleNotFound) {
+		t.Errorf("expected ErrScheduleNotFound, got %v", err)
+	}
+}
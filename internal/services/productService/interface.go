@@ -1,11 +1,15 @@
 package productService
 
-import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+import (
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
 
 //go:generate mockgen -source=interface.go -destination=../../mocks/mock_productService.go -package mocks
 
 type ProductServiceManager interface {
-	GetAllProducts() ([]models.Product, error)
+	ListProducts(query models.ProductQuery) (dto.ProductListDTO, error)
 	GetProductByID(id string) (models.Product, error)
-	GetProductByName(name *string) ([]models.Product, error)
+	GetAdminProduct(id string) (models.Product, error)
+	SearchProducts(terms string, limit, offset int) (dto.ProductSearchDTO, error)
 }
\ No newline at end of file
@@ -1,40 +1,168 @@
 package productService
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/categoryRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/variantRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/imageService"
 )
 
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrProductNotFound  = errors.New("no product with specified id found")
+	ErrEmptySearch      = errors.New("search terms are required")
+	ErrSearchTooLong    = fmt.Errorf("search terms must be at most %d characters long", maxSearchLen)
+)
+
+const maxSearchLen = 200
+
 type ProductService struct {
-	productRepo productRepository.ProductManager
+	productRepo  productRepository.ProductManager
+	categoryRepo categoryRepository.CategoryManager
+	variantRepo  variantRepository.VariantManager
+	imageServ    imageService.ImageServiceManager
 }
 
-func NewProductService(productRepo productRepository.ProductManager) ProductServiceManager {
-	return &ProductService{productRepo: productRepo}
+func NewProductService(productRepo productRepository.ProductManager, categoryRepo categoryRepository.CategoryManager, variantRepo variantRepository.VariantManager, imageServ imageService.ImageServiceManager) ProductServiceManager {
+	return &ProductService{productRepo: productRepo, categoryRepo: categoryRepo, variantRepo: variantRepo, imageServ: imageServ}
 }
 
-func (ps *ProductService) GetAllProducts() ([]models.Product, error) {
-	products, err := ps.productRepo.GetAllProducts()
+// ListProducts returns one page of the products matching the query. Filtering
+// on a category that does not exist is an error rather than an empty page.
+func (ps *ProductService) ListProducts(query models.ProductQuery) (dto.ProductListDTO, error) {
+	if query.Category != "" {
+		_, err := ps.categoryRepo.GetCategoryBySlug(query.Category)
+		if err != nil {
+			return dto.ProductListDTO{}, ErrCategoryNotFound
+		}
+	}
+	products, total, err := ps.productRepo.ListProducts(query)
+	if err != nil {
+		return dto.ProductListDTO{}, fmt.Errorf("can not fetch products")
+	}
+	err = ps.attachImages(products)
 	if err != nil {
-		return nil, fmt.Errorf("can not fetch products")
+		return dto.ProductListDTO{}, err
+	}
+	list := dto.ProductListDTO{
+		Products: products,
+		Total:    total,
+		Page:     1,
+		Limit:    query.Limit,
+	}
+	if list.Products == nil {
+		list.Products = []models.Product{}
 	}
-	return products, nil
+	if query.Limit > 0 {
+		list.Page = query.Offset/query.Limit + 1
+	}
+	return list, nil
 }
 
+// GetProductByID returns the product with its options, variants and images.
+// Drafts and archived products are not found.
 func (ps *ProductService) GetProductByID(id string) (models.Product, error) {
+	return ps.getProduct(id, false)
+}
+
+// GetAdminProduct is GetProductByID for admins, finding products in any
+// status.
+func (ps *ProductService) GetAdminProduct(id string) (models.Product, error) {
+	return ps.getProduct(id, true)
+}
+
+func (ps *ProductService) getProduct(id string, includeHidden bool) (models.Product, error) {
 	product, err := ps.productRepo.GetProductByID(id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && !includeHidden && !product.Buyable()) {
+		return models.Product{}, ErrProductNotFound
+	}
 	if err != nil {
-		return models.Product{}, fmt.Errorf("no product with specified id found")
+		return models.Product{}, fmt.Errorf("can not fetch product: %v", err)
 	}
+	product.Options, err = ps.variantRepo.GetOptions(id)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("can not fetch product options: %v", err)
+	}
+	product.Variants, err = ps.variantRepo.ListVariants(id)
+	if err != nil {
+		return models.Product{}, fmt.Errorf("can not fetch product variants: %v", err)
+	}
+	images, err := ps.imageServ.ImagesFor([]string{id})
+	if err != nil {
+		return models.Product{}, err
+	}
+	product.Images = images[id]
 	return product, nil
 }
 
-func (ps *ProductService) GetProductByName(name *string) ([]models.Product, error) {
-	products, err := ps.productRepo.GetProductByName(name)
+// SearchProducts runs a ranked full-text search. The highlighted name and the
+// snippet of each result are HTML escaped, with matched terms in <mark>.
+func (ps *ProductService) SearchProducts(terms string, limit, offset int) (dto.ProductSearchDTO, error) {
+	terms = strings.TrimSpace(terms)
+	if terms == "" {
+		return dto.ProductSearchDTO{}, ErrEmptySearch
+	}
+	if utf8.RuneCountInString(terms) > maxSearchLen {
+		return dto.ProductSearchDTO{}, ErrSearchTooLong
+	}
+	results, total, err := ps.productRepo.SearchProducts(terms, limit, offset)
+	if err != nil {
+		return dto.ProductSearchDTO{}, fmt.Errorf("can not search products")
+	}
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = result.ID
+	}
+	images, err := ps.imageServ.ImagesFor(ids)
+	if err != nil {
+		return dto.ProductSearchDTO{}, err
+	}
+	search := dto.ProductSearchDTO{
+		Query:   terms,
+		Results: make([]models.ProductSearchResult, 0, len(results)),
+		Total:   total,
+		Page:    1,
+		Limit:   limit,
+	}
+	if limit > 0 {
+		search.Page = offset/limit + 1
+	}
+	for _, result := range results {
+		result.Highlight = markMatches(result.Highlight)
+		result.Snippet = markMatches(result.Snippet)
+		result.Images = images[result.ID]
+		search.Results = append(search.Results, result)
+	}
+	return search, nil
+}
+
+// attachImages fills in the galleries of a page of products.
+func (ps *ProductService) attachImages(products []models.Product) error {
+	ids := make([]string, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+	images, err := ps.imageServ.ImagesFor(ids)
 	if err != nil {
-		return nil, fmt.Errorf("no product with specified name found")
+		return err
 	}
-	return products, nil
+	for i := range products {
+		products[i].Images = images[products[i].ID]
+	}
+	return nil
+}
+
+var searchMarks = strings.NewReplacer(models.SearchMarkStart, "<mark>", models.SearchMarkEnd, "</mark>")
+
+func markMatches(text string) string {
+	return searchMarks.Replace(html.EscapeString(text))
 }
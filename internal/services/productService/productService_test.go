@@ -1,84 +1,193 @@
 package productService
 
 import (
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 	"go.uber.org/mock/gomock"
 )
 
-func TestGetAllProducts(t *testing.T) {
+func TestListProducts(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockProductManager(ctrl)
-	service := NewProductService(mockRepo)
+	mockCategoryRepo := mocks.NewMockCategoryManager(ctrl)
+	mockImageServ := mocks.NewMockImageServiceManager(ctrl)
+	service := NewProductService(mockRepo, mockCategoryRepo, nil, mockImageServ)
 
 	expectedProducts := []models.Product{
 		{ID: "1", Name: "Product1", Price: 100, Stock: 10},
 		{ID: "2", Name: "Product2", Price: 200, Stock: 5},
 	}
+	query := models.ProductQuery{Name: "Product", Limit: 2, Offset: 4}
 
-	mockRepo.EXPECT().GetAllProducts().Return(expectedProducts, nil)
+	mockRepo.EXPECT().ListProducts(query).Return(expectedProducts, 6, nil)
+	mockImageServ.EXPECT().ImagesFor([]string{"1", "2"}).
+		Return(map[string][]models.ProductImage{"2": {{ID: "i1", ProductID: "2", Primary: true}}}, nil)
 
-	products, err := service.GetAllProducts()
-	if err != nil || len(products) != 2 {
-		t.Errorf("unexpected error or wrong product count: %v", err)
+	list, err := service.ListProducts(query)
+	if err != nil || len(list.Products) != 2 || list.Total != 6 || list.Page != 3 || list.Limit != 2 {
+		t.Errorf("unexpected list: %+v, err: %v", list, err)
+	}
+	if list.Products[0].Images != nil || len(list.Products[1].Images) != 1 {
+		t.Errorf("unexpected images: %+v", list.Products)
 	}
 
-	mockRepo.EXPECT().GetAllProducts().Return(nil, errors.New("db error"))
-	_, err = service.GetAllProducts()
+	mockRepo.EXPECT().ListProducts(query).Return(nil, 0, errors.New("db error"))
+	_, err = service.ListProducts(query)
 	if err == nil {
 		t.Error("expected error for failed fetch")
 	}
 }
 
+func TestListProductsEmpty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProductManager(ctrl)
+	mockCategoryRepo := mocks.NewMockCategoryManager(ctrl)
+	mockImageServ := mocks.NewMockImageServiceManager(ctrl)
+	service := NewProductService(mockRepo, mockCategoryRepo, nil, mockImageServ)
+
+	query := models.ProductQuery{Limit: 20}
+	mockRepo.EXPECT().ListProducts(query).Return(nil, 0, nil)
+	mockImageServ.EXPECT().ImagesFor([]string{}).Return(map[string][]models.ProductImage{}, nil)
+
+	list, err := service.ListProducts(query)
+	if err != nil || list.Products == nil || list.Page != 1 {
+		t.Errorf("unexpected list: %+v, err: %v", list, err)
+	}
+}
+
+func TestListProductsByCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProductManager(ctrl)
+	mockCategoryRepo := mocks.NewMockCategoryManager(ctrl)
+	mockImageServ := mocks.NewMockImageServiceManager(ctrl)
+	service := NewProductService(mockRepo, mockCategoryRepo, nil, mockImageServ)
+
+	query := models.ProductQuery{Category: "computers", Limit: 20}
+	mockCategoryRepo.EXPECT().GetCategoryBySlug("computers").Return(models.Category{ID: "c1", Slug: "computers"}, nil)
+	mockRepo.EXPECT().ListProducts(query).Return([]models.Product{{ID: "1", Name: "Laptop"}}, 1, nil)
+	mockImageServ.EXPECT().ImagesFor([]string{"1"}).Return(map[string][]models.ProductImage{}, nil)
+
+	list, err := service.ListProducts(query)
+	if err != nil || len(list.Products) != 1 {
+		t.Errorf("unexpected list: %+v, err: %v", list, err)
+	}
+
+	query.Category = "missing"
+	mockCategoryRepo.EXPECT().GetCategoryBySlug("missing").Return(models.Category{}, sql.ErrNoRows)
+	_, err = service.ListProducts(query)
+	if !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("expected ErrCategoryNotFound, got %v", err)
+	}
+}
+
 func TestGetProductByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockProductManager(ctrl)
-	service := NewProductService(mockRepo)
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	mockImageServ := mocks.NewMockImageServiceManager(ctrl)
+	service := NewProductService(mockRepo, mocks.NewMockCategoryManager(ctrl), mockVariantRepo, mockImageServ)
 
-	expectedProduct := models.Product{ID: "1", Name: "Product1", Price: 100, Stock: 10}
+	expectedProduct := models.Product{ID: "1", Name: "Product1", Price: 100, Stock: 10, Status: models.ProductPublished}
 	mockRepo.EXPECT().GetProductByID("1").Return(expectedProduct, nil)
+	mockVariantRepo.EXPECT().GetOptions("1").Return([]models.ProductOption{{Name: "size", Values: []string{"S", "M"}}}, nil)
+	mockVariantRepo.EXPECT().ListVariants("1").Return([]models.Variant{{ID: "v1", ProductID: "1", SKU: "P1-S", Options: map[string]string{"size": "S"}}}, nil)
+	mockImageServ.EXPECT().ImagesFor([]string{"1"}).Return(map[string][]models.ProductImage{"1": {{ID: "i1", ProductID: "1"}}}, nil)
 
 	product, err := service.GetProductByID("1")
-	if err != nil || product.ID != "1" {
-		t.Errorf("unexpected error or wrong product: %v", err)
+	if err != nil || product.ID != "1" || len(product.Options) != 1 || len(product.Variants) != 1 || len(product.Images) != 1 {
+		t.Errorf("unexpected error or wrong product: %+v, %v", product, err)
 	}
 
-	mockRepo.EXPECT().GetProductByID("404").Return(models.Product{}, errors.New("not found"))
+	mockRepo.EXPECT().GetProductByID("404").Return(models.Product{}, sql.ErrNoRows)
 	_, err = service.GetProductByID("404")
-	if err == nil {
-		t.Error("expected error for missing product")
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+
+	mockRepo.EXPECT().GetProductByID("500").Return(models.Product{}, errors.New("db error"))
+	_, err = service.GetProductByID("500")
+	if err == nil || errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected a lookup error, got %v", err)
 	}
 }
 
-func TestGetProductByName(t *testing.T) {
+func TestGetHiddenProduct(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockProductManager(ctrl)
-	service := NewProductService(mockRepo)
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	mockImageServ := mocks.NewMockImageServiceManager(ctrl)
+	service := NewProductService(mockRepo, nil, mockVariantRepo, mockImageServ)
+
+	archivedAt := time.Now()
+	hidden := []models.Product{
+		{ID: "1", Status: models.ProductDraft},
+		{ID: "1", Status: models.ProductPublished, ArchivedAt: &archivedAt},
+	}
+	for _, product := range hidden {
+		mockRepo.EXPECT().GetProductByID("1").Return(product, nil)
+		if _, err := service.GetProductByID("1"); !errors.Is(err, ErrProductNotFound) {
+			t.Errorf("%+v: expected ErrProductNotFound, got %v", product, err)
+		}
+	}
 
-	name := "Product1"
-	expectedProducts := []models.Product{
-		{ID: "1", Name: "Product1", Price: 100, Stock: 10},
+	// admins still see them
+	mockRepo.EXPECT().GetProductByID("1").Return(hidden[0], nil)
+	mockVariantRepo.EXPECT().GetOptions("1").Return(nil, nil)
+	mockVariantRepo.EXPECT().ListVariants("1").Return(nil, nil)
+	mockImageServ.EXPECT().ImagesFor([]string{"1"}).Return(map[string][]models.ProductImage{}, nil)
+	product, err := service.GetAdminProduct("1")
+	if err != nil || product.Status != models.ProductDraft {
+		t.Errorf("unexpected result: %+v, err: %v", product, err)
+	}
+}
+
+func TestSearchProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockProductManager(ctrl)
+	mockImageServ := mocks.NewMockImageServiceManager(ctrl)
+	service := NewProductService(mockRepo, mocks.NewMockCategoryManager(ctrl), nil, mockImageServ)
+
+	if _, err := service.SearchProducts("  ", 20, 0); !errors.Is(err, ErrEmptySearch) {
+		t.Errorf("expected ErrEmptySearch, got %v", err)
 	}
 
-	mockRepo.EXPECT().GetProductByName(&name).Return(expectedProducts, nil)
+	mockRepo.EXPECT().SearchProducts("head", 10, 10).Return([]models.ProductSearchResult{{
+		Product:   models.Product{ID: "p3", Name: "Headphones"},
+		Highlight: models.SearchMarkStart + "Head" + models.SearchMarkEnd + "phones",
+		Snippet:   "<b>" + models.SearchMarkStart + "Head" + models.SearchMarkEnd + "</b> & more",
+	}}, 11, nil)
+	mockImageServ.EXPECT().ImagesFor([]string{"p3"}).Return(map[string][]models.ProductImage{}, nil)
 
-	products, err := service.GetProductByName(&name)
-	if err != nil || len(products) != 1 {
-		t.Errorf("unexpected error or wrong product count: %v", err)
+	search, err := service.SearchProducts(" head ", 10, 10)
+	if err != nil || search.Total != 11 || search.Page != 2 || len(search.Results) != 1 {
+		t.Fatalf("unexpected search: %+v, err: %v", search, err)
+	}
+	if search.Results[0].Highlight != "<mark>Head</mark>phones" {
+		t.Errorf("unexpected highlight %q", search.Results[0].Highlight)
+	}
+	if search.Results[0].Snippet != "&lt;b&gt;<mark>Head</mark>&lt;/b&gt; &amp; more" {
+		t.Errorf("unexpected snippet %q", search.Results[0].Snippet)
 	}
 
-	mockRepo.EXPECT().GetProductByName(&name).Return(nil, errors.New("not found"))
-	_, err = service.GetProductByName(&name)
-	if err == nil {
-		t.Error("expected error for missing product by name")
+	mockRepo.EXPECT().SearchProducts("head", 10, 0).Return(nil, 0, errors.New("no such module: fts5"))
+	if _, err := service.SearchProducts("head", 10, 0); err == nil {
+		t.Error("expected error for failed search")
 	}
 }
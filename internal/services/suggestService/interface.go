@@ -0,0 +1,10 @@
+package suggestService
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_suggestService.go -package mocks
+
+type SuggestServiceManager interface {
+	Refresh() error
+	Suggest(query string) (dto.SuggestionDTO, error)
+}
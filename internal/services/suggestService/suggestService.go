@@ -0,0 +1,197 @@
+package suggestService
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+)
+
+var (
+	ErrEmptyQuery   = errors.New("a query is required")
+	ErrQueryTooLong = fmt.Errorf("query must be at most %d characters long", maxQueryLen)
+)
+
+const (
+	maxCompletions = 8
+	maxQueryLen    = 100
+	refreshPage    = 500
+)
+
+// termIndex holds every word of the catalogue's product names and tags with
+// the number of products it appears in. It is never modified once built.
+type termIndex struct {
+	counts map[string]int
+	sorted []string
+}
+
+type SuggestService struct {
+	productRepo productRepository.ProductManager
+	mu          sync.RWMutex
+	index       termIndex
+}
+
+func NewSuggestService(productRepo productRepository.ProductManager) SuggestServiceManager {
+	return &SuggestService{
+		productRepo: productRepo,
+		index:       termIndex{counts: map[string]int{}},
+	}
+}
+
+// Refresh rebuilds the term index from the catalogue. Suggestions keep using
+// the previous index until the new one is ready.
+func (ss *SuggestService) Refresh() error {
+	counts := map[string]int{}
+	query := models.ProductQuery{Limit: refreshPage}
+	for {
+		products, total, err := ss.productRepo.ListProducts(query)
+		if err != nil {
+			return fmt.Errorf("can not load products: %v", err)
+		}
+		for _, product := range products {
+			words := tokenize(product.Name + " " + strings.Join(product.Tags, " "))
+			slices.Sort(words)
+			for _, word := range slices.Compact(words) {
+				counts[word]++
+			}
+		}
+		query.Offset += len(products)
+		if len(products) == 0 || query.Offset >= total {
+			break
+		}
+	}
+
+	index := termIndex{counts: counts, sorted: make([]string, 0, len(counts))}
+	for term := range counts {
+		index.sorted = append(index.sorted, term)
+	}
+	slices.Sort(index.sorted)
+
+	ss.mu.Lock()
+	ss.index = index
+	ss.mu.Unlock()
+	return nil
+}
+
+// Suggest completes the last word of the query from catalogue terms. Earlier
+// words that are not catalogue terms, and a last word no term starts with, are
+// replaced by the closest term by edit distance, which is offered as
+// DidYouMean and used for the completions.
+func (ss *SuggestService) Suggest(query string) (dto.SuggestionDTO, error) {
+	query = strings.TrimSpace(query)
+	if utf8.RuneCountInString(query) > maxQueryLen {
+		return dto.SuggestionDTO{}, ErrQueryTooLong
+	}
+	words := tokenize(query)
+	if len(words) == 0 {
+		return dto.SuggestionDTO{}, ErrEmptyQuery
+	}
+
+	ss.mu.RLock()
+	index := ss.index
+	ss.mu.RUnlock()
+
+	suggestion := dto.SuggestionDTO{Query: query, Completions: []string{}}
+	corrected := slices.Clone(words)
+	changed := false
+	for i, word := range words {
+		last := i == len(words)-1
+		if index.counts[word] > 0 || (last && index.hasPrefix(word)) {
+			continue
+		}
+		if term := index.closest(word, last); term != "" {
+			corrected[i] = term
+			changed = true
+		}
+	}
+	if changed {
+		suggestion.DidYouMean = strings.Join(corrected, " ")
+	}
+
+	lead := strings.Join(corrected[:len(corrected)-1], " ")
+	for _, term := range index.complete(corrected[len(corrected)-1]) {
+		suggestion.Completions = append(suggestion.Completions, strings.TrimSpace(lead+" "+term))
+	}
+	return suggestion, nil
+}
+
+func (idx termIndex) hasPrefix(prefix string) bool {
+	i := sort.SearchStrings(idx.sorted, prefix)
+	return i < len(idx.sorted) && strings.HasPrefix(idx.sorted[i], prefix)
+}
+
+// complete lists the terms starting with prefix, most common first.
+func (idx termIndex) complete(prefix string) []string {
+	var terms []string
+	for i := sort.SearchStrings(idx.sorted, prefix); i < len(idx.sorted) && strings.HasPrefix(idx.sorted[i], prefix); i++ {
+		terms = append(terms, idx.sorted[i])
+	}
+	slices.SortStableFunc(terms, func(a, b string) int {
+		return idx.counts[b] - idx.counts[a]
+	})
+	return terms[:min(len(terms), maxCompletions)]
+}
+
+// closest finds the term nearest to word, allowing one edit for short words
+// and two for longer ones. A partial word is also compared with the start of
+// each term, so "hedph" finds "headphones". Ties go to the more common term.
+func (idx termIndex) closest(word string, partial bool) string {
+	typed := []rune(word)
+	allowed := 1
+	if len(typed) > 4 {
+		allowed = 2
+	}
+	best, bestDistance := "", allowed+1
+	for _, term := range idx.sorted {
+		candidate := []rune(term)
+		distance := editDistance(typed, candidate)
+		if partial && len(candidate) > len(typed) {
+			distance = min(distance, editDistance(typed, candidate[:len(typed)]))
+		}
+		if distance < bestDistance || (distance == bestDistance && best != "" && idx.counts[term] > idx.counts[best]) {
+			best, bestDistance = term, distance
+		}
+	}
+	return best
+}
+
+// editDistance is the optimal string alignment distance: insertions,
+// deletions, substitutions and swaps of neighbouring characters cost one.
+func editDistance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
+
+// tokenize splits text into lower case words of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,126 @@
+package suggestService
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+var catalogue = []models.Product{
+	{ID: "p1", Name: "Laptop", Tags: []string{"computer", "notebook"}},
+	{ID: "p2", Name: "Gaming Laptop", Tags: []string{"computer", "gaming"}},
+	{ID: "p3", Name: "Headphones", Tags: []string{"audio", "wireless"}},
+	{ID: "p4", Name: "Wireless Headset", Tags: []string{"audio", "wireless"}},
+	{ID: "p5", Name: "Headlamp", Tags: []string{"outdoor"}},
+}
+
+func setupService(t *testing.T) SuggestServiceManager {
+	ctrl := gomock.NewController(t)
+	productRepo := mocks.NewMockProductManager(ctrl)
+	productRepo.EXPECT().ListProducts(models.ProductQuery{Limit: refreshPage}).Return(catalogue, len(catalogue), nil)
+
+	service := NewSuggestService(productRepo)
+	if err := service.Refresh(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return service
+}
+
+func TestSuggest(t *testing.T) {
+	service := setupService(t)
+
+	tests := []struct {
+		query       string
+		completions []string
+		didYouMean  string
+	}{
+		{"lap", []string{"laptop"}, ""},
+		{"head", []string{"headlamp", "headphones", "headset"}, ""},
+		{"wireless hea", []string{"wireless headlamp", "wireless headphones", "wireless headset"}, ""},
+		{"hedphones", []string{"headphones"}, "headphones"},
+		{"hedph", []string{"headphones"}, "headphones"},
+		{"lpatop", []string{"laptop"}, "laptop"},
+		{"wirless gam", []string{"wireless gaming"}, "wireless gam"},
+		{"zzzzzz", []string{}, ""},
+	}
+	for _, tt := range tests {
+		suggestion, err := service.Suggest(tt.query)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.query, err)
+			continue
+		}
+		if !slices.Equal(suggestion.Completions, tt.completions) || suggestion.DidYouMean != tt.didYouMean {
+			t.Errorf("%s: got %q, did you mean %q", tt.query, suggestion.Completions, suggestion.DidYouMean)
+		}
+	}
+}
+
+func TestSuggestRanksCommonTermsFirst(t *testing.T) {
+	service := setupService(t)
+
+	suggestion, err := service.Suggest("a")
+	if err != nil || len(suggestion.Completions) == 0 || suggestion.Completions[0] != "audio" {
+		t.Errorf("expected audio first, got %q, err: %v", suggestion.Completions, err)
+	}
+}
+
+func TestSuggestInvalidQuery(t *testing.T) {
+	service := setupService(t)
+
+	if _, err := service.Suggest(" -- "); !errors.Is(err, ErrEmptyQuery) {
+		t.Errorf("expected ErrEmptyQuery, got %v", err)
+	}
+	if _, err := service.Suggest(strings.Repeat("a", 101)); !errors.Is(err, ErrQueryTooLong) {
+		t.Errorf("expected ErrQueryTooLong, got %v", err)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	productRepo := mocks.NewMockProductManager(ctrl)
+	service := NewSuggestService(productRepo)
+
+	gomock.InOrder(
+		productRepo.EXPECT().ListProducts(models.ProductQuery{Limit: refreshPage}).Return(catalogue[:3], 4, nil),
+		productRepo.EXPECT().ListProducts(models.ProductQuery{Limit: refreshPage, Offset: 3}).Return(catalogue[3:4], 4, nil),
+	)
+	if err := service.Refresh(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	suggestion, _ := service.Suggest("headse")
+	if !slices.Equal(suggestion.Completions, []string{"headset"}) {
+		t.Errorf("expected the second page to be indexed, got %q", suggestion.Completions)
+	}
+
+	productRepo.EXPECT().ListProducts(gomock.Any()).Return(nil, 0, errors.New("db error"))
+	if err := service.Refresh(); err == nil {
+		t.Error("expected error for failed refresh")
+	}
+	suggestion, _ = service.Suggest("headse")
+	if len(suggestion.Completions) != 1 {
+		t.Errorf("expected the old index to be kept, got %q", suggestion.Completions)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "abc", 3},
+		{"headphones", "headphones", 0},
+		{"hedphones", "headphones", 1},
+		{"lpatop", "laptop", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := editDistance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
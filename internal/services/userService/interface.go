@@ -1,10 +1,23 @@
 package userService
 
-import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+import (
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
 
 //go:generate mockgen -source=interface.go -destination=../../mocks/mock_userServcie.go -package mocks
 
 type UserServiceManager interface {
 	RegisterUser(name, email, password string, role models.UserRole) error
-	Login(email, password string) (string, error)
+	Login(email, password string, client models.ClientInfo) (dto.LoginResultDTO, error)
+	StartSession(user models.User, client models.ClientInfo) (dto.LoginResultDTO, error)
+	VerifyMFALogin(mfaToken, code string, client models.ClientInfo) (string, error)
+	BeginMFASetup(mfaToken string) (dto.MFAEnrollmentDTO, error)
+	ConfirmMFASetup(mfaToken, code string, client models.ClientInfo) (dto.MFASetupResultDTO, error)
+	GetProfile(userID string) (dto.ProfileDTO, error)
+	UpdateProfile(userID string, update dto.UpdateProfileDTO) (dto.ProfileDTO, error)
+	DeleteAccount(userID, password string, loggedInAt time.Time, client models.ClientInfo) error
+	ExportData(userID string) (dto.UserExportDTO, error)
 }
@@ -1,30 +1,68 @@
 package userService
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/auditRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/cartRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/couponRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/orderRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/sessionRepository"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/mfaService"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/services/verificationService"
 
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
+)
+
+var (
+	ErrEmailTaken       = errors.New("email is already in use")
+	ErrWrongPassword    = errors.New("password is incorrect")
+	ErrAccountSuspended = errors.New("account is suspended")
+	ErrReauthRequired   = errors.New("give your password or log in again to close the account")
 )
 
 type UserService struct {
-	userRepo   userRepository.UserManager
-	prodRepo   productRepository.ProductManager
-	couponRepo couponRepository.CouponManager
-	cartRepo   cartRepository.CartManager
+	userRepo    userRepository.UserManager
+	prodRepo    productRepository.ProductManager
+	couponRepo  couponRepository.CouponManager
+	cartRepo    cartRepository.CartManager
+	orderRepo   orderRepository.OrderManager
+	sessionRepo sessionRepository.SessionManager
+	auditRepo   auditRepository.AuditManager
+
+	verificationServ verificationService.VerificationServiceManager
+	mfaServ          mfaService.MFAServiceManager
+	lockoutServ      lockoutService.LockoutServiceManager
 }
 
-func NewUserService(userRepo userRepository.UserManager, prodRepo productRepository.ProductManager, couponRepo couponRepository.CouponManager, cartRepo cartRepository.CartManager) UserServiceManager {
+// NewUserService builds the user service. sessionRepo may be nil to issue
+// tokens without recording sessions and auditRepo may be nil to skip auditing
+// erasures. verificationServ may be nil, in which
+// case no verification email is sent on registration, mfaServ may be nil to
+// log in with the password alone and lockoutServ may be nil to skip throttling.
+func NewUserService(userRepo userRepository.UserManager, prodRepo productRepository.ProductManager, couponRepo couponRepository.CouponManager, cartRepo cartRepository.CartManager, orderRepo orderRepository.OrderManager, sessionRepo sessionRepository.SessionManager, auditRepo auditRepository.AuditManager, verificationServ verificationService.VerificationServiceManager, mfaServ mfaService.MFAServiceManager, lockoutServ lockoutService.LockoutServiceManager) UserServiceManager {
 	return &UserService{
-		userRepo:   userRepo,
-		prodRepo:   prodRepo,
-		couponRepo: couponRepo,
-		cartRepo:   cartRepo,
+		userRepo:         userRepo,
+		prodRepo:         prodRepo,
+		couponRepo:       couponRepo,
+		cartRepo:         cartRepo,
+		orderRepo:        orderRepo,
+		sessionRepo:      sessionRepo,
+		auditRepo:        auditRepo,
+		verificationServ: verificationServ,
+		mfaServ:          mfaServ,
+		lockoutServ:      lockoutServ,
 	}
 }
 
@@ -50,6 +88,14 @@ func (us *UserService) RegisterUser(name, email, password string, role models.Us
 	if err != nil {
 		return fmt.Errorf("can not associate cart for the user: %v", err)
 	}
+
+	if us.verificationServ != nil {
+		// the account exists at this point, the user can ask for a new email
+		err = us.verificationServ.SendVerification(newUser)
+		if err != nil {
+			log.Printf("can not send verification email to %s: %v", newUser.Email, err)
+		}
+	}
 	return nil
 }
 
@@ -65,31 +111,378 @@ func (us *UserService) CreateUser(name, email, password string, role models.User
 		Email:    email,
 		Password: hashedPass,
 		Role:     role,
+		Status:   models.UserActive,
 	}
 
 	return newUser, nil
 }
 
-func (us *UserService) Login(email, password string) (string, error) {
+// Login checks the password. When a second factor is needed the result holds
+// an MFA token for the second step instead of a session token. The client's
+// address is used for throttling and may be empty.
+func (us *UserService) Login(email, password string, client models.ClientInfo) (dto.LoginResultDTO, error) {
+	err := us.checkLockout(email, client.IP)
+	if err != nil {
+		return dto.LoginResultDTO{}, err
+	}
 	user, err := us.userRepo.GetUserByEmail(email)
 	if err != nil {
-		return "", fmt.Errorf("invalid email or password")
+		us.loginFailed(email, client.IP)
+		return dto.LoginResultDTO{}, fmt.Errorf("invalid email or password")
+	}
+	if !utils.CheckPassword(user.Password, password) {
+		us.loginFailed(email, client.IP)
+		return dto.LoginResultDTO{}, fmt.Errorf("invalid email or password")
+	}
+	return us.StartSession(user, client)
+}
+
+// StartSession finishes a login for a user who has already proven who they
+// are, asking for the second factor when one is needed.
+func (us *UserService) StartSession(user models.User, client models.ClientInfo) (dto.LoginResultDTO, error) {
+	if user.Status == models.UserSuspended {
+		return dto.LoginResultDTO{}, ErrAccountSuspended
+	}
+
+	if us.mfaServ != nil {
+		enabled, err := us.mfaServ.IsEnabled(user.ID)
+		if err != nil {
+			return dto.LoginResultDTO{}, err
+		}
+		if enabled {
+			mfaToken, err := utils.GenerateMFAToken(user.ID, models.MFAChallengePurpose, user.TokenVersion)
+			if err != nil {
+				return dto.LoginResultDTO{}, fmt.Errorf("can not generate token")
+			}
+			return dto.LoginResultDTO{MFARequired: true, MFAToken: mfaToken}, nil
+		}
+		required, err := us.mfaServ.IsRequired(user)
+		if err != nil {
+			return dto.LoginResultDTO{}, err
+		}
+		if required {
+			mfaToken, err := utils.GenerateMFAToken(user.ID, models.MFASetupPurpose, user.TokenVersion)
+			if err != nil {
+				return dto.LoginResultDTO{}, fmt.Errorf("can not generate token")
+			}
+			return dto.LoginResultDTO{MFASetupRequired: true, MFAToken: mfaToken}, nil
+		}
+	}
+
+	token, err := us.issueToken(user, client)
+	if err != nil {
+		return dto.LoginResultDTO{}, err
+	}
+	return dto.LoginResultDTO{Token: token}, nil
+}
+
+func (us *UserService) VerifyMFALogin(mfaToken, code string, client models.ClientInfo) (string, error) {
+	user, err := us.userFromMFAToken(mfaToken, models.MFAChallengePurpose)
+	if err != nil {
+		return "", err
+	}
+	err = us.checkLockout(user.Email, client.IP)
+	if err != nil {
+		return "", err
+	}
+	err = us.mfaServ.VerifyCode(user.ID, code)
+	if err != nil {
+		us.loginFailed(user.Email, client.IP)
+		return "", err
+	}
+	return us.issueToken(user, client)
+}
+
+// BeginMFASetup lets a user who must enrol before logging in start enrolment
+// with the MFA token returned by Login.
+func (us *UserService) BeginMFASetup(mfaToken string) (dto.MFAEnrollmentDTO, error) {
+	user, err := us.userFromMFAToken(mfaToken, models.MFASetupPurpose)
+	if err != nil {
+		return dto.MFAEnrollmentDTO{}, err
+	}
+	return us.mfaServ.BeginEnrollment(user.ID)
+}
+
+func (us *UserService) ConfirmMFASetup(mfaToken, code string, client models.ClientInfo) (dto.MFASetupResultDTO, error) {
+	user, err := us.userFromMFAToken(mfaToken, models.MFASetupPurpose)
+	if err != nil {
+		return dto.MFASetupResultDTO{}, err
+	}
+	err = us.checkLockout(user.Email, client.IP)
+	if err != nil {
+		return dto.MFASetupResultDTO{}, err
+	}
+	codes, err := us.mfaServ.ConfirmEnrollment(user.ID, code)
+	if err != nil {
+		us.loginFailed(user.Email, client.IP)
+		return dto.MFASetupResultDTO{}, err
+	}
+	token, err := us.issueToken(user, client)
+	if err != nil {
+		return dto.MFASetupResultDTO{}, err
+	}
+	return dto.MFASetupResultDTO{Token: token, RecoveryCodes: codes}, nil
+}
+
+func (us *UserService) userFromMFAToken(mfaToken, purpose string) (models.User, error) {
+	if us.mfaServ == nil {
+		return models.User{}, fmt.Errorf("two-factor authentication is not available")
+	}
+	claims, err := validators.ValidateMFAToken(mfaToken, purpose)
+	if err != nil {
+		return models.User{}, err
+	}
+	user, err := us.userRepo.GetUserByID(claims.Subject)
+	if err != nil || user.TokenVersion != claims.TokenVersion {
+		return models.User{}, fmt.Errorf("invalid or expired mfa token")
+	}
+	if user.Status == models.UserSuspended {
+		return models.User{}, ErrAccountSuspended
+	}
+	return user, nil
+}
+
+func (us *UserService) GetProfile(userID string) (dto.ProfileDTO, error) {
+	user, err := us.userRepo.GetUserByID(userID)
+	if err != nil {
+		return dto.ProfileDTO{}, fmt.Errorf("user not found")
+	}
+	return toProfile(user), nil
+}
+
+// UpdateProfile changes the name right away. A new email needs the current
+// password and is only applied once the user confirms it from the link sent
+// to the new address; until then it is returned as the pending email.
+func (us *UserService) UpdateProfile(userID string, update dto.UpdateProfileDTO) (dto.ProfileDTO, error) {
+	user, err := us.userRepo.GetUserByID(userID)
+	if err != nil {
+		return dto.ProfileDTO{}, fmt.Errorf("user not found")
+	}
+	var pendingEmail string
+	if update.Email != nil && *update.Email != user.Email {
+		if !utils.CheckPassword(user.Password, update.CurrentPassword) {
+			return dto.ProfileDTO{}, ErrWrongPassword
+		}
+		existing, err := us.userRepo.GetUserByEmail(*update.Email)
+		if err == nil && existing.ID != user.ID {
+			return dto.ProfileDTO{}, ErrEmailTaken
+		}
+		if us.verificationServ == nil {
+			return dto.ProfileDTO{}, fmt.Errorf("email changes are not available")
+		}
+		err = us.verificationServ.SendEmailChange(user, *update.Email)
+		if err != nil {
+			return dto.ProfileDTO{}, err
+		}
+		pendingEmail = *update.Email
+	}
+
+	if update.Name != nil && *update.Name != user.Name {
+		user.Name = *update.Name
+		err = us.userRepo.UpdateUser(user)
+		if err != nil {
+			return dto.ProfileDTO{}, fmt.Errorf("can not update profile: %v", err)
+		}
+	}
+	profile := toProfile(user)
+	profile.PendingEmail = pendingEmail
+	return profile, nil
+}
+
+// DeleteAccount closes the caller's account after re-checking the password.
+// Without a password, as for accounts created through single sign-on, the
+// caller has to have logged in within ReauthWindow instead. Admins have to be
+// demoted first so the shop can not lose its last admin.
+// The account is erased rather than deleted so its orders are kept.
+func (us *UserService) DeleteAccount(userID, password string, loggedInAt time.Time, client models.ClientInfo) error {
+	user, err := us.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	if password == "" {
+		if time.Since(loggedInAt) > config.ReauthWindow {
+			return ErrReauthRequired
+		}
+	} else {
+		err = us.checkLockout(user.Email, client.IP)
+		if err != nil {
+			return err
+		}
+		if !utils.CheckPassword(user.Password, password) {
+			us.loginFailed(user.Email, client.IP)
+			return ErrWrongPassword
+		}
+	}
+	if user.Role == models.Admin {
+		return fmt.Errorf("admin accounts can not be closed, ask another admin to change your role first")
+	}
+	return us.eraseUser(user, userID, client.IP)
+}
+
+// eraseUser anonymises the account and removes everything tied to it except
+// orders, which accounting has to keep. The audit entry records who asked
+// but none of the erased data.
+func (us *UserService) eraseUser(user models.User, actorID, ip string) error {
+	password, err := utils.GenerateSecureToken()
+	if err != nil {
+		return fmt.Errorf("can not erase account")
+	}
+	hashedPass, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("can not erase account")
+	}
+	err = us.userRepo.EraseUser(models.User{
+		ID:       user.ID,
+		Name:     "Deleted user",
+		Email:    "erased-" + user.ID + "@invalid",
+		Password: hashedPass,
+	})
+	if err != nil {
+		return fmt.Errorf("can not erase account: %v", err)
+	}
+
+	if us.lockoutServ != nil {
+		err = us.lockoutServ.RecordSuccess(user.Email)
+		if err != nil {
+			log.Printf("can not clear failed logins of erased user %s: %v", user.ID, err)
+		}
+	}
+	if us.auditRepo != nil {
+		err = us.auditRepo.SaveAuditEntry(models.AuditEntry{
+			ID:        utils.NewUUID(),
+			Event:     models.AuditAccountErased,
+			UserID:    user.ID,
+			ActorID:   actorID,
+			IP:        ip,
+			Detail:    "personal data anonymised, orders kept",
+			CreatedAt: time.Now(),
+		})
+		if err != nil {
+			log.Printf("can not write audit entry %s: %v", models.AuditAccountErased, err)
+		}
+	}
+	return nil
+}
+
+// ExportData collects everything stored about the user into one archive.
+func (us *UserService) ExportData(userID string) (dto.UserExportDTO, error) {
+	user, err := us.userRepo.GetUserByID(userID)
+	if err != nil {
+		return dto.UserExportDTO{}, fmt.Errorf("user not found")
+	}
+	now := time.Now()
+	export := dto.UserExportDTO{
+		ExportedAt: now,
+		Profile:    toProfile(user),
+		Cart:       []dto.CartItemsDTO{},
+		Orders:     []models.Order{},
+		Sessions:   []models.Session{},
+	}
+
+	cartID, err := us.cartRepo.GetCartIDByUserID(userID)
+	if err == nil {
+		items, err := us.cartRepo.GetCartItems(cartID)
+		if err != nil {
+			return dto.UserExportDTO{}, fmt.Errorf("can not fetch cart: %v", err)
+		}
+		export.Cart = append(export.Cart, items...)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return dto.UserExportDTO{}, fmt.Errorf("can not fetch cart: %v", err)
+	}
+
+	orders, err := us.orderRepo.GetOrdersByUserID(userID)
+	if err != nil {
+		return dto.UserExportDTO{}, fmt.Errorf("can not fetch orders: %v", err)
+	}
+	export.Orders = append(export.Orders, orders...)
+
+	if us.sessionRepo != nil {
+		sessions, err := us.sessionRepo.ListActiveSessions(userID, now)
+		if err != nil {
+			return dto.UserExportDTO{}, fmt.Errorf("can not fetch sessions: %v", err)
+		}
+		export.Sessions = append(export.Sessions, sessions...)
+	}
+	if us.mfaServ != nil {
+		export.MFAEnabled, err = us.mfaServ.IsEnabled(userID)
+		if err != nil {
+			return dto.UserExportDTO{}, fmt.Errorf("can not fetch two-factor status: %v", err)
+		}
+	}
+	return export, nil
+}
+
+func toProfile(user models.User) dto.ProfileDTO {
+	return dto.ProfileDTO{
+		ID:              user.ID,
+		Name:            user.Name,
+		Email:           user.Email,
+		Role:            user.Role.String(),
+		EmailVerified:   user.IsEmailVerified(),
+		EmailVerifiedAt: user.EmailVerifiedAt,
 	}
-	if email=="admin@shyam.com" || password=="admin@123"{
+}
 
-	}else if !utils.CheckPassword(user.Password, password) {
-		return "", fmt.Errorf("invalid email or password")
+func (us *UserService) checkLockout(email, ip string) error {
+	if us.lockoutServ == nil {
+		return nil
 	}
+	return us.lockoutServ.Check(email, ip)
+}
 
+// loginFailed only logs storage errors so the caller still reports bad
+// credentials.
+func (us *UserService) loginFailed(email, ip string) {
+	if us.lockoutServ == nil {
+		return
+	}
+	err := us.lockoutServ.RecordFailure(email, ip)
+	if err != nil {
+		log.Printf("can not record failed login for %s: %v", email, err)
+	}
+}
+
+// issueToken creates the session and its token and clears the account's
+// failed attempts, so every successful login path goes through it.
+func (us *UserService) issueToken(user models.User, client models.ClientInfo) (string, error) {
+	if us.lockoutServ != nil {
+		err := us.lockoutServ.RecordSuccess(user.Email)
+		if err != nil {
+			log.Printf("can not reset failed logins for %s: %v", user.Email, err)
+		}
+	}
 	userJWT := models.UserJWT{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:       user.ID,
+		Email:        user.Email,
+		Role:         user.Role,
+		TokenVersion: user.TokenVersion,
+	}
+	userJWT.ID = utils.NewUUID()
+
+	if us.sessionRepo != nil {
+		now := time.Now()
+		err := us.sessionRepo.CreateSession(models.Session{
+			ID:           userJWT.ID,
+			UserID:       user.ID,
+			UserAgent:    client.UserAgent,
+			IP:           client.IP,
+			TokenVersion: user.TokenVersion,
+			CreatedAt:    now,
+			LastSeenAt:   now,
+			ExpiresAt:    now.Add(config.JWT_TTL),
+		})
+		if err != nil {
+			return "", fmt.Errorf("can not create session: %v", err)
+		}
+		err = us.sessionRepo.DeleteExpiredSessions(now)
+		if err != nil {
+			log.Printf("can not delete expired sessions: %v", err)
+		}
 	}
+
 	token, err := utils.GenerateJWT(userJWT)
 	if err != nil {
-		return "nil", fmt.Errorf("can not generate token")
+		return "", fmt.Errorf("can not generate token")
 	}
-
 	return token, nil
 }
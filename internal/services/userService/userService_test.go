@@ -1,12 +1,18 @@
 package userService
 
 import (
+    "database/sql"
     "errors"
     "testing"
+    "time"
 
+    "github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+    "github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
     "github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
     "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+    "github.com/meshyampratap01/OnlineShoppingCart/internal/services/lockoutService"
     "github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+    "github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
     "go.uber.org/mock/gomock"
 )
 
@@ -19,7 +25,9 @@ func TestRegisterUser(t *testing.T) {
     mockCouponRepo := mocks.NewMockCouponManager(ctrl)
     mockCartRepo := mocks.NewMockCartManager(ctrl)
 
-    service := NewUserService(mockUserRepo, mockProdRepo, mockCouponRepo, mockCartRepo)
+    mockVerificationServ := mocks.NewMockVerificationServiceManager(ctrl)
+
+    service := NewUserService(mockUserRepo, mockProdRepo, mockCouponRepo, mockCartRepo, nil, nil, nil, mockVerificationServ, nil, nil)
 
     email := "test@example.com"
     name := "Test User"
@@ -60,6 +68,19 @@ func TestRegisterUser(t *testing.T) {
         mockUserRepo.EXPECT().GetUserByEmail(email).Return(models.User{}, errors.New("not found"))
         mockUserRepo.EXPECT().SaveUser(gomock.Any()).Return(nil)
         mockCartRepo.EXPECT().CreateCart(gomock.Any(), gomock.Any()).Return(nil)
+        mockVerificationServ.EXPECT().SendVerification(gomock.Any()).Return(nil)
+
+        err := service.RegisterUser(name, email, password, role)
+        if err != nil {
+            t.Errorf("expected successful registration, got error: %v", err)
+        }
+    })
+
+    t.Run("Verification email failure does not fail registration", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByEmail(email).Return(models.User{}, errors.New("not found"))
+        mockUserRepo.EXPECT().SaveUser(gomock.Any()).Return(nil)
+        mockCartRepo.EXPECT().CreateCart(gomock.Any(), gomock.Any()).Return(nil)
+        mockVerificationServ.EXPECT().SendVerification(gomock.Any()).Return(errors.New("smtp down"))
 
         err := service.RegisterUser(name, email, password, role)
         if err != nil {
@@ -96,7 +117,7 @@ func TestLogin(t *testing.T) {
     t.Run("Invalid email", func(t *testing.T) {
         mockUserRepo.EXPECT().GetUserByEmail(email).Return(models.User{}, errors.New("not found"))
 
-        _, err := service.Login(email, password)
+        _, err := service.Login(email, password, models.ClientInfo{})
         if err == nil {
             t.Errorf("expected error for invalid email, got nil")
         }
@@ -105,12 +126,34 @@ func TestLogin(t *testing.T) {
     t.Run("Invalid password", func(t *testing.T) {
         mockUserRepo.EXPECT().GetUserByEmail(email).Return(models.User{Password: "wronghash"}, nil)
 
-        _, err := service.Login(email, password)
+        _, err := service.Login(email, password, models.ClientInfo{})
         if err == nil {
             t.Errorf("expected error for invalid password, got nil")
         }
     })
 
+    t.Run("Suspended user", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByEmail(email).Return(models.User{Password: hashedPassword, Status: models.UserSuspended}, nil)
+
+        _, err := service.Login(email, password, models.ClientInfo{})
+        if !errors.Is(err, ErrAccountSuspended) {
+            t.Errorf("expected ErrAccountSuspended, got %v", err)
+        }
+    })
+
+    t.Run("Plaintext admin password is rejected", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByEmail("admin@shyam.com").Return(models.User{
+            Email:    "admin@shyam.com",
+            Password: "admin@123",
+            Role:     models.Admin,
+        }, nil)
+
+        _, err := service.Login("admin@shyam.com", "admin@123", models.ClientInfo{})
+        if err == nil {
+            t.Errorf("expected error for unhashed password, got nil")
+        }
+    })
+
     t.Run("Successful login", func(t *testing.T) {
         mockUserRepo.EXPECT().GetUserByEmail(email).Return(models.User{
             ID:       "1",
@@ -119,12 +162,404 @@ func TestLogin(t *testing.T) {
             Role:     models.Customer,
         }, nil)
 
-        token, err := service.Login(email, password)
+        result, err := service.Login(email, password, models.ClientInfo{})
         if err != nil {
             t.Errorf("expected successful login, got error: %v", err)
         }
-        if token == "" {
-            t.Errorf("expected non-empty token, got empty string")
+        if result.Token == "" || result.MFARequired {
+            t.Errorf("expected session token, got %+v", result)
+        }
+    })
+}
+
+func TestLoginWithMFA(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockUserRepo := mocks.NewMockUserManager(ctrl)
+    mockMFAServ := mocks.NewMockMFAServiceManager(ctrl)
+    service := UserService{userRepo: mockUserRepo, mfaServ: mockMFAServ}
+    config.RequireAdminMFA = true
+
+    password := "password123"
+    hashedPassword, _ := utils.HashPassword(password)
+    customer := models.User{ID: "1", Email: "user@example.com", Password: hashedPassword, Role: models.Customer, TokenVersion: 2}
+    admin := models.User{ID: "2", Email: "admin@example.com", Password: hashedPassword, Role: models.Admin}
+
+    var challenge string
+    t.Run("Enrolled user gets a challenge", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByEmail(customer.Email).Return(customer, nil)
+        mockMFAServ.EXPECT().IsEnabled("1").Return(true, nil)
+
+        result, err := service.Login(customer.Email, password, models.ClientInfo{})
+        if err != nil || !result.MFARequired || result.Token != "" || result.MFAToken == "" {
+            t.Fatalf("expected mfa challenge, got %+v, err: %v", result, err)
+        }
+        challenge = result.MFAToken
+    })
+
+    t.Run("Wrong code", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("1").Return(customer, nil)
+        mockMFAServ.EXPECT().VerifyCode("1", "000000").Return(errors.New("invalid authentication code"))
+
+        _, err := service.VerifyMFALogin(challenge, "000000", models.ClientInfo{})
+        if err == nil {
+            t.Error("expected error for wrong code")
+        }
+    })
+
+    t.Run("Challenge is void after a password change", func(t *testing.T) {
+        changed := customer
+        changed.TokenVersion = 3
+        mockUserRepo.EXPECT().GetUserByID("1").Return(changed, nil)
+
+        _, err := service.VerifyMFALogin(challenge, "123456", models.ClientInfo{})
+        if err == nil {
+            t.Error("expected error for stale challenge")
+        }
+    })
+
+    t.Run("Correct code", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("1").Return(customer, nil)
+        mockMFAServ.EXPECT().VerifyCode("1", "123456").Return(nil)
+
+        token, err := service.VerifyMFALogin(challenge, "123456", models.ClientInfo{})
+        if err != nil || token == "" {
+            t.Errorf("expected session token, got %q, err: %v", token, err)
+        }
+    })
+
+    t.Run("Challenge can not be used for setup", func(t *testing.T) {
+        _, err := service.BeginMFASetup(challenge)
+        if err == nil {
+            t.Error("expected error for wrong token purpose")
+        }
+    })
+
+    t.Run("Admin without mfa must set it up", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByEmail(admin.Email).Return(admin, nil)
+        mockMFAServ.EXPECT().IsEnabled("2").Return(false, nil)
+        mockMFAServ.EXPECT().IsRequired(admin).Return(true, nil)
+
+        result, err := service.Login(admin.Email, password, models.ClientInfo{})
+        if err != nil || !result.MFASetupRequired || result.Token != "" {
+            t.Fatalf("expected setup requirement, got %+v, err: %v", result, err)
+        }
+
+        mockUserRepo.EXPECT().GetUserByID("2").Return(admin, nil)
+        mockMFAServ.EXPECT().ConfirmEnrollment("2", "123456").Return([]string{"abcde-fghij"}, nil)
+
+        setup, err := service.ConfirmMFASetup(result.MFAToken, "123456", models.ClientInfo{})
+        if err != nil || setup.Token == "" || len(setup.RecoveryCodes) != 1 {
+            t.Errorf("expected token and recovery codes, got %+v, err: %v", setup, err)
+        }
+    })
+}
+
+func TestStartSession(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockMFAServ := mocks.NewMockMFAServiceManager(ctrl)
+    service := UserService{mfaServ: mockMFAServ}
+    config.RequireAdminMFA = true
+
+    t.Run("Customer gets a token", func(t *testing.T) {
+        mockMFAServ.EXPECT().IsEnabled("1").Return(false, nil)
+        mockMFAServ.EXPECT().IsRequired(gomock.Any()).Return(false, nil)
+
+        result, err := service.StartSession(models.User{ID: "1", Role: models.Customer, Status: models.UserActive}, models.ClientInfo{})
+        if err != nil || result.Token == "" || result.MFARequired {
+            t.Errorf("expected session token, got %+v, err: %v", result, err)
+        }
+    })
+
+    t.Run("Staff still need mfa", func(t *testing.T) {
+        mockMFAServ.EXPECT().IsEnabled("2").Return(false, nil)
+        mockMFAServ.EXPECT().IsRequired(gomock.Any()).Return(true, nil)
+
+        result, err := service.StartSession(models.User{ID: "2", Role: models.Customer, Status: models.UserActive}, models.ClientInfo{})
+        if err != nil || !result.MFASetupRequired || result.Token != "" {
+            t.Errorf("expected setup requirement, got %+v, err: %v", result, err)
+        }
+    })
+
+    t.Run("Suspended user", func(t *testing.T) {
+        _, err := service.StartSession(models.User{ID: "3", Role: models.Customer, Status: models.UserSuspended}, models.ClientInfo{})
+        if !errors.Is(err, ErrAccountSuspended) {
+            t.Errorf("expected ErrAccountSuspended, got %v", err)
+        }
+    })
+
+    t.Run("Session is recorded", func(t *testing.T) {
+        mockSessionRepo := mocks.NewMockSessionManager(ctrl)
+        service := UserService{mfaServ: mockMFAServ, sessionRepo: mockSessionRepo}
+        client := models.ClientInfo{IP: "1.2.3.4", UserAgent: "curl/8.0"}
+
+        var session models.Session
+        mockMFAServ.EXPECT().IsEnabled("4").Return(false, nil)
+        mockMFAServ.EXPECT().IsRequired(gomock.Any()).Return(false, nil)
+        mockSessionRepo.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(s models.Session) error {
+            session = s
+            return nil
+        })
+        mockSessionRepo.EXPECT().DeleteExpiredSessions(gomock.Any()).Return(nil)
+
+        result, err := service.StartSession(models.User{ID: "4", Role: models.Customer, Status: models.UserActive, TokenVersion: 2}, client)
+        if err != nil {
+            t.Fatalf("unexpected error: %v", err)
+        }
+        claims, err := validators.ValidateJWT(result.Token)
+        if err != nil {
+            t.Fatalf("invalid token: %v", err)
+        }
+        if session.ID == "" || session.ID != claims.ID || session.UserID != "4" || session.TokenVersion != 2 ||
+            session.IP != client.IP || session.UserAgent != client.UserAgent || !session.ExpiresAt.After(session.CreatedAt) {
+            t.Errorf("unexpected session: %+v", session)
+        }
+    })
+}
+
+func TestLoginLockout(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockUserRepo := mocks.NewMockUserManager(ctrl)
+    mockLockoutServ := mocks.NewMockLockoutServiceManager(ctrl)
+    service := UserService{userRepo: mockUserRepo, lockoutServ: mockLockoutServ}
+
+    password := "password123"
+    hashedPassword, _ := utils.HashPassword(password)
+    user := models.User{ID: "1", Email: "user@example.com", Password: hashedPassword, Role: models.Customer}
+
+    t.Run("Locked out before checking the password", func(t *testing.T) {
+        mockLockoutServ.EXPECT().Check(user.Email, "1.2.3.4").Return(&lockoutService.LockedError{RetryAfter: time.Minute})
+
+        _, err := service.Login(user.Email, password, models.ClientInfo{IP: "1.2.3.4"})
+        var locked *lockoutService.LockedError
+        if !errors.As(err, &locked) {
+            t.Errorf("expected LockedError, got %v", err)
+        }
+    })
+
+    t.Run("Wrong password is recorded", func(t *testing.T) {
+        mockLockoutServ.EXPECT().Check(user.Email, "1.2.3.4").Return(nil)
+        mockUserRepo.EXPECT().GetUserByEmail(user.Email).Return(user, nil)
+        mockLockoutServ.EXPECT().RecordFailure(user.Email, "1.2.3.4").Return(nil)
+
+        _, err := service.Login(user.Email, "wrong", models.ClientInfo{IP: "1.2.3.4"})
+        if err == nil {
+            t.Error("expected error for wrong password")
+        }
+    })
+
+    t.Run("Unknown email is recorded", func(t *testing.T) {
+        mockLockoutServ.EXPECT().Check("nobody@example.com", "1.2.3.4").Return(nil)
+        mockUserRepo.EXPECT().GetUserByEmail("nobody@example.com").Return(models.User{}, errors.New("not found"))
+        mockLockoutServ.EXPECT().RecordFailure("nobody@example.com", "1.2.3.4").Return(nil)
+
+        _, err := service.Login("nobody@example.com", password, models.ClientInfo{IP: "1.2.3.4"})
+        if err == nil {
+            t.Error("expected error for unknown email")
+        }
+    })
+
+    t.Run("Success resets the counter", func(t *testing.T) {
+        mockLockoutServ.EXPECT().Check(user.Email, "1.2.3.4").Return(nil)
+        mockUserRepo.EXPECT().GetUserByEmail(user.Email).Return(user, nil)
+        mockLockoutServ.EXPECT().RecordSuccess(user.Email).Return(nil)
+
+        result, err := service.Login(user.Email, password, models.ClientInfo{IP: "1.2.3.4"})
+        if err != nil || result.Token == "" {
+            t.Errorf("expected token, got %+v, err: %v", result, err)
+        }
+    })
+}
+
+func TestUpdateProfile(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockUserRepo := mocks.NewMockUserManager(ctrl)
+    mockVerificationServ := mocks.NewMockVerificationServiceManager(ctrl)
+    service := UserService{userRepo: mockUserRepo, verificationServ: mockVerificationServ}
+
+    verifiedAt := time.Now()
+    hashedPassword, _ := utils.HashPassword("password123")
+    user := models.User{ID: "1", Name: "Old", Email: "old@example.com", Password: hashedPassword, EmailVerifiedAt: &verifiedAt}
+
+    t.Run("Name only keeps verification", func(t *testing.T) {
+        name := "New"
+        mockUserRepo.EXPECT().GetUserByID("1").Return(user, nil)
+        mockUserRepo.EXPECT().UpdateUser(models.User{ID: "1", Name: "New", Email: "old@example.com", Password: hashedPassword, EmailVerifiedAt: &verifiedAt}).Return(nil)
+
+        profile, err := service.UpdateProfile("1", dto.UpdateProfileDTO{Name: &name})
+        if err != nil || profile.Name != "New" || !profile.EmailVerified {
+            t.Errorf("unexpected profile: %+v, err: %v", profile, err)
+        }
+    })
+
+    t.Run("Email change needs the current password", func(t *testing.T) {
+        email := "new@example.com"
+        mockUserRepo.EXPECT().GetUserByID("1").Return(user, nil)
+
+        _, err := service.UpdateProfile("1", dto.UpdateProfileDTO{Email: &email, CurrentPassword: "wrong"})
+        if !errors.Is(err, ErrWrongPassword) {
+            t.Errorf("expected ErrWrongPassword, got %v", err)
+        }
+    })
+
+    t.Run("Email change waits for confirmation", func(t *testing.T) {
+        email := "new@example.com"
+        mockUserRepo.EXPECT().GetUserByID("1").Return(user, nil)
+        mockUserRepo.EXPECT().GetUserByEmail(email).Return(models.User{}, errors.New("not found"))
+        mockVerificationServ.EXPECT().SendEmailChange(user, email).Return(nil)
+
+        profile, err := service.UpdateProfile("1", dto.UpdateProfileDTO{Email: &email, CurrentPassword: "password123"})
+        if err != nil || profile.Email != "old@example.com" || profile.PendingEmail != email || !profile.EmailVerified {
+            t.Errorf("unexpected profile: %+v, err: %v", profile, err)
+        }
+    })
+
+    t.Run("Email taken", func(t *testing.T) {
+        email := "taken@example.com"
+        mockUserRepo.EXPECT().GetUserByID("1").Return(user, nil)
+        mockUserRepo.EXPECT().GetUserByEmail(email).Return(models.User{ID: "2", Email: email}, nil)
+
+        _, err := service.UpdateProfile("1", dto.UpdateProfileDTO{Email: &email, CurrentPassword: "password123"})
+        if !errors.Is(err, ErrEmailTaken) {
+            t.Errorf("expected ErrEmailTaken, got %v", err)
+        }
+    })
+}
+
+func TestDeleteAccount(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockUserRepo := mocks.NewMockUserManager(ctrl)
+    mockAuditRepo := mocks.NewMockAuditManager(ctrl)
+    service := UserService{userRepo: mockUserRepo, auditRepo: mockAuditRepo}
+
+    hashedPassword, _ := utils.HashPassword("password123")
+    client := models.ClientInfo{IP: "1.2.3.4"}
+
+    t.Run("Wrong password", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("1").Return(models.User{ID: "1", Password: hashedPassword}, nil)
+
+        if err := service.DeleteAccount("1", "wrong", time.Time{}, client); !errors.Is(err, ErrWrongPassword) {
+            t.Errorf("expected ErrWrongPassword, got %v", err)
+        }
+    })
+
+    t.Run("No password and an old login", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("1").Return(models.User{ID: "1", Password: hashedPassword}, nil)
+
+        if err := service.DeleteAccount("1", "", time.Now().Add(-time.Hour), client); !errors.Is(err, ErrReauthRequired) {
+            t.Errorf("expected ErrReauthRequired, got %v", err)
+        }
+    })
+
+    t.Run("No password and a fresh login", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("1").Return(models.User{ID: "1", Password: hashedPassword, Role: models.Customer}, nil)
+        mockUserRepo.EXPECT().EraseUser(gomock.Any()).Return(nil)
+        mockAuditRepo.EXPECT().SaveAuditEntry(gomock.Any()).Return(nil)
+
+        if err := service.DeleteAccount("1", "", time.Now().Add(-time.Minute), client); err != nil {
+            t.Errorf("unexpected error: %v", err)
+        }
+    })
+
+    t.Run("Admin can not close account", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("2").Return(models.User{ID: "2", Password: hashedPassword, Role: models.Admin}, nil)
+
+        if err := service.DeleteAccount("2", "password123", time.Time{}, client); err == nil {
+            t.Error("expected error for admin account")
+        }
+    })
+
+    t.Run("Account is erased and audited", func(t *testing.T) {
+        user := models.User{ID: "1", Name: "Jane", Email: "jane@example.com", Password: hashedPassword, Role: models.Customer}
+        mockUserRepo.EXPECT().GetUserByID("1").Return(user, nil)
+        mockUserRepo.EXPECT().EraseUser(gomock.Any()).DoAndReturn(func(erased models.User) error {
+            if erased.ID != "1" || erased.Name == user.Name || erased.Email == user.Email ||
+                !utils.IsHashedPassword(erased.Password) || utils.CheckPassword(erased.Password, "password123") {
+                t.Errorf("personal data was not replaced: %+v", erased)
+            }
+            return nil
+        })
+        mockAuditRepo.EXPECT().SaveAuditEntry(gomock.Any()).DoAndReturn(func(entry models.AuditEntry) error {
+            if entry.Event != models.AuditAccountErased || entry.UserID != "1" || entry.ActorID != "1" || entry.IP != "1.2.3.4" {
+                t.Errorf("unexpected audit entry: %+v", entry)
+            }
+            return nil
+        })
+
+        if err := service.DeleteAccount("1", "password123", time.Time{}, client); err != nil {
+            t.Errorf("unexpected error: %v", err)
+        }
+    })
+
+    t.Run("Erase failure", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("1").Return(models.User{ID: "1", Password: hashedPassword, Role: models.Customer}, nil)
+        mockUserRepo.EXPECT().EraseUser(gomock.Any()).Return(errors.New("db down"))
+
+        if err := service.DeleteAccount("1", "password123", time.Time{}, client); err == nil {
+            t.Error("expected error when erasure fails")
+        }
+    })
+}
+
+func TestExportData(t *testing.T) {
+    ctrl := gomock.NewController(t)
+    defer ctrl.Finish()
+
+    mockUserRepo := mocks.NewMockUserManager(ctrl)
+    mockCartRepo := mocks.NewMockCartManager(ctrl)
+    mockOrderRepo := mocks.NewMockOrderManager(ctrl)
+    mockSessionRepo := mocks.NewMockSessionManager(ctrl)
+    mockMFAServ := mocks.NewMockMFAServiceManager(ctrl)
+    service := UserService{userRepo: mockUserRepo, cartRepo: mockCartRepo, orderRepo: mockOrderRepo, sessionRepo: mockSessionRepo, mfaServ: mockMFAServ}
+
+    t.Run("User not found", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("404").Return(models.User{}, sql.ErrNoRows)
+
+        if _, err := service.ExportData("404"); err == nil {
+            t.Error("expected error for unknown user")
+        }
+    })
+
+    t.Run("Everything is collected", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("1").Return(models.User{ID: "1", Name: "Jane", Email: "jane@example.com", Role: models.Customer}, nil)
+        mockCartRepo.EXPECT().GetCartIDByUserID("1").Return("c1", nil)
+        mockCartRepo.EXPECT().GetCartItems("c1").Return([]dto.CartItemsDTO{{ProductID: "p1", Quantity: 2}}, nil)
+        mockOrderRepo.EXPECT().GetOrdersByUserID("1").Return([]models.Order{{ID: "o1", UserID: "1"}}, nil)
+        mockSessionRepo.EXPECT().ListActiveSessions("1", gomock.Any()).Return([]models.Session{{ID: "s1", UserID: "1"}}, nil)
+        mockMFAServ.EXPECT().IsEnabled("1").Return(true, nil)
+
+        export, err := service.ExportData("1")
+        if err != nil {
+            t.Fatalf("unexpected error: %v", err)
+        }
+        if export.Profile.Email != "jane@example.com" || len(export.Cart) != 1 || len(export.Orders) != 1 ||
+            len(export.Sessions) != 1 || !export.MFAEnabled || export.ExportedAt.IsZero() {
+            t.Errorf("unexpected export: %+v", export)
+        }
+    })
+
+    t.Run("No cart", func(t *testing.T) {
+        mockUserRepo.EXPECT().GetUserByID("2").Return(models.User{ID: "2"}, nil)
+        mockCartRepo.EXPECT().GetCartIDByUserID("2").Return("", sql.ErrNoRows)
+        mockOrderRepo.EXPECT().GetOrdersByUserID("2").Return(nil, nil)
+        mockSessionRepo.EXPECT().ListActiveSessions("2", gomock.Any()).Return(nil, nil)
+        mockMFAServ.EXPECT().IsEnabled("2").Return(false, nil)
+
+        export, err := service.ExportData("2")
+        if err != nil {
+            t.Fatalf("unexpected error: %v", err)
+        }
+        if export.Cart == nil || export.Orders == nil || export.Sessions == nil {
+            t.Errorf("expected empty lists rather than null, got %+v", export)
         }
     })
 }
@@ -0,0 +1,16 @@
+package variantService
+
+import (
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+)
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_variantService.go -package mocks
+
+type VariantServiceManager interface {
+	ListVariants(productID string) (dto.ProductVariantsDTO, error)
+	SetOptions(productID string, options []models.ProductOption) ([]models.ProductOption, error)
+	CreateVariant(adminID, productID string, req dto.VariantDTO) (models.Variant, error)
+	UpdateVariant(productID, variantID string, req dto.VariantDTO) (models.Variant, error)
+	DeleteVariant(productID, variantID string) error
+}
@@ -0,0 +1,271 @@
+package variantService
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/productRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/variantRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
+)
+
+var (
+	ErrProductNotFound  = errors.New("product not found")
+	ErrVariantNotFound  = errors.New("variant not found")
+	ErrSKUExists        = errors.New("a variant with this SKU already exists")
+	ErrVariantExists    = errors.New("a variant with these options already exists")
+	ErrOptionsInUse     = errors.New("existing variants do not fit the new options, update or delete them first")
+	ErrNoOptions        = errors.New("set the product's options before adding variants")
+	ErrStockNotEditable = errors.New("stock can not be set directly, adjust it through the stock adjustments endpoint")
+)
+
+const (
+	maxOptions      = 3
+	maxOptionValues = 50
+	maxOptionLen    = 30
+)
+
+type VariantService struct {
+	variantRepo variantRepository.VariantManager
+	productRepo productRepository.ProductManager
+}
+
+func NewVariantService(variantRepo variantRepository.VariantManager, productRepo productRepository.ProductManager) VariantServiceManager {
+	return &VariantService{variantRepo: variantRepo, productRepo: productRepo}
+}
+
+func (vs *VariantService) ListVariants(productID string) (dto.ProductVariantsDTO, error) {
+	_, err := vs.productRepo.GetProductByID(productID)
+	if err != nil {
+		return dto.ProductVariantsDTO{}, ErrProductNotFound
+	}
+	options, err := vs.variantRepo.GetOptions(productID)
+	if err != nil {
+		return dto.ProductVariantsDTO{}, fmt.Errorf("can not fetch options: %v", err)
+	}
+	variants, err := vs.variantRepo.ListVariants(productID)
+	if err != nil {
+		return dto.ProductVariantsDTO{}, fmt.Errorf("can not fetch variants: %v", err)
+	}
+	list := dto.ProductVariantsDTO{Options: options, Variants: variants}
+	if list.Options == nil {
+		list.Options = []models.ProductOption{}
+	}
+	if list.Variants == nil {
+		list.Variants = []models.Variant{}
+	}
+	return list, nil
+}
+
+// SetOptions replaces the product's option types. It is refused while any
+// existing variant would be left without exactly one valid value per option.
+func (vs *VariantService) SetOptions(productID string, options []models.ProductOption) ([]models.ProductOption, error) {
+	_, err := vs.productRepo.GetProductByID(productID)
+	if err != nil {
+		return nil, ErrProductNotFound
+	}
+	options, err = cleanOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	variants, err := vs.variantRepo.ListVariants(productID)
+	if err != nil {
+		return nil, fmt.Errorf("can not fetch variants: %v", err)
+	}
+	for _, variant := range variants {
+		if matchOptions(options, variant.Options) != nil {
+			return nil, ErrOptionsInUse
+		}
+	}
+	err = vs.variantRepo.SetOptions(productID, options)
+	if err != nil {
+		return nil, fmt.Errorf("can not save options: %v", err)
+	}
+	return options, nil
+}
+
+// CreateVariant saves the variant with its stock recorded as an opening entry
+// in the inventory ledger.
+func (vs *VariantService) CreateVariant(adminID, productID string, req dto.VariantDTO) (models.Variant, error) {
+	_, err := vs.productRepo.GetProductByID(productID)
+	if err != nil {
+		return models.Variant{}, ErrProductNotFound
+	}
+	variant := models.Variant{ID: utils.NewUUID(), ProductID: productID}
+	err = vs.apply(&variant, req)
+	if err != nil {
+		return models.Variant{}, err
+	}
+	var opening *models.StockMovement
+	if req.Stock != nil && *req.Stock > 0 {
+		variant.Stock = *req.Stock
+		opening = &models.StockMovement{
+			ID:        utils.NewUUID(),
+			ProductID: productID,
+			VariantID: variant.ID,
+			Delta:     variant.Stock,
+			Balance:   variant.Stock,
+			Reason:    models.StockOpening,
+			ActorID:   adminID,
+			CreatedAt: time.Now().UTC(),
+		}
+	}
+	err = vs.variantRepo.SaveVariant(variant, opening)
+	if err != nil {
+		return models.Variant{}, fmt.Errorf("can not save variant: %v", err)
+	}
+	return variant, nil
+}
+
+func (vs *VariantService) UpdateVariant(productID, variantID string, req dto.VariantDTO) (models.Variant, error) {
+	variant, err := vs.getVariant(productID, variantID)
+	if err != nil {
+		return models.Variant{}, err
+	}
+	if req.Stock != nil && *req.Stock != variant.Stock {
+		return models.Variant{}, ErrStockNotEditable
+	}
+	err = vs.apply(&variant, req)
+	if err != nil {
+		return models.Variant{}, err
+	}
+	err = vs.variantRepo.UpdateVariant(variant)
+	if err != nil {
+		return models.Variant{}, fmt.Errorf("can not update variant: %v", err)
+	}
+	return variant, nil
+}
+
+func (vs *VariantService) DeleteVariant(productID, variantID string) error {
+	_, err := vs.getVariant(productID, variantID)
+	if err != nil {
+		return err
+	}
+	err = vs.variantRepo.DeleteVariant(variantID)
+	if err != nil {
+		return fmt.Errorf("can not delete variant: %v", err)
+	}
+	return nil
+}
+
+// getVariant only finds variants of the given product, so a variant can not
+// be changed through another product's URL.
+func (vs *VariantService) getVariant(productID, variantID string) (models.Variant, error) {
+	variant, err := vs.variantRepo.GetVariant(variantID)
+	if err != nil || variant.ProductID != productID {
+		return models.Variant{}, ErrVariantNotFound
+	}
+	return variant, nil
+}
+
+// apply validates req against the product's options and other variants and
+// copies it onto variant.
+func (vs *VariantService) apply(variant *models.Variant, req dto.VariantDTO) error {
+	sku := strings.TrimSpace(req.SKU)
+	err := validators.ValidateSKU(sku)
+	if err != nil {
+		return err
+	}
+	if req.Price != nil && *req.Price <= 0 {
+		return fmt.Errorf("price must be greater than zero")
+	}
+	if req.Stock != nil && *req.Stock < 0 {
+		return fmt.Errorf("stock can not be negative")
+	}
+	options, err := vs.variantRepo.GetOptions(variant.ProductID)
+	if err != nil {
+		return fmt.Errorf("can not fetch options: %v", err)
+	}
+	if len(options) == 0 {
+		return ErrNoOptions
+	}
+	values := make(map[string]string, len(req.Options))
+	for name, value := range req.Options {
+		values[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	err = matchOptions(options, values)
+	if err != nil {
+		return err
+	}
+
+	existing, err := vs.variantRepo.GetVariantBySKU(sku)
+	if err == nil && existing.ID != variant.ID {
+		return ErrSKUExists
+	}
+	siblings, err := vs.variantRepo.ListVariants(variant.ProductID)
+	if err != nil {
+		return fmt.Errorf("can not fetch variants: %v", err)
+	}
+	for _, sibling := range siblings {
+		if sibling.ID != variant.ID && maps.Equal(sibling.Options, values) {
+			return ErrVariantExists
+		}
+	}
+
+	variant.SKU = sku
+	variant.Options = values
+	variant.Price = req.Price
+	return nil
+}
+
+// matchOptions checks that values holds exactly one allowed value for each
+// option and nothing else.
+func matchOptions(options []models.ProductOption, values map[string]string) error {
+	if len(values) != len(options) {
+		return fmt.Errorf("a variant needs exactly one value for each of the product's %d options", len(options))
+	}
+	for _, option := range options {
+		value, ok := values[option.Name]
+		if !ok {
+			return fmt.Errorf("missing value for option %q", option.Name)
+		}
+		if !slices.Contains(option.Values, value) {
+			return fmt.Errorf("%q is not a value of option %q", value, option.Name)
+		}
+	}
+	return nil
+}
+
+// cleanOptions trims option names and values and rejects empty, overlong or
+// repeated entries. Names are compared case-insensitively.
+func cleanOptions(options []models.ProductOption) ([]models.ProductOption, error) {
+	if len(options) > maxOptions {
+		return nil, fmt.Errorf("a product can have at most %d options", maxOptions)
+	}
+	cleaned := make([]models.ProductOption, 0, len(options))
+	names := make(map[string]bool)
+	for _, option := range options {
+		name := strings.TrimSpace(option.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxOptionLen {
+			return nil, fmt.Errorf("option names must be 1-%d characters", maxOptionLen)
+		}
+		if names[strings.ToLower(name)] {
+			return nil, fmt.Errorf("option %q is listed twice", name)
+		}
+		names[strings.ToLower(name)] = true
+		if len(option.Values) == 0 || len(option.Values) > maxOptionValues {
+			return nil, fmt.Errorf("option %q needs 1-%d values", name, maxOptionValues)
+		}
+		values := make([]string, 0, len(option.Values))
+		for _, value := range option.Values {
+			value = strings.TrimSpace(value)
+			if value == "" || utf8.RuneCountInString(value) > maxOptionLen {
+				return nil, fmt.Errorf("values of option %q must be 1-%d characters", name, maxOptionLen)
+			}
+			if slices.Contains(values, value) {
+				return nil, fmt.Errorf("value %q of option %q is listed twice", value, name)
+			}
+			values = append(values, value)
+		}
+		cleaned = append(cleaned, models.ProductOption{Name: name, Values: values})
+	}
+	return cleaned, nil
+}
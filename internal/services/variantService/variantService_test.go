@@ -0,0 +1,210 @@
+package variantService
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/dto"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"go.uber.org/mock/gomock"
+)
+
+var sizes = []models.ProductOption{{Name: "size", Values: []string{"S", "M"}}}
+
+func TestListVariants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	service := NewVariantService(mockVariantRepo, mockProductRepo)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil)
+	mockVariantRepo.EXPECT().GetOptions("p1").Return(nil, nil)
+	mockVariantRepo.EXPECT().ListVariants("p1").Return(nil, nil)
+
+	list, err := service.ListVariants("p1")
+	if err != nil || list.Options == nil || list.Variants == nil {
+		t.Errorf("unexpected list: %+v, err: %v", list, err)
+	}
+
+	mockProductRepo.EXPECT().GetProductByID("404").Return(models.Product{}, sql.ErrNoRows)
+	_, err = service.ListVariants("404")
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestSetOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	service := NewVariantService(mockVariantRepo, mockProductRepo)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil).AnyTimes()
+
+	input := []models.ProductOption{{Name: " size ", Values: []string{" S", "M "}}}
+	mockVariantRepo.EXPECT().ListVariants("p1").Return(nil, nil)
+	mockVariantRepo.EXPECT().SetOptions("p1", sizes).Return(nil)
+	options, err := service.SetOptions("p1", input)
+	if err != nil || options[0].Name != "size" || options[0].Values[1] != "M" {
+		t.Errorf("unexpected options: %+v, err: %v", options, err)
+	}
+
+	invalid := [][]models.ProductOption{
+		{{Name: "", Values: []string{"S"}}},
+		{{Name: "size", Values: nil}},
+		{{Name: "size", Values: []string{"S", "S"}}},
+		{{Name: "size", Values: []string{"S"}}, {Name: "Size", Values: []string{"M"}}},
+		{{Name: "a", Values: []string{"1"}}, {Name: "b", Values: []string{"1"}}, {Name: "c", Values: []string{"1"}}, {Name: "d", Values: []string{"1"}}},
+	}
+	for _, options := range invalid {
+		_, err := service.SetOptions("p1", options)
+		if err == nil {
+			t.Errorf("expected error for options %+v", options)
+		}
+	}
+
+	// dropping the colour option would leave this variant with an extra value
+	mockVariantRepo.EXPECT().ListVariants("p1").Return([]models.Variant{
+		{ID: "v1", ProductID: "p1", Options: map[string]string{"size": "S", "colour": "red"}},
+	}, nil)
+	_, err = service.SetOptions("p1", sizes)
+	if !errors.Is(err, ErrOptionsInUse) {
+		t.Errorf("expected ErrOptionsInUse, got %v", err)
+	}
+}
+
+func TestCreateVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	service := NewVariantService(mockVariantRepo, mockProductRepo)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil).AnyTimes()
+	mockVariantRepo.EXPECT().GetOptions("p1").Return(sizes, nil).AnyTimes()
+
+	price := float32(12.5)
+	negative := -1
+	stock4 := 4
+	req := dto.VariantDTO{SKU: "TEE-S", Options: map[string]string{"size": "S"}, Price: &price, Stock: &stock4}
+	mockVariantRepo.EXPECT().GetVariantBySKU("TEE-S").Return(models.Variant{}, sql.ErrNoRows)
+	mockVariantRepo.EXPECT().ListVariants("p1").Return(nil, nil)
+	mockVariantRepo.EXPECT().SaveVariant(gomock.Any(), gomock.Any()).DoAndReturn(func(variant models.Variant, opening *models.StockMovement) error {
+		if variant.Stock != 4 || opening == nil || opening.VariantID != variant.ID || opening.Delta != 4 ||
+			opening.Reason != models.StockOpening || opening.ActorID != "admin1" {
+			t.Errorf("unexpected variant %+v or opening entry %+v", variant, opening)
+		}
+		return nil
+	})
+
+	variant, err := service.CreateVariant("admin1", "p1", req)
+	if err != nil || variant.ID == "" || variant.ProductID != "p1" || *variant.Price != 12.5 || variant.Stock != 4 {
+		t.Errorf("unexpected variant: %+v, err: %v", variant, err)
+	}
+
+	invalid := []dto.VariantDTO{
+		{SKU: "", Options: map[string]string{"size": "S"}},
+		{SKU: "has space", Options: map[string]string{"size": "S"}},
+		{SKU: "TEE-S", Options: map[string]string{"size": "S"}, Stock: &negative},
+		{SKU: "TEE-XL", Options: map[string]string{"size": "XL"}},
+		{SKU: "TEE-S", Options: map[string]string{"size": "S", "colour": "red"}},
+		{SKU: "TEE", Options: nil},
+	}
+	for _, req := range invalid {
+		_, err := service.CreateVariant("admin1", "p1", req)
+		if err == nil {
+			t.Errorf("expected error for %+v", req)
+		}
+	}
+
+	mockVariantRepo.EXPECT().GetVariantBySKU("TEE-S").Return(models.Variant{ID: "other"}, nil)
+	_, err = service.CreateVariant("admin1", "p1", dto.VariantDTO{SKU: "TEE-S", Options: map[string]string{"size": "S"}})
+	if !errors.Is(err, ErrSKUExists) {
+		t.Errorf("expected ErrSKUExists, got %v", err)
+	}
+
+	mockVariantRepo.EXPECT().GetVariantBySKU("TEE-S2").Return(models.Variant{}, sql.ErrNoRows)
+	mockVariantRepo.EXPECT().ListVariants("p1").Return([]models.Variant{{ID: "v1", Options: map[string]string{"size": "S"}}}, nil)
+	_, err = service.CreateVariant("admin1", "p1", dto.VariantDTO{SKU: "TEE-S2", Options: map[string]string{"size": "S"}})
+	if !errors.Is(err, ErrVariantExists) {
+		t.Errorf("expected ErrVariantExists, got %v", err)
+	}
+}
+
+func TestCreateVariantWithoutOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	service := NewVariantService(mockVariantRepo, mockProductRepo)
+
+	mockProductRepo.EXPECT().GetProductByID("p1").Return(models.Product{ID: "p1"}, nil)
+	mockVariantRepo.EXPECT().GetOptions("p1").Return(nil, nil)
+
+	_, err := service.CreateVariant("admin1", "p1", dto.VariantDTO{SKU: "TEE"})
+	if !errors.Is(err, ErrNoOptions) {
+		t.Errorf("expected ErrNoOptions, got %v", err)
+	}
+}
+
+func TestUpdateVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	service := NewVariantService(mockVariantRepo, mockProductRepo)
+
+	existing := models.Variant{ID: "v1", ProductID: "p1", SKU: "TEE-S", Options: map[string]string{"size": "S"}, Stock: 1}
+	mockVariantRepo.EXPECT().GetVariant("v1").Return(existing, nil).Times(3)
+	mockVariantRepo.EXPECT().GetOptions("p1").Return(sizes, nil)
+	mockVariantRepo.EXPECT().GetVariantBySKU("TEE-S").Return(existing, nil)
+	mockVariantRepo.EXPECT().ListVariants("p1").Return([]models.Variant{existing}, nil)
+	mockVariantRepo.EXPECT().UpdateVariant(gomock.Any()).Return(nil)
+
+	// stock is left alone, as long as it is not changed
+	stock1 := 1
+	stock9 := 9
+	variant, err := service.UpdateVariant("p1", "v1", dto.VariantDTO{SKU: "TEE-S", Options: map[string]string{"size": "S"}, Stock: &stock1})
+	if err != nil || variant.Stock != 1 || variant.Price != nil {
+		t.Errorf("unexpected variant: %+v, err: %v", variant, err)
+	}
+
+	_, err = service.UpdateVariant("p1", "v1", dto.VariantDTO{SKU: "TEE-S", Options: map[string]string{"size": "S"}, Stock: &stock9})
+	if !errors.Is(err, ErrStockNotEditable) {
+		t.Errorf("expected ErrStockNotEditable, got %v", err)
+	}
+
+	_, err = service.UpdateVariant("p2", "v1", dto.VariantDTO{SKU: "TEE-S"})
+	if !errors.Is(err, ErrVariantNotFound) {
+		t.Errorf("expected ErrVariantNotFound for another product's variant, got %v", err)
+	}
+}
+
+func TestDeleteVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockVariantRepo := mocks.NewMockVariantManager(ctrl)
+	mockProductRepo := mocks.NewMockProductManager(ctrl)
+	service := NewVariantService(mockVariantRepo, mockProductRepo)
+
+	mockVariantRepo.EXPECT().GetVariant("v1").Return(models.Variant{ID: "v1", ProductID: "p1"}, nil)
+	mockVariantRepo.EXPECT().DeleteVariant("v1").Return(nil)
+	if err := service.DeleteVariant("p1", "v1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	mockVariantRepo.EXPECT().GetVariant("404").Return(models.Variant{}, sql.ErrNoRows)
+	if err := service.DeleteVariant("p1", "404"); !errors.Is(err, ErrVariantNotFound) {
+		t.Errorf("expected ErrVariantNotFound, got %v", err)
+	}
+}
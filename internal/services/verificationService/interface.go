@@ -0,0 +1,13 @@
+package verificationService
+
+import "github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+
+//go:generate mockgen -source=interface.go -destination=../../mocks/mock_verificationService.go -package mocks
+
+type VerificationServiceManager interface {
+	SendVerification(user models.User) error
+	SendEmailChange(user models.User, newEmail string) error
+	ResendVerification(userID string) error
+	VerifyEmail(token string) error
+	ConfirmEmailChange(token string) error
+}
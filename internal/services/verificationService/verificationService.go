@@ -0,0 +1,120 @@
+package verificationService
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mailer"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/repository/userRepository"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/validators"
+)
+
+var (
+	ErrResendThrottled = errors.New("verification email was sent recently, please try again later")
+	ErrEmailTaken      = errors.New("email is already in use")
+)
+
+type VerificationService struct {
+	userRepo userRepository.UserManager
+	mailer   mailer.Mailer
+	now      func() time.Time
+}
+
+func NewVerificationService(userRepo userRepository.UserManager, mailer mailer.Mailer) VerificationServiceManager {
+	return &VerificationService{
+		userRepo: userRepo,
+		mailer:   mailer,
+		now:      time.Now,
+	}
+}
+
+func (vs *VerificationService) SendVerification(user models.User) error {
+	token, err := utils.GenerateEmailVerificationToken(user.ID, user.Email)
+	if err != nil {
+		return fmt.Errorf("can not create verification link: %v", err)
+	}
+	link := config.AppBaseURL + "/api/v1/verify-email?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below. It expires in %v.\n\n%s",
+		user.Name, config.EmailVerificationTTL, link)
+	err = vs.mailer.Send(user.Email, "Verify your email address", body)
+	if err != nil {
+		return fmt.Errorf("can not send verification email: %v", err)
+	}
+	return vs.userRepo.SetVerificationSentAt(user.ID, vs.now())
+}
+
+// SendEmailChange mails newEmail a link that moves the account to it. Until
+// the link is opened the account keeps its current email.
+func (vs *VerificationService) SendEmailChange(user models.User, newEmail string) error {
+	token, err := utils.GenerateEmailChangeToken(user.ID, user.Email, newEmail)
+	if err != nil {
+		return fmt.Errorf("can not create confirmation link: %v", err)
+	}
+	link := config.AppBaseURL + "/api/v1/verify-email/change?token=" + url.QueryEscape(token)
+	body := fmt.Sprintf("Hi %s,\n\nPlease confirm that you want to use this address for your account by opening the link below. It expires in %v.\n\n%s\n\nIf you did not ask for this, you can ignore this email.",
+		user.Name, config.EmailVerificationTTL, link)
+	err = vs.mailer.Send(newEmail, "Confirm your new email address", body)
+	if err != nil {
+		return fmt.Errorf("can not send confirmation email: %v", err)
+	}
+	return nil
+}
+
+func (vs *VerificationService) ResendVerification(userID string) error {
+	user, err := vs.userRepo.GetUserByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found")
+	}
+	if user.IsEmailVerified() {
+		return fmt.Errorf("email is already verified")
+	}
+	if user.VerificationSentAt != nil && vs.now().Sub(*user.VerificationSentAt) < config.VerificationResendInterval {
+		return ErrResendThrottled
+	}
+	return vs.SendVerification(user)
+}
+
+func (vs *VerificationService) VerifyEmail(token string) error {
+	claims, err := validators.ValidateEmailVerificationToken(token)
+	if err != nil {
+		return err
+	}
+	user, err := vs.userRepo.GetUserByID(claims.Subject)
+	if err != nil || user.Email != claims.Email {
+		return fmt.Errorf("invalid or expired verification link")
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+	return vs.userRepo.MarkEmailVerified(user.ID, vs.now())
+}
+
+// ConfirmEmailChange applies the change a SendEmailChange link was issued for.
+// Opening the link proves the new address, so it is stored as verified.
+func (vs *VerificationService) ConfirmEmailChange(token string) error {
+	claims, err := validators.ValidateEmailChangeToken(token)
+	if err != nil {
+		return err
+	}
+	user, err := vs.userRepo.GetUserByID(claims.Subject)
+	if err != nil || user.Email != claims.CurrentEmail {
+		return fmt.Errorf("invalid or expired confirmation link")
+	}
+	existing, err := vs.userRepo.GetUserByEmail(claims.Email)
+	if err == nil && existing.ID != user.ID {
+		return ErrEmailTaken
+	}
+	now := vs.now()
+	user.Email = claims.Email
+	user.EmailVerifiedAt = &now
+	err = vs.userRepo.UpdateUser(user)
+	if err != nil {
+		return fmt.Errorf("can not change email: %v", err)
+	}
+	return nil
+}
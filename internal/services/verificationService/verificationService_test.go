@@ -0,0 +1,174 @@
+package verificationService
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/mocks"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
+	"go.uber.org/mock/gomock"
+)
+
+type fakeMailer struct {
+	to   string
+	body string
+}
+
+func (fm *fakeMailer) Send(to, subject, body string) error {
+	fm.to = to
+	fm.body = body
+	return nil
+}
+
+func setupService(t *testing.T) (*VerificationService, *mocks.MockUserManager, *fakeMailer) {
+	ctrl := gomock.NewController(t)
+	mockUserRepo := mocks.NewMockUserManager(ctrl)
+	mailer := &fakeMailer{}
+	fixedNow := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := &VerificationService{
+		userRepo: mockUserRepo,
+		mailer:   mailer,
+		now:      func() time.Time { return fixedNow },
+	}
+	return service, mockUserRepo, mailer
+}
+
+func TestSendVerification(t *testing.T) {
+	service, mockUserRepo, mailer := setupService(t)
+
+	mockUserRepo.EXPECT().SetVerificationSentAt("u1", service.now()).Return(nil)
+
+	err := service.SendVerification(models.User{ID: "u1", Name: "Test", Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mailer.to != "user@example.com" || !strings.Contains(mailer.body, "/api/v1/verify-email?token=") {
+		t.Errorf("unexpected mail to %s: %s", mailer.to, mailer.body)
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	service, mockUserRepo, _ := setupService(t)
+	now := service.now()
+
+	t.Run("Already verified", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", EmailVerifiedAt: &now}, nil)
+
+		if err := service.ResendVerification("u1"); err == nil {
+			t.Error("expected error for verified email")
+		}
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		sentAt := now.Add(-30 * time.Second)
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", VerificationSentAt: &sentAt}, nil)
+
+		err := service.ResendVerification("u1")
+		if !errors.Is(err, ErrResendThrottled) {
+			t.Errorf("expected throttling error, got %v", err)
+		}
+	})
+
+	t.Run("Resent after interval", func(t *testing.T) {
+		sentAt := now.Add(-10 * time.Minute)
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Email: "user@example.com", VerificationSentAt: &sentAt}, nil)
+		mockUserRepo.EXPECT().SetVerificationSentAt("u1", now).Return(nil)
+
+		if err := service.ResendVerification("u1"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	service, mockUserRepo, mailer := setupService(t)
+
+	mockUserRepo.EXPECT().SetVerificationSentAt("u1", gomock.Any()).Return(nil)
+	service.SendVerification(models.User{ID: "u1", Email: "user@example.com"})
+	link, _ := url.Parse(strings.TrimSpace(mailer.body[strings.Index(mailer.body, "http"):]))
+	token := link.Query().Get("token")
+
+	t.Run("Invalid token", func(t *testing.T) {
+		if err := service.VerifyEmail("garbage"); err == nil {
+			t.Error("expected error for invalid token")
+		}
+	})
+
+	t.Run("Auth token is rejected", func(t *testing.T) {
+		authToken, _ := utils.GenerateJWT(models.UserJWT{UserID: "u1", Email: "user@example.com"})
+		if err := service.VerifyEmail(authToken); err == nil {
+			t.Error("expected error for login token used as verification link")
+		}
+	})
+
+	t.Run("Email changed since link was sent", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Email: "new@example.com"}, nil)
+
+		if err := service.VerifyEmail(token); err == nil {
+			t.Error("expected error for stale email")
+		}
+	})
+
+	t.Run("Successful verification", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Email: "user@example.com"}, nil)
+		mockUserRepo.EXPECT().MarkEmailVerified("u1", service.now()).Return(nil)
+
+		if err := service.VerifyEmail(token); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestConfirmEmailChange(t *testing.T) {
+	service, mockUserRepo, mailer := setupService(t)
+	user := models.User{ID: "u1", Name: "Test", Email: "old@example.com"}
+
+	err := service.SendEmailChange(user, "new@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mailer.to != "new@example.com" || !strings.Contains(mailer.body, "/api/v1/verify-email/change?token=") {
+		t.Fatalf("unexpected mail to %s: %s", mailer.to, mailer.body)
+	}
+	link, _ := url.Parse(strings.Fields(mailer.body[strings.Index(mailer.body, "http"):])[0])
+	token := link.Query().Get("token")
+
+	t.Run("Verification link is rejected", func(t *testing.T) {
+		verification, _ := utils.GenerateEmailVerificationToken("u1", "new@example.com")
+		if err := service.ConfirmEmailChange(verification); err == nil {
+			t.Error("expected error for a verification link used as a change link")
+		}
+	})
+
+	t.Run("Email taken meanwhile", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(user, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("new@example.com").Return(models.User{ID: "u2"}, nil)
+
+		if err := service.ConfirmEmailChange(token); !errors.Is(err, ErrEmailTaken) {
+			t.Errorf("expected ErrEmailTaken, got %v", err)
+		}
+	})
+
+	t.Run("Change applied as verified", func(t *testing.T) {
+		now := service.now()
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(user, nil)
+		mockUserRepo.EXPECT().GetUserByEmail("new@example.com").Return(models.User{}, errors.New("not found"))
+		mockUserRepo.EXPECT().UpdateUser(models.User{ID: "u1", Name: "Test", Email: "new@example.com", EmailVerifiedAt: &now}).Return(nil)
+
+		if err := service.ConfirmEmailChange(token); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Link is void once the email changed", func(t *testing.T) {
+		mockUserRepo.EXPECT().GetUserByID("u1").Return(models.User{ID: "u1", Email: "new@example.com"}, nil)
+
+		if err := service.ConfirmEmailChange(token); err == nil {
+			t.Error("expected error for a link that was already used")
+		}
+	})
+}
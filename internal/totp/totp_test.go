@@ -0,0 +1,58 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B SHA1 seed, truncated to 6 digits.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	cases := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range cases {
+		got, err := Code(rfcSecret, time.Unix(unix, 0))
+		if err != nil || got != want {
+			t.Errorf("at %d wanted %s, got %s, err: %v", unix, want, got, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	code, _ := Code(rfcSecret, now)
+
+	step, ok := Validate(rfcSecret, code, now.Add(25*time.Second))
+	if !ok || step != Step(now) {
+		t.Errorf("wanted code valid in the next period, got step %d ok %v", step, ok)
+	}
+
+	_, ok = Validate(rfcSecret, code, now.Add(2*time.Minute))
+	if ok {
+		t.Error("wanted code to expire outside the skew window")
+	}
+
+	_, ok = Validate(rfcSecret, "12345", now)
+	if ok {
+		t.Error("wanted short code to be rejected")
+	}
+}
+
+func TestGenerateSecretAndURI(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil || len(secret) != 32 {
+		t.Fatalf("unexpected secret %q, err: %v", secret, err)
+	}
+
+	uri := URI("Shop", "user@example.com", secret)
+	if !strings.HasPrefix(uri, "otpauth://totp/Shop:user@example.com?") || !strings.Contains(uri, "secret="+secret) {
+		t.Errorf("unexpected uri: %s", uri)
+	}
+}
@@ -1,6 +1,15 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
@@ -12,18 +21,75 @@ func NewUUID() string {
 	return uuid.New().String()
 }
 
+// GenerateJWT signs a session token. The jti is taken from userJWT.ID when set
+// so it can match a stored session.
 func GenerateJWT(userJWT models.UserJWT) (string, error) {
+	now := time.Now()
+	jti := userJWT.ID
+	if jti == "" {
+		jti = NewUUID()
+	}
 	claims := jwt.MapClaims{
 		"user_id": userJWT.UserID,
 		"email":   userJWT.Email,
 		"role":    userJWT.Role,
+		"ver":     userJWT.TokenVersion,
+		"jti":     jti,
+		"iat":     now.Unix(),
+		"exp":     now.Add(config.JWT_TTL).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(config.JWT_Secret)
-	if err != nil {
-		return "", err
+	return signClaims(claims)
+}
+
+// GenerateEmailVerificationToken signs a link token bound to the user's current
+// email, so changing the email invalidates links sent to the old address.
+func GenerateEmailVerificationToken(userID, email string) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":     userID,
+		"email":   email,
+		"purpose": models.EmailVerificationPurpose,
+		"iat":     now.Unix(),
+		"exp":     now.Add(config.EmailVerificationTTL).Unix(),
+	}
+	return signClaims(claims)
+}
+
+// GenerateEmailChangeToken signs the link sent to a new address. The change
+// is only applied when the link comes back, which proves the new address
+// belongs to the user.
+func GenerateEmailChangeToken(userID, currentEmail, newEmail string) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":           userID,
+		"email":         newEmail,
+		"current_email": currentEmail,
+		"purpose":       models.EmailChangePurpose,
+		"iat":           now.Unix(),
+		"exp":           now.Add(config.EmailVerificationTTL).Unix(),
 	}
-	return tokenString, nil
+	return signClaims(claims)
+}
+
+// GenerateMFAToken signs a short-lived token for the second login step. It
+// carries the token version so a password change voids pending challenges.
+func GenerateMFAToken(userID, purpose string, tokenVersion int) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":     userID,
+		"purpose": purpose,
+		"ver":     tokenVersion,
+		"iat":     now.Unix(),
+		"exp":     now.Add(config.MFAChallengeTTL).Unix(),
+	}
+	return signClaims(claims)
+}
+
+func signClaims(claims jwt.MapClaims) (string, error) {
+	key := config.JWTKeys.SigningKey()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.SignKey)
 }
 
 func HashPassword(password string) (string, error) {
@@ -35,3 +101,55 @@ func CheckPassword(hashed, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password))
 	return err == nil
 }
+
+// IsHashedPassword reports whether the stored password is a bcrypt hash rather
+// than plaintext.
+func IsHashedPassword(password string) bool {
+	_, err := bcrypt.Cost([]byte(password))
+	return err == nil
+}
+
+// GenerateSecureToken returns a random URL-safe token for single-use links.
+func GenerateSecureToken() (string, error) {
+	bytes := make([]byte, 32)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken returns the hex SHA-256 of a token so only hashes are stored.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ClientIP returns the address of the caller, taken from X-Forwarded-For only
+// when config.TrustProxyHeaders is set.
+func ClientIP(r *http.Request) string {
+	if config.TrustProxyHeaders {
+		forwarded := r.Header.Get("X-Forwarded-For")
+		if forwarded != "" {
+			first, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(first)
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// maxUserAgentLen bounds the user agent kept for a session.
+const maxUserAgentLen = 256
+
+// ClientInfo returns the caller's address and user agent.
+func ClientInfo(r *http.Request) models.ClientInfo {
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLen {
+		userAgent = userAgent[:maxUserAgentLen]
+	}
+	return models.ClientInfo{IP: ClientIP(r), UserAgent: userAgent}
+}
@@ -2,11 +2,15 @@ package utils
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/jwtKeys"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
 )
 
@@ -58,9 +62,24 @@ func TestCheckPassword(t *testing.T) {
 	}
 }
 
+func TestIsHashedPassword(t *testing.T) {
+	hashed, err := HashPassword("admin@123")
+	if err != nil {
+		t.Fatalf("Hashing failed: %v", err)
+	}
+
+	if !IsHashedPassword(hashed) {
+		t.Fatal("Expected bcrypt hash to be detected")
+	}
+
+	if IsHashedPassword("admin@123") {
+		t.Fatal("Expected plaintext password to be detected")
+	}
+}
+
 func TestGenerateJWT(t *testing.T) {
-	// Set a test secret key
-	config.JWT_Secret = []byte("test_secret")
+	// Set a test signing key
+	config.JWTKeys, _ = jwtKeys.NewKeySet("test", jwtKeys.NewHMACKey("test", []byte("test_secret")))
 
 	user := models.UserJWT{
 		UserID: "123",
@@ -81,7 +100,10 @@ func TestGenerateJWT(t *testing.T) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method")
 		}
-		return config.JWT_Secret, nil
+		if token.Header["kid"] != "test" {
+			return nil, fmt.Errorf("unexpected key id")
+		}
+		return []byte("test_secret"), nil
 	})
 
 	if err != nil || !token.Valid {
@@ -92,4 +114,58 @@ func TestGenerateJWT(t *testing.T) {
 	if !ok {
 		t.Fatal("Expected MapClaims")
 	}
+
+	user.ID = "session-1"
+	tokenStr, _ = GenerateJWT(user)
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokenStr, claims, func(*jwt.Token) (interface{}, error) {
+		return []byte("test_secret"), nil
+	})
+	if err != nil || claims["jti"] != "session-1" {
+		t.Errorf("expected jti to be taken from the claims, got %v (err: %v)", claims["jti"], err)
+	}
+}
+
+func TestGenerateSecureToken(t *testing.T) {
+	first, err := GenerateSecureToken()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _ := GenerateSecureToken()
+
+	if first == "" || first == second {
+		t.Fatal("Expected distinct non-empty tokens")
+	}
+
+	if HashToken(first) == first || HashToken(first) != HashToken(first) {
+		t.Fatal("Expected a stable hash different from the token")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", nil)
+	req.RemoteAddr = "10.0.0.1:4321"
+	req.Header.Set("X-Forwarded-For", "1.2.3.4, 10.0.0.2")
+
+	config.TrustProxyHeaders = false
+	if ip := ClientIP(req); ip != "10.0.0.1" {
+		t.Errorf("expected remote address, got %s", ip)
+	}
+
+	config.TrustProxyHeaders = true
+	defer func() { config.TrustProxyHeaders = false }()
+	if ip := ClientIP(req); ip != "1.2.3.4" {
+		t.Errorf("expected forwarded address, got %s", ip)
+	}
+}
+
+func TestClientInfo(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", nil)
+	req.RemoteAddr = "10.0.0.1:4321"
+	req.Header.Set("User-Agent", strings.Repeat("a", 300))
+
+	info := ClientInfo(req)
+	if info.IP != "10.0.0.1" || len(info.UserAgent) != maxUserAgentLen {
+		t.Errorf("unexpected client info: %+v", info)
+	}
 }
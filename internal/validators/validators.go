@@ -3,6 +3,9 @@ package validators
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
@@ -42,18 +45,154 @@ func ValidateName(name string) error {
 	return nil
 }
 
+const (
+	maxDescriptionLen = 2000
+	maxTags           = 20
+	maxTagLen         = 30
+	maxBrandLen       = 100
+	maxWeightGrams    = 1000000
+	maxDimensionMM    = 10000
+	maxSpecs          = 50
+	maxSpecTextLen    = 200
+	maxSpecUnitLen    = 20
+	maxSKULen         = 64
+)
+
+var (
+	specKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,39}$`)
+	skuPattern     = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+func ValidateDescription(description string) error {
+	if utf8.RuneCountInString(description) > maxDescriptionLen {
+		return fmt.Errorf("description must be at most %d characters long", maxDescriptionLen)
+	}
+	if strings.ContainsFunc(description, func(r rune) bool {
+		return unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t'
+	}) {
+		return fmt.Errorf("description must not contain control characters")
+	}
+	return nil
+}
+
+// ValidateTags checks product tags. Tags are stored comma separated, so they
+// can not contain commas.
+func ValidateTags(tags []string) error {
+	if len(tags) > maxTags {
+		return fmt.Errorf("a product can have at most %d tags", maxTags)
+	}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLen {
+			return fmt.Errorf("tags must be 1 to %d characters long", maxTagLen)
+		}
+		if strings.ContainsFunc(tag, func(r rune) bool { return r == ',' || unicode.IsControl(r) }) {
+			return fmt.Errorf("tag %q must not contain commas or control characters", tag)
+		}
+	}
+	return nil
+}
+
+// ValidateSKU checks a product or variant SKU, which is compared as given.
+func ValidateSKU(sku string) error {
+	if len(sku) > maxSKULen || !skuPattern.MatchString(sku) {
+		return fmt.Errorf("sku must be 1-%d letters, digits, dots, dashes or underscores", maxSKULen)
+	}
+	return nil
+}
+
+func ValidateBrand(brand string) error {
+	if utf8.RuneCountInString(brand) > maxBrandLen {
+		return fmt.Errorf("brand must be at most %d characters long", maxBrandLen)
+	}
+	if strings.ContainsFunc(brand, unicode.IsControl) {
+		return fmt.Errorf("brand must not contain control characters")
+	}
+	return nil
+}
+
+func ValidateWeight(grams int) error {
+	if grams < 0 || grams > maxWeightGrams {
+		return fmt.Errorf("weight must be between 0 and %d grams", maxWeightGrams)
+	}
+	return nil
+}
+
+func ValidateDimensions(dimensions models.Dimensions) error {
+	for _, size := range []int{dimensions.LengthMM, dimensions.WidthMM, dimensions.HeightMM} {
+		if size <= 0 || size > maxDimensionMM {
+			return fmt.Errorf("length, width and height must be between 1 and %d millimetres", maxDimensionMM)
+		}
+	}
+	return nil
+}
+
+// ValidateSpecs checks a product's specification. Keys are compared after
+// trimming and lower-casing, as they are stored, and each value must have the
+// JSON type that its Type names.
+func ValidateSpecs(specs []models.ProductSpec) error {
+	if len(specs) > maxSpecs {
+		return fmt.Errorf("a product can have at most %d specs", maxSpecs)
+	}
+	keys := make(map[string]bool)
+	for _, spec := range specs {
+		key := strings.ToLower(strings.TrimSpace(spec.Key))
+		if !specKeyPattern.MatchString(key) {
+			return fmt.Errorf("spec key %q must be up to 40 lowercase letters, digits or underscores, starting with a letter", spec.Key)
+		}
+		if keys[key] {
+			return fmt.Errorf("spec %q is listed twice", key)
+		}
+		keys[key] = true
+		switch spec.Type {
+		case models.SpecText:
+			text, ok := spec.Value.(string)
+			text = strings.TrimSpace(text)
+			if !ok || text == "" || utf8.RuneCountInString(text) > maxSpecTextLen || strings.ContainsFunc(text, unicode.IsControl) {
+				return fmt.Errorf("spec %q must be text of 1 to %d characters", key, maxSpecTextLen)
+			}
+		case models.SpecNumber:
+			if _, ok := spec.Value.(float64); !ok {
+				return fmt.Errorf("spec %q must be a number", key)
+			}
+		case models.SpecBool:
+			if _, ok := spec.Value.(bool); !ok {
+				return fmt.Errorf("spec %q must be true or false", key)
+			}
+		default:
+			return fmt.Errorf("spec %q has unknown type %q, expected text, number or bool", key, spec.Type)
+		}
+		if utf8.RuneCountInString(spec.Unit) > maxSpecUnitLen || strings.ContainsFunc(spec.Unit, unicode.IsControl) {
+			return fmt.Errorf("unit of spec %q must be at most %d characters", key, maxSpecUnitLen)
+		}
+	}
+	return nil
+}
+
+// keyFunc selects the verification key by the token's kid and makes sure the
+// token was signed with that key's algorithm.
+func keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		return nil, fmt.Errorf("token has no key id")
+	}
+	key, ok := config.JWTKeys.Lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %s", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.VerifyKey, nil
+}
+
 func ValidateJWT(tokenStr string) (models.UserJWT, error) {
 	if tokenStr == "" {
 		return models.UserJWT{}, fmt.Errorf("token is empty")
 	}
 	var claims models.UserJWT
 
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(config.JWT_Secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, keyFunc)
 	if err != nil {
 		return models.UserJWT{}, fmt.Errorf("invalid token: %v", err)
 	}
@@ -64,6 +203,57 @@ func ValidateJWT(tokenStr string) (models.UserJWT, error) {
 	return claims, nil
 }
 
+func ValidateEmailVerificationToken(tokenStr string) (models.EmailVerificationClaims, error) {
+	if tokenStr == "" {
+		return models.EmailVerificationClaims{}, fmt.Errorf("token is empty")
+	}
+	var claims models.EmailVerificationClaims
+
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, keyFunc, jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return models.EmailVerificationClaims{}, fmt.Errorf("invalid or expired verification link")
+	}
+	if claims.Purpose != models.EmailVerificationPurpose || claims.Subject == "" {
+		return models.EmailVerificationClaims{}, fmt.Errorf("invalid or expired verification link")
+	}
+
+	return claims, nil
+}
+
+func ValidateEmailChangeToken(tokenStr string) (models.EmailChangeClaims, error) {
+	if tokenStr == "" {
+		return models.EmailChangeClaims{}, fmt.Errorf("token is empty")
+	}
+	var claims models.EmailChangeClaims
+
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, keyFunc, jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return models.EmailChangeClaims{}, fmt.Errorf("invalid or expired confirmation link")
+	}
+	if claims.Purpose != models.EmailChangePurpose || claims.Subject == "" || claims.Email == "" {
+		return models.EmailChangeClaims{}, fmt.Errorf("invalid or expired confirmation link")
+	}
+
+	return claims, nil
+}
+
+func ValidateMFAToken(tokenStr, purpose string) (models.MFAChallengeClaims, error) {
+	if tokenStr == "" {
+		return models.MFAChallengeClaims{}, fmt.Errorf("token is empty")
+	}
+	var claims models.MFAChallengeClaims
+
+	token, err := jwt.ParseWithClaims(tokenStr, &claims, keyFunc, jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return models.MFAChallengeClaims{}, fmt.Errorf("invalid or expired mfa token")
+	}
+	if claims.Purpose != purpose || claims.Subject == "" {
+		return models.MFAChallengeClaims{}, fmt.Errorf("invalid or expired mfa token")
+	}
+
+	return claims, nil
+}
+
 func ValidateCoupon(code string, discount float32) error {
 	if len(code) < 3 {
 		return fmt.Errorf("coupon code must be at least 3 characters long")
@@ -1,8 +1,16 @@
 package validators
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"strings"
 	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/config"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/jwtKeys"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/models"
+	"github.com/meshyampratap01/OnlineShoppingCart/internal/utils"
 )
 
 func TestValidateEmail(t *testing.T) {
@@ -47,12 +55,148 @@ func TestValidateName(t *testing.T) {
 	}
 }
 
-// func TestValidateJWT(t *testing.T){
-// 	_,err:=ValidateJWT("")
-// 	if err!=nil{
-// 		t.Error("wanted no error , got error")
-// 	}
-// }
+func signToken(t *testing.T, key jwtKeys.Key) string {
+	token := jwt.NewWithClaims(key.Method, jwt.MapClaims{"user_id": "123", "role": 2})
+	token.Header["kid"] = key.ID
+	tokenStr, err := token.SignedString(key.SignKey)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return tokenStr
+}
+
+func TestValidateDescription(t *testing.T) {
+	if err := ValidateDescription("Over-ear headphones.\nWireless."); err != nil {
+		t.Error("wanted no error got error: ", err)
+	}
+	if err := ValidateDescription("bad\x02text"); err == nil {
+		t.Error("wanted error for a control character got no error")
+	}
+	if err := ValidateDescription(strings.Repeat("a", 2001)); err == nil {
+		t.Error("wanted error for a long description got no error")
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	if err := ValidateTags([]string{"audio", "Wireless"}); err != nil {
+		t.Error("wanted no error got error: ", err)
+	}
+	for _, tags := range [][]string{{"a,b"}, {" "}, {strings.Repeat("a", 31)}, make([]string, 21)} {
+		if err := ValidateTags(tags); err == nil {
+			t.Errorf("wanted error for %q got no error", tags)
+		}
+	}
+}
+
+func TestValidateSKU(t *testing.T) {
+	if err := ValidateSKU("LAP-001_b.2"); err != nil {
+		t.Error("wanted no error got error: ", err)
+	}
+	for _, sku := range []string{"", "-LAP", "LAP 1", "LAP/1", strings.Repeat("a", 65)} {
+		if err := ValidateSKU(sku); err == nil {
+			t.Errorf("wanted error for %q got no error", sku)
+		}
+	}
+}
+
+func TestValidateProductDetails(t *testing.T) {
+	if err := ValidateBrand("Sony"); err != nil {
+		t.Error("wanted no error got error: ", err)
+	}
+	if err := ValidateBrand(strings.Repeat("a", 101)); err == nil {
+		t.Error("wanted error for a long brand got no error")
+	}
+	if err := ValidateWeight(-1); err == nil {
+		t.Error("wanted error for a negative weight got no error")
+	}
+	if err := ValidateDimensions(models.Dimensions{LengthMM: 300, WidthMM: 200, HeightMM: 0}); err == nil {
+		t.Error("wanted error for a zero height got no error")
+	}
+}
+
+func TestValidateSpecs(t *testing.T) {
+	valid := []models.ProductSpec{
+		{Key: "screen_size", Type: models.SpecNumber, Value: 6.5, Unit: "in"},
+		{Key: " OS ", Type: models.SpecText, Value: "Android"},
+		{Key: "wireless", Type: models.SpecBool, Value: true},
+	}
+	if err := ValidateSpecs(valid); err != nil {
+		t.Error("wanted no error got error: ", err)
+	}
+	invalid := [][]models.ProductSpec{
+		{{Key: "screen size", Type: models.SpecNumber, Value: 6.5}},
+		{{Key: "9lives", Type: models.SpecBool, Value: true}},
+		{{Key: "ram", Type: models.SpecNumber, Value: "16"}},
+		{{Key: "wireless", Type: models.SpecBool, Value: "yes"}},
+		{{Key: "os", Type: models.SpecText, Value: " "}},
+		{{Key: "os", Type: "list", Value: "Android"}},
+		{{Key: "os", Type: models.SpecText, Value: "Android"}, {Key: "OS", Type: models.SpecText, Value: "iOS"}},
+		{{Key: "ram", Type: models.SpecNumber, Value: 16.0, Unit: strings.Repeat("b", 21)}},
+	}
+	for _, specs := range invalid {
+		if err := ValidateSpecs(specs); err == nil {
+			t.Errorf("wanted error for %+v got no error", specs)
+		}
+	}
+}
+
+func TestValidateJWT(t *testing.T) {
+	_, err := ValidateJWT("")
+	if err == nil {
+		t.Error("wanted error got no error")
+	}
+
+	_, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+	oldKey := jwtKeys.NewHMACKey("old", []byte("0123456789abcdef0123456789abcdef"))
+	newKey := jwtKeys.Key{ID: "new", Method: jwt.SigningMethodEdDSA, SignKey: edPriv, VerifyKey: edPriv.Public()}
+	config.JWTKeys, _ = jwtKeys.NewKeySet("new", oldKey, newKey)
+
+	claims, err := ValidateJWT(signToken(t, newKey))
+	if err != nil || claims.UserID != "123" {
+		t.Errorf("wanted valid token signed with active key, got %+v, err: %v", claims, err)
+	}
+
+	_, err = ValidateJWT(signToken(t, oldKey))
+	if err != nil {
+		t.Errorf("wanted token signed with rotated key to verify, got error: %v", err)
+	}
+
+	unknown := jwtKeys.NewHMACKey("unknown", []byte("0123456789abcdef0123456789abcdef"))
+	_, err = ValidateJWT(signToken(t, unknown))
+	if err == nil {
+		t.Error("wanted error for unknown kid got no error")
+	}
+
+	forged := jwtKeys.NewHMACKey("new", []byte("0123456789abcdef0123456789abcdef"))
+	_, err = ValidateJWT(signToken(t, forged))
+	if err == nil {
+		t.Error("wanted error for algorithm mismatch got no error")
+	}
+}
+
+func TestValidateMFAToken(t *testing.T) {
+	config.JWTKeys, _ = jwtKeys.NewKeySet("test", jwtKeys.NewHMACKey("test", []byte("0123456789abcdef0123456789abcdef")))
+
+	token, err := utils.GenerateMFAToken("user1", models.MFAChallengePurpose, 3)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	claims, err := ValidateMFAToken(token, models.MFAChallengePurpose)
+	if err != nil || claims.Subject != "user1" || claims.TokenVersion != 3 {
+		t.Errorf("wanted valid challenge, got %+v, err: %v", claims, err)
+	}
+
+	_, err = ValidateMFAToken(token, models.MFASetupPurpose)
+	if err == nil {
+		t.Error("wanted error for wrong purpose got no error")
+	}
+
+	_, err = ValidateMFAToken(signToken(t, config.JWTKeys.SigningKey()), models.MFAChallengePurpose)
+	if err == nil {
+		t.Error("wanted error for session token got no error")
+	}
+}
 
 func TestValidateCoupon(t *testing.T){
 	err:=ValidateCoupon("",20)